@@ -2,21 +2,62 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
+	"github.com/yankeguo/bastion/daemon"
 	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
 	"golang.org/x/crypto/ssh"
 	"google.golang.org/grpc"
 )
 
+const exportFileVersion = 1
+
+// exportFile JSON export of all records, keyed by model name
+type exportFile struct {
+	Version int                          `json:"version"`
+	Models  map[string][]json.RawMessage `json:"models"`
+}
+
 func newConnection(c *cli.Context) (conn *grpc.ClientConn, err error) {
-	if conn, err = grpc.Dial(c.GlobalString("endpoint"), grpc.WithInsecure()); err != nil {
+	var dos []grpc.DialOption
+	if dos, err = types.DialOptions(types.SourceBastionAdmin, c.GlobalString("actor"), types.TLSOptions{
+		CA:         c.GlobalString("tls-ca"),
+		Cert:       c.GlobalString("tls-cert"),
+		Key:        c.GlobalString("tls-key"),
+		ServerName: c.GlobalString("tls-server-name"),
+	}); err != nil {
+		return
+	}
+	if conn, err = grpc.Dial(c.GlobalString("endpoint"), dos...); err != nil {
+		return
+	}
+	return
+}
+
+// parseTimeFlag parse a time flag in RFC3339 or "2006-01-02" in local time, returns unix seconds, zero for empty
+func parseTimeFlag(c *cli.Context, name string) (ts int64, err error) {
+	v := c.String(name)
+	if len(v) == 0 {
 		return
 	}
+	var t time.Time
+	if t, err = time.Parse(time.RFC3339, v); err != nil {
+		if t, err = time.ParseInLocation("2006-01-02", v, time.Local); err != nil {
+			err = errors.Errorf("invalid %s, should be RFC3339 or 2006-01-02", name)
+			return
+		}
+	}
+	ts = t.Unix()
 	return
 }
 
@@ -31,6 +72,11 @@ func main() {
 	app.Author = "Yanke Guo <guoyk.cn@gmail.com>"
 	app.Flags = []cli.Flag{
 		cli.StringFlag{Name: "endpoint", Usage: "bastiond rpc address", Value: "127.0.0.1:9777"},
+		cli.StringFlag{Name: "actor", Usage: "act as the bastion account, recorded in audit events and checked against roles of the account", EnvVar: "BASTION_ACTOR"},
+		cli.StringFlag{Name: "tls-ca", Usage: "ca certificate file of bastiond", EnvVar: "BASTION_TLS_CA"},
+		cli.StringFlag{Name: "tls-cert", Usage: "client certificate file, tls is enabled if not empty", EnvVar: "BASTION_TLS_CERT"},
+		cli.StringFlag{Name: "tls-key", Usage: "client private key file", EnvVar: "BASTION_TLS_KEY"},
+		cli.StringFlag{Name: "tls-server-name", Usage: "override server name of bastiond", EnvVar: "BASTION_TLS_SERVER_NAME"},
 	}
 	app.Commands = []cli.Command{
 		{
@@ -197,6 +243,32 @@ func main() {
 						return nil
 					},
 				},
+				{
+					Name:  "set-roles",
+					Usage: "set roles of a user, auditor, node-admin, node-admin:HOSTNAME_PATTERN, user-admin or super-admin",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "account", Usage: "account name of the user"},
+						cli.StringSliceFlag{Name: "role", Usage: "role of the user, can be specified multiple times, omit to clear roles"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						us := types.NewUserServiceClient(conn)
+						res, err := us.UpdateUser(context.Background(), &types.UpdateUserRequest{
+							Account:     c.String("account"),
+							UpdateRoles: true,
+							Roles:       c.StringSlice("role"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.User)
+						return nil
+					},
+				},
 				{
 					Name:  "change-password",
 					Usage: "change password",
@@ -255,8 +327,13 @@ func main() {
 						cli.StringFlag{Name: "hostname", Usage: "hostname of node"},
 						cli.StringFlag{Name: "address", Usage: "address of the node, default port is 22"},
 						cli.StringFlag{Name: "user", Usage: "ssh user will be used in bastion", Value: types.NodeUserRoot},
+						cli.StringFlag{Name: "labels", Usage: "labels of the node, for example 'env=prod,role=db'"},
 					},
 					Action: func(c *cli.Context) error {
+						labels, err := utils.ParseLabels(c.String("labels"))
+						if err != nil {
+							return err
+						}
 						conn, err := newConnection(c)
 						if err != nil {
 							return err
@@ -268,7 +345,41 @@ func main() {
 							Address:  c.String("address"),
 							User:     c.String("user"),
 							Source:   types.NodeSourceManual,
+							Labels:   labels,
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.Node)
+						return nil
+					},
+				},
+				{
+					Name:  "set-labels",
+					Usage: "set labels of a node, labels are used by label selectors of grants",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "hostname", Usage: "hostname of node"},
+						cli.StringFlag{Name: "labels", Usage: "labels of the node, for example 'env=prod,role=db', omit to clear labels"},
+					},
+					Action: func(c *cli.Context) error {
+						labels, err := utils.ParseLabels(c.String("labels"))
+						if err != nil {
+							return err
+						}
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						ns := types.NewNodeServiceClient(conn)
+						res, err := ns.UpdateNode(context.Background(), &types.UpdateNodeRequest{
+							Hostname:     c.String("hostname"),
+							UpdateLabels: true,
+							Labels:       labels,
 						})
+						if err != nil {
+							return err
+						}
 						log.Println(res.Node)
 						return nil
 					},
@@ -276,84 +387,1120 @@ func main() {
 			},
 		},
 		{
-			Name:  "sessions",
-			Usage: "session related commands",
+			Name:  "master-keys",
+			Usage: "master keys, client keys of sshd installed on key-managed nodes",
 			Subcommands: []cli.Command{
 				{
-					Name:  "seed",
-					Usage: "create 111 dummy sessions for testing purpose",
+					Name:  "list",
+					Usage: "list all master keys",
 					Action: func(c *cli.Context) error {
 						conn, err := newConnection(c)
 						if err != nil {
 							return err
 						}
 						defer conn.Close()
-						us := types.NewUserServiceClient(conn)
-						res1, err := us.ListUsers(context.Background(), &types.ListUsersRequest{})
+						mks := types.NewMasterKeyServiceClient(conn)
+						res, err := mks.ListMasterKeys(context.Background(), &types.ListMasterKeysRequest{})
 						if err != nil {
 							return err
 						}
-						if len(res1.Users) == 0 {
-							return errors.New("no users")
+						for _, k := range res.MasterKeys {
+							log.Println(k.Fingerprint, strings.TrimSpace(k.PublicKey))
 						}
-						ss := types.NewSessionServiceClient(conn)
-						for i := 0; i < 111; i++ {
-							u := res1.Users[i%len(res1.Users)]
-							res2, err := ss.CreateSession(context.Background(), &types.CreateSessionRequest{
-								Account:    u.Account,
-								Command:    "dummy_command",
-								IsRecorded: i%2 == 0,
-							})
+						return nil
+					},
+				},
+				{
+					Name:  "set",
+					Usage: "replace all master keys with public keys of sshd client keys",
+					Flags: []cli.Flag{
+						cli.StringSliceFlag{Name: "file", Usage: "public key file, can be specified multiple times"},
+					},
+					Action: func(c *cli.Context) error {
+						var keys []*types.MasterKey
+						for _, file := range c.StringSlice("file") {
+							buf, err := ioutil.ReadFile(file)
 							if err != nil {
 								return err
 							}
-							ss.FinishSession(context.Background(), &types.FinishSessionRequest{
-								Id: res2.Session.Id,
+							pk, _, _, _, err := ssh.ParseAuthorizedKey(buf)
+							if err != nil {
+								return err
+							}
+							keys = append(keys, &types.MasterKey{
+								Fingerprint: ssh.FingerprintSHA256(pk),
+								PublicKey:   string(ssh.MarshalAuthorizedKey(pk)),
 							})
 						}
+						if len(keys) == 0 {
+							return errors.New("no public key file specified")
+						}
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						mks := types.NewMasterKeyServiceClient(conn)
+						if _, err = mks.UpdateAllMasterKeys(context.Background(), &types.UpdateAllMasterKeysRequest{MasterKeys: keys}); err != nil {
+							return err
+						}
+						log.Println(len(keys), "master keys set")
 						return nil
 					},
 				},
+			},
+		},
+		{
+			Name:  "volumes",
+			Usage: "shared volume related commands",
+			Subcommands: []cli.Command{
 				{
-					Name:  "submit",
-					Usage: "submit replays to elasticsearch",
+					Name:  "list",
+					Usage: "list all shared volumes",
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						vs := types.NewVolumeServiceClient(conn)
+						res, err := vs.ListVolumes(context.Background(), &types.ListVolumesRequest{})
+						if err != nil {
+							return err
+						}
+						for _, v := range res.Volumes {
+							log.Println(v)
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "create",
+					Usage: "create or update a shared volume",
 					Flags: []cli.Flag{
-						cli.BoolFlag{Name: "all", Usage: "submit all replays, ONLY use for migration"},
+						cli.StringFlag{Name: "name", Usage: "name of the volume, mounted as /shared/NAME"},
+						cli.StringFlag{Name: "description", Usage: "description of the volume"},
 					},
-					Action: func(c *cli.Context) (err error) {
-						if !c.Bool("all") {
-							err = errors.New("only support with all flag")
-							return
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
 						}
-						var conn *grpc.ClientConn
-						if conn, err = newConnection(c); err != nil {
-							return
+						defer conn.Close()
+						vs := types.NewVolumeServiceClient(conn)
+						res, err := vs.PutVolume(context.Background(), &types.PutVolumeRequest{
+							Name:        c.String("name"),
+							Description: c.String("description"),
+						})
+						if err != nil {
+							return err
 						}
-						ss := types.NewSessionServiceClient(conn)
-						var res1 *types.ListSessionsResponse
-						if res1, err = ss.ListSessions(context.Background(), &types.ListSessionsRequest{
-							Skip:  0,
-							Limit: 1,
-						}); err != nil {
-							return
+						log.Println(res.Volume)
+						return nil
+					},
+				},
+				{
+					Name:  "delete",
+					Usage: "delete a shared volume, files on disk are kept",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the volume"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
 						}
-						if len(res1.Sessions) != 1 {
-							err = errors.New("no sessions")
-							return
+						defer conn.Close()
+						vs := types.NewVolumeServiceClient(conn)
+						_, err = vs.DeleteVolume(context.Background(), &types.DeleteVolumeRequest{
+							Name: c.String("name"),
+						})
+						return err
+					},
+				},
+				{
+					Name:  "list-members",
+					Usage: "list members of a shared volume",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the volume"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
 						}
-						sessionID := res1.Sessions[0].Id
-						rs := types.NewReplayServiceClient(conn)
-						for sessionID > 1 {
-							if _, err = rs.SubmitReplay(context.Background(), &types.SubmitReplayRequest{SessionId: sessionID}); err != nil {
-								log.Printf("%d failed\n", sessionID)
-							} else {
-								log.Printf("%d success\n", sessionID)
-							}
-							sessionID--
+						defer conn.Close()
+						vs := types.NewVolumeServiceClient(conn)
+						res, err := vs.ListVolumeMembers(context.Background(), &types.ListVolumeMembersRequest{
+							Volume: c.String("name"),
+						})
+						if err != nil {
+							return err
 						}
-						return
+						for _, m := range res.Members {
+							log.Println(m)
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "add-member",
+					Usage: "add a user or a group to a shared volume",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the volume"},
+						cli.StringFlag{Name: "kind", Usage: "kind of the member, 'user' or 'group'", Value: types.VolumeMemberKindUser},
+						cli.StringFlag{Name: "member", Usage: "account of the user, or name of the group"},
+						cli.BoolFlag{Name: "read-only", Usage: "mount the volume as read-only"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						vs := types.NewVolumeServiceClient(conn)
+						res, err := vs.PutVolumeMember(context.Background(), &types.PutVolumeMemberRequest{
+							Volume:     c.String("name"),
+							Kind:       c.String("kind"),
+							Name:       c.String("member"),
+							IsReadOnly: c.Bool("read-only"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.Member)
+						return nil
+					},
+				},
+				{
+					Name:  "remove-member",
+					Usage: "remove a user or a group from a shared volume",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the volume"},
+						cli.StringFlag{Name: "kind", Usage: "kind of the member, 'user' or 'group'", Value: types.VolumeMemberKindUser},
+						cli.StringFlag{Name: "member", Usage: "account of the user, or name of the group"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						vs := types.NewVolumeServiceClient(conn)
+						_, err = vs.DeleteVolumeMember(context.Background(), &types.DeleteVolumeMemberRequest{
+							Volume: c.String("name"),
+							Kind:   c.String("kind"),
+							Name:   c.String("member"),
+						})
+						return err
+					},
+				},
+			},
+		},
+		{
+			Name:  "requests",
+			Usage: "just-in-time access request related commands",
+			Subcommands: []cli.Command{
+				{
+					Name:  "list",
+					Usage: "list access requests",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "account", Usage: "account of the requester, optional"},
+						cli.StringFlag{Name: "status", Usage: "status of requests, pending, approved, rejected or cancelled, optional"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						ars := types.NewAccessRequestServiceClient(conn)
+						res, err := ars.ListAccessRequests(context.Background(), &types.ListAccessRequestsRequest{
+							Account: c.String("account"),
+							Status:  c.String("status"),
+						})
+						if err != nil {
+							return err
+						}
+						for _, r := range res.AccessRequests {
+							log.Println(r)
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "show",
+					Usage: "show an access request with its history",
+					Flags: []cli.Flag{
+						cli.Int64Flag{Name: "id", Usage: "id of the access request"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						ars := types.NewAccessRequestServiceClient(conn)
+						res, err := ars.GetAccessRequest(context.Background(), &types.GetAccessRequestRequest{
+							Id: c.Int64("id"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.AccessRequest)
+						for _, e := range res.Events {
+							log.Println(e)
+						}
+						return nil
 					},
 				},
+				{
+					Name:  "create",
+					Usage: "request access on behalf of a user",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "account", Usage: "account of the requester"},
+						cli.StringFlag{Name: "hostname-pattern", Usage: "hostname pattern of nodes, wildcard supported"},
+						cli.StringFlag{Name: "user", Usage: "linux user on nodes", Value: types.NodeUserRoot},
+						cli.DurationFlag{Name: "duration", Usage: "how long the access lasts once approved", Value: time.Hour},
+						cli.StringFlag{Name: "justification", Usage: "why the access is needed"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						ars := types.NewAccessRequestServiceClient(conn)
+						res, err := ars.CreateAccessRequest(context.Background(), &types.CreateAccessRequestRequest{
+							Account:         c.String("account"),
+							HostnamePattern: c.String("hostname-pattern"),
+							User:            c.String("user"),
+							Duration:        int64(c.Duration("duration") / time.Second),
+							Justification:   c.String("justification"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.AccessRequest)
+						return nil
+					},
+				},
+				{
+					Name:  "approve",
+					Usage: "approve a pending access request, creates a time-boxed grant",
+					Flags: []cli.Flag{
+						cli.Int64Flag{Name: "id", Usage: "id of the access request"},
+						cli.StringFlag{Name: "reviewer", Usage: "account of the reviewer"},
+						cli.StringFlag{Name: "comment", Usage: "review comment"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						ars := types.NewAccessRequestServiceClient(conn)
+						res, err := ars.ApproveAccessRequest(context.Background(), &types.ReviewAccessRequestRequest{
+							Id:       c.Int64("id"),
+							Reviewer: c.String("reviewer"),
+							Comment:  c.String("comment"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.AccessRequest)
+						return nil
+					},
+				},
+				{
+					Name:  "reject",
+					Usage: "reject a pending access request",
+					Flags: []cli.Flag{
+						cli.Int64Flag{Name: "id", Usage: "id of the access request"},
+						cli.StringFlag{Name: "reviewer", Usage: "account of the reviewer"},
+						cli.StringFlag{Name: "comment", Usage: "review comment"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						ars := types.NewAccessRequestServiceClient(conn)
+						res, err := ars.RejectAccessRequest(context.Background(), &types.ReviewAccessRequestRequest{
+							Id:       c.Int64("id"),
+							Reviewer: c.String("reviewer"),
+							Comment:  c.String("comment"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.AccessRequest)
+						return nil
+					},
+				},
+				{
+					Name:  "cancel",
+					Usage: "cancel a pending access request",
+					Flags: []cli.Flag{
+						cli.Int64Flag{Name: "id", Usage: "id of the access request"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						ars := types.NewAccessRequestServiceClient(conn)
+						res, err := ars.CancelAccessRequest(context.Background(), &types.CancelAccessRequestRequest{
+							Id: c.Int64("id"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.AccessRequest)
+						return nil
+					},
+				},
+			},
+		},
+		{
+			Name:  "groups",
+			Usage: "user group related commands",
+			Subcommands: []cli.Command{
+				{
+					Name:  "list",
+					Usage: "list all groups, or groups of a user",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "account", Usage: "account of the user, optional"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						gs := types.NewGroupServiceClient(conn)
+						res, err := gs.ListGroups(context.Background(), &types.ListGroupsRequest{
+							Account: c.String("account"),
+						})
+						if err != nil {
+							return err
+						}
+						for _, g := range res.Groups {
+							log.Println(g)
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "create",
+					Usage: "create or update a group",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the group"},
+						cli.StringFlag{Name: "description", Usage: "description of the group"},
+						cli.StringSliceFlag{Name: "role", Usage: "role granted to members of the group, can be specified multiple times, roles are kept if omitted"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						gs := types.NewGroupServiceClient(conn)
+						res, err := gs.PutGroup(context.Background(), &types.PutGroupRequest{
+							Name:        c.String("name"),
+							Description: c.String("description"),
+							UpdateRoles: c.IsSet("role"),
+							Roles:       c.StringSlice("role"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.Group)
+						return nil
+					},
+				},
+				{
+					Name:  "delete",
+					Usage: "delete a group, with its members and grants",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the group"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						gs := types.NewGroupServiceClient(conn)
+						_, err = gs.DeleteGroup(context.Background(), &types.DeleteGroupRequest{
+							Name: c.String("name"),
+						})
+						return err
+					},
+				},
+				{
+					Name:  "list-members",
+					Usage: "list members of a group",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the group"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						gs := types.NewGroupServiceClient(conn)
+						res, err := gs.ListGroupMembers(context.Background(), &types.ListGroupMembersRequest{
+							Group: c.String("name"),
+						})
+						if err != nil {
+							return err
+						}
+						for _, m := range res.Members {
+							log.Println(m)
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "add-member",
+					Usage: "add a user to a group",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the group"},
+						cli.StringFlag{Name: "account", Usage: "account of the user"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						gs := types.NewGroupServiceClient(conn)
+						res, err := gs.PutGroupMember(context.Background(), &types.PutGroupMemberRequest{
+							Group:   c.String("name"),
+							Account: c.String("account"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.Member)
+						return nil
+					},
+				},
+				{
+					Name:  "remove-member",
+					Usage: "remove a user from a group",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the group"},
+						cli.StringFlag{Name: "account", Usage: "account of the user"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						gs := types.NewGroupServiceClient(conn)
+						_, err = gs.DeleteGroupMember(context.Background(), &types.DeleteGroupMemberRequest{
+							Group:   c.String("name"),
+							Account: c.String("account"),
+						})
+						return err
+					},
+				},
+				{
+					Name:  "list-grants",
+					Usage: "list grants of a group",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the group"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						rs := types.NewGrantServiceClient(conn)
+						res, err := rs.ListGrants(context.Background(), &types.ListGrantsRequest{
+							Group: c.String("name"),
+						})
+						if err != nil {
+							return err
+						}
+						for _, g := range res.Grants {
+							log.Println(g)
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "add-grant",
+					Usage: "grant a group to access nodes",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the group"},
+						cli.StringFlag{Name: "hostname-pattern", Usage: "hostname pattern of nodes, wildcard supported"},
+						cli.StringFlag{Name: "label-selector", Usage: "label selector of nodes, for example 'env=prod,role=db', optional"},
+						cli.StringFlag{Name: "user", Usage: "user on the nodes", Value: types.NodeUserRoot},
+						cli.DurationFlag{Name: "expires-in", Usage: "expires in duration, never expires if not set"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						var expiredAt int64
+						if d := c.Duration("expires-in"); d > 0 {
+							expiredAt = time.Now().Add(d).Unix()
+						}
+						rs := types.NewGrantServiceClient(conn)
+						res, err := rs.PutGrant(context.Background(), &types.PutGrantRequest{
+							Group:           c.String("name"),
+							HostnamePattern: c.String("hostname-pattern"),
+							LabelSelector:   c.String("label-selector"),
+							User:            c.String("user"),
+							ExpiredAt:       expiredAt,
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.Grant)
+						return nil
+					},
+				},
+				{
+					Name:  "remove-grant",
+					Usage: "remove a grant of a group",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "name", Usage: "name of the group"},
+						cli.StringFlag{Name: "hostname-pattern", Usage: "hostname pattern of nodes"},
+						cli.StringFlag{Name: "label-selector", Usage: "label selector of nodes"},
+						cli.StringFlag{Name: "user", Usage: "user on the nodes", Value: types.NodeUserRoot},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						rs := types.NewGrantServiceClient(conn)
+						_, err = rs.DeleteGrant(context.Background(), &types.DeleteGrantRequest{
+							Group:           c.String("name"),
+							HostnamePattern: c.String("hostname-pattern"),
+							LabelSelector:   c.String("label-selector"),
+							User:            c.String("user"),
+						})
+						return err
+					},
+				},
+			},
+		},
+		{
+			Name:  "sessions",
+			Usage: "session related commands",
+			Subcommands: []cli.Command{
+				{
+					Name:  "list",
+					Usage: "list sessions newest first, with optional filters",
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "account", Usage: "account of the sessions"},
+						cli.StringFlag{Name: "hostname", Usage: "hostname of target node"},
+						cli.StringFlag{Name: "command", Usage: "substring of command, case-insensitive"},
+						cli.StringFlag{Name: "since", Usage: "sessions created at or after, RFC3339 or 2006-01-02"},
+						cli.StringFlag{Name: "until", Usage: "sessions created before, RFC3339 or 2006-01-02"},
+						cli.BoolFlag{Name: "recorded", Usage: "only recorded sessions"},
+						cli.BoolFlag{Name: "active", Usage: "only sessions not finished"},
+						cli.IntFlag{Name: "limit", Usage: "max number of sessions", Value: 20},
+						cli.Int64Flag{Name: "cursor", Usage: "next cursor printed by previous page"},
+					},
+					Action: func(c *cli.Context) error {
+						since, err := parseTimeFlag(c, "since")
+						if err != nil {
+							return err
+						}
+						until, err := parseTimeFlag(c, "until")
+						if err != nil {
+							return err
+						}
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						ss := types.NewSessionServiceClient(conn)
+						res, err := ss.ListSessions(context.Background(), &types.ListSessionsRequest{
+							Account:      c.String("account"),
+							Hostname:     c.String("hostname"),
+							Command:      c.String("command"),
+							Since:        since,
+							Until:        until,
+							OnlyRecorded: c.Bool("recorded"),
+							OnlyActive:   c.Bool("active"),
+							Limit:        int32(c.Int("limit")),
+							Cursor:       c.Int64("cursor"),
+						})
+						if err != nil {
+							return err
+						}
+						for _, s := range res.Sessions {
+							log.Println(s)
+						}
+						// total is only counted for the first page
+						if c.Int64("cursor") == 0 {
+							log.Printf("total: %d\n", res.Total)
+						}
+						if res.NextCursor > 0 {
+							log.Printf("next cursor: %d\n", res.NextCursor)
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "legal-hold",
+					Usage: "place or release legal hold of a session, replays on legal hold are never purged",
+					Flags: []cli.Flag{
+						cli.Int64Flag{Name: "id", Usage: "id of the session"},
+						cli.BoolFlag{Name: "release", Usage: "release the legal hold"},
+					},
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						ss := types.NewSessionServiceClient(conn)
+						res, err := ss.UpdateSessionLegalHold(context.Background(), &types.UpdateSessionLegalHoldRequest{
+							Id:        c.Int64("id"),
+							LegalHold: !c.Bool("release"),
+						})
+						if err != nil {
+							return err
+						}
+						log.Println(res.Session)
+						return nil
+					},
+				},
+				{
+					Name:  "seed",
+					Usage: "create 111 dummy sessions for testing purpose",
+					Action: func(c *cli.Context) error {
+						conn, err := newConnection(c)
+						if err != nil {
+							return err
+						}
+						defer conn.Close()
+						us := types.NewUserServiceClient(conn)
+						res1, err := us.ListUsers(context.Background(), &types.ListUsersRequest{})
+						if err != nil {
+							return err
+						}
+						if len(res1.Users) == 0 {
+							return errors.New("no users")
+						}
+						ss := types.NewSessionServiceClient(conn)
+						for i := 0; i < 111; i++ {
+							u := res1.Users[i%len(res1.Users)]
+							res2, err := ss.CreateSession(context.Background(), &types.CreateSessionRequest{
+								Account:    u.Account,
+								Command:    "dummy_command",
+								IsRecorded: i%2 == 0,
+							})
+							if err != nil {
+								return err
+							}
+							ss.FinishSession(context.Background(), &types.FinishSessionRequest{
+								Id: res2.Session.Id,
+							})
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "submit",
+					Usage: "submit replays to search index, previously indexed documents are replaced",
+					Flags: []cli.Flag{
+						cli.BoolFlag{Name: "all", Usage: "submit all replays, ONLY use for migration, like indexing hostnames of replays indexed before filtering by hostname"},
+					},
+					Action: func(c *cli.Context) (err error) {
+						if !c.Bool("all") {
+							err = errors.New("only support with all flag")
+							return
+						}
+						var conn *grpc.ClientConn
+						if conn, err = newConnection(c); err != nil {
+							return
+						}
+						ss := types.NewSessionServiceClient(conn)
+						var res1 *types.ListSessionsResponse
+						if res1, err = ss.ListSessions(context.Background(), &types.ListSessionsRequest{
+							Skip:  0,
+							Limit: 1,
+						}); err != nil {
+							return
+						}
+						if len(res1.Sessions) != 1 {
+							err = errors.New("no sessions")
+							return
+						}
+						sessionID := res1.Sessions[0].Id
+						rs := types.NewReplayServiceClient(conn)
+						for sessionID > 1 {
+							if _, err = rs.SubmitReplay(context.Background(), &types.SubmitReplayRequest{SessionId: sessionID}); err != nil {
+								log.Printf("%d failed\n", sessionID)
+							} else {
+								log.Printf("%d success\n", sessionID)
+							}
+							sessionID--
+						}
+						return
+					},
+				},
+			},
+		},
+		{
+			Name:  "replays",
+			Usage: "manage replays",
+			Subcommands: []cli.Command{
+				{
+					Name:  "export",
+					Usage: "export replay of a session",
+					Flags: []cli.Flag{
+						cli.Int64Flag{Name: "id", Usage: "id of the session"},
+						cli.StringFlag{Name: "format", Usage: "'cast' for asciicast v2, or 'raw' for frame format of bastion", Value: utils.ReplayFormatCast},
+						cli.StringFlag{Name: "output", Usage: "file to write, default to stdout"},
+					},
+					Action: func(c *cli.Context) (err error) {
+						var conn *grpc.ClientConn
+						if conn, err = newConnection(c); err != nil {
+							return
+						}
+						defer conn.Close()
+						ss, rs := types.NewSessionServiceClient(conn), types.NewReplayServiceClient(conn)
+						var res *types.GetSessionResponse
+						if res, err = ss.GetSession(context.Background(), &types.GetSessionRequest{Id: c.Int64("id")}); err != nil {
+							return
+						}
+						var w io.Writer = os.Stdout
+						if len(c.String("output")) > 0 {
+							var f *os.File
+							if f, err = os.OpenFile(c.String("output"), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640); err != nil {
+								return
+							}
+							defer f.Close()
+							w = f
+						}
+						var e utils.ReplayExporter
+						if e, err = utils.NewReplayExporter(w, c.String("format"), res.Session); err != nil {
+							return
+						}
+						var s types.ReplayService_ReadReplayClient
+						if s, err = rs.ReadReplay(context.Background(), &types.ReadReplayRequest{SessionId: res.Session.Id}); err != nil {
+							return
+						}
+						for {
+							var f *types.ReplayFrame
+							if f, err = s.Recv(); err != nil {
+								if err == io.EOF {
+									break
+								}
+								return
+							}
+							if err = e.WriteFrame(f); err != nil {
+								return
+							}
+						}
+						return e.Close()
+					},
+				},
+				{
+					Name:  "transcript",
+					Usage: "show commands typed in a session, extracted from the replay",
+					Flags: []cli.Flag{
+						cli.Int64Flag{Name: "id", Usage: "id of the session"},
+						cli.BoolFlag{Name: "output", Usage: "show truncated output of commands"},
+					},
+					Action: func(c *cli.Context) (err error) {
+						var conn *grpc.ClientConn
+						if conn, err = newConnection(c); err != nil {
+							return
+						}
+						defer conn.Close()
+						rs := types.NewReplayServiceClient(conn)
+						var res *types.GetTranscriptResponse
+						if res, err = rs.GetTranscript(context.Background(), &types.GetTranscriptRequest{SessionId: c.Int64("id")}); err != nil {
+							return
+						}
+						for _, e := range res.Entries {
+							fmt.Printf("[%s] %s %s\n", time.Duration(e.Timestamp)*time.Millisecond, e.Prompt, e.Command)
+							if c.Bool("output") && len(e.Output) > 0 {
+								fmt.Print(e.Output)
+								if e.OutputTruncated {
+									fmt.Println("...")
+								}
+							}
+						}
+						return
+					},
+				},
+				{
+					Name:  "verify",
+					Usage: "verify replays against their signed digests, all recorded and unpurged sessions if id is not specified",
+					Flags: []cli.Flag{
+						cli.Int64Flag{Name: "id", Usage: "id of the session"},
+					},
+					Action: func(c *cli.Context) (err error) {
+						var conn *grpc.ClientConn
+						if conn, err = newConnection(c); err != nil {
+							return
+						}
+						defer conn.Close()
+						ss, rs := types.NewSessionServiceClient(conn), types.NewReplayServiceClient(conn)
+						counts := map[string]int{}
+						verify := func(id int64) (err error) {
+							var res *types.VerifyReplayResponse
+							if res, err = rs.VerifyReplay(context.Background(), &types.VerifyReplayRequest{SessionId: id}); err != nil {
+								return
+							}
+							counts[res.Result]++
+							fmt.Printf("%d\t%s\t%s\n", id, res.Result, res.Message)
+							return
+						}
+						if id := c.Int64("id"); id > 0 {
+							if err = verify(id); err != nil {
+								return
+							}
+						} else {
+							var cursor int64
+							for {
+								var res *types.ListSessionsResponse
+								if res, err = ss.ListSessions(context.Background(), &types.ListSessionsRequest{
+									OnlyRecorded: true,
+									Limit:        100,
+									Cursor:       cursor,
+								}); err != nil {
+									return
+								}
+								for _, s := range res.Sessions {
+									if s.PurgedAt > 0 {
+										continue
+									}
+									if err = verify(s.Id); err != nil {
+										return
+									}
+								}
+								if cursor = res.NextCursor; cursor == 0 {
+									break
+								}
+							}
+						}
+						fmt.Printf("valid: %d, unsigned: %d, no digest: %d, missing: %d, untrusted: %d, tampered: %d\n",
+							counts[types.ReplayVerifyValid],
+							counts[types.ReplayVerifyUnsigned],
+							counts[types.ReplayVerifyNoDigest],
+							counts[types.ReplayVerifyMissing],
+							counts[types.ReplayVerifyUntrusted],
+							counts[types.ReplayVerifyTampered],
+						)
+						// replays recorded before signing are unsigned, not failures
+						if counts[types.ReplayVerifyNoDigest]+counts[types.ReplayVerifyMissing]+counts[types.ReplayVerifyUntrusted]+counts[types.ReplayVerifyTampered] > 0 {
+							err = errors.New("some replays failed verification")
+						}
+						return
+					},
+				},
+			},
+		},
+		{
+			Name:  "backup",
+			Usage: "take a consistent snapshot of the database from running bastiond",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "output", Usage: "file to write the snapshot"},
+			},
+			Action: func(c *cli.Context) (err error) {
+				if len(c.String("output")) == 0 {
+					return errors.New("missing flag 'output'")
+				}
+				var conn *grpc.ClientConn
+				if conn, err = newConnection(c); err != nil {
+					return
+				}
+				defer conn.Close()
+				bs := types.NewBackupServiceClient(conn)
+				var s types.BackupService_BackupClient
+				if s, err = bs.Backup(context.Background(), &types.BackupRequest{}); err != nil {
+					return
+				}
+				// write to a temporary file, a failed backup never leaves a partial snapshot
+				tmp := c.String("output") + ".tmp"
+				var f *os.File
+				if f, err = os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600); err != nil {
+					return
+				}
+				defer os.Remove(tmp)
+				defer f.Close()
+				var size int
+				for {
+					var chunk *types.BackupChunk
+					if chunk, err = s.Recv(); err != nil {
+						if err == io.EOF {
+							break
+						}
+						return
+					}
+					if _, err = f.Write(chunk.Data); err != nil {
+						return
+					}
+					size += len(chunk.Data)
+				}
+				if err = f.Sync(); err != nil {
+					return
+				}
+				if err = os.Rename(tmp, c.String("output")); err != nil {
+					return
+				}
+				log.Printf("%d bytes written to %s\n", size, c.String("output"))
+				return
+			},
+		},
+		{
+			Name:  "restore",
+			Usage: "replace the database file with a snapshot, bastiond must be stopped",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "input", Usage: "snapshot file created by backup command"},
+				cli.StringFlag{Name: "db", Usage: "database file of bastiond", Value: "/var/lib/bastion/database.bolt"},
+			},
+			Action: func(c *cli.Context) (err error) {
+				if len(c.String("input")) == 0 {
+					return errors.New("missing flag 'input'")
+				}
+				if err = daemon.Restore(c.String("input"), c.String("db")); err != nil {
+					return
+				}
+				log.Printf("%s restored to %s\n", c.String("input"), c.String("db"))
+				return
+			},
+		},
+		{
+			Name:  "migrate",
+			Usage: "copy all records from one database to another, for example from bolt to postgres, bastiond must be stopped",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "from-driver", Usage: "driver of source database, bolt, sqlite3 or postgres", Value: types.DBDriverBolt},
+				cli.StringFlag{Name: "from", Usage: "source database, file path or data source name", Value: "/var/lib/bastion/database.bolt"},
+				cli.StringFlag{Name: "to-driver", Usage: "driver of destination database, bolt, sqlite3 or postgres"},
+				cli.StringFlag{Name: "to", Usage: "destination database, file path or data source name, must be empty"},
+			},
+			Action: func(c *cli.Context) (err error) {
+				if len(c.String("to-driver")) == 0 {
+					return errors.New("missing flag 'to-driver'")
+				}
+				if len(c.String("to")) == 0 {
+					return errors.New("missing flag 'to'")
+				}
+				var src, dst daemon.Store
+				if src, err = daemon.OpenStore(types.DaemonOptions{DBDriver: c.String("from-driver"), DB: c.String("from")}); err != nil {
+					return
+				}
+				defer src.Close()
+				if dst, err = daemon.OpenStore(types.DaemonOptions{DBDriver: c.String("to-driver"), DB: c.String("to")}); err != nil {
+					return
+				}
+				defer dst.Close()
+				var count int
+				if count, err = daemon.Migrate(src, dst); err != nil {
+					return
+				}
+				log.Printf("%d records migrated from %s to %s\n", count, c.String("from-driver"), c.String("to-driver"))
+				return
+			},
+		},
+		{
+			Name:  "export",
+			Usage: "export all records from running bastiond as JSON",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "output", Usage: "file to write the JSON"},
+			},
+			Action: func(c *cli.Context) (err error) {
+				if len(c.String("output")) == 0 {
+					return errors.New("missing flag 'output'")
+				}
+				var conn *grpc.ClientConn
+				if conn, err = newConnection(c); err != nil {
+					return
+				}
+				defer conn.Close()
+				bs := types.NewBackupServiceClient(conn)
+				var s types.BackupService_ExportClient
+				if s, err = bs.Export(context.Background(), &types.ExportRequest{}); err != nil {
+					return
+				}
+				out := exportFile{Version: exportFileVersion, Models: map[string][]json.RawMessage{}}
+				var count int
+				for {
+					var r *types.ModelRecord
+					if r, err = s.Recv(); err != nil {
+						if err == io.EOF {
+							break
+						}
+						return
+					}
+					out.Models[r.Model] = append(out.Models[r.Model], json.RawMessage(r.Data))
+					count++
+				}
+				var buf []byte
+				if buf, err = json.MarshalIndent(out, "", "  "); err != nil {
+					return
+				}
+				if err = ioutil.WriteFile(c.String("output"), buf, 0600); err != nil {
+					return
+				}
+				log.Printf("%d records exported to %s\n", count, c.String("output"))
+				return
+			},
+		},
+		{
+			Name:  "import",
+			Usage: "import records from a JSON export into running bastiond, existing records with same ids are overwritten",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "input", Usage: "JSON file created by export command"},
+			},
+			Action: func(c *cli.Context) (err error) {
+				var buf []byte
+				if buf, err = ioutil.ReadFile(c.String("input")); err != nil {
+					return
+				}
+				in := exportFile{}
+				if err = json.Unmarshal(buf, &in); err != nil {
+					return
+				}
+				if in.Version != exportFileVersion {
+					return fmt.Errorf("unsupported export version %d", in.Version)
+				}
+				var conn *grpc.ClientConn
+				if conn, err = newConnection(c); err != nil {
+					return
+				}
+				defer conn.Close()
+				bs := types.NewBackupServiceClient(conn)
+				var s types.BackupService_ImportClient
+				if s, err = bs.Import(context.Background()); err != nil {
+					return
+				}
+				for model, records := range in.Models {
+					for _, r := range records {
+						if err = s.Send(&types.ModelRecord{Model: model, Data: r}); err != nil {
+							return
+						}
+					}
+				}
+				var res *types.ImportResponse
+				if res, err = s.CloseAndRecv(); err != nil {
+					return
+				}
+				log.Printf("%d records imported\n", res.Count)
+				return
 			},
 		},
 	}
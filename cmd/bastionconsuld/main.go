@@ -15,6 +15,7 @@ import (
 var (
 	dev      bool
 	endpoint string
+	tlsOpts  types.TLSOptions
 
 	lastIndex uint64
 )
@@ -28,6 +29,10 @@ func main() {
 
 	flag.StringVar(&endpoint, "endpoint", "127.0.0.1:9777", "endpoint address of bunkerd")
 	flag.BoolVar(&dev, "dev", false, "enable dev mode")
+	flag.StringVar(&tlsOpts.CA, "tls-ca", "", "ca certificate file of bastiond")
+	flag.StringVar(&tlsOpts.Cert, "tls-cert", "", "client certificate file, tls is enabled if not empty")
+	flag.StringVar(&tlsOpts.Key, "tls-key", "", "client private key file")
+	flag.StringVar(&tlsOpts.ServerName, "tls-server-name", "", "override server name of bastiond")
 	flag.Parse()
 
 	// update logger
@@ -64,8 +69,12 @@ func update() (err error) {
 	}
 	lastIndex = mt.LastIndex
 	// create grpc connection
+	var dos []grpc.DialOption
+	if dos, err = types.DialOptions(types.SourceConsul, "", tlsOpts); err != nil {
+		return
+	}
 	var bcn *grpc.ClientConn
-	if bcn, err = grpc.Dial(endpoint, grpc.WithInsecure()); err != nil {
+	if bcn, err = grpc.Dial(endpoint, dos...); err != nil {
 		return
 	}
 	defer bcn.Close()
@@ -103,7 +112,7 @@ addLoop:
 	for _, cn := range cns {
 		// check existed and equal
 		for _, n := range lnr.Nodes {
-			if n.Hostname == cn.Node && n.User == types.NodeUserRoot && n.Address == cn.Address && n.Source == types.NodeSourceConsul {
+			if n.Hostname == cn.Node && n.User == types.NodeUserRoot && n.Address == cn.Address && n.Source == types.NodeSourceConsul && labelsEqual(n.Labels, consulLabels(cn.Meta)) {
 				log.Debug().Str("hostname", cn.Node).Str("address", cn.Address).Msg("synced node")
 				continue addLoop
 			}
@@ -114,6 +123,7 @@ addLoop:
 			User:     types.NodeUserRoot,
 			Address:  cn.Address,
 			Source:   types.NodeSourceConsul,
+			Labels:   consulLabels(cn.Meta),
 		}); err != nil {
 			log.Error().Str("hostname", cn.Node).Str("address", cn.Address).Err(err).Msg("failed to add node")
 			err = nil
@@ -122,3 +132,26 @@ addLoop:
 	}
 	return
 }
+
+// consulLabels labels from consul node meta, entries not valid as labels are ignored
+func consulLabels(meta map[string]string) map[string]string {
+	labels := map[string]string{}
+	for k, v := range meta {
+		if types.LabelKeyPattern.MatchString(k) && types.LabelValuePattern.MatchString(v) {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
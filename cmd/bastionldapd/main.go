@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/ldapsync"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+)
+
+var (
+	dev      bool
+	endpoint string
+	interval time.Duration
+	tlsOpts  types.TLSOptions
+
+	opts ldapsync.Options
+)
+
+func main() {
+	var err error
+
+	// init logger
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout, NoColor: true})
+
+	flag.StringVar(&endpoint, "endpoint", "127.0.0.1:9777", "endpoint address of bastiond")
+	flag.BoolVar(&dev, "dev", false, "enable dev mode")
+	flag.DurationVar(&interval, "interval", time.Minute*5, "interval between synchronizations")
+	flag.StringVar(&tlsOpts.CA, "tls-ca", "", "ca certificate file of bastiond")
+	flag.StringVar(&tlsOpts.Cert, "tls-cert", "", "client certificate file, tls is enabled if not empty")
+	flag.StringVar(&tlsOpts.Key, "tls-key", "", "client private key file")
+	flag.StringVar(&tlsOpts.ServerName, "tls-server-name", "", "override server name of bastiond")
+	flag.StringVar(&opts.URL, "url", "ldap://127.0.0.1:389", "url of ldap server, ldap://HOST:PORT or ldaps://HOST:PORT")
+	flag.BoolVar(&opts.StartTLS, "start-tls", false, "upgrade ldap:// connection with StartTLS")
+	flag.BoolVar(&opts.InsecureSkipVerify, "insecure-skip-verify", false, "skip verification of server certificate")
+	flag.StringVar(&opts.BindDN, "bind-dn", "", "bind dn for searching, anonymous if empty")
+	flag.StringVar(&opts.BindPassword, "bind-password", os.Getenv("BASTION_LDAP_BIND_PASSWORD"), "bind password, default to environment variable BASTION_LDAP_BIND_PASSWORD")
+	flag.StringVar(&opts.UserBaseDN, "user-base-dn", "", "base dn of users")
+	flag.StringVar(&opts.UserFilter, "user-filter", ldapsync.DefaultUserFilter, "filter of users")
+	flag.StringVar(&opts.AccountAttribute, "account-attribute", ldapsync.DefaultAccountAttribute, "attribute of account")
+	flag.StringVar(&opts.NicknameAttribute, "nickname-attribute", ldapsync.DefaultNicknameAttribute, "attribute of nickname")
+	flag.StringVar(&opts.KeyAttribute, "key-attribute", ldapsync.DefaultKeyAttribute, "attribute of ssh public keys")
+	flag.StringVar(&opts.GroupBaseDN, "group-base-dn", "", "base dn of groups, groups are not synchronized if empty")
+	flag.StringVar(&opts.GroupFilter, "group-filter", ldapsync.DefaultGroupFilter, "filter of groups")
+	flag.StringVar(&opts.GroupNameAttribute, "group-name-attribute", ldapsync.DefaultGroupNameAttribute, "attribute of group name")
+	flag.StringVar(&opts.GroupMemberAttribute, "group-member-attribute", ldapsync.DefaultGroupMemberAttribute, "attribute of group members, dn of users like 'member' or accounts like 'memberUid'")
+	flag.Parse()
+
+	// update logger
+	if dev {
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout})
+	}
+
+	for {
+		log.Debug().Msg("sync started")
+		if err = sync(); err != nil {
+			log.Error().Err(err).Msg("sync failed")
+		} else {
+			log.Debug().Msg("sync finished")
+		}
+		time.Sleep(interval)
+	}
+}
+
+func sync() (err error) {
+	// query directory
+	var dir ldapsync.Directory
+	if dir, err = ldapsync.Fetch(opts); err != nil {
+		return
+	}
+	// create grpc connection
+	var dos []grpc.DialOption
+	if dos, err = types.DialOptions(types.SourceLDAP, "", tlsOpts); err != nil {
+		return
+	}
+	var bcn *grpc.ClientConn
+	if bcn, err = grpc.Dial(endpoint, dos...); err != nil {
+		return
+	}
+	defer bcn.Close()
+	return ldapsync.Sync(bcn, dir)
+}
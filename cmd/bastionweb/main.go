@@ -5,6 +5,7 @@ import (
 	"flag"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/sshd/sandbox"
 	"github.com/yankeguo/bastion/types"
 	"github.com/yankeguo/bastion/web"
 	"net/http"
@@ -52,8 +53,16 @@ func main() {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout})
 	}
 
+	// create sandbox manager, shared by all requests
+	var m sandbox.Manager
+	if m, err = sandbox.NewManager(options.SSHD); err != nil {
+		log.Error().Err(err).Msg("failed to create sandbox manager")
+		os.Exit(1)
+		return
+	}
+
 	// create http server
-	s := web.NewServer(options.Web)
+	s := web.NewServer(options.Web, options.SSHD, m)
 
 	// run the signalHandler
 	go signalHandler(s)
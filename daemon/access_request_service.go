@@ -0,0 +1,253 @@
+package daemon
+
+import (
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errAccessRequestNotPending = status.Error(codes.FailedPrecondition, "access request is not pending")
+	errAccessRequestSelfReview = status.Error(codes.PermissionDenied, "can not review own access request")
+)
+
+func (d *Daemon) CreateAccessRequest(c context.Context, req *types.CreateAccessRequestRequest) (res *types.CreateAccessRequestResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	r := models.AccessRequest{
+		Account:         req.Account,
+		HostnamePattern: req.HostnamePattern,
+		User:            req.User,
+		Duration:        req.Duration,
+		Justification:   req.Justification,
+		Status:          types.AccessRequestStatusPending,
+		CreatedAt:       now(),
+	}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		// only existing users can request access
+		if _, err = db.Users().Get(req.Account); err != nil {
+			return
+		}
+		if err = db.AccessRequests().Save(&r); err != nil {
+			return
+		}
+		if err = saveAccessRequestEvent(db, r.Id, types.AccessRequestActionCreate, actorOr(c, r.Account), r.Justification); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionAccessRequestCreate, accessRequestTarget(r.Id), nil, r.ToGRPCAccessRequest())
+	}); err != nil {
+		return
+	}
+	log.Info().Int64("id", r.Id).Str("account", r.Account).Str("hostnamePattern", r.HostnamePattern).Str("user", r.User).Int64("duration", r.Duration).Msg("access request created")
+	res = &types.CreateAccessRequestResponse{AccessRequest: r.ToGRPCAccessRequest()}
+	return
+}
+
+func (d *Daemon) ListAccessRequests(c context.Context, req *types.ListAccessRequestsRequest) (res *types.ListAccessRequestsResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var rs []models.AccessRequest
+	if len(req.Account) > 0 {
+		rs, err = d.db.AccessRequests().ListByAccount(req.Account)
+	} else if len(req.Status) > 0 {
+		rs, err = d.db.AccessRequests().ListByStatus(req.Status)
+	} else {
+		rs, err = d.db.AccessRequests().List()
+	}
+	if err != nil {
+		return
+	}
+	// newest first
+	ret := make([]*types.AccessRequest, 0, len(rs))
+	for i := len(rs) - 1; i >= 0; i-- {
+		if len(req.Status) > 0 && rs[i].Status != req.Status {
+			continue
+		}
+		ret = append(ret, rs[i].ToGRPCAccessRequest())
+	}
+	res = &types.ListAccessRequestsResponse{AccessRequests: ret}
+	return
+}
+
+func (d *Daemon) GetAccessRequest(c context.Context, req *types.GetAccessRequestRequest) (res *types.GetAccessRequestResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	r := models.AccessRequest{}
+	if r, err = d.db.AccessRequests().Get(req.Id); err != nil {
+		return
+	}
+	// requester can always see the request
+	if actor := actorFromContext(c); actor != r.Account {
+		if err = d.authorizeHostname(c, types.PermissionGrantsRead, r.HostnamePattern); err != nil {
+			return
+		}
+	}
+	var es []models.AccessRequestEvent
+	if es, err = d.db.AccessRequestEvents().ListByRequest(r.Id); err != nil {
+		return
+	}
+	ret := make([]*types.AccessRequestEvent, 0, len(es))
+	for _, e := range es {
+		ret = append(ret, e.ToGRPCAccessRequestEvent())
+	}
+	res = &types.GetAccessRequestResponse{AccessRequest: r.ToGRPCAccessRequest(), Events: ret}
+	return
+}
+
+func (d *Daemon) ApproveAccessRequest(c context.Context, req *types.ReviewAccessRequestRequest) (res *types.ReviewAccessRequestResponse, err error) {
+	return d.reviewAccessRequest(c, req, true)
+}
+
+func (d *Daemon) RejectAccessRequest(c context.Context, req *types.ReviewAccessRequestRequest) (res *types.ReviewAccessRequestResponse, err error) {
+	return d.reviewAccessRequest(c, req, false)
+}
+
+func (d *Daemon) reviewAccessRequest(c context.Context, req *types.ReviewAccessRequestRequest, approve bool) (res *types.ReviewAccessRequestResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	// reviewer is the actor if request is made on behalf of a user
+	reviewer := actorOr(c, req.Reviewer)
+	if len(reviewer) == 0 {
+		err = status.Error(codes.InvalidArgument, "missing field 'reviewer'")
+		return
+	}
+	r := models.AccessRequest{}
+	if r, err = d.db.AccessRequests().Get(req.Id); err != nil {
+		return
+	}
+	if err = d.authorizeHostname(c, types.PermissionGrantsWrite, r.HostnamePattern); err != nil {
+		return
+	}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		// re-read in transaction, request might be reviewed concurrently
+		if r, err = db.AccessRequests().Get(req.Id); err != nil {
+			return
+		}
+		if r.Status != types.AccessRequestStatusPending {
+			return errAccessRequestNotPending
+		}
+		before := r.ToGRPCAccessRequest()
+		if r.Account == reviewer {
+			return errAccessRequestSelfReview
+		}
+		r.Reviewer = reviewer
+		r.ReviewComment = req.Comment
+		r.ReviewedAt = now()
+		action, auditAction := types.AccessRequestActionReject, types.AuditActionAccessRequestReject
+		r.Status = types.AccessRequestStatusRejected
+		if approve {
+			action, auditAction = types.AccessRequestActionApprove, types.AuditActionAccessRequestApprove
+			r.Status = types.AccessRequestStatusApproved
+			r.GrantExpiredAt = r.ReviewedAt + r.Duration
+			if err = putAccessRequestGrant(db, r); err != nil {
+				return
+			}
+		}
+		if err = db.AccessRequests().Save(&r); err != nil {
+			return
+		}
+		if err = saveAccessRequestEvent(db, r.Id, action, reviewer, req.Comment); err != nil {
+			return
+		}
+		return d.audit(c, db, auditAction, accessRequestTarget(r.Id), before, r.ToGRPCAccessRequest())
+	}); err != nil {
+		return
+	}
+	if approve {
+		d.grantWatcher.Notify(r.Account)
+	}
+	log.Info().Int64("id", r.Id).Str("account", r.Account).Str("reviewer", reviewer).Str("status", r.Status).Msg("access request reviewed")
+	res = &types.ReviewAccessRequestResponse{AccessRequest: r.ToGRPCAccessRequest()}
+	return
+}
+
+func (d *Daemon) CancelAccessRequest(c context.Context, req *types.CancelAccessRequestRequest) (res *types.CancelAccessRequestResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	r := models.AccessRequest{}
+	if r, err = d.db.AccessRequests().Get(req.Id); err != nil {
+		return
+	}
+	// requester cancels own request, approvers can cancel any request they can review
+	actor := actorOr(c, req.Account)
+	if actor != r.Account {
+		if err = d.authorizeHostname(c, types.PermissionGrantsWrite, r.HostnamePattern); err != nil {
+			return
+		}
+	}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		if r, err = db.AccessRequests().Get(req.Id); err != nil {
+			return
+		}
+		if r.Status != types.AccessRequestStatusPending {
+			return errAccessRequestNotPending
+		}
+		before := r.ToGRPCAccessRequest()
+		r.Status = types.AccessRequestStatusCancelled
+		if err = db.AccessRequests().Save(&r); err != nil {
+			return
+		}
+		if err = saveAccessRequestEvent(db, r.Id, types.AccessRequestActionCancel, actor, ""); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionAccessRequestCancel, accessRequestTarget(r.Id), before, r.ToGRPCAccessRequest())
+	}); err != nil {
+		return
+	}
+	log.Info().Int64("id", r.Id).Str("account", r.Account).Str("actor", actor).Msg("access request cancelled")
+	res = &types.CancelAccessRequestResponse{AccessRequest: r.ToGRPCAccessRequest()}
+	return
+}
+
+// putAccessRequestGrant create the time-boxed grant of an approved request, an existing grant lasts longer is kept as it is
+func putAccessRequestGrant(db Repositories, r models.AccessRequest) (err error) {
+	g := models.Grant{
+		Account:         r.Account,
+		HostnamePattern: r.HostnamePattern,
+		User:            r.User,
+		ExpiredAt:       r.GrantExpiredAt,
+		CreatedAt:       now(),
+	}
+	g.Id = g.BuildId()
+	var e models.Grant
+	if e, err = db.Grants().Get(g.Id); err == nil {
+		if e.ExpiredAt == 0 || e.ExpiredAt >= g.ExpiredAt {
+			return
+		}
+	} else if err != errRecordNotFound {
+		return
+	}
+	return db.Grants().Save(&g)
+}
+
+func saveAccessRequestEvent(db Repositories, id int64, action string, actor string, comment string) error {
+	return db.AccessRequestEvents().Save(&models.AccessRequestEvent{
+		RequestId: id,
+		Action:    action,
+		Actor:     actor,
+		Comment:   comment,
+		CreatedAt: now(),
+	})
+}
+
+func accessRequestTarget(id int64) string {
+	return "access_request:" + strconv.FormatInt(id, 10)
+}
+
+// actorOr the actor of request, or fallback for internal callers
+func actorOr(c context.Context, fallback string) string {
+	if actor := actorFromContext(c); len(actor) > 0 {
+		return actor
+	}
+	return fallback
+}
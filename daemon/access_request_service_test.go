@@ -0,0 +1,98 @@
+package daemon
+
+import (
+	"context"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+func TestDaemon_AccessRequestWorkflow(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		us := types.NewUserServiceClient(conn)
+		ars := types.NewAccessRequestServiceClient(conn)
+		gs := types.NewGrantServiceClient(conn)
+		ns := types.NewNodeServiceClient(conn)
+
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "dev1", Password: "qwerty"})
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "lead1", Password: "qwerty"})
+		us.UpdateUser(context.Background(), &types.UpdateUserRequest{Account: "lead1", UpdateRoles: true, Roles: []string{"node-admin:web-*"}})
+
+		as := func(account string) context.Context {
+			return metadata.AppendToOutgoingContext(context.Background(), types.MetadataKeyActor, account)
+		}
+
+		if _, err := ars.CreateAccessRequest(as("dev1"), &types.CreateAccessRequestRequest{Account: "lead1", HostnamePattern: "web-1", Duration: 3600, Justification: "debug"}); err == nil {
+			t.Fatal("should not request access for others")
+		}
+		if _, err := ars.CreateAccessRequest(as("dev1"), &types.CreateAccessRequestRequest{Account: "dev1", HostnamePattern: "web-1", Duration: 3600}); err == nil {
+			t.Fatal("should fail without justification")
+		}
+		res1, err := ars.CreateAccessRequest(as("dev1"), &types.CreateAccessRequestRequest{Account: "dev1", HostnamePattern: "web-1", Duration: 3600, Justification: "debug"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res2, err := ars.CreateAccessRequest(as("dev1"), &types.CreateAccessRequestRequest{Account: "dev1", HostnamePattern: "db-1", Duration: 3600, Justification: "debug"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res3, err := ars.ListAccessRequests(as("lead1"), &types.ListAccessRequestsRequest{Status: types.AccessRequestStatusPending})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res3.AccessRequests) != 2 {
+			t.Fatal("bad pending requests count", res3.AccessRequests)
+		}
+		if _, err = ars.ApproveAccessRequest(as("dev1"), &types.ReviewAccessRequestRequest{Id: res1.AccessRequest.Id}); err == nil {
+			t.Fatal("should not approve without permission")
+		}
+		if _, err = ars.ApproveAccessRequest(as("lead1"), &types.ReviewAccessRequestRequest{Id: res2.AccessRequest.Id}); err == nil {
+			t.Fatal("should not approve out of scope")
+		}
+		res4, err := ars.ApproveAccessRequest(as("lead1"), &types.ReviewAccessRequestRequest{Id: res1.AccessRequest.Id, Comment: "ok"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res4.AccessRequest.Status != types.AccessRequestStatusApproved || res4.AccessRequest.Reviewer != "lead1" || res4.AccessRequest.GrantExpiredAt == 0 {
+			t.Fatal("bad approved request", res4.AccessRequest)
+		}
+		if _, err = ars.RejectAccessRequest(as("lead1"), &types.ReviewAccessRequestRequest{Id: res1.AccessRequest.Id}); err == nil {
+			t.Fatal("should not review twice")
+		}
+		if _, err = ns.PutNode(context.Background(), &types.PutNodeRequest{Hostname: "web-1", Address: "10.0.0.1"}); err != nil {
+			t.Fatal(err)
+		}
+		res5, err := gs.CheckGrant(context.Background(), &types.CheckGrantRequest{Account: "dev1", Hostname: "web-1", User: "root"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res5.Ok {
+			t.Fatal("approved request should grant access")
+		}
+		res6, err := gs.ListGrants(context.Background(), &types.ListGrantsRequest{Account: "dev1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res6.Grants) != 1 || res6.Grants[0].ExpiredAt != res4.AccessRequest.GrantExpiredAt {
+			t.Fatal("grant should be time-boxed", res6.Grants)
+		}
+		if _, err = ars.CancelAccessRequest(as("dev1"), &types.CancelAccessRequestRequest{Id: res2.AccessRequest.Id}); err != nil {
+			t.Fatal(err)
+		}
+		res7, err := ars.GetAccessRequest(as("dev1"), &types.GetAccessRequestRequest{Id: res1.AccessRequest.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res7.Events) != 2 || res7.Events[0].Action != types.AccessRequestActionCreate || res7.Events[1].Action != types.AccessRequestActionApprove || res7.Events[1].Actor != "lead1" {
+			t.Fatal("bad events", res7.Events)
+		}
+		res8, err := ars.ListAccessRequests(as("dev1"), &types.ListAccessRequestsRequest{Account: "dev1", Status: types.AccessRequestStatusCancelled})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res8.AccessRequests) != 1 || res8.AccessRequests[0].Id != res2.AccessRequest.Id {
+			t.Fatal("bad cancelled requests", res8.AccessRequests)
+		}
+	})
+}
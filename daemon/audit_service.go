@@ -0,0 +1,89 @@
+package daemon
+
+import (
+	"encoding/json"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// audit append an audit event in the transaction of the audited change, before and after are marshalled as JSON,
+// nil for non-existent state, the change is rolled back if the event can not be saved
+func (d *Daemon) audit(c context.Context, db Repositories, action string, target string, before interface{}, after interface{}) (err error) {
+	e := models.AuditEvent{
+		Actor:     actorFromContext(c),
+		Action:    action,
+		Target:    target,
+		Before:    marshalAuditValue(before),
+		After:     marshalAuditValue(after),
+		CreatedAt: now(),
+	}
+	if md, ok := metadata.FromIncomingContext(c); ok {
+		if vs := md.Get(types.MetadataKeySource); len(vs) > 0 {
+			e.Source = vs[0]
+		}
+		if vs := md.Get(types.MetadataKeyClientAddr); len(vs) > 0 {
+			e.Address = vs[0]
+		}
+	}
+	// identity of client certificate is preferred over the declared source
+	if caller := callerFromContext(c); len(caller) > 0 {
+		e.Source = caller
+	}
+	if len(e.Address) == 0 {
+		if p, ok := peer.FromContext(c); ok && p.Addr != nil {
+			e.Address = p.Addr.String()
+		}
+	}
+	if err = db.AuditEvents().Append(&e); err != nil {
+		log.Error().Err(err).Str("action", action).Str("target", target).Msg("failed to save audit event")
+	}
+	return
+}
+
+func marshalAuditValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	buf, err := json.Marshal(v)
+	// typed nil pointers are marshalled as null
+	if err != nil || string(buf) == "null" {
+		return ""
+	}
+	return string(buf)
+}
+
+func (d *Daemon) ListAuditEvents(c context.Context, req *types.ListAuditEventsRequest) (res *types.ListAuditEventsResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var es []models.AuditEvent
+	var total int
+	if es, total, err = d.db.AuditEvents().Query(AuditEventQuery{
+		Actor:  req.Actor,
+		Action: req.Action,
+		Target: req.Target,
+		Source: req.Source,
+		Since:  req.Since,
+		Until:  req.Until,
+		Skip:   int(req.Skip),
+		Limit:  int(req.Limit),
+	}); err != nil {
+		return
+	}
+	ret := make([]*types.AuditEvent, 0, len(es))
+	for _, e := range es {
+		ret = append(ret, e.ToGRPCAuditEvent())
+	}
+	res = &types.ListAuditEventsResponse{
+		AuditEvents: ret,
+		Total:       int32(total),
+		Skip:        req.Skip,
+		Limit:       req.Limit,
+	}
+	return
+}
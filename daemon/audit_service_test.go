@@ -0,0 +1,65 @@
+package daemon
+
+import (
+	"context"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+	"testing"
+)
+
+func TestDaemon_ListAuditEvents(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		us := types.NewUserServiceClient(conn)
+		gs := types.NewGrantServiceClient(conn)
+		as := types.NewAuditServiceClient(conn)
+
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "admin1", Password: "qwerty"})
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "dev1", Password: "qwerty"})
+		us.UpdateUser(context.Background(), &types.UpdateUserRequest{Account: "admin1", UpdateRoles: true, Roles: []string{"super-admin"}})
+
+		c := metadata.AppendToOutgoingContext(context.Background(),
+			types.MetadataKeyActor, "admin1",
+			types.MetadataKeySource, types.SourceWeb,
+			types.MetadataKeyClientAddr, "10.0.0.1",
+		)
+		if _, err := gs.PutGrant(c, &types.PutGrantRequest{Account: "dev1", HostnamePattern: "web-*", User: "root"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gs.PutGrant(c, &types.PutGrantRequest{Account: "dev1", HostnamePattern: "web-*", User: "root", ExpiredAt: 1000}); err != nil {
+			t.Fatal(err)
+		}
+
+		res1, err := as.ListAuditEvents(context.Background(), &types.ListAuditEventsRequest{Action: types.AuditActionGrantPut})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res1.Total != 2 || len(res1.AuditEvents) != 2 {
+			t.Fatal("bad audit events count", res1.AuditEvents)
+		}
+		// newest first
+		e := res1.AuditEvents[0]
+		if e.Actor != "admin1" || e.Source != types.SourceWeb || e.Address != "10.0.0.1" || e.Target != "grant:dev1$web-*$root" {
+			t.Fatal("bad audit event", e)
+		}
+		if !strings.Contains(e.Before, `"account":"dev1"`) || !strings.Contains(e.After, `"expired_at":1000`) {
+			t.Fatal("bad before or after", e)
+		}
+		if len(res1.AuditEvents[1].Before) != 0 {
+			t.Fatal("first put should have no before", res1.AuditEvents[1])
+		}
+
+		res2, err := as.ListAuditEvents(context.Background(), &types.ListAuditEventsRequest{Actor: "admin1", Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res2.Total != 2 || len(res2.AuditEvents) != 1 {
+			t.Fatal("bad audit events by actor", res2)
+		}
+
+		if _, err = as.ListAuditEvents(metadata.AppendToOutgoingContext(context.Background(), types.MetadataKeyActor, "dev1"), &types.ListAuditEventsRequest{}); err == nil {
+			t.Fatal("should not list audit events without permission")
+		}
+	})
+}
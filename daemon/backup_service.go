@@ -0,0 +1,190 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	backupChunkSize = 64 * 1024
+	backupTarget    = "database"
+)
+
+// modelTypes struct types of all models, keyed by name
+func modelTypes() map[string]reflect.Type {
+	ret := map[string]reflect.Type{}
+	for _, m := range models.AllModels {
+		t := reflect.TypeOf(m).Elem()
+		ret[t.Name()] = t
+	}
+	return ret
+}
+
+// backupChunkWriter sends everything written as backup chunks
+type backupChunkWriter struct {
+	s types.BackupService_BackupServer
+}
+
+func (w backupChunkWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		l := len(p)
+		if l > backupChunkSize {
+			l = backupChunkSize
+		}
+		// grpc may hold the buffer after Send returns, copy it
+		buf := make([]byte, l)
+		copy(buf, p[:l])
+		if err = w.s.Send(&types.BackupChunk{Data: buf}); err != nil {
+			return
+		}
+		n, p = n+l, p[l:]
+	}
+	return
+}
+
+func (d *Daemon) Backup(req *types.BackupRequest, s types.BackupService_BackupServer) (err error) {
+	// only file based storage can be backed up as a whole, use Export for others
+	wt, ok := d.db.(io.WriterTo)
+	if !ok {
+		err = status.Error(codes.Unimplemented, "backup is not supported by the database driver, use export instead")
+		return
+	}
+	// audited before any data is sent, a backup is refused if it can not be audited
+	if err = d.db.Tx(true, func(db Repositories) error {
+		return d.audit(s.Context(), db, types.AuditActionDatabaseBackup, backupTarget, nil, nil)
+	}); err != nil {
+		return
+	}
+	var n int64
+	if n, err = wt.WriteTo(backupChunkWriter{s: s}); err != nil {
+		return
+	}
+	log.Info().Int64("size", n).Msg("database backup sent")
+	return
+}
+
+func (d *Daemon) Export(req *types.ExportRequest, s types.BackupService_ExportServer) (err error) {
+	if err = d.db.Tx(true, func(db Repositories) error {
+		return d.audit(s.Context(), db, types.AuditActionDatabaseExport, backupTarget, nil, nil)
+	}); err != nil {
+		return
+	}
+	return d.db.Export(func(record interface{}) (err error) {
+		var buf []byte
+		if buf, err = json.Marshal(record); err != nil {
+			return
+		}
+		return s.Send(&types.ModelRecord{Model: reflect.TypeOf(record).Elem().Name(), Data: buf})
+	})
+}
+
+func (d *Daemon) Import(s types.BackupService_ImportServer) (err error) {
+	ts := modelTypes()
+	// receive all records, then import them in a single transaction
+	var records []interface{}
+	var accounts []string
+	for {
+		var r *types.ModelRecord
+		if r, err = s.Recv(); err != nil {
+			if err == io.EOF {
+				err = nil
+				break
+			}
+			return
+		}
+		t := ts[r.Model]
+		if t == nil {
+			err = status.Errorf(codes.InvalidArgument, "unknown model '%s'", r.Model)
+			return
+		}
+		v := reflect.New(t)
+		if err = json.Unmarshal(r.Data, v.Interface()); err != nil {
+			err = status.Errorf(codes.InvalidArgument, "invalid record of model '%s': %s", r.Model, err.Error())
+			return
+		}
+		records = append(records, v.Interface())
+		if g, ok := v.Interface().(*models.Grant); ok {
+			accounts = append(accounts, d.grantAccounts(*g)...)
+		}
+	}
+	// existing audit events are refused by the store, the import itself is audited in the same transaction
+	if err = d.db.Import(records, func(db Repositories) error {
+		return d.audit(s.Context(), db, types.AuditActionDatabaseImport, backupTarget, nil, map[string]int{"count": len(records)})
+	}); err != nil {
+		return
+	}
+	d.grantWatcher.Notify(accounts...)
+	log.Info().Int("count", len(records)).Msg("database records imported")
+	return s.SendAndClose(&types.ImportResponse{Count: int64(len(records))})
+}
+
+// Restore replace database file dst with backup file src, daemon using dst must be stopped first
+func Restore(src string, dst string) (err error) {
+	// verify the backup is a bastion database
+	var b *bolt.DB
+	if b, err = bolt.Open(src, 0640, &bolt.Options{ReadOnly: true, Timeout: time.Second}); err != nil {
+		return
+	}
+	err = b.View(func(tx *bolt.Tx) error {
+		if tx.Bucket([]byte(reflect.TypeOf(models.User{}).Name())) == nil {
+			return fmt.Errorf("%s is not a bastion database backup", src)
+		}
+		return nil
+	})
+	b.Close()
+	if err != nil {
+		return
+	}
+	// bolt holds an exclusive lock on opened database, fails if daemon is still running
+	if _, err = os.Stat(dst); err == nil {
+		if b, err = bolt.Open(dst, 0640, &bolt.Options{Timeout: time.Second}); err != nil {
+			err = fmt.Errorf("database %s is in use, stop the daemon first: %s", dst, err.Error())
+			return
+		}
+		b.Close()
+	} else if !os.IsNotExist(err) {
+		return
+	}
+	// copy to a temporary file and rename, never leaves a partial database
+	if err = os.MkdirAll(filepath.Dir(dst), 0750); err != nil {
+		return
+	}
+	tmp := dst + ".restoring"
+	if err = copyFile(src, tmp); err != nil {
+		os.Remove(tmp)
+		return
+	}
+	if err = os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return
+	}
+	return
+}
+
+func copyFile(src string, dst string) (err error) {
+	var r, w *os.File
+	if r, err = os.Open(src); err != nil {
+		return
+	}
+	defer r.Close()
+	if w, err = os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640); err != nil {
+		return
+	}
+	defer w.Close()
+	if _, err = io.Copy(w, r); err != nil {
+		return
+	}
+	return w.Sync()
+}
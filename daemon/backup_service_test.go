@@ -0,0 +1,161 @@
+package daemon
+
+import (
+	"context"
+	"github.com/asdine/storm"
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestDaemon_BackupRestore(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		if _, ok := daemon.db.(io.WriterTo); !ok {
+			t.Skip("backup is not supported by the database driver")
+		}
+		us := types.NewUserServiceClient(conn)
+		bs := types.NewBackupServiceClient(conn)
+
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "test1", Password: "qwerty"})
+
+		s, err := bs.Backup(context.Background(), &types.BackupRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		src := temporaryFile()
+		defer os.Remove(src)
+		f, err := os.Create(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for {
+			chunk, err := s.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.Write(chunk.Data)
+		}
+		f.Close()
+
+		if err = Restore(src, daemon.opts.DB); err == nil {
+			t.Fatal("should not restore to database in use")
+		}
+		dst := temporaryFile()
+		defer os.Remove(dst)
+		if err = Restore(src, dst); err != nil {
+			t.Fatal(err)
+		}
+		db, err := storm.Open(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		u := models.User{}
+		if err = db.One("Account", "test1", &u); err != nil {
+			t.Fatal(err)
+		}
+		// the backup is audited before it is taken
+		if err = db.One("Action", types.AuditActionDatabaseBackup, &models.AuditEvent{}); err != nil {
+			t.Fatal("should audit backup", err)
+		}
+
+		invalid := temporaryFile()
+		defer os.Remove(invalid)
+		ioutil.WriteFile(invalid, []byte("not a database"), 0640)
+		if err = Restore(invalid, temporaryFile()); err == nil {
+			t.Fatal("should not restore invalid backup")
+		}
+	})
+}
+
+func TestDaemon_ExportImport(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		us := types.NewUserServiceClient(conn)
+		ss := types.NewSessionServiceClient(conn)
+		bs := types.NewBackupServiceClient(conn)
+
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "test1", Password: "qwerty"})
+		ss.CreateSession(context.Background(), &types.CreateSessionRequest{Account: "test1"})
+
+		s1, err := bs.Export(context.Background(), &types.ExportRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var records []*types.ModelRecord
+		for {
+			r, err := s1.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			records = append(records, r)
+		}
+		if len(records) != 4 || records[0].Model != "User" || records[1].Model != "Session" || records[2].Model != "AuditEvent" || records[3].Model != "AuditEvent" {
+			t.Fatal("bad exported records", records)
+		}
+
+		// import a session with larger id, ids of new sessions should continue from it
+		s2, err := bs.Import(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		s2.Send(records[0])
+		s2.Send(&types.ModelRecord{Model: "Session", Data: []byte(`{"Id":10,"Account":"test1"}`)})
+		res1, err := s2.CloseAndRecv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res1.Count != 2 {
+			t.Fatal("bad imported count", res1.Count)
+		}
+		res2, err := ss.CreateSession(context.Background(), &types.CreateSessionRequest{Account: "test1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res2.Session.Id != 11 {
+			t.Fatal("bad session id after import", res2.Session.Id)
+		}
+		res3, err := us.GetUser(context.Background(), &types.GetUserRequest{Account: "test1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res3.User.Account != "test1" {
+			t.Fatal("bad imported user", res3.User)
+		}
+
+		s3, err := bs.Import(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		s3.Send(&types.ModelRecord{Model: "Unknown", Data: []byte(`{}`)})
+		if _, err = s3.CloseAndRecv(); err == nil {
+			t.Fatal("should fail to import unknown model")
+		}
+
+		// existing audit events are never overwritten
+		s4, err := bs.Import(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		s4.Send(records[2])
+		if _, err = s4.CloseAndRecv(); status.Code(err) != codes.FailedPrecondition {
+			t.Fatal("should not import existing audit event", err)
+		}
+		for _, action := range []string{types.AuditActionDatabaseExport, types.AuditActionDatabaseImport} {
+			if es, _, err := daemon.db.AuditEvents().Query(AuditEventQuery{Action: action}); err != nil || len(es) != 1 {
+				t.Fatal("should audit", action, es, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// callerFromContext identity of the caller, common name of the verified client certificate, empty if TLS is not enabled
+func callerFromContext(c context.Context) string {
+	p, ok := peer.FromContext(c)
+	if !ok {
+		return ""
+	}
+	ti, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(ti.State.VerifiedChains) == 0 || len(ti.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return ti.State.VerifiedChains[0][0].Subject.CommonName
+}
+
+// callerMethods allowed methods of every caller, default to types.DefaultCallerMethods
+func (d *Daemon) callerMethods() map[string][]string {
+	if len(d.opts.Callers) > 0 {
+		return d.opts.Callers
+	}
+	return types.DefaultCallerMethods
+}
+
+// systemMethods methods every caller may call without actor, default to types.DefaultSystemMethods
+func (d *Daemon) systemMethods() map[string][]string {
+	if len(d.opts.SystemMethods) > 0 {
+		return d.opts.SystemMethods
+	}
+	return types.DefaultSystemMethods
+}
+
+func matchMethod(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if utils.MatchAsterisk(pattern, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizeCaller check the full method against methods allowed for the caller, calls without actor skip role checks,
+// so they must also be system methods of the caller, all callers are trusted if TLS is not enabled
+func (d *Daemon) authorizeCaller(c context.Context, method string) (err error) {
+	if !d.opts.TLS.Enabled() {
+		return
+	}
+	caller := callerFromContext(c)
+	if !matchMethod(d.callerMethods()[caller], method) {
+		err = errPermissionDenied
+		return
+	}
+	if len(actorFromContext(c)) == 0 && !matchMethod(d.systemMethods()[caller], method) {
+		err = errActorRequired
+		return
+	}
+	return
+}
+
+func (d *Daemon) callerUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	if err = d.authorizeCaller(ctx, info.FullMethod); err != nil {
+		return
+	}
+	return handler(ctx, req)
+}
+
+func (d *Daemon) callerStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	if err = d.authorizeCaller(stream.Context(), info.FullMethod); err != nil {
+		return
+	}
+	return handler(srv, stream)
+}
@@ -0,0 +1,200 @@
+package daemon
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testCA issues certificates for tests, files are written into dir
+type testCA struct {
+	dir  string
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTestCA(t *testing.T, dir string, name string) *testCA {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	ca := &testCA{dir: dir}
+	ca.key, ca.cert = ca.issue(t, name, name, true, nil)
+	return ca
+}
+
+func (ca *testCA) issue(t *testing.T, file string, cn string, isCA bool, ips []net.IP) (*ecdsa.PrivateKey, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sn, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tpl := &x509.Certificate{
+		SerialNumber:          sn,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		IPAddresses:           ips,
+	}
+	parent, signer := tpl, key
+	if ca.cert != nil {
+		parent, signer = ca.cert, ca.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	kb, _ := x509.MarshalECPrivateKey(key)
+	ioutil.WriteFile(filepath.Join(ca.dir, file+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(filepath.Join(ca.dir, file+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kb}), 0600)
+	return key, cert
+}
+
+func (ca *testCA) options(file string) types.TLSOptions {
+	return types.TLSOptions{
+		CA:   filepath.Join(ca.dir, "ca.crt"),
+		Cert: filepath.Join(ca.dir, file+".crt"),
+		Key:  filepath.Join(ca.dir, file+".key"),
+	}
+}
+
+func TestDaemon_CallerAuthorization(t *testing.T) {
+	dir := temporaryDir()
+	ca := newTestCA(t, dir, "ca")
+	ca.issue(t, "daemon", "bastiond", false, []net.IP{net.ParseIP("127.0.0.1")})
+	ca.issue(t, "sshd", types.SourceSSHD, false, nil)
+	ca.issue(t, "web", types.SourceWeb, false, nil)
+	ca.issue(t, "bastionadmin", types.SourceBastionAdmin, false, nil)
+	ca.issue(t, "unknown", "unknown", false, nil)
+	other := newTestCA(t, temporaryDir(), "ca")
+	other.issue(t, "web", types.SourceWeb, false, nil)
+
+	d := New(types.DaemonOptions{
+		DB:        temporaryFile(),
+		Host:      "127.0.0.1",
+		Port:      2993,
+		ReplayDir: temporaryDir(),
+		TLS:       ca.options("daemon"),
+	})
+	go d.Run()
+	defer d.Stop()
+	time.Sleep(time.Second / 2)
+
+	dialAs := func(source string, actor string, opts types.TLSOptions) *grpc.ClientConn {
+		dos, err := types.DialOptions(source, actor, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn, err := grpc.Dial("127.0.0.1:2993", dos...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+	dial := func(source string, opts types.TLSOptions) *grpc.ClientConn {
+		return dialAs(source, "", opts)
+	}
+	ctx := context.Background()
+	grant := &types.PutGrantRequest{Account: "test", HostnamePattern: "web-*", User: "root"}
+
+	admin := dial(types.SourceBastionAdmin, ca.options("bastionadmin"))
+	defer admin.Close()
+	if _, err := types.NewUserServiceClient(admin).CreateUser(ctx, &types.CreateUserRequest{Account: "test", Password: "qwerty123"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := types.NewGrantServiceClient(admin).PutGrant(ctx, grant); err != nil {
+		t.Fatal(err)
+	}
+
+	// web makes system calls to authenticate users only, other calls require an actor, which is checked by roles
+	web := dial(types.SourceWeb, ca.options("web"))
+	defer web.Close()
+	if _, err := types.NewUserServiceClient(web).AuthenticateUser(ctx, &types.AuthenticateUserRequest{Account: "test", Password: "qwerty123"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := types.NewGrantServiceClient(web).PutGrant(ctx, grant); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("web should not put grants without actor", err)
+	}
+	if _, err := types.NewUserServiceClient(web).ListUsers(ctx, &types.ListUsersRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("web should not list users without actor", err)
+	}
+	webAsTest := dialAs(types.SourceWeb, "test", ca.options("web"))
+	defer webAsTest.Close()
+	if _, err := types.NewGrantServiceClient(webAsTest).ListGrantItems(ctx, &types.ListGrantItemsRequest{Account: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := types.NewGrantServiceClient(webAsTest).PutGrant(ctx, grant); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("test should not put grants", err)
+	}
+
+	// sshd can check grants but can not put grants or master keys, even if declared as web
+	sshd := dial(types.SourceWeb, ca.options("sshd"))
+	defer sshd.Close()
+	if _, err := types.NewGrantServiceClient(sshd).ListGrantItems(ctx, &types.ListGrantItemsRequest{Account: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := types.NewGrantServiceClient(sshd).PutGrant(ctx, grant); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("sshd should not put grants", err)
+	}
+	if _, err := types.NewMasterKeyServiceClient(sshd).UpdateAllMasterKeys(ctx, &types.UpdateAllMasterKeysRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("sshd should not update master keys", err)
+	}
+	if _, err := types.NewKeyServiceClient(sshd).CreateKey(ctx, &types.CreateKeyRequest{Account: "test", Fingerprint: "SHA256:testtesttesttesttesttesttesttesttesttesttes"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("sshd should only create sandbox keys", err)
+	}
+	if _, err := types.NewKeyServiceClient(sshd).CreateKey(ctx, &types.CreateKeyRequest{Account: "test", Fingerprint: "SHA256:testtesttesttesttesttesttesttesttesttesttes", Source: types.KeySourceSandbox}); err != nil {
+		t.Fatal(err)
+	}
+
+	unknown := dial(types.SourceWeb, ca.options("unknown"))
+	defer unknown.Close()
+	if _, err := types.NewUserServiceClient(unknown).ListUsers(ctx, &types.ListUsersRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("unknown caller should be denied", err)
+	}
+
+	// certificates of other CA and plaintext connections are rejected by handshake
+	untrusted := dial(types.SourceWeb, types.TLSOptions{CA: ca.options("web").CA, Cert: other.options("web").Cert, Key: other.options("web").Key})
+	defer untrusted.Close()
+	if _, err := types.NewUserServiceClient(untrusted).ListUsers(ctx, &types.ListUsersRequest{}); err == nil {
+		t.Fatal("certificate of other ca should be rejected")
+	}
+	plain := dial(types.SourceWeb, types.TLSOptions{})
+	defer plain.Close()
+	if _, err := types.NewUserServiceClient(plain).ListUsers(ctx, &types.ListUsersRequest{}); err == nil {
+		t.Fatal("plaintext connection should be rejected")
+	}
+
+	// caller identity is recorded as source of audit events, instead of the declared source
+	es, _, err := d.db.AuditEvents().Query(AuditEventQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) == 0 {
+		t.Fatal("no audit event")
+	}
+	for _, e := range es {
+		if e.Source != types.SourceBastionAdmin && e.Source != types.SourceSSHD {
+			t.Fatal("bad source of audit event", e)
+		}
+	}
+}
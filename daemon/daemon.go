@@ -3,51 +3,40 @@ package daemon
 import (
 	"fmt"
 	"net"
-	"os"
-	"path/filepath"
 
-	"github.com/asdine/storm"
 	"github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/go-grpc-middleware/recovery"
-	"github.com/olivere/elastic"
-	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/rs/zerolog/log"
 	"github.com/yankeguo/bastion/types"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // Daemon daemon instance
 type Daemon struct {
-	opts     types.DaemonOptions
-	db       *DB
-	server   *grpc.Server
-	esClient *elastic.Client
+	opts         types.DaemonOptions
+	db           Store
+	server       *grpc.Server
+	search       SearchIndex
+	replays      ReplayStorage
+	signer       *replaySigner
+	grantWatcher *grantWatcher
+	retention    *retentionPolicy
+	// purgedBefore recorded sessions with smaller id are all purged, only accessed by the replay purger
+	purgedBefore int64
 }
 
 func New(opts types.DaemonOptions) *Daemon {
-	return &Daemon{opts: opts}
+	return &Daemon{opts: opts, grantWatcher: newGrantWatcher()}
 }
 
-func (d *Daemon) initEsClient() (err error) {
-	d.esClient, err = elastic.NewClient(elastic.SetURL(d.opts.Elasticsearch...))
+func (d *Daemon) initSearch() (err error) {
+	d.search, err = OpenSearchIndex(d.opts)
 	return
 }
 
 func (d *Daemon) initDB() (err error) {
-	// ensure database directory
-	os.MkdirAll(filepath.Dir(d.opts.DB), 0640)
-	// open db
-	var stormDB *storm.DB
-	if stormDB, err = storm.Open(d.opts.DB); err != nil {
-		return
-	}
-	// migrate database
-	for _, m := range models.AllModels {
-		if err = stormDB.Init(m); err != nil {
-			stormDB.Close()
-			return
-		}
-	}
-	d.db = &DB{db: stormDB}
+	d.db, err = OpenStore(d.opts)
 	return
 }
 
@@ -55,11 +44,21 @@ func (d *Daemon) createListener() (l net.Listener, err error) {
 	return net.Listen("tcp", fmt.Sprintf("%s:%d", d.opts.Host, d.opts.Port))
 }
 
-func (d *Daemon) createGRPCServer() *grpc.Server {
-	s := grpc.NewServer(
-		grpc_middleware.WithUnaryServerChain(zerologUnaryInterceptor, grpc_recovery.UnaryServerInterceptor()),
-		grpc_middleware.WithStreamServerChain(zerologStreamInterceptor, grpc_recovery.StreamServerInterceptor()),
-	)
+func (d *Daemon) createGRPCServer() (s *grpc.Server, err error) {
+	opts := []grpc.ServerOption{
+		grpc_middleware.WithUnaryServerChain(zerologUnaryInterceptor, grpc_recovery.UnaryServerInterceptor(), d.callerUnaryInterceptor, d.rbacUnaryInterceptor),
+		grpc_middleware.WithStreamServerChain(zerologStreamInterceptor, grpc_recovery.StreamServerInterceptor(), d.callerStreamInterceptor, d.rbacStreamInterceptor),
+	}
+	if d.opts.TLS.Enabled() {
+		var c credentials.TransportCredentials
+		if c, err = d.opts.TLS.ServerCredentials(); err != nil {
+			return
+		}
+		opts = append(opts, grpc.Creds(c))
+	} else {
+		log.Warn().Msg("rpc is served without TLS, every caller is trusted")
+	}
+	s = grpc.NewServer(opts...)
 	types.RegisterUserServiceServer(s, d)
 	types.RegisterNodeServiceServer(s, d)
 	types.RegisterKeyServiceServer(s, d)
@@ -68,15 +67,37 @@ func (d *Daemon) createGRPCServer() *grpc.Server {
 	types.RegisterTokenServiceServer(s, d)
 	types.RegisterReplayServiceServer(s, d)
 	types.RegisterMasterKeyServiceServer(s, d)
-	return s
+	types.RegisterVolumeServiceServer(s, d)
+	types.RegisterTransferServiceServer(s, d)
+	types.RegisterGroupServiceServer(s, d)
+	types.RegisterAccessRequestServiceServer(s, d)
+	types.RegisterAuditServiceServer(s, d)
+	types.RegisterBackupServiceServer(s, d)
+	return
 }
 
 func (d *Daemon) Run() (err error) {
-	// create elasticsearch client
-	if err = d.initEsClient(); err != nil {
+	// compile retention policy
+	if d.retention, err = newRetentionPolicy(d.opts.Retention); err != nil {
+		return
+	}
+
+	// open replay storage
+	if d.replays, err = OpenReplayStorage(d.opts); err != nil {
 		return
 	}
 
+	// load signing key of replays
+	if d.signer, err = newReplaySigner(d.opts); err != nil {
+		return
+	}
+
+	// open search index
+	if err = d.initSearch(); err != nil {
+		return
+	}
+	defer d.search.Close()
+
 	// open db
 	if err = d.initDB(); err != nil {
 		return
@@ -91,7 +112,23 @@ func (d *Daemon) Run() (err error) {
 	defer l.Close()
 
 	// create server
-	d.server = d.createGRPCServer()
+	if d.server, err = d.createGRPCServer(); err != nil {
+		return
+	}
+
+	// run grant expiry checker
+	go d.runGrantExpiryChecker()
+
+	// run grant syncer, grants may be changed by other daemons sharing the postgres database
+	if d.opts.DBDriver == types.DBDriverPostgres {
+		go d.runGrantSyncer()
+	}
+
+	// run token sweeper
+	go d.runTokenSweeper()
+
+	// run replay purger
+	go d.runReplayPurger()
 
 	// run server
 	if err = d.server.Serve(l); err != nil {
@@ -103,6 +140,8 @@ func (d *Daemon) Run() (err error) {
 }
 
 func (d *Daemon) Stop() {
+	// finish all WatchGrants streams, or GracefulStop will block
+	d.grantWatcher.Close()
 	if d.server != nil {
 		d.server.GracefulStop()
 	}
@@ -27,11 +27,17 @@ func temporaryDir() string {
 }
 
 func withDaemon(t *testing.T, cb func(*testing.T, *Daemon, *grpc.ClientConn)) {
+	// BASTION_TEST_DB_DRIVER runs tests against another database driver, "sqlite3" for example,
+	// BASTION_TEST_SEARCH_BACKEND runs tests against another search backend, "embedded" for example
 	d = New(types.DaemonOptions{
-		DB:        temporaryFile(),
-		Host:      "127.0.0.1",
-		Port:      2997,
-		ReplayDir: temporaryDir(),
+		DBDriver:      os.Getenv("BASTION_TEST_DB_DRIVER"),
+		DB:            temporaryFile(),
+		SearchBackend: os.Getenv("BASTION_TEST_SEARCH_BACKEND"),
+		SearchIndex:   temporaryFile(),
+		Host:          "127.0.0.1",
+		Port:          2997,
+		ReplayDir:     temporaryDir(),
+		SigningKey:    temporaryFile(),
 	})
 	go func() {
 		err := d.Run()
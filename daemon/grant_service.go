@@ -2,23 +2,43 @@ package daemon
 
 import (
 	"github.com/jinzhu/copier"
+	"github.com/rs/zerolog/log"
 	"github.com/yankeguo/bastion/daemon/models"
 	"github.com/yankeguo/bastion/types"
 	"github.com/yankeguo/bastion/utils"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func (d *Daemon) PutGrant(c context.Context, req *types.PutGrantRequest) (res *types.PutGrantResponse, err error) {
 	if err = req.Validate(); err != nil {
 		return
 	}
+	if err = canonicalLabelSelector(&req.LabelSelector); err != nil {
+		return
+	}
 	n := models.Grant{}
 	copier.Copy(&n, req)
 	n.Id = n.BuildId()
 	n.CreatedAt = now()
-	if err = d.db.Save(&n); err != nil {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var before *types.Grant
+		var o models.Grant
+		if o, err = db.Grants().Get(n.Id); err == nil {
+			before = o.ToGRPCGrant()
+		} else if err != errRecordNotFound {
+			return
+		}
+		if err = db.Grants().Save(&n); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionGrantPut, "grant:"+n.Id, before, n.ToGRPCGrant())
+	}); err != nil {
 		return
 	}
+	d.grantWatcher.Notify(d.grantAccounts(n)...)
 	res = &types.PutGrantResponse{Grant: n.ToGRPCGrant()}
 	return
 }
@@ -28,7 +48,12 @@ func (d *Daemon) ListGrants(c context.Context, req *types.ListGrantsRequest) (re
 		return
 	}
 	var rs []models.Grant
-	if err = d.db.Find("Account", req.Account, &rs); err != nil {
+	if len(req.Group) > 0 {
+		rs, err = d.db.Grants().ListByGroup(req.Group)
+	} else {
+		rs, err = d.db.Grants().ListByAccount(req.Account)
+	}
+	if err != nil {
 		return
 	}
 	ret := make([]*types.Grant, 0, len(rs))
@@ -43,12 +68,28 @@ func (d *Daemon) DeleteGrant(c context.Context, req *types.DeleteGrantRequest) (
 	if err = req.Validate(); err != nil {
 		return
 	}
+	if err = canonicalLabelSelector(&req.LabelSelector); err != nil {
+		return
+	}
 	n := models.Grant{}
 	copier.Copy(&n, req)
 	n.Id = n.BuildId()
-	if err = d.db.DeleteStruct(&n); err != nil {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var before *types.Grant
+		var o models.Grant
+		if o, err = db.Grants().Get(n.Id); err == nil {
+			n, before = o, o.ToGRPCGrant()
+		} else if err != errRecordNotFound {
+			return
+		}
+		if err = db.Grants().Delete(n.Id); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionGrantDelete, "grant:"+n.Id, before, nil)
+	}); err != nil {
 		return
 	}
+	d.grantWatcher.Notify(d.grantAccounts(n)...)
 	res = &types.DeleteGrantResponse{}
 	return
 }
@@ -57,14 +98,19 @@ func (d *Daemon) CheckGrant(c context.Context, req *types.CheckGrantRequest) (re
 	if err = req.Validate(); err != nil {
 		return
 	}
+	// labels are required by label selectors, a missing node must not match negative selectors like "!env"
+	var nd models.Node
+	if nd, err = d.db.Nodes().Get(req.Hostname); err != nil {
+		return
+	}
 	var rs []models.Grant
-	if err = d.db.Find("Account", req.Account, &rs); err != nil {
+	if rs, err = d.findGrants(req.Account); err != nil {
 		return
 	}
 	var ok bool
 	for _, n := range rs {
 		if n.User == req.User && (n.ExpiredAt == 0 || n.ExpiredAt > now()) {
-			if utils.MatchAsterisk(n.HostnamePattern, req.Hostname) {
+			if grantMatchesNode(n, nd) {
 				ok = true
 				break
 			}
@@ -79,17 +125,17 @@ func (d *Daemon) ListGrantItems(c context.Context, req *types.ListGrantItemsRequ
 		return
 	}
 	var rs []models.Grant
-	if err = d.db.Find("Account", req.Account, &rs); err != nil {
+	if rs, err = d.findGrants(req.Account); err != nil {
 		return
 	}
 	var ns []models.Node
-	if err = d.db.All(&ns); err != nil {
+	if ns, err = d.db.Nodes().List(); err != nil {
 		return
 	}
 	ret := make([]*types.GrantItem, 0)
 	for _, n := range ns {
 		for _, r := range rs {
-			if utils.MatchAsterisk(r.HostnamePattern, n.Hostname) && (r.ExpiredAt == 0 || r.ExpiredAt > now()) {
+			if grantMatchesNode(r, n) && (r.ExpiredAt == 0 || r.ExpiredAt > now()) {
 				ret = append(ret, &types.GrantItem{
 					Hostname:  n.Hostname,
 					User:      r.User,
@@ -102,6 +148,86 @@ func (d *Daemon) ListGrantItems(c context.Context, req *types.ListGrantItemsRequ
 	return
 }
 
+func (d *Daemon) WatchGrants(req *types.WatchGrantsRequest, s types.GrantService_WatchGrantsServer) (err error) {
+	ch := d.grantWatcher.Subscribe()
+	defer d.grantWatcher.Unsubscribe(ch)
+	// header is sent once subscribed, clients wait for it to not miss notifications
+	if err = s.SendHeader(metadata.MD{}); err != nil {
+		return
+	}
+	for {
+		select {
+		case <-s.Context().Done():
+			return
+		case <-d.grantWatcher.Done():
+			return
+		case accounts := <-ch:
+			if err = s.Send(&types.WatchGrantsResponse{Accounts: accounts}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// findGrants grants of the account, including grants of groups the account belongs to
+func (d *Daemon) findGrants(account string) (rs []models.Grant, err error) {
+	if rs, err = d.db.Grants().ListByAccount(account); err != nil {
+		return
+	}
+	var names []string
+	if names, err = d.listGroupNames(account); err != nil {
+		return
+	}
+	for _, name := range names {
+		var gs []models.Grant
+		if gs, err = d.db.Grants().ListByGroup(name); err != nil {
+			return
+		}
+		rs = append(rs, gs...)
+	}
+	return
+}
+
+// grantAccounts accounts affected by the grant, members are expanded for group grant
+func (d *Daemon) grantAccounts(g models.Grant) []string {
+	if len(g.Group) == 0 {
+		return []string{g.Account}
+	}
+	accounts, err := d.listGroupAccounts(g.Group)
+	if err != nil {
+		log.Error().Err(err).Str("group", g.Group).Msg("failed to list group members")
+	}
+	return accounts
+}
+
+// grantMatchesNode check the grant covers the node, by hostname pattern and label selector, whichever specified
+func grantMatchesNode(g models.Grant, n models.Node) bool {
+	if len(g.HostnamePattern) > 0 && !utils.MatchAsterisk(g.HostnamePattern, n.Hostname) {
+		return false
+	}
+	if len(g.LabelSelector) > 0 {
+		ls, err := utils.ParseLabelSelector(g.LabelSelector)
+		if err != nil || !ls.Matches(n.Labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalLabelSelector validate and canonicalize the label selector, equivalent selectors results in same grant id
+func canonicalLabelSelector(s *string) (err error) {
+	if len(*s) == 0 {
+		return
+	}
+	var ls utils.LabelSelector
+	if ls, err = utils.ParseLabelSelector(*s); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		return
+	}
+	*s = ls.String()
+	return
+}
+
 func compactGrantItems(is []*types.GrantItem) []*types.GrantItem {
 	ret := make([]*types.GrantItem, 0, len(is))
 	for _, i := range is {
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"context"
+	"github.com/yankeguo/bastion/daemon/models"
 	"github.com/yankeguo/bastion/types"
 	"google.golang.org/grpc"
 	"testing"
@@ -185,3 +186,198 @@ func TestDaemon_CheckGrantListGrantItems(t *testing.T) {
 		}
 	})
 }
+
+func TestDaemon_WatchGrants(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		rs := types.NewGrantServiceClient(conn)
+		ns := types.NewNodeServiceClient(conn)
+
+		wc, err := rs.WatchGrants(context.Background(), &types.WatchGrantsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// wait for subscription
+		if _, err = wc.Header(); err != nil {
+			t.Fatal(err)
+		}
+
+		rs.PutGrant(context.Background(), &types.PutGrantRequest{
+			Account:         "test",
+			HostnamePattern: "local.*",
+			User:            "root",
+		})
+		res, err := wc.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Accounts) != 1 || res.Accounts[0] != "test" {
+			t.Fatal("failed 1", res)
+		}
+		ns.PutNode(context.Background(), &types.PutNodeRequest{
+			Hostname: "local.host1",
+			Address:  "127.0.0.1:2222",
+		})
+		if res, err = wc.Recv(); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Accounts) != 1 || res.Accounts[0] != "test" {
+			t.Fatal("failed 2", res)
+		}
+		rs.DeleteGrant(context.Background(), &types.DeleteGrantRequest{
+			Account:         "test",
+			HostnamePattern: "local.*",
+			User:            "root",
+		})
+		if res, err = wc.Recv(); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Accounts) != 1 || res.Accounts[0] != "test" {
+			t.Fatal("failed 3", res)
+		}
+	})
+}
+
+func TestDaemon_LabelSelectorGrant(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		s := types.NewGrantServiceClient(conn)
+		ns := types.NewNodeServiceClient(conn)
+
+		ns.PutNode(context.Background(), &types.PutNodeRequest{Hostname: "db-prod-1", Address: "10.0.0.1", Labels: map[string]string{"env": "prod", "role": "db"}})
+		ns.PutNode(context.Background(), &types.PutNodeRequest{Hostname: "db-test-1", Address: "10.0.0.2", Labels: map[string]string{"env": "test", "role": "db"}})
+		ns.PutNode(context.Background(), &types.PutNodeRequest{Hostname: "web-prod-1", Address: "10.0.0.3", Labels: map[string]string{"env": "prod", "role": "web"}})
+
+		if _, err := s.PutGrant(context.Background(), &types.PutGrantRequest{Account: "test", LabelSelector: "role = db, env=prod", User: "root"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.PutGrant(context.Background(), &types.PutGrantRequest{Account: "test", LabelSelector: "env=prod,role=db", User: "root"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.PutGrant(context.Background(), &types.PutGrantRequest{Account: "test", HostnamePattern: "web-*", LabelSelector: "env=test", User: "root"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.PutGrant(context.Background(), &types.PutGrantRequest{Account: "test", LabelSelector: "env=p r o d", User: "root"}); err == nil {
+			t.Fatal("should fail with invalid label selector")
+		}
+		if _, err := s.PutGrant(context.Background(), &types.PutGrantRequest{Account: "test", User: "root"}); err == nil {
+			t.Fatal("should fail without hostname pattern and label selector")
+		}
+		res1, err := s.ListGrants(context.Background(), &types.ListGrantsRequest{Account: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res1.Grants) != 2 {
+			t.Fatal("equivalent selectors should share the same grant", res1.Grants)
+		}
+		res2, err := s.ListGrantItems(context.Background(), &types.ListGrantItemsRequest{Account: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res2.GrantItems) != 1 || res2.GrantItems[0].Hostname != "db-prod-1" {
+			t.Fatal("bad grant items", res2.GrantItems)
+		}
+		res3, err := s.CheckGrant(context.Background(), &types.CheckGrantRequest{Account: "test", Hostname: "db-test-1", User: "root"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res3.Ok {
+			t.Fatal("should not grant db-test-1")
+		}
+		if _, err = ns.UpdateNode(context.Background(), &types.UpdateNodeRequest{Hostname: "db-test-1", UpdateLabels: true, Labels: map[string]string{"env": "prod", "role": "db"}}); err != nil {
+			t.Fatal(err)
+		}
+		if res3, err = s.CheckGrant(context.Background(), &types.CheckGrantRequest{Account: "test", Hostname: "db-test-1", User: "root"}); err != nil {
+			t.Fatal(err)
+		}
+		if !res3.Ok {
+			t.Fatal("should grant db-test-1 after labels changed")
+		}
+		if _, err = s.DeleteGrant(context.Background(), &types.DeleteGrantRequest{Account: "test", LabelSelector: "env=prod,role=db", User: "root"}); err != nil {
+			t.Fatal(err)
+		}
+		if res3, err = s.CheckGrant(context.Background(), &types.CheckGrantRequest{Account: "test", Hostname: "db-prod-1", User: "root"}); err != nil {
+			t.Fatal(err)
+		}
+		if res3.Ok {
+			t.Fatal("should not grant db-prod-1 after grant deleted")
+		}
+		// negative selectors must not match nodes not existed
+		if _, err = s.PutGrant(context.Background(), &types.PutGrantRequest{Account: "test", LabelSelector: "!env", User: "root"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.CheckGrant(context.Background(), &types.CheckGrantRequest{Account: "test", Hostname: "db-missing-1", User: "root"}); err == nil {
+			t.Fatal("should fail with missing node")
+		}
+	})
+}
+
+func TestDaemon_ChangedGrantAccounts(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		prev, err := daemon.takeGrantSnapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// changes made directly in database, like by another daemon
+		g1 := models.Grant{Account: "test1", HostnamePattern: "*", User: "root"}
+		g1.Id = g1.BuildId()
+		g2 := models.Grant{Group: "ops", LabelSelector: "env=prod", User: "root"}
+		g2.Id = g2.BuildId()
+		if err = daemon.db.Grants().Save(&g1); err != nil {
+			t.Fatal(err)
+		}
+		if err = daemon.db.Grants().Save(&g2); err != nil {
+			t.Fatal(err)
+		}
+		if err = daemon.db.Groups().Save(&models.Group{Name: "ops"}); err != nil {
+			t.Fatal(err)
+		}
+		m := models.GroupMember{Group: "ops", Account: "test2"}
+		m.Id = m.BuildId()
+		if err = daemon.db.GroupMembers().Save(&m); err != nil {
+			t.Fatal(err)
+		}
+		next, err := daemon.takeGrantSnapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		accounts := changedGrantAccounts(prev, next)
+		if len(accounts) != 2 || !containsString(accounts, "test1") || !containsString(accounts, "test2") {
+			t.Fatal("bad changed accounts", accounts)
+		}
+		if accounts = changedGrantAccounts(next, next); len(accounts) != 0 {
+			t.Fatal("bad unchanged accounts", accounts)
+		}
+		// node labels changed to match the group grant
+		if err = daemon.db.Nodes().Save(&models.Node{Hostname: "test-node", Labels: map[string]string{"env": "prod"}}); err != nil {
+			t.Fatal(err)
+		}
+		prev = next
+		if next, err = daemon.takeGrantSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+		if accounts = changedGrantAccounts(prev, next); len(accounts) != 2 {
+			// test1 matches all nodes, test2 matches the labels
+			t.Fatal("bad changed accounts of node", accounts)
+		}
+		if err = daemon.db.Nodes().Save(&models.Node{Hostname: "test-node", Labels: map[string]string{"env": "dev"}}); err != nil {
+			t.Fatal(err)
+		}
+		prev = next
+		if next, err = daemon.takeGrantSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+		if accounts = changedGrantAccounts(prev, next); len(accounts) != 2 {
+			t.Fatal("bad changed accounts of node labels", accounts)
+		}
+		// member left the group
+		if err = daemon.db.GroupMembers().Delete(m.Id); err != nil {
+			t.Fatal(err)
+		}
+		prev = next
+		if next, err = daemon.takeGrantSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+		if accounts = changedGrantAccounts(prev, next); len(accounts) != 1 || accounts[0] != "test2" {
+			t.Fatal("bad changed accounts of group member", accounts)
+		}
+	})
+}
@@ -0,0 +1,289 @@
+package daemon
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/daemon/models"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	grantWatchBufferSize     = 64
+	grantExpiryCheckInterval = time.Minute
+	grantSyncInterval        = 10 * time.Second
+)
+
+// grantWatcher broadcasts accounts with changed grants to WatchGrants streams,
+// notifications are in-process, changes made by other daemons sharing a postgres database are found by
+// runGrantSyncer and delayed up to grantSyncInterval
+type grantWatcher struct {
+	mutex *sync.Mutex
+	chans map[chan []string]bool
+	done  chan struct{}
+}
+
+func newGrantWatcher() *grantWatcher {
+	return &grantWatcher{
+		mutex: &sync.Mutex{},
+		chans: map[chan []string]bool{},
+		done:  make(chan struct{}),
+	}
+}
+
+func (w *grantWatcher) Subscribe() chan []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	ch := make(chan []string, grantWatchBufferSize)
+	w.chans[ch] = true
+	return ch
+}
+
+func (w *grantWatcher) Unsubscribe(ch chan []string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	delete(w.chans, ch)
+}
+
+func (w *grantWatcher) Notify(accounts ...string) {
+	if len(accounts) == 0 {
+		return
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for ch := range w.chans {
+		select {
+		case ch <- accounts:
+		default:
+			log.Warn().Strs("accounts", accounts).Msg("grant watcher is full, notification dropped")
+		}
+	}
+}
+
+// Done closed when the watcher is closed, all streams should finish
+func (w *grantWatcher) Done() <-chan struct{} {
+	return w.done
+}
+
+func (w *grantWatcher) Close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	select {
+	case <-w.done:
+	default:
+		close(w.done)
+	}
+}
+
+// notifyNodeChanged notify accounts with grants matching any state of the changed node, labels may change
+func (d *Daemon) notifyNodeChanged(ns ...models.Node) {
+	gs, err := d.db.Grants().List()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list grants for node change")
+		return
+	}
+	accounts := make([]string, 0)
+	for _, g := range gs {
+		for _, n := range ns {
+			if grantMatchesNode(g, n) {
+				for _, account := range d.grantAccounts(g) {
+					accounts = appendUniqueString(accounts, account)
+				}
+				break
+			}
+		}
+	}
+	d.grantWatcher.Notify(accounts...)
+}
+
+// runGrantExpiryChecker periodically notify accounts with grants expired since last check
+func (d *Daemon) runGrantExpiryChecker() {
+	t := time.NewTicker(grantExpiryCheckInterval)
+	defer t.Stop()
+	last := now()
+	for {
+		select {
+		case <-d.grantWatcher.Done():
+			return
+		case <-t.C:
+		}
+		n := now()
+		gs, err := d.db.Grants().List()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list grants for expiry check")
+			continue
+		}
+		accounts := make([]string, 0)
+		for _, g := range gs {
+			if g.ExpiredAt > last && g.ExpiredAt <= n {
+				for _, account := range d.grantAccounts(g) {
+					accounts = appendUniqueString(accounts, account)
+				}
+			}
+		}
+		d.grantWatcher.Notify(accounts...)
+		last = n
+	}
+}
+
+// grantSnapshot grants, node labels and group members, accounts with changed grants are found by comparing snapshots
+type grantSnapshot struct {
+	grants  map[string]models.Grant
+	labels  map[string]map[string]string
+	members map[string][]string
+}
+
+func (d *Daemon) takeGrantSnapshot() (s grantSnapshot, err error) {
+	s = grantSnapshot{
+		grants:  map[string]models.Grant{},
+		labels:  map[string]map[string]string{},
+		members: map[string][]string{},
+	}
+	err = d.db.Tx(false, func(db Repositories) (err error) {
+		var gs []models.Grant
+		if gs, err = db.Grants().List(); err != nil {
+			return
+		}
+		for _, g := range gs {
+			s.grants[g.Id] = g
+		}
+		var ns []models.Node
+		if ns, err = db.Nodes().List(); err != nil {
+			return
+		}
+		for _, n := range ns {
+			s.labels[n.Hostname] = n.Labels
+		}
+		var groups []models.Group
+		if groups, err = db.Groups().List(); err != nil {
+			return
+		}
+		for _, g := range groups {
+			var ms []models.GroupMember
+			if ms, err = db.GroupMembers().ListByGroup(g.Name); err != nil {
+				return
+			}
+			for _, m := range ms {
+				s.members[g.Name] = append(s.members[g.Name], m.Account)
+			}
+		}
+		return
+	})
+	return
+}
+
+func (s grantSnapshot) grantAccounts(g models.Grant) []string {
+	if len(g.Group) == 0 {
+		return []string{g.Account}
+	}
+	return s.members[g.Group]
+}
+
+// changedGrantAccounts accounts with grants changed between snapshots, either by grants, group members or node labels
+func changedGrantAccounts(prev, next grantSnapshot) []string {
+	accounts := make([]string, 0)
+	addGrant := func(g models.Grant) {
+		for _, account := range prev.grantAccounts(g) {
+			accounts = appendUniqueString(accounts, account)
+		}
+		for _, account := range next.grantAccounts(g) {
+			accounts = appendUniqueString(accounts, account)
+		}
+	}
+	// grants created, changed or deleted
+	for id, g := range next.grants {
+		if pg, ok := prev.grants[id]; !ok || !reflect.DeepEqual(pg, g) {
+			addGrant(g)
+		}
+	}
+	for id, g := range prev.grants {
+		if _, ok := next.grants[id]; !ok {
+			addGrant(g)
+		}
+	}
+	// members joined or left groups
+	for group, ms := range next.members {
+		for _, account := range ms {
+			if !containsString(prev.members[group], account) {
+				accounts = appendUniqueString(accounts, account)
+			}
+		}
+	}
+	for group, ms := range prev.members {
+		for _, account := range ms {
+			if !containsString(next.members[group], account) {
+				accounts = appendUniqueString(accounts, account)
+			}
+		}
+	}
+	// nodes created, deleted or labels changed, grants matching either state are affected
+	ns := make([]models.Node, 0)
+	for hostname, labels := range next.labels {
+		if pl, ok := prev.labels[hostname]; !ok || !reflect.DeepEqual(pl, labels) {
+			ns = append(ns, models.Node{Hostname: hostname, Labels: labels})
+			if ok {
+				ns = append(ns, models.Node{Hostname: hostname, Labels: pl})
+			}
+		}
+	}
+	for hostname, labels := range prev.labels {
+		if _, ok := next.labels[hostname]; !ok {
+			ns = append(ns, models.Node{Hostname: hostname, Labels: labels})
+		}
+	}
+	if len(ns) > 0 {
+		for _, gs := range []map[string]models.Grant{prev.grants, next.grants} {
+			for _, g := range gs {
+				for _, n := range ns {
+					if grantMatchesNode(g, n) {
+						addGrant(g)
+						break
+					}
+				}
+			}
+		}
+	}
+	return accounts
+}
+
+// runGrantSyncer periodically notify accounts with grants changed in database, including changes by other daemons
+func (d *Daemon) runGrantSyncer() {
+	t := time.NewTicker(grantSyncInterval)
+	defer t.Stop()
+	prev, err := d.takeGrantSnapshot()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to take grant snapshot")
+	}
+	for {
+		select {
+		case <-d.grantWatcher.Done():
+			return
+		case <-t.C:
+		}
+		var next grantSnapshot
+		if next, err = d.takeGrantSnapshot(); err != nil {
+			log.Error().Err(err).Msg("failed to take grant snapshot")
+			continue
+		}
+		if prev.grants != nil {
+			d.grantWatcher.Notify(changedGrantAccounts(prev, next)...)
+		}
+		prev = next
+	}
+}
+
+func containsString(ss []string, s string) bool {
+	for _, e := range ss {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUniqueString(ss []string, s string) []string {
+	if containsString(ss, s) {
+		return ss
+	}
+	return append(ss, s)
+}
@@ -0,0 +1,224 @@
+package daemon
+
+import (
+	"github.com/jinzhu/copier"
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/net/context"
+)
+
+func (d *Daemon) ListGroups(c context.Context, req *types.ListGroupsRequest) (res *types.ListGroupsResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var gs []models.Group
+	if len(req.Account) > 0 {
+		// groups of the account
+		var names []string
+		if names, err = d.listGroupNames(req.Account); err != nil {
+			return
+		}
+		for _, name := range names {
+			g := models.Group{}
+			if g, err = d.db.Groups().Get(name); err != nil {
+				return
+			}
+			gs = append(gs, g)
+		}
+	} else {
+		if gs, err = d.db.Groups().List(); err != nil {
+			return
+		}
+	}
+	ret := make([]*types.Group, 0, len(gs))
+	for _, g := range gs {
+		ret = append(ret, g.ToGRPCGroup())
+	}
+	res = &types.ListGroupsResponse{Groups: ret}
+	return
+}
+
+func (d *Daemon) PutGroup(c context.Context, req *types.PutGroupRequest) (res *types.PutGroupResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	g := models.Group{}
+	var before *types.Group
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		// keep created_at of existing group
+		if g, err = db.Groups().Get(req.Name); err != nil {
+			if err != errRecordNotFound {
+				return
+			}
+			g = models.Group{Name: req.Name, CreatedAt: now()}
+		} else {
+			before = g.ToGRPCGroup()
+		}
+		g.Description = req.Description
+		if req.UpdateRoles {
+			g.Roles = req.Roles
+		}
+		if err = db.Groups().Save(&g); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionGroupPut, "group:"+g.Name, before, g.ToGRPCGroup())
+	}); err != nil {
+		return
+	}
+	res = &types.PutGroupResponse{Group: g.ToGRPCGroup()}
+	return
+}
+
+func (d *Daemon) DeleteGroup(c context.Context, req *types.DeleteGroupRequest) (res *types.DeleteGroupResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var accounts []string
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var before *types.Group
+		g := models.Group{}
+		if g, err = db.Groups().Get(req.Name); err == nil {
+			before = g.ToGRPCGroup()
+		} else if err != errRecordNotFound {
+			return
+		}
+		// delete members, grants and shared volume memberships first
+		var ms []models.GroupMember
+		if ms, err = db.GroupMembers().ListByGroup(req.Name); err != nil {
+			return
+		}
+		for _, m := range ms {
+			accounts = append(accounts, m.Account)
+			if err = db.GroupMembers().Delete(m.Id); err != nil {
+				return
+			}
+		}
+		var gs []models.Grant
+		if gs, err = db.Grants().ListByGroup(req.Name); err != nil {
+			return
+		}
+		for _, g := range gs {
+			if err = db.Grants().Delete(g.Id); err != nil {
+				return
+			}
+		}
+		var vms []models.VolumeMember
+		if vms, err = db.VolumeMembers().ListByName(req.Name); err != nil {
+			return
+		}
+		for _, vm := range vms {
+			if vm.Kind != types.VolumeMemberKindGroup {
+				continue
+			}
+			if err = db.VolumeMembers().Delete(vm.Id); err != nil {
+				return
+			}
+		}
+		if err = db.Groups().Delete(req.Name); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionGroupDelete, "group:"+req.Name, before, nil)
+	}); err != nil {
+		return
+	}
+	d.grantWatcher.Notify(accounts...)
+	res = &types.DeleteGroupResponse{}
+	return
+}
+
+func (d *Daemon) ListGroupMembers(c context.Context, req *types.ListGroupMembersRequest) (res *types.ListGroupMembersResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var ms []models.GroupMember
+	if ms, err = d.db.GroupMembers().ListByGroup(req.Group); err != nil {
+		return
+	}
+	ret := make([]*types.GroupMember, 0, len(ms))
+	for _, m := range ms {
+		ret = append(ret, m.ToGRPCGroupMember())
+	}
+	res = &types.ListGroupMembersResponse{Members: ret}
+	return
+}
+
+func (d *Daemon) PutGroupMember(c context.Context, req *types.PutGroupMemberRequest) (res *types.PutGroupMemberResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	m := models.GroupMember{}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		// ensure group and user exist
+		if _, err = db.Groups().Get(req.Group); err != nil {
+			return
+		}
+		if _, err = db.Users().Get(req.Account); err != nil {
+			return
+		}
+		copier.Copy(&m, req)
+		m.Id = m.BuildId()
+		m.CreatedAt = now()
+		if err = db.GroupMembers().Save(&m); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionGroupMemberPut, "group_member:"+m.Id, nil, m.ToGRPCGroupMember())
+	}); err != nil {
+		return
+	}
+	d.grantWatcher.Notify(m.Account)
+	res = &types.PutGroupMemberResponse{Member: m.ToGRPCGroupMember()}
+	return
+}
+
+func (d *Daemon) DeleteGroupMember(c context.Context, req *types.DeleteGroupMemberRequest) (res *types.DeleteGroupMemberResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	m := models.GroupMember{}
+	copier.Copy(&m, req)
+	m.Id = m.BuildId()
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var before *types.GroupMember
+		var o models.GroupMember
+		if o, err = db.GroupMembers().Get(m.Id); err == nil {
+			m, before = o, o.ToGRPCGroupMember()
+		} else if err != errRecordNotFound {
+			return
+		}
+		if err = db.GroupMembers().Delete(m.Id); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionGroupMemberDelete, "group_member:"+m.Id, before, nil)
+	}); err != nil {
+		return
+	}
+	d.grantWatcher.Notify(m.Account)
+	res = &types.DeleteGroupMemberResponse{}
+	return
+}
+
+// listGroupNames names of groups the account belongs to
+func (d *Daemon) listGroupNames(account string) (names []string, err error) {
+	var ms []models.GroupMember
+	if ms, err = d.db.GroupMembers().ListByAccount(account); err != nil {
+		return
+	}
+	names = make([]string, 0, len(ms))
+	for _, m := range ms {
+		names = append(names, m.Group)
+	}
+	return
+}
+
+// listGroupAccounts accounts of members of the group
+func (d *Daemon) listGroupAccounts(group string) (accounts []string, err error) {
+	var ms []models.GroupMember
+	if ms, err = d.db.GroupMembers().ListByGroup(group); err != nil {
+		return
+	}
+	accounts = make([]string, 0, len(ms))
+	for _, m := range ms {
+		accounts = append(accounts, m.Account)
+	}
+	return
+}
@@ -0,0 +1,150 @@
+package daemon
+
+import (
+	"context"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+func TestDaemon_PutListDeleteGroupMembers(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		gs := types.NewGroupServiceClient(conn)
+		us := types.NewUserServiceClient(conn)
+
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "test1", Password: "qwerty"})
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "test2", Password: "qwerty"})
+
+		if _, err := gs.PutGroup(context.Background(), &types.PutGroupRequest{Name: "dba", Description: "database admins"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gs.PutGroup(context.Background(), &types.PutGroupRequest{Name: "ops"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gs.PutGroup(context.Background(), &types.PutGroupRequest{Name: "@bad"}); err == nil {
+			t.Fatal("should fail with invalid name")
+		}
+		if _, err := gs.PutGroupMember(context.Background(), &types.PutGroupMemberRequest{Group: "dba", Account: "test1"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gs.PutGroupMember(context.Background(), &types.PutGroupMemberRequest{Group: "dba", Account: "test2"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gs.PutGroupMember(context.Background(), &types.PutGroupMemberRequest{Group: "ops", Account: "test1"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gs.PutGroupMember(context.Background(), &types.PutGroupMemberRequest{Group: "ops", Account: "nobody"}); err == nil {
+			t.Fatal("should fail with non-existed user")
+		}
+		if _, err := gs.PutGroupMember(context.Background(), &types.PutGroupMemberRequest{Group: "nogroup", Account: "test1"}); err == nil {
+			t.Fatal("should fail with non-existed group")
+		}
+		res1, err := gs.ListGroups(context.Background(), &types.ListGroupsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res1.Groups) != 2 {
+			t.Fatal("bad groups count")
+		}
+		res1, err = gs.ListGroups(context.Background(), &types.ListGroupsRequest{Account: "test2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res1.Groups) != 1 || res1.Groups[0].Name != "dba" || res1.Groups[0].Description != "database admins" {
+			t.Fatal("bad groups of account", res1.Groups)
+		}
+		res2, err := gs.ListGroupMembers(context.Background(), &types.ListGroupMembersRequest{Group: "dba"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res2.Members) != 2 {
+			t.Fatal("bad members count")
+		}
+		if _, err = gs.DeleteGroupMember(context.Background(), &types.DeleteGroupMemberRequest{Group: "dba", Account: "test2"}); err != nil {
+			t.Fatal(err)
+		}
+		if res2, err = gs.ListGroupMembers(context.Background(), &types.ListGroupMembersRequest{Group: "dba"}); err != nil {
+			t.Fatal(err)
+		}
+		if len(res2.Members) != 1 || res2.Members[0].Account != "test1" {
+			t.Fatal("bad members after delete")
+		}
+		if _, err = gs.DeleteGroup(context.Background(), &types.DeleteGroupRequest{Name: "dba"}); err != nil {
+			t.Fatal(err)
+		}
+		if res1, err = gs.ListGroups(context.Background(), &types.ListGroupsRequest{Account: "test1"}); err != nil {
+			t.Fatal(err)
+		}
+		if len(res1.Groups) != 1 || res1.Groups[0].Name != "ops" {
+			t.Fatal("bad groups after delete", res1.Groups)
+		}
+	})
+}
+
+func TestDaemon_GroupGrantsAndVolumes(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		gs := types.NewGroupServiceClient(conn)
+		us := types.NewUserServiceClient(conn)
+		rs := types.NewGrantServiceClient(conn)
+		ns := types.NewNodeServiceClient(conn)
+		vs := types.NewVolumeServiceClient(conn)
+
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "test1", Password: "qwerty"})
+		ns.PutNode(context.Background(), &types.PutNodeRequest{Hostname: "db.host1", Address: "127.0.0.1:2222"})
+		gs.PutGroup(context.Background(), &types.PutGroupRequest{Name: "dba"})
+		gs.PutGroupMember(context.Background(), &types.PutGroupMemberRequest{Group: "dba", Account: "test1"})
+
+		if _, err := rs.PutGrant(context.Background(), &types.PutGrantRequest{Account: "test1", Group: "dba", HostnamePattern: "db.*", User: "root"}); err == nil {
+			t.Fatal("should fail with both account and group")
+		}
+		if _, err := rs.PutGrant(context.Background(), &types.PutGrantRequest{Group: "dba", HostnamePattern: "db.*", User: "mysql"}); err != nil {
+			t.Fatal(err)
+		}
+		res1, err := rs.ListGrants(context.Background(), &types.ListGrantsRequest{Group: "dba"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res1.Grants) != 1 || res1.Grants[0].Group != "dba" {
+			t.Fatal("bad group grants", res1.Grants)
+		}
+		if res1, err = rs.ListGrants(context.Background(), &types.ListGrantsRequest{Account: "test1"}); err != nil {
+			t.Fatal(err)
+		}
+		if len(res1.Grants) != 0 {
+			t.Fatal("group grants should not be listed as account grants")
+		}
+		res2, err := rs.CheckGrant(context.Background(), &types.CheckGrantRequest{Account: "test1", Hostname: "db.host1", User: "mysql"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res2.Ok {
+			t.Fatal("group grant should be effective")
+		}
+		res3, err := rs.ListGrantItems(context.Background(), &types.ListGrantItemsRequest{Account: "test1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res3.GrantItems) != 1 || res3.GrantItems[0].User != "mysql" {
+			t.Fatal("bad grant items", res3.GrantItems)
+		}
+
+		vs.PutVolume(context.Background(), &types.PutVolumeRequest{Name: "dumps"})
+		vs.PutVolumeMember(context.Background(), &types.PutVolumeMemberRequest{Volume: "dumps", Kind: types.VolumeMemberKindGroup, Name: "dba", IsReadOnly: true})
+		res4, err := vs.ListVolumeMounts(context.Background(), &types.ListVolumeMountsRequest{Account: "test1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res4.Mounts) != 1 || res4.Mounts[0].Volume != "dumps" || !res4.Mounts[0].IsReadOnly {
+			t.Fatal("bad volume mounts", res4.Mounts)
+		}
+
+		// removing member revokes group grants
+		gs.DeleteGroupMember(context.Background(), &types.DeleteGroupMemberRequest{Group: "dba", Account: "test1"})
+		if res2, err = rs.CheckGrant(context.Background(), &types.CheckGrantRequest{Account: "test1", Hostname: "db.host1", User: "mysql"}); err != nil {
+			t.Fatal(err)
+		}
+		if res2.Ok {
+			t.Fatal("group grant should be revoked")
+		}
+	})
+}
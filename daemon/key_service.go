@@ -1,7 +1,6 @@
 package daemon
 
 import (
-	"github.com/asdine/storm/q"
 	"github.com/jinzhu/copier"
 	"github.com/yankeguo/bastion/daemon/models"
 	"github.com/yankeguo/bastion/types"
@@ -13,7 +12,7 @@ func (d *Daemon) ListKeys(c context.Context, req *types.ListKeysRequest) (res *t
 		return
 	}
 	var keys []models.Key
-	if err = d.db.Find("Account", req.Account, &keys); err != nil {
+	if keys, err = d.db.Keys().ListByAccount(req.Account); err != nil {
 		return
 	}
 	ret := make([]*types.Key, 0, len(keys))
@@ -24,29 +23,39 @@ func (d *Daemon) ListKeys(c context.Context, req *types.ListKeysRequest) (res *t
 	return
 }
 
+// sandboxKeyCallers callers preparing sandboxes, system calls of them only create sandbox keys
+var sandboxKeyCallers = []string{types.SourceSSHD, types.SourceWeb}
+
 func (d *Daemon) CreateKey(c context.Context, req *types.CreateKeyRequest) (res *types.CreateKeyResponse, err error) {
 	if err = req.Validate(); err != nil {
 		return
 	}
+	if len(actorFromContext(c)) == 0 && containsString(sandboxKeyCallers, callerFromContext(c)) && req.Source != types.KeySourceSandbox {
+		err = errPermissionDenied
+		return
+	}
 	k := models.Key{}
-	if err = d.db.Tx(true, func(db *Node) (err error) {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
 		// delete existed sandbox keys
 		if req.Source == types.KeySourceSandbox {
-			if err = db.Select(q.Eq("Source", types.KeySourceSandbox), q.Eq("Account", req.Account)).Delete(new(models.Key)); err != nil {
+			if err = db.Keys().DeleteByAccountAndSource(req.Account, types.KeySourceSandbox); err != nil {
 				return
 			}
 		}
 		// check duplicated
-		if err = db.CheckDuplicated("Key", "fingerprint", req.Fingerprint); err != nil {
+		if _, err = db.Keys().Get(req.Fingerprint); err == nil {
+			err = errDuplicatedField("fingerprint")
+			return
+		} else if err != errRecordNotFound {
 			return
 		}
 		// copy and save new key
 		copier.Copy(&k, req)
 		k.CreatedAt = now()
-		if err = db.Save(&k); err != nil {
+		if err = db.Keys().Save(&k); err != nil {
 			return
 		}
-		return
+		return d.audit(c, db, types.AuditActionKeyCreate, "key:"+k.Fingerprint, nil, k.ToGRPCKey())
 	}); err != nil {
 		return
 	}
@@ -58,7 +67,27 @@ func (d *Daemon) DeleteKey(c context.Context, req *types.DeleteKeyRequest) (res
 	if err = req.Validate(); err != nil {
 		return
 	}
-	err = d.db.DeleteStruct(&models.Key{Fingerprint: req.Fingerprint})
+	// owner is checked outside of transaction, permissions of actor are read from database
+	if k, kerr := d.db.Keys().Get(req.Fingerprint); kerr == nil {
+		if err = d.authorizeAccount(c, types.PermissionUsersWrite, k.Account); err != nil {
+			return
+		}
+	}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var before *types.Key
+		var k models.Key
+		if k, err = db.Keys().Get(req.Fingerprint); err == nil {
+			before = k.ToGRPCKey()
+		} else if err != errRecordNotFound {
+			return
+		}
+		if err = db.Keys().Delete(req.Fingerprint); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionKeyDelete, "key:"+req.Fingerprint, before, nil)
+	}); err != nil {
+		return
+	}
 	res = &types.DeleteKeyResponse{}
 	return
 }
@@ -68,7 +97,10 @@ func (d *Daemon) GetKey(c context.Context, req *types.GetKeyRequest) (res *types
 		return
 	}
 	k := models.Key{}
-	if err = d.db.One("Fingerprint", req.Fingerprint, &k); err != nil {
+	if k, err = d.db.Keys().Get(req.Fingerprint); err != nil {
+		return
+	}
+	if err = d.authorizeAccount(c, types.PermissionUsersRead, k.Account); err != nil {
 		return
 	}
 	res = &types.GetKeyResponse{Key: k.ToGRPCKey()}
@@ -80,12 +112,12 @@ func (d *Daemon) TouchKey(c context.Context, req *types.TouchKeyRequest) (res *t
 		return
 	}
 	k := models.Key{}
-	if err = d.db.Tx(true, func(db *Node) (err error) {
-		if err = db.One("Fingerprint", req.Fingerprint, &k); err != nil {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		if k, err = db.Keys().Get(req.Fingerprint); err != nil {
 			return
 		}
 		k.ViewedAt = now()
-		if err = db.Save(&k); err != nil {
+		if err = db.Keys().Save(&k); err != nil {
 			return
 		}
 		return
@@ -6,9 +6,11 @@ import (
 	"golang.org/x/net/context"
 )
 
+const masterKeysTarget = "master_keys"
+
 func (d *Daemon) ListMasterKeys(ctx context.Context, req *types.ListMasterKeysRequest) (res *types.ListMasterKeysResponse, err error) {
 	var mKeys []models.MasterKey
-	if err = d.db.All(&mKeys); err != nil {
+	if mKeys, err = d.db.MasterKeys().List(); err != nil {
 		return
 	}
 	ret := make([]*types.MasterKey, 0, len(mKeys))
@@ -20,28 +22,33 @@ func (d *Daemon) ListMasterKeys(ctx context.Context, req *types.ListMasterKeysRe
 }
 
 func (d *Daemon) UpdateAllMasterKeys(ctx context.Context, req *types.UpdateAllMasterKeysRequest) (res *types.UpdateAllMasterKeysResponse, err error) {
-	if err = d.db.Tx(true, func(db *Node) (err error) {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
 		// find existing master keys
 		var mKeys []models.MasterKey
-		if err = db.All(&mKeys); err != nil {
+		if mKeys, err = db.MasterKeys().List(); err != nil {
 			return
 		}
+		before := make([]*types.MasterKey, 0, len(mKeys))
 		// delete all master keys
 		for _, k := range mKeys {
-			if err = db.DeleteStruct(&k); err != nil {
+			before = append(before, k.ToGRPCModel())
+			if err = db.MasterKeys().Delete(k.Fingerprint); err != nil {
 				return
 			}
 		}
 		// save new master keys
+		after := make([]*types.MasterKey, 0, len(req.MasterKeys))
 		for _, k := range req.MasterKeys {
-			if err = db.Save(&models.MasterKey{
+			mk := models.MasterKey{
 				Fingerprint: k.Fingerprint,
 				PublicKey:   k.PublicKey,
-			}); err != nil {
+			}
+			if err = db.MasterKeys().Save(&mk); err != nil {
 				return
 			}
+			after = append(after, mk.ToGRPCModel())
 		}
-		return
+		return d.audit(ctx, db, types.AuditActionMasterKeyUpdateAll, masterKeysTarget, before, after)
 	}); err != nil {
 		return
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/yankeguo/bastion/types"
 	"google.golang.org/grpc"
+	"strings"
 	"testing"
 )
 
@@ -72,5 +73,12 @@ func TestDaemon_UpdateAllAndListMasterKeys(t *testing.T) {
 				t.Fatal("what ?")
 			}
 		}
+		ares, err := types.NewAuditServiceClient(conn).ListAuditEvents(context.Background(), &types.ListAuditEventsRequest{Action: types.AuditActionMasterKeyUpdateAll})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ares.AuditEvents) != 2 || !strings.Contains(ares.AuditEvents[0].Before, `"fingerprint":"e"`) || !strings.Contains(ares.AuditEvents[0].After, `"fingerprint":"f"`) {
+			t.Fatal("bad audit events", ares.AuditEvents)
+		}
 	})
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"github.com/jinzhu/copier"
+	"github.com/yankeguo/bastion/types"
+)
+
+// AccessRequest request of a time-boxed grant, reviewed by approvers
+type AccessRequest struct {
+	Id              int64  `storm:"id,increment"`
+	Account         string `storm:"index"`
+	HostnamePattern string
+	User            string
+	Duration        int64
+	Justification   string
+	Status          string `storm:"index"`
+	Reviewer        string
+	ReviewComment   string
+	CreatedAt       int64
+	ReviewedAt      int64
+	GrantExpiredAt  int64
+}
+
+func (r AccessRequest) ToGRPCAccessRequest() *types.AccessRequest {
+	o := types.AccessRequest{}
+	copier.Copy(&o, &r)
+	return &o
+}
+
+// AccessRequestEvent history of an access request, every step is recorded
+type AccessRequestEvent struct {
+	Id        int64 `storm:"id,increment"`
+	RequestId int64 `storm:"index"`
+	Action    string
+	Actor     string
+	Comment   string
+	CreatedAt int64
+}
+
+func (e AccessRequestEvent) ToGRPCAccessRequestEvent() *types.AccessRequestEvent {
+	o := types.AccessRequestEvent{}
+	copier.Copy(&o, &e)
+	return &o
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/jinzhu/copier"
+	"github.com/yankeguo/bastion/types"
+)
+
+// AuditEvent append-only record of a change to security state, never updated or deleted
+type AuditEvent struct {
+	Id        int64  `storm:"id,increment"`
+	Actor     string `storm:"index"`
+	Action    string `storm:"index"`
+	Target    string `storm:"index"`
+	Before    string
+	After     string
+	Source    string `storm:"index"`
+	Address   string
+	CreatedAt int64 `storm:"index"`
+}
+
+func (e AuditEvent) ToGRPCAuditEvent() *types.AuditEvent {
+	o := types.AuditEvent{}
+	copier.Copy(&o, &e)
+	return &o
+}
@@ -8,14 +8,25 @@ import (
 type Grant struct {
 	Id              string `storm:"id"`
 	Account         string `storm:"index"`
+	Group           string `storm:"index"`
 	HostnamePattern string
+	LabelSelector   string
 	User            string
 	ExpiredAt       int64
 	CreatedAt       int64
 }
 
 func (n Grant) BuildId() string {
-	return n.Account + "$" + n.HostnamePattern + "$" + n.User
+	id := n.Account + "$" + n.HostnamePattern + "$" + n.User
+	// group grants are prefixed with '@', which is not allowed in account
+	if len(n.Group) > 0 {
+		id = "@" + n.Group + "$" + n.HostnamePattern + "$" + n.User
+	}
+	// keeps id of grants without label selector unchanged
+	if len(n.LabelSelector) > 0 {
+		id = id + "$" + n.LabelSelector
+	}
+	return id
 }
 
 func (n Grant) ToGRPCGrant() *types.Grant {
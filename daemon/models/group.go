@@ -0,0 +1,39 @@
+package models
+
+import (
+	"github.com/jinzhu/copier"
+	"github.com/yankeguo/bastion/types"
+)
+
+// Group group of users, grants and shared volumes can target a group
+type Group struct {
+	Name        string `storm:"id"`
+	Description string
+	Roles       []string
+	CreatedAt   int64
+}
+
+func (g Group) ToGRPCGroup() *types.Group {
+	o := types.Group{}
+	copier.Copy(&o, &g)
+	return &o
+}
+
+// GroupMember membership of a user in a group
+type GroupMember struct {
+	Id        string `storm:"id"`
+	Group     string `storm:"index"`
+	Account   string `storm:"index"`
+	Source    string
+	CreatedAt int64
+}
+
+func (m GroupMember) BuildId() string {
+	return m.Group + "$" + m.Account
+}
+
+func (m GroupMember) ToGRPCGroupMember() *types.GroupMember {
+	o := types.GroupMember{}
+	copier.Copy(&o, &m)
+	return &o
+}
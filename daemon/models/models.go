@@ -8,4 +8,12 @@ var AllModels = []interface{}{
 	new(Session),
 	new(Token),
 	new(MasterKey),
+	new(Volume),
+	new(VolumeMember),
+	new(Transfer),
+	new(Group),
+	new(GroupMember),
+	new(AccessRequest),
+	new(AccessRequestEvent),
+	new(AuditEvent),
 }
@@ -13,6 +13,7 @@ type Node struct {
 	Source       string `storm:"index"`
 	CreatedAt    int64
 	IsKeyManaged bool
+	Labels       map[string]string
 	ViewedAt     int64
 }
 
@@ -6,12 +6,30 @@ import (
 )
 
 type Session struct {
-	Id         int64  `storm:"id,increment"`
-	Account    string `storm:"index"`
-	Command    string
-	CreatedAt  int64
-	FinishedAt int64
-	IsRecorded bool
+	Id             int64  `storm:"id,increment"`
+	Account        string `storm:"index"`
+	Command        string `sql:"column"`
+	CreatedAt      int64  `storm:"index"`
+	FinishedAt     int64  `storm:"index"`
+	IsRecorded     bool   `storm:"index"`
+	Stage          string
+	Hostname       string `storm:"index"`
+	User           string
+	ClientAddr     string
+	ClientVersion  string
+	KeyFingerprint string
+	PtyTerm        string
+	PtyWidth       int32
+	PtyHeight      int32
+	SshdInstance   string
+	ParentId       int64 `storm:"index"`
+	LegalHold      bool
+	PurgedAt       int64 `storm:"index"`
+	// ReplayDigest last link of hash chain over frames of replay, signed with ReplaySignature by ReplaySigner
+	ReplayDigest    string
+	ReplayFrames    int64
+	ReplaySignature string
+	ReplaySigner    string
 }
 
 func (s Session) ToGRPCSession() *types.Session {
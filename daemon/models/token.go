@@ -12,6 +12,11 @@ type Token struct {
 	Description string
 	CreatedAt   int64
 	ViewedAt    int64
+	Kind        string
+	Name        string
+	Scopes      []string
+	ExpiresAt   int64
+	IdleTimeout int64
 }
 
 func (n Token) ToGRPCToken() *types.Token {
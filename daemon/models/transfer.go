@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/jinzhu/copier"
+	"github.com/yankeguo/bastion/types"
+)
+
+// Transfer audit record of a file transferred into or out of a sandbox
+type Transfer struct {
+	Id        int64  `storm:"id,increment"`
+	Account   string `storm:"index"`
+	SessionId int64  `storm:"index"`
+	Source    string
+	Direction string
+	Filename  string
+	Mode      uint32
+	Size      int64
+	Sha256    string
+	CreatedAt int64
+}
+
+func (t Transfer) ToGRPCTransfer() *types.Transfer {
+	o := types.Transfer{}
+	copier.Copy(&o, &t)
+	return &o
+}
@@ -13,6 +13,10 @@ type User struct {
 	PasswordFailed int64
 	IsAdmin        bool
 	IsBlocked      bool
+	Roles          []string
+	Source         string
+	OidcIssuer     string
+	OidcSubject    string
 	CreatedAt      int64
 	UpdatedAt      int64
 	ViewedAt       int64
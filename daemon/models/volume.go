@@ -0,0 +1,39 @@
+package models
+
+import (
+	"github.com/jinzhu/copier"
+	"github.com/yankeguo/bastion/types"
+)
+
+// Volume shared volume mounted into sandboxes under /shared
+type Volume struct {
+	Name        string `storm:"id"`
+	Description string
+	CreatedAt   int64
+}
+
+func (v Volume) ToGRPCVolume() *types.Volume {
+	o := types.Volume{}
+	copier.Copy(&o, &v)
+	return &o
+}
+
+// VolumeMember membership of a user or a group in a shared volume
+type VolumeMember struct {
+	Id         string `storm:"id"`
+	Volume     string `storm:"index"`
+	Kind       string
+	Name       string `storm:"index"`
+	IsReadOnly bool
+	CreatedAt  int64
+}
+
+func (m VolumeMember) BuildId() string {
+	return m.Volume + "$" + m.Kind + "$" + m.Name
+}
+
+func (m VolumeMember) ToGRPCVolumeMember() *types.VolumeMember {
+	o := types.VolumeMember{}
+	copier.Copy(&o, &m)
+	return &o
+}
@@ -10,7 +10,7 @@ import (
 
 func (d *Daemon) ListNodes(c context.Context, req *types.ListNodesRequest) (res *types.ListNodesResponse, err error) {
 	var ns []models.Node
-	if err = d.db.All(&ns); err != nil {
+	if ns, err = d.db.Nodes().List(); err != nil {
 		return
 	}
 	ret := make([]*types.Node, 0, len(ns))
@@ -27,13 +27,29 @@ func (d *Daemon) PutNode(c context.Context, req *types.PutNodeRequest) (res *typ
 	if err = req.Validate(); err != nil {
 		return
 	}
-	// create node
+	ns := make([]models.Node, 0, 2)
 	n := models.Node{}
 	copier.Copy(&n, req)
 	n.CreatedAt = now()
-	if err = d.db.Save(&n); err != nil {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		// find existing node, grants matching previous labels should be notified
+		var before *types.Node
+		o := models.Node{}
+		if o, err = db.Nodes().Get(req.Hostname); err == nil {
+			ns = append(ns, o)
+			before = o.ToGRPCNode()
+		} else if err != errRecordNotFound {
+			return
+		}
+		// create node
+		if err = db.Nodes().Save(&n); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionNodePut, "node:"+n.Hostname, before, n.ToGRPCNode())
+	}); err != nil {
 		return
 	}
+	d.notifyNodeChanged(append(ns, n)...)
 	// build response
 	res = &types.PutNodeResponse{Node: n.ToGRPCNode()}
 	return
@@ -42,7 +58,23 @@ func (d *Daemon) PutNode(c context.Context, req *types.PutNodeRequest) (res *typ
 func (d *Daemon) DeleteNode(c context.Context, req *types.DeleteNodeRequest) (res *types.DeleteNodeResponse, err error) {
 	req.Hostname = strings.TrimSpace(req.Hostname)
 	res = &types.DeleteNodeResponse{}
-	err = d.db.DeleteStruct(&models.Node{Hostname: req.Hostname})
+	n := models.Node{Hostname: req.Hostname}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var before *types.Node
+		var o models.Node
+		if o, err = db.Nodes().Get(req.Hostname); err == nil {
+			n, before = o, o.ToGRPCNode()
+		} else if err != errRecordNotFound {
+			return
+		}
+		if err = db.Nodes().Delete(req.Hostname); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionNodeDelete, "node:"+req.Hostname, before, nil)
+	}); err != nil {
+		return
+	}
+	d.notifyNodeChanged(n)
 	return
 }
 
@@ -51,7 +83,7 @@ func (d *Daemon) GetNode(c context.Context, req *types.GetNodeRequest) (res *typ
 		return
 	}
 	n := models.Node{}
-	if err = d.db.One("Hostname", req.Hostname, &n); err != nil {
+	if n, err = d.db.Nodes().Get(req.Hostname); err != nil {
 		return
 	}
 	res = &types.GetNodeResponse{Node: n.ToGRPCNode()}
@@ -63,12 +95,12 @@ func (d *Daemon) TouchNode(c context.Context, req *types.TouchNodeRequest) (res
 		return
 	}
 	n := models.Node{}
-	if err = d.db.Tx(true, func(db *Node) (err error) {
-		if err = db.One("Hostname", req.Hostname, &n); err != nil {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		if n, err = db.Nodes().Get(req.Hostname); err != nil {
 			return
 		}
 		n.ViewedAt = now()
-		if err = db.Save(&n); err != nil {
+		if err = db.Nodes().Save(&n); err != nil {
 			return
 		}
 		return
@@ -83,21 +115,28 @@ func (d *Daemon) UpdateNode(c context.Context, req *types.UpdateNodeRequest) (re
 	if err = req.Validate(); err != nil {
 		return
 	}
-	n := models.Node{}
-	if err = d.db.Tx(true, func(db *Node) (err error) {
-		if err = db.One("Hostname", req.Hostname, &n); err != nil {
+	n, o := models.Node{}, models.Node{}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		if n, err = db.Nodes().Get(req.Hostname); err != nil {
 			return
 		}
+		o = n
 		if req.UpdateIsKeyManaged {
 			n.IsKeyManaged = req.IsKeyManaged
 		}
-		if err = db.Save(&n); err != nil {
+		if req.UpdateLabels {
+			n.Labels = req.Labels
+		}
+		if err = db.Nodes().Save(&n); err != nil {
 			return
 		}
-		return
+		return d.audit(c, db, types.AuditActionNodeUpdate, "node:"+n.Hostname, o.ToGRPCNode(), n.ToGRPCNode())
 	}); err != nil {
 		return
 	}
+	if req.UpdateLabels {
+		d.notifyNodeChanged(o, n)
+	}
 	res = &types.UpdateNodeResponse{Node: n.ToGRPCNode()}
 	return
 }
@@ -0,0 +1,275 @@
+package daemon
+
+import (
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errPermissionDenied = status.Error(codes.PermissionDenied, "permission denied")
+	errActorRequired    = status.Error(codes.PermissionDenied, "actor required")
+)
+
+// actorFromContext account on whose behalf the request is made, empty for system calls from callers like sshd and bastionadmin
+func actorFromContext(c context.Context) string {
+	md, ok := metadata.FromIncomingContext(c)
+	if !ok {
+		return ""
+	}
+	vs := md.Get(types.MetadataKeyActor)
+	if len(vs) == 0 {
+		return ""
+	}
+	return vs[0]
+}
+
+// effectiveRoles roles of user and groups of user, legacy is_admin is treated as super-admin
+func (d *Daemon) effectiveRoles(u models.User) (roles []string, err error) {
+	for _, r := range u.Roles {
+		roles = appendUniqueString(roles, r)
+	}
+	if u.IsAdmin {
+		roles = appendUniqueString(roles, types.RoleSuperAdmin)
+	}
+	var names []string
+	if names, err = d.listGroupNames(u.Account); err != nil {
+		return
+	}
+	for _, name := range names {
+		g := models.Group{}
+		if g, err = d.db.Groups().Get(name); err != nil {
+			if err == errRecordNotFound {
+				err = nil
+				continue
+			}
+			return
+		}
+		for _, r := range g.Roles {
+			roles = appendUniqueString(roles, r)
+		}
+	}
+	return
+}
+
+// actorPermissions resolve permissions of the actor, blocked users have no permission at all
+func (d *Daemon) actorPermissions(actor string) (p utils.Permissions, err error) {
+	u := models.User{}
+	if u, err = d.db.Users().Get(actor); err != nil {
+		if err == errRecordNotFound {
+			err = errPermissionDenied
+		}
+		return
+	}
+	if u.IsBlocked {
+		err = errPermissionDenied
+		return
+	}
+	var roles []string
+	if roles, err = d.effectiveRoles(u); err != nil {
+		return
+	}
+	p = utils.ResolvePermissions(roles)
+	return
+}
+
+// authorize check the request against permissions of the actor, requests not listed are denied,
+// requests carrying no account or hostname are further checked by services with authorizeAccount or authorizeHostname
+func authorize(p utils.Permissions, actor string, req interface{}) bool {
+	switch r := req.(type) {
+	// users
+	case *types.ListUsersRequest:
+		return p.Has(types.PermissionUsersRead)
+	case *types.GetUserRequest:
+		return r.Account == actor || p.Has(types.PermissionUsersRead)
+	case *types.TouchUserRequest:
+		return r.Account == actor || p.Has(types.PermissionUsersWrite)
+	case *types.AuthenticateUserRequest:
+		return r.Account == actor
+	case *types.CreateUserRequest:
+		return p.Has(types.PermissionUsersWrite)
+	case *types.UpdateUserRequest:
+		if r.UpdateIsAdmin || r.UpdateRoles {
+			return p.Has(types.PermissionRolesWrite)
+		}
+		if r.Account == actor && !r.UpdateIsBlocked {
+			return true
+		}
+		return p.Has(types.PermissionUsersWrite)
+	// keys and tokens, owners of GetKey, DeleteKey, GetToken and DeleteToken are checked by services
+	case *types.ListKeysRequest:
+		return r.Account == actor || p.Has(types.PermissionUsersRead)
+	case *types.CreateKeyRequest:
+		return r.Account == actor || p.Has(types.PermissionUsersWrite)
+	case *types.GetKeyRequest, *types.DeleteKeyRequest:
+		return true
+	case *types.TouchKeyRequest:
+		return p.Has(types.PermissionUsersWrite)
+	case *types.ListTokensRequest:
+		return r.Account == actor || p.Has(types.PermissionUsersRead)
+	case *types.CreateTokenRequest:
+		return r.Account == actor || p.Has(types.PermissionUsersWrite)
+	case *types.GetTokenRequest, *types.DeleteTokenRequest:
+		return true
+	case *types.TouchTokenRequest:
+		return p.Has(types.PermissionUsersWrite)
+	// nodes
+	case *types.ListNodesRequest:
+		return p.Has(types.PermissionNodesRead)
+	case *types.PutNodeRequest:
+		return p.HasForHostname(types.PermissionNodesWrite, r.Hostname)
+	case *types.UpdateNodeRequest:
+		return p.HasForHostname(types.PermissionNodesWrite, r.Hostname)
+	case *types.DeleteNodeRequest:
+		return p.HasForHostname(types.PermissionNodesWrite, r.Hostname)
+	case *types.GetNodeRequest:
+		return p.HasForHostname(types.PermissionNodesRead, r.Hostname)
+	case *types.TouchNodeRequest:
+		return p.HasForHostname(types.PermissionNodesWrite, r.Hostname)
+	// master keys
+	case *types.ListMasterKeysRequest:
+		return p.Has(types.PermissionNodesRead)
+	case *types.UpdateAllMasterKeysRequest:
+		return p.Has(types.PermissionNodesWrite)
+	// grants
+	case *types.ListGrantsRequest:
+		return (r.Account == actor && len(r.Group) == 0) || p.Has(types.PermissionGrantsRead)
+	case *types.PutGrantRequest:
+		return p.HasForHostname(types.PermissionGrantsWrite, r.HostnamePattern)
+	case *types.DeleteGrantRequest:
+		return p.HasForHostname(types.PermissionGrantsWrite, r.HostnamePattern)
+	case *types.CheckGrantRequest:
+		return r.Account == actor || p.HasForHostname(types.PermissionGrantsRead, r.Hostname)
+	case *types.ListGrantItemsRequest:
+		return r.Account == actor || p.Has(types.PermissionGrantsRead)
+	case *types.WatchGrantsRequest:
+		return p.Has(types.PermissionGrantsRead)
+	// access requests, approvers are further checked against hostname pattern of the request
+	case *types.CreateAccessRequestRequest:
+		return r.Account == actor || p.Has(types.PermissionGrantsWrite)
+	case *types.ListAccessRequestsRequest:
+		return (r.Account == actor && len(actor) > 0) || p.Has(types.PermissionGrantsRead)
+	case *types.ReviewAccessRequestRequest:
+		return p.Has(types.PermissionGrantsWrite)
+	case *types.GetAccessRequestRequest, *types.CancelAccessRequestRequest:
+		return true
+	// audit
+	case *types.ListAuditEventsRequest:
+		return p.Has(types.PermissionAuditRead)
+	// backup, export and import, every imported record is checked
+	case *types.BackupRequest, *types.ExportRequest, *types.ModelRecord:
+		return p.Has(types.PermissionDatabaseBackup)
+	// sessions, replays and transfers
+	case *types.ListSessionsRequest, *types.GetSessionRequest, *types.ReadReplayRequest, *types.SearchReplayRequest, *types.GetTranscriptRequest, *types.VerifyReplayRequest, *types.ListTransfersRequest:
+		return p.Has(types.PermissionSessionsRead)
+	case *types.UpdateSessionLegalHoldRequest, *types.SubmitReplayRequest:
+		return p.Has(types.PermissionSessionsWrite)
+	case *types.CreateSessionRequest:
+		return r.Account == actor || p.Has(types.PermissionSessionsWrite)
+	case *types.FinishSessionRequest:
+		return true
+	case *types.CreateTransferRequest:
+		return r.Account == actor || p.Has(types.PermissionSessionsWrite)
+	case *types.ReplayFrame:
+		// replays are only written by system callers
+		return false
+	// volumes
+	case *types.ListVolumesRequest, *types.ListVolumeMembersRequest:
+		return p.Has(types.PermissionUsersRead)
+	case *types.PutVolumeRequest, *types.DeleteVolumeRequest, *types.PutVolumeMemberRequest, *types.DeleteVolumeMemberRequest:
+		return p.Has(types.PermissionUsersWrite)
+	case *types.ListVolumeMountsRequest:
+		return r.Account == actor || p.Has(types.PermissionUsersRead)
+	// groups
+	case *types.ListGroupsRequest:
+		return (r.Account == actor && len(actor) > 0) || p.Has(types.PermissionUsersRead)
+	case *types.ListGroupMembersRequest:
+		return p.Has(types.PermissionUsersRead)
+	case *types.PutGroupRequest:
+		if r.UpdateRoles {
+			return p.Has(types.PermissionRolesWrite)
+		}
+		return p.Has(types.PermissionUsersWrite)
+	case *types.DeleteGroupRequest, *types.PutGroupMemberRequest, *types.DeleteGroupMemberRequest:
+		return p.Has(types.PermissionUsersWrite)
+	}
+	return false
+}
+
+// authorizeRequest check the request against permissions of the actor, requests without actor are system calls,
+// which are already checked by authorizeCaller
+func (d *Daemon) authorizeRequest(c context.Context, req interface{}) (err error) {
+	actor := actorFromContext(c)
+	if len(actor) == 0 {
+		return
+	}
+	var p utils.Permissions
+	if p, err = d.actorPermissions(actor); err != nil {
+		return
+	}
+	if !authorize(p, actor, req) {
+		err = errPermissionDenied
+	}
+	return
+}
+
+func (d *Daemon) rbacUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	if err = d.authorizeRequest(ctx, req); err != nil {
+		return
+	}
+	return handler(ctx, req)
+}
+
+// rbacServerStream authorizes every message received from client
+type rbacServerStream struct {
+	grpc.ServerStream
+	d *Daemon
+}
+
+func (s *rbacServerStream) RecvMsg(m interface{}) (err error) {
+	if err = s.ServerStream.RecvMsg(m); err != nil {
+		return
+	}
+	return s.d.authorizeRequest(s.Context(), m)
+}
+
+func (d *Daemon) rbacStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	return handler(srv, &rbacServerStream{ServerStream: stream, d: d})
+}
+
+// authorizeHostname check the actor has the permission for the hostname, for requests not carrying the hostname themselves
+func (d *Daemon) authorizeHostname(c context.Context, perm string, hostname string) (err error) {
+	actor := actorFromContext(c)
+	if len(actor) == 0 {
+		return
+	}
+	var p utils.Permissions
+	if p, err = d.actorPermissions(actor); err != nil {
+		return
+	}
+	if !p.HasForHostname(perm, hostname) {
+		err = errPermissionDenied
+	}
+	return
+}
+
+// authorizeAccount check the actor is the account itself or has the permission, for requests not carrying the account themselves
+func (d *Daemon) authorizeAccount(c context.Context, perm string, account string) (err error) {
+	actor := actorFromContext(c)
+	if len(actor) == 0 || actor == account {
+		return
+	}
+	var p utils.Permissions
+	if p, err = d.actorPermissions(actor); err != nil {
+		return
+	}
+	if !p.Has(perm) {
+		err = errPermissionDenied
+	}
+	return
+}
@@ -0,0 +1,108 @@
+package daemon
+
+import (
+	"context"
+	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+func TestDaemon_RBAC(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		us := types.NewUserServiceClient(conn)
+		gs := types.NewGroupServiceClient(conn)
+		ns := types.NewNodeServiceClient(conn)
+		grs := types.NewGrantServiceClient(conn)
+
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "auditor1", Password: "qwerty"})
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "lead1", Password: "qwerty"})
+		us.CreateUser(context.Background(), &types.CreateUserRequest{Account: "normal1", Password: "qwerty"})
+
+		if _, err := us.UpdateUser(context.Background(), &types.UpdateUserRequest{Account: "auditor1", UpdateRoles: true, Roles: []string{"auditor"}}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := us.UpdateUser(context.Background(), &types.UpdateUserRequest{Account: "auditor1", UpdateRoles: true, Roles: []string{"auditor:web-*"}}); err == nil {
+			t.Fatal("should fail with scoped auditor")
+		}
+		gs.PutGroup(context.Background(), &types.PutGroupRequest{Name: "leads", UpdateRoles: true, Roles: []string{"node-admin:web-*"}})
+		gs.PutGroupMember(context.Background(), &types.PutGroupMemberRequest{Group: "leads", Account: "lead1"})
+
+		res1, err := us.GetUser(context.Background(), &types.GetUserRequest{Account: "lead1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res1.EffectiveRoles) != 1 || res1.EffectiveRoles[0] != "node-admin:web-*" {
+			t.Fatal("bad effective roles", res1.EffectiveRoles)
+		}
+
+		as := func(account string) context.Context {
+			return metadata.AppendToOutgoingContext(context.Background(), types.MetadataKeyActor, account)
+		}
+
+		// auditor reads but never writes
+		if _, err = us.ListUsers(as("auditor1"), &types.ListUsersRequest{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = grs.PutGrant(as("auditor1"), &types.PutGrantRequest{Account: "normal1", HostnamePattern: "web-1", User: "root"}); err == nil {
+			t.Fatal("auditor should not put grant")
+		}
+		// node-admin writes grants within scope only
+		if _, err = grs.PutGrant(as("lead1"), &types.PutGrantRequest{Account: "normal1", HostnamePattern: "web-1", User: "root"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = grs.PutGrant(as("lead1"), &types.PutGrantRequest{Account: "normal1", HostnamePattern: "db-1", User: "root"}); err == nil {
+			t.Fatal("node-admin should not put grant out of scope")
+		}
+		if _, err = ns.DeleteNode(as("lead1"), &types.DeleteNodeRequest{Hostname: "db-1"}); err == nil {
+			t.Fatal("node-admin should not delete node out of scope")
+		}
+		if _, err = us.UpdateUser(as("lead1"), &types.UpdateUserRequest{Account: "normal1", UpdateRoles: true, Roles: []string{"super-admin"}}); err == nil {
+			t.Fatal("node-admin should not update roles")
+		}
+		// normal user can only access itself
+		if _, err = us.GetUser(as("normal1"), &types.GetUserRequest{Account: "normal1"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = us.GetUser(as("normal1"), &types.GetUserRequest{Account: "lead1"}); err == nil {
+			t.Fatal("normal user should not get other user")
+		}
+		if _, err = us.UpdateUser(as("normal1"), &types.UpdateUserRequest{Account: "normal1", UpdateNickname: true, Nickname: "Normal"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = us.UpdateUser(as("normal1"), &types.UpdateUserRequest{Account: "normal1", UpdateIsAdmin: true, IsAdmin: true}); err == nil {
+			t.Fatal("normal user should not promote itself")
+		}
+		// owners of keys are checked by service
+		ks := types.NewKeyServiceClient(conn)
+		if _, err = ks.CreateKey(context.Background(), &types.CreateKeyRequest{Account: "lead1", Fingerprint: "SHA256:lead1lead1lead1lead1lead1lead1lead1lead1lea", Name: "lead1"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = ks.GetKey(as("normal1"), &types.GetKeyRequest{Fingerprint: "SHA256:lead1lead1lead1lead1lead1lead1lead1lead1lea"}); err == nil {
+			t.Fatal("normal user should not get key of other user")
+		}
+		if _, err = ks.DeleteKey(as("normal1"), &types.DeleteKeyRequest{Fingerprint: "SHA256:lead1lead1lead1lead1lead1lead1lead1lead1lea"}); err == nil {
+			t.Fatal("normal user should not delete key of other user")
+		}
+		if _, err = ks.GetKey(as("lead1"), &types.GetKeyRequest{Fingerprint: "SHA256:lead1lead1lead1lead1lead1lead1lead1lead1lea"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = types.NewMasterKeyServiceClient(conn).UpdateAllMasterKeys(as("normal1"), &types.UpdateAllMasterKeysRequest{}); err == nil {
+			t.Fatal("normal user should not update master keys")
+		}
+	})
+}
+
+func TestAuthorize_Unlisted(t *testing.T) {
+	p := utils.ResolvePermissions([]string{types.RoleSuperAdmin})
+	if authorize(p, "admin", &struct{}{}) {
+		t.Fatal("unlisted request should be denied")
+	}
+	if authorize(p, "admin", &types.ReplayFrame{}) {
+		t.Fatal("replay frame should be denied for actors")
+	}
+	if !authorize(p, "admin", &types.ListUsersRequest{}) {
+		t.Fatal("super-admin should list users")
+	}
+}
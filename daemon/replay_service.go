@@ -3,24 +3,33 @@ package daemon
 import (
 	"compress/gzip"
 	"encoding/json"
+	"fmt"
 	"io"
-	"os"
-	"path/filepath"
 	"time"
 
-	"github.com/olivere/elastic"
+	"github.com/rs/zerolog/log"
 
 	"github.com/yankeguo/bastion/daemon/models"
 
 	"github.com/yankeguo/bastion/types"
 	"github.com/yankeguo/bastion/utils"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errReplayPurged = status.Error(codes.FailedPrecondition, "replay is purged by retention policy")
+	errReplaySigned = status.Error(codes.AlreadyExists, "replay is already stored and signed")
 )
 
 func (d *Daemon) WriteReplay(s types.ReplayService_WriteReplayServer) (err error) {
-	var w *os.File
+	var w io.WriteCloser
 	var zw *gzip.Writer
 	var sessionID int64
+	// failed replay failed to store, it's neither signed nor submitted
+	var failed bool
+	chain := utils.NewReplayHashChain()
 	for {
 		var f *types.ReplayFrame
 		// receive frame
@@ -32,46 +41,83 @@ func (d *Daemon) WriteReplay(s types.ReplayService_WriteReplayServer) (err error
 		}
 		// ensure rec frame writer
 		if zw == nil {
-			// create filename
+			// signed replay must not be replaced
 			sessionID = f.SessionId
-			filename := FilenameForSessionID(sessionID, d.opts.ReplayDir)
-			// ensure directory
-			if err = os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+			var sess models.Session
+			if sess, err = d.db.Sessions().Get(sessionID); err != nil {
+				break
+			}
+			if sess.PurgedAt > 0 {
+				err = errReplayPurged
 				break
 			}
-			// open file
-			if w, err = os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0640); err != nil {
+			if len(sess.ReplayDigest) > 0 {
+				err = errReplaySigned
+				break
+			}
+			// create replay
+			if w, err = d.replays.Create(sessionID, replaySuffixFrames); err != nil {
 				break
 			}
 			// create frame writer with GZIP
 			zw = gzip.NewWriter(w)
 		}
-		// write the frame
-		if err = utils.WriteReplayFrame(f, zw); err != nil {
+		// write the frame, large frames are split since readers refuse them
+		for _, sf := range utils.SplitReplayFrame(f) {
+			if err = utils.WriteReplayFrame(sf, zw); err != nil {
+				break
+			}
+			chain.Write(sf)
+		}
+		if err != nil {
+			failed = true
 			break
 		}
 	}
+	// replay is not created
+	if w == nil {
+		return
+	}
 	// close GZIP writer
-	if zw != nil {
-		zw.Close()
+	if zerr := zw.Close(); zerr != nil {
+		failed = true
+		if err == nil {
+			err = zerr
+		}
 	}
-	// close the GZIP writer won't close the file, so we have to close it manually
-	if w != nil {
-		w.Close()
+	// close the GZIP writer won't close the replay, so we have to close it manually,
+	// replay is not stored until closed
+	if cerr := w.Close(); cerr != nil {
+		failed = true
+		if err == nil {
+			err = cerr
+		}
+	}
+	if failed {
+		return
+	}
+	// replay is stored, record signed digest of frames written
+	if serr := d.signReplay(sessionID, chain); serr != nil && err == nil {
+		err = serr
 	}
 	// submit replay to elasticsearch
-	if sessionID > 0 {
-		if err = d.submitReplay(sessionID); err != nil {
-			return
-		}
+	if serr := d.submitReplay(sessionID); serr != nil && err == nil {
+		err = serr
 	}
 	return
 }
 
 func (d *Daemon) ReadReplay(req *types.ReadReplayRequest, s types.ReplayService_ReadReplayServer) (err error) {
-	filename := FilenameForSessionID(req.SessionId, d.opts.ReplayDir)
-	var r *os.File
-	if r, err = os.Open(filename); err != nil {
+	var sess models.Session
+	if sess, err = d.db.Sessions().Get(req.SessionId); err != nil {
+		return
+	}
+	if sess.PurgedAt > 0 {
+		err = errReplayPurged
+		return
+	}
+	var r io.ReadCloser
+	if r, err = d.replays.Open(req.SessionId, replaySuffixFrames); err != nil {
 		return
 	}
 	defer r.Close()
@@ -96,44 +142,123 @@ func (d *Daemon) ReadReplay(req *types.ReadReplayRequest, s types.ReplayService_
 	return
 }
 
-func (d *Daemon) submitReplay(sessionID int64) (err error) {
-	// find session
-	s := models.Session{}
-	if err = d.db.One("Id", sessionID, &s); err != nil {
-		return
-	}
-	// open file
-	filename := FilenameForSessionID(sessionID, d.opts.ReplayDir)
-	var r *os.File
-	if r, err = os.Open(filename); err != nil {
+// readReplay read all frames of replay
+func (d *Daemon) readReplay(sessionID int64, fn func(f *types.ReplayFrame) error) (err error) {
+	// open replay
+	var r io.ReadCloser
+	if r, err = d.replays.Open(sessionID, replaySuffixFrames); err != nil {
 		return
 	}
 	defer r.Close()
+	return readReplayFrames(r, fn)
+}
+
+// readReplayFrames read all frames from gzipped replay file
+func readReplayFrames(r io.Reader, fn func(f *types.ReplayFrame) error) (err error) {
 	// unzip stream
 	var zr *gzip.Reader
 	if zr, err = gzip.NewReader(r); err != nil {
 		return
 	}
 	defer zr.Close()
-	// submitter
-	st := NewReplaySubmitter(time.Unix(s.CreatedAt, 0), s.Id, s.Account, d.esClient)
 	for {
 		var f types.ReplayFrame
 		if err = utils.ReadReplayFrame(&f, zr); err != nil {
 			if err == io.EOF {
 				err = nil
-				break
-			} else {
-				return
 			}
+			return
 		}
-		if err = st.Add(f); err != nil {
+		if err = fn(&f); err != nil {
 			return
 		}
 	}
+}
+
+// submitReplay submit contents and commands of replay to search index, transcript is saved alongside the replay
+func (d *Daemon) submitReplay(sessionID int64) (err error) {
+	// find session
+	s := models.Session{}
+	if s, err = d.db.Sessions().Get(sessionID); err != nil {
+		return
+	}
+	var hostnames []string
+	if hostnames, err = d.sessionHostnames(s); err != nil {
+		return
+	}
+	// submitter
+	st := NewReplaySubmitter(time.Unix(s.CreatedAt, 0), s.Id, s.Account, d.search)
+	st.Hostnames = hostnames
+	tr := utils.NewTranscriber()
+	if err = d.readReplay(sessionID, func(f *types.ReplayFrame) error {
+		tr.WriteFrame(f)
+		return st.Add(*f)
+	}); err != nil {
+		return
+	}
 	if err = st.Close(); err != nil {
 		return
 	}
+	entries := tr.Entries()
+	if err = d.saveTranscript(sessionID, entries); err != nil {
+		return
+	}
+	commands := make([]CommandIndice, 0, len(entries))
+	for _, e := range entries {
+		commands = append(commands, CommandIndice{
+			SessionId: s.Id,
+			Timestamp: e.Timestamp,
+			Command:   e.Command,
+			Account:   s.Account,
+			Hostnames: hostnames,
+			CreatedAt: time.Unix(s.CreatedAt, 0),
+		})
+	}
+	if err = d.search.IndexCommands(s.Id, commands); err != nil {
+		return
+	}
+	return
+}
+
+func (d *Daemon) saveTranscript(sessionID int64, entries []*types.TranscriptEntry) (err error) {
+	var buf []byte
+	if buf, err = json.Marshal(entries); err != nil {
+		return
+	}
+	var w io.WriteCloser
+	if w, err = d.replays.Create(sessionID, replaySuffixTranscript); err != nil {
+		return
+	}
+	if _, err = w.Write(buf); err != nil {
+		w.Close()
+		return
+	}
+	return w.Close()
+}
+
+// loadTranscript load saved transcript, or extract from replay if not saved yet
+func (d *Daemon) loadTranscript(sessionID int64) (entries []*types.TranscriptEntry, err error) {
+	var r io.ReadCloser
+	if r, err = d.replays.Open(sessionID, replaySuffixTranscript); err == nil {
+		defer r.Close()
+		err = json.NewDecoder(r).Decode(&entries)
+		return
+	}
+	if err != errReplayNotExist {
+		return
+	}
+	tr := utils.NewTranscriber()
+	if err = d.readReplay(sessionID, func(f *types.ReplayFrame) error {
+		tr.WriteFrame(f)
+		return nil
+	}); err != nil {
+		return
+	}
+	entries = tr.Entries()
+	if err = d.saveTranscript(sessionID, entries); err != nil {
+		log.Error().Err(err).Int64("sessionId", sessionID).Msg("failed to save transcript")
+		err = nil
+	}
 	return
 }
 
@@ -141,6 +266,10 @@ func (d *Daemon) SubmitReplay(ctx context.Context, req *types.SubmitReplayReques
 	if err = req.Validate(); err != nil {
 		return
 	}
+	// documents indexed previously are replaced, without duplicates
+	if err = d.search.DeleteSession(req.SessionId); err != nil {
+		return
+	}
 	if err = d.submitReplay(req.SessionId); err != nil {
 		return
 	}
@@ -152,35 +281,131 @@ func (d *Daemon) SearchReplay(ctx context.Context, req *types.SearchReplayReques
 	if err = req.Validate(); err != nil {
 		return
 	}
-	var sres *elastic.SearchResult
-	if sres, err = d.esClient.Search().Index(types.ReplayElasticsearchIndexPrefix + "*").Query(elastic.NewTermQuery("content", req.Keyword)).From(0).Size(100).Do(context.Background()); err != nil {
+	var results []*types.ReplaySearchResult
+	var total int64
+	if results, total, err = d.search.Search(SearchQuery{
+		Keyword:  req.Keyword,
+		Commands: req.Commands,
+		Match:    req.Match,
+		Account:  req.Account,
+		Hostname: req.Hostname,
+		Since:    req.Since,
+		Until:    req.Until,
+		From:     int(req.From),
+		Size:     int(req.Size),
+	}); err != nil {
 		return
 	}
-	hits := sres.Hits
-	if hits == nil {
-		err = errRecordNotFound
+	resp = &types.SearchReplayResponse{
+		Results: results,
+		Total:   total,
+	}
+	return
+}
+
+func (d *Daemon) GetTranscript(ctx context.Context, req *types.GetTranscriptRequest) (resp *types.GetTranscriptResponse, err error) {
+	if err = req.Validate(); err != nil {
 		return
 	}
-	resp = &types.SearchReplayResponse{
-		Results: []*types.ReplaySearchResult{},
+	var s models.Session
+	if s, err = d.db.Sessions().Get(req.SessionId); err != nil {
+		return
 	}
-	for _, h := range hits.Hits {
-		if h == nil {
-			continue
-		}
-		if h.Source == nil {
-			continue
+	if s.PurgedAt > 0 {
+		err = errReplayPurged
+		return
+	}
+	if !s.IsRecorded {
+		err = errReplayNotExist
+		return
+	}
+	var entries []*types.TranscriptEntry
+	if entries, err = d.loadTranscript(req.SessionId); err != nil {
+		return
+	}
+	resp = &types.GetTranscriptResponse{Entries: entries}
+	return
+}
+
+// signReplay record signed digest of replay in session, digest recorded already is never replaced
+func (d *Daemon) signReplay(sessionID int64, chain *utils.ReplayHashChain) error {
+	digest, frames := chain.Digest(), chain.Frames()
+	signature := d.signer.Sign(sessionID, frames, digest)
+	return d.db.Tx(true, func(db Repositories) (err error) {
+		var s models.Session
+		if s, err = db.Sessions().Get(sessionID); err != nil {
+			return
 		}
-		var ri ReplayIndice
-		if err = json.Unmarshal(*h.Source, &ri); err != nil {
+		if len(s.ReplayDigest) > 0 {
+			err = errReplaySigned
 			return
 		}
-		resp.Results = append(resp.Results, &types.ReplaySearchResult{
-			SessionId: ri.SessionId,
-			Timestamp: ri.Timestamp,
-			Account:   ri.Account,
-			CreatedAt: ri.CreatedAt.Unix(),
-		})
+		s.ReplayDigest = digest
+		s.ReplayFrames = frames
+		s.ReplaySignature = signature
+		s.ReplaySigner = d.signer.PublicKey()
+		return db.Sessions().Save(&s)
+	})
+}
+
+func (d *Daemon) VerifyReplay(ctx context.Context, req *types.VerifyReplayRequest) (resp *types.VerifyReplayResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var s models.Session
+	if s, err = d.db.Sessions().Get(req.SessionId); err != nil {
+		return
+	}
+	if s.PurgedAt > 0 {
+		err = errReplayPurged
+		return
+	}
+	if !s.IsRecorded {
+		err = errReplayNotExist
+		return
+	}
+	resp = &types.VerifyReplayResponse{SessionId: s.Id, Signer: s.ReplaySigner}
+	if len(s.ReplayDigest) == 0 {
+		// digest is recorded once the replay is stored, cleared digest must not pass as unsigned
+		if s.FinishedAt > 0 && d.signer.IsRequired(s.CreatedAt) {
+			resp.Result, resp.Message = types.ReplayVerifyNoDigest, "no digest recorded, but replays are signed since the session is created"
+		} else {
+			resp.Result, resp.Message = types.ReplayVerifyUnsigned, "no digest recorded"
+		}
+		return
+	}
+	// signature protects the recorded digest
+	if trusted, ok := d.signer.Verify(s.ReplaySigner, s.ReplaySignature, s.Id, s.ReplayFrames, s.ReplayDigest); !trusted {
+		resp.Result, resp.Message = types.ReplayVerifyUntrusted, "signed by untrusted key"
+		return
+	} else if !ok {
+		resp.Result, resp.Message = types.ReplayVerifyTampered, "bad signature of recorded digest"
+		return
+	}
+	// chain frames of stored replay
+	var r io.ReadCloser
+	if r, err = d.replays.Open(s.Id, replaySuffixFrames); err != nil {
+		if err == errReplayNotExist {
+			err = nil
+			resp.Result, resp.Message = types.ReplayVerifyMissing, "replay file not found"
+		}
+		return
+	}
+	defer r.Close()
+	chain := utils.NewReplayHashChain()
+	if rerr := readReplayFrames(r, func(f *types.ReplayFrame) error {
+		chain.Write(f)
+		return nil
+	}); rerr != nil {
+		resp.Result, resp.Message = types.ReplayVerifyTampered, "replay file is corrupted: "+rerr.Error()
+		return
+	}
+	resp.Digest, resp.Frames = chain.Digest(), chain.Frames()
+	if resp.Digest != s.ReplayDigest || resp.Frames != s.ReplayFrames {
+		resp.Result = types.ReplayVerifyTampered
+		resp.Message = fmt.Sprintf("digest mismatch, %d frames recorded, %d frames found", s.ReplayFrames, resp.Frames)
+		return
 	}
+	resp.Result = types.ReplayVerifyValid
 	return
 }
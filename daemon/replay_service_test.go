@@ -1,9 +1,15 @@
 package daemon
 
 import (
+	"compress/gzip"
 	"context"
+	"github.com/yankeguo/bastion/daemon/models"
 	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
 	"testing"
 )
 
@@ -20,26 +26,33 @@ func TestDaemon_WriteReadReply(t *testing.T) {
 	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
 		rs := types.NewReplayServiceClient(conn)
 
+		// replays are submitted to elasticsearch with the session
+		res, err := types.NewSessionServiceClient(conn).CreateSession(context.Background(), &types.CreateSessionRequest{Account: "test", IsRecorded: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := res.Session.Id
+
 		s, err := rs.WriteReplay(context.Background())
 		if err != nil {
 			t.Fatal(err)
 		}
 		if err = s.Send(&types.ReplayFrame{
-			SessionId: 0x123400004321,
+			SessionId: id,
 			Type:      1,
 			Payload:   []byte{0x01, 0x02, 0x03, 0x04, 0x05},
 		}); err != nil {
 			t.Fatal(err)
 		}
 		if err = s.Send(&types.ReplayFrame{
-			SessionId: 0x123400004321,
+			SessionId: id,
 			Type:      2,
 			Payload:   []byte{0x05, 0x04, 0x03, 0x02, 0x01},
 		}); err != nil {
 			t.Fatal(err)
 		}
 		if err = s.Send(&types.ReplayFrame{
-			SessionId: 0x123400004321,
+			SessionId: id,
 			Type:      3,
 			Payload:   []byte{0x04, 0x03, 0x02, 0x01, 0x01, 0x02, 0x03, 0x04},
 		}); err != nil {
@@ -50,7 +63,7 @@ func TestDaemon_WriteReadReply(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		s2, err := rs.ReadReplay(context.Background(), &types.ReadReplayRequest{SessionId: 0x123400004321})
+		s2, err := rs.ReadReplay(context.Background(), &types.ReadReplayRequest{SessionId: id})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -59,7 +72,7 @@ func TestDaemon_WriteReadReply(t *testing.T) {
 			t.Fatal(err)
 		}
 		t.Log(f)
-		if f.Type != 1 || f.SessionId != 0x123400004321 || f.Payload[4] != 0x05 {
+		if f.Type != 1 || f.SessionId != id || f.Payload[4] != 0x05 {
 			t.Fatal("failed 1")
 		}
 		f, err = s2.Recv()
@@ -67,7 +80,7 @@ func TestDaemon_WriteReadReply(t *testing.T) {
 			t.Fatal(err)
 		}
 		t.Log(f)
-		if f.Type != 2 || f.SessionId != 0x123400004321 || f.Payload[4] != 0x01 {
+		if f.Type != 2 || f.SessionId != id || f.Payload[4] != 0x01 {
 			t.Fatal("failed 2")
 		}
 		f, err = s2.Recv()
@@ -75,7 +88,7 @@ func TestDaemon_WriteReadReply(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if f.Type != 3 || f.SessionId != 0x123400004321 || f.Payload[3] != 0x01 {
+		if f.Type != 3 || f.SessionId != id || f.Payload[3] != 0x01 {
 			t.Fatal("failed 3")
 		}
 		_, err = s2.Recv()
@@ -84,3 +97,177 @@ func TestDaemon_WriteReadReply(t *testing.T) {
 		}
 	})
 }
+
+func TestDaemon_GetTranscript(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		rs := types.NewReplayServiceClient(conn)
+		res, err := types.NewSessionServiceClient(conn).CreateSession(context.Background(), &types.CreateSessionRequest{Account: "test", IsRecorded: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := res.Session.Id
+		s, err := rs.WriteReplay(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, out := range []string{"test@web-1:~$ ", "uptime\r\n", "up 3 days\r\n", "test@web-1:~$ "} {
+			if err = s.Send(&types.ReplayFrame{SessionId: id, Timestamp: uint32(i * 100), Type: types.ReplayFrameTypeStdout, Payload: []byte(out)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err = s.CloseAndRecv(); err != nil {
+			t.Fatal(err)
+		}
+		// transcript is saved alongside the replay
+		if _, err = os.Stat(FilenameForSessionID(id, daemon.opts.ReplayDir) + replaySuffixTranscript); err != nil {
+			t.Fatal(err)
+		}
+		tres, err := rs.GetTranscript(context.Background(), &types.GetTranscriptRequest{SessionId: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tres.Entries) != 1 || tres.Entries[0].Command != "uptime" || tres.Entries[0].Timestamp != 100 || tres.Entries[0].Output != "up 3 days\n" {
+			t.Fatal("bad transcript", tres.Entries)
+		}
+		// transcript is extracted again if missing
+		os.Remove(FilenameForSessionID(id, daemon.opts.ReplayDir) + replaySuffixTranscript)
+		if tres, err = rs.GetTranscript(context.Background(), &types.GetTranscriptRequest{SessionId: id}); err != nil {
+			t.Fatal(err)
+		}
+		if len(tres.Entries) != 1 || tres.Entries[0].Command != "uptime" {
+			t.Fatal("bad transcript", tres.Entries)
+		}
+	})
+}
+
+func TestDaemon_VerifyReplay(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		rs, ss := types.NewReplayServiceClient(conn), types.NewSessionServiceClient(conn)
+		res, err := ss.CreateSession(context.Background(), &types.CreateSessionRequest{Account: "test", IsRecorded: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := res.Session.Id
+		verify := func(result string) *types.VerifyReplayResponse {
+			vres, err := rs.VerifyReplay(context.Background(), &types.VerifyReplayRequest{SessionId: id})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if vres.Result != result {
+				t.Fatal("bad result", vres)
+			}
+			return vres
+		}
+		// no replay written yet
+		verify(types.ReplayVerifyUnsigned)
+
+		s, err := rs.WriteReplay(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames := []*types.ReplayFrame{
+			{SessionId: id, Timestamp: 0, Type: types.ReplayFrameTypeStdout, Payload: []byte("test@web-1:~$ ")},
+			{SessionId: id, Timestamp: 100, Type: types.ReplayFrameTypeStdout, Payload: []byte("rm -rf /tmp/x\r\n")},
+		}
+		for _, f := range frames {
+			if err = s.Send(f); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err = s.CloseAndRecv(); err != nil {
+			t.Fatal(err)
+		}
+		// signed digest is recorded in session
+		sess, err := daemon.db.Sessions().Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sess.ReplayFrames != 2 || len(sess.ReplayDigest) != 64 || len(sess.ReplaySignature) == 0 || sess.ReplaySigner != daemon.signer.PublicKey() {
+			t.Fatal("bad digest", sess)
+		}
+		vres := verify(types.ReplayVerifyValid)
+		if vres.Digest != sess.ReplayDigest || vres.Frames != 2 || vres.Signer != daemon.signer.PublicKey() {
+			t.Fatal("bad result", vres)
+		}
+
+		// signed replay is not replaced by another write
+		if s, err = rs.WriteReplay(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.Send(&types.ReplayFrame{SessionId: id, Type: types.ReplayFrameTypeStdout, Payload: []byte("ls\r\n")}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.CloseAndRecv(); status.Code(err) != codes.AlreadyExists {
+			t.Fatal("signed replay should not be replaced", err)
+		}
+		if err = daemon.signReplay(id, utils.NewReplayHashChain()); err != errReplaySigned {
+			t.Fatal("signed digest should not be replaced", err)
+		}
+		verify(types.ReplayVerifyValid)
+
+		// rewrite the replay with an altered frame
+		rewrite := func(frames ...*types.ReplayFrame) {
+			w, err := daemon.replays.Create(id, replaySuffixFrames)
+			if err != nil {
+				t.Fatal(err)
+			}
+			zw := gzip.NewWriter(w)
+			for _, f := range frames {
+				utils.WriteReplayFrame(f, zw)
+			}
+			zw.Close()
+			w.Close()
+		}
+		rewrite(frames[0], &types.ReplayFrame{Timestamp: 100, Type: types.ReplayFrameTypeStdout, Payload: []byte("ls\r\n")})
+		verify(types.ReplayVerifyTampered)
+		rewrite(frames[0])
+		verify(types.ReplayVerifyTampered)
+		rewrite(frames...)
+		verify(types.ReplayVerifyValid)
+
+		// altered digest fails the signature
+		save := func(fn func(s *models.Session)) {
+			if err = daemon.db.Tx(true, func(db Repositories) (err error) {
+				var s models.Session
+				if s, err = db.Sessions().Get(id); err != nil {
+					return
+				}
+				fn(&s)
+				return db.Sessions().Save(&s)
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		save(func(s *models.Session) { s.ReplayFrames = 1 })
+		verify(types.ReplayVerifyTampered)
+		save(func(s *models.Session) { s.ReplayFrames = 2 })
+		verify(types.ReplayVerifyValid)
+
+		// cleared digest of a finished session created after signing is enabled is not unsigned
+		digest, signature := sess.ReplayDigest, sess.ReplaySignature
+		save(func(s *models.Session) { s.ReplayDigest, s.ReplaySignature, s.FinishedAt = "", "", s.CreatedAt+1 })
+		verify(types.ReplayVerifyNoDigest)
+		save(func(s *models.Session) { s.CreatedAt = daemon.signer.since - 1 })
+		verify(types.ReplayVerifyUnsigned)
+		save(func(s *models.Session) { s.ReplayDigest, s.ReplaySignature = digest, signature })
+		verify(types.ReplayVerifyValid)
+
+		// signed by another key
+		other := &replaySigner{trusted: map[string]bool{}}
+		other.key, _, _ = loadSigningKey(temporaryFile())
+		save(func(s *models.Session) {
+			s.ReplaySigner, s.ReplaySignature = other.PublicKey(), other.Sign(id, s.ReplayFrames, s.ReplayDigest)
+		})
+		verify(types.ReplayVerifyUntrusted)
+
+		// replay file removed
+		save(func(s *models.Session) {
+			s.ReplaySigner, s.ReplaySignature = daemon.signer.PublicKey(), daemon.signer.Sign(id, s.ReplayFrames, s.ReplayDigest)
+		})
+		verify(types.ReplayVerifyValid)
+		if err = daemon.replays.Remove(id, replaySuffixFrames); err != nil {
+			t.Fatal(err)
+		}
+		verify(types.ReplayVerifyMissing)
+	})
+}
@@ -0,0 +1,145 @@
+package daemon
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
+	"golang.org/x/crypto/ed25519"
+)
+
+const (
+	replaySigningKeyPEMType = "BASTION SIGNING KEY"
+	// replaySigningKeyHeaderSince PEM header of the unix time since which replays are signed by the key
+	replaySigningKeyHeaderSince = "Signing-Since"
+)
+
+// replaySigner signs digests of replays with ed25519 key of the daemon, signatures by public keys of other daemons
+// sharing the database and replay storage are also trusted
+type replaySigner struct {
+	key     ed25519.PrivateKey
+	trusted map[string]bool
+	// since unix time since which replays are signed, finished replays of sessions created later must have a digest
+	since int64
+}
+
+func newReplaySigner(opts types.DaemonOptions) (s *replaySigner, err error) {
+	s = &replaySigner{trusted: map[string]bool{}}
+	if len(opts.SigningKey) == 0 {
+		log.Warn().Msg("replays are signed with an ephemeral key, they can not be verified after restart")
+		if _, s.key, err = ed25519.GenerateKey(rand.Reader); err != nil {
+			return
+		}
+		s.since = now()
+	} else if s.key, s.since, err = loadSigningKey(opts.SigningKey); err != nil {
+		return
+	}
+	if opts.SigningSince > 0 {
+		s.since = opts.SigningSince
+	}
+	s.trusted[s.PublicKey()] = true
+	for _, k := range opts.TrustedSigningKeys {
+		var buf []byte
+		if buf, err = base64.StdEncoding.DecodeString(k); err != nil || len(buf) != ed25519.PublicKeySize {
+			err = fmt.Errorf("invalid trusted signing key '%s'", k)
+			return
+		}
+		s.trusted[k] = true
+	}
+	log.Info().Str("publicKey", s.PublicKey()).Int64("since", s.since).Msg("replays are signed")
+	return
+}
+
+// writeSigningKey write seed of ed25519 key and the signing time to PEM file
+func writeSigningKey(file string, seed []byte, since int64) (err error) {
+	if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return
+	}
+	return ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{
+		Type:    replaySigningKeyPEMType,
+		Headers: map[string]string{replaySigningKeyHeaderSince: strconv.FormatInt(since, 10)},
+		Bytes:   seed,
+	}), 0600)
+}
+
+// loadSigningKey load seed of ed25519 key and the signing time from PEM file, the file is created with a new key if not exists,
+// key files created before the signing time is recorded are updated with the current time
+func loadSigningKey(file string) (key ed25519.PrivateKey, since int64, err error) {
+	var buf []byte
+	if buf, err = ioutil.ReadFile(file); err != nil {
+		if !os.IsNotExist(err) {
+			return
+		}
+		seed := make([]byte, ed25519.SeedSize)
+		if _, err = rand.Read(seed); err != nil {
+			return
+		}
+		since = now()
+		if err = writeSigningKey(file, seed, since); err != nil {
+			return
+		}
+		log.Info().Str("file", file).Msg("signing key created")
+		key = ed25519.NewKeyFromSeed(seed)
+		return
+	}
+	b, _ := pem.Decode(buf)
+	if b == nil || b.Type != replaySigningKeyPEMType || len(b.Bytes) != ed25519.SeedSize {
+		err = errors.New("invalid signing key file " + file)
+		return
+	}
+	key = ed25519.NewKeyFromSeed(b.Bytes)
+	if v, ok := b.Headers[replaySigningKeyHeaderSince]; ok {
+		if since, err = strconv.ParseInt(v, 10, 64); err != nil {
+			err = errors.New("invalid signing time in signing key file " + file)
+		}
+		return
+	}
+	since = now()
+	if err = writeSigningKey(file, b.Bytes, since); err != nil {
+		return
+	}
+	log.Info().Str("file", file).Int64("since", since).Msg("signing time recorded in signing key")
+	return
+}
+
+// IsRequired check if a finished replay of a session created at the time must have a signed digest
+func (s *replaySigner) IsRequired(createdAt int64) bool {
+	return createdAt >= s.since
+}
+
+// PublicKey base64 public key
+func (s *replaySigner) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey))
+}
+
+// Sign base64 signature of digest of replay
+func (s *replaySigner) Sign(sessionID int64, frames int64, digest string) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, utils.ReplayDigestMessage(sessionID, frames, digest)))
+}
+
+// Verify check signature of digest of replay, signer must be trusted
+func (s *replaySigner) Verify(signer string, signature string, sessionID int64, frames int64, digest string) (trusted bool, ok bool) {
+	if !s.trusted[signer] {
+		return
+	}
+	trusted = true
+	pub, err := base64.StdEncoding.DecodeString(signer)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return
+	}
+	ok = ed25519.Verify(ed25519.PublicKey(pub), utils.ReplayDigestMessage(sessionID, frames, digest), sig)
+	return
+}
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"testing"
+
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestReplaySigner(t *testing.T) {
+	file := temporaryFile()
+	s, err := newReplaySigner(types.DaemonOptions{SigningKey: file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// key is created and loaded again
+	s2, err := newReplaySigner(types.DaemonOptions{SigningKey: file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.PublicKey() != s2.PublicKey() || s.since == 0 || s.since != s2.since {
+		t.Fatal("key not loaded")
+	}
+	if !s.IsRequired(s.since) || s.IsRequired(s.since-1) {
+		t.Fatal("bad signing time")
+	}
+	if s4, err := newReplaySigner(types.DaemonOptions{SigningKey: file, SigningSince: 100}); err != nil || s4.since != 100 {
+		t.Fatal("signing time should be overridden", err)
+	}
+
+	// signing time is recorded in key files without one
+	legacy := temporaryFile()
+	seed := make([]byte, ed25519.SeedSize)
+	ioutil.WriteFile(legacy, pem.EncodeToMemory(&pem.Block{Type: replaySigningKeyPEMType, Bytes: seed}), 0600)
+	_, since, err := loadSigningKey(legacy)
+	if err != nil || since == 0 {
+		t.Fatal("should record signing time", since, err)
+	}
+	if _, since2, err := loadSigningKey(legacy); err != nil || since2 != since {
+		t.Fatal("should load recorded signing time", since2, err)
+	}
+	sig := s.Sign(1, 2, "abcd")
+	if trusted, ok := s2.Verify(s.PublicKey(), sig, 1, 2, "abcd"); !trusted || !ok {
+		t.Fatal("should verify")
+	}
+	if _, ok := s2.Verify(s.PublicKey(), sig, 2, 2, "abcd"); ok {
+		t.Fatal("signature should be bound to session")
+	}
+	if _, ok := s2.Verify(s.PublicKey(), sig, 1, 2, "abce"); ok {
+		t.Fatal("signature should be bound to digest")
+	}
+
+	// ephemeral key is not trusted by others, unless configured
+	e, err := newReplaySigner(types.DaemonOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trusted, _ := s.Verify(e.PublicKey(), e.Sign(1, 2, "abcd"), 1, 2, "abcd"); trusted {
+		t.Fatal("should not be trusted")
+	}
+	s3, err := newReplaySigner(types.DaemonOptions{SigningKey: file, TrustedSigningKeys: []string{e.PublicKey()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trusted, ok := s3.Verify(e.PublicKey(), e.Sign(1, 2, "abcd"), 1, 2, "abcd"); !trusted || !ok {
+		t.Fatal("should verify")
+	}
+	if _, err = newReplaySigner(types.DaemonOptions{SigningKey: file, TrustedSigningKeys: []string{"invalid"}}); err == nil {
+		t.Fatal("should fail")
+	}
+}
@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// replaySuffixFrames gzipped replay frames
+	replaySuffixFrames = ""
+	// replaySuffixTranscript JSON transcript extracted from replay frames
+	replaySuffixTranscript = ".transcript.json"
+)
+
+// replaySuffixes all files stored for a replay
+var replaySuffixes = []string{replaySuffixFrames, replaySuffixTranscript}
+
+var errReplayNotExist = status.Error(codes.NotFound, "replay not exist")
+
+// ReplayStorage storage of replay files, keyed by session id and suffix of file
+type ReplayStorage interface {
+	// Create create the file of replay, file is stored once the writer is closed
+	Create(id int64, suffix string) (io.WriteCloser, error)
+	// Open open the file of replay, errReplayNotExist if not exist
+	Open(id int64, suffix string) (io.ReadCloser, error)
+	// Remove remove the file of replay, missing file is not an error
+	Remove(id int64, suffix string) error
+}
+
+// OpenReplayStorage open replay storage with driver specified in options
+func OpenReplayStorage(opts types.DaemonOptions) (s ReplayStorage, err error) {
+	switch opts.ReplayStorage {
+	case "", types.ReplayStorageLocal:
+		s = &localReplayStorage{dir: opts.ReplayDir}
+	case types.ReplayStorageS3:
+		s, err = newS3ReplayStorage(opts.S3)
+	default:
+		err = fmt.Errorf("unknown replay storage '%s'", opts.ReplayStorage)
+	}
+	return
+}
+
+// localReplayStorage replay files in local directory
+type localReplayStorage struct {
+	dir string
+}
+
+func (s *localReplayStorage) Create(id int64, suffix string) (w io.WriteCloser, err error) {
+	filename := FilenameForSessionID(id, s.dir) + suffix
+	// ensure directory
+	if err = os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return
+	}
+	return os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0640)
+}
+
+func (s *localReplayStorage) Open(id int64, suffix string) (r io.ReadCloser, err error) {
+	if r, err = os.Open(FilenameForSessionID(id, s.dir) + suffix); err != nil && os.IsNotExist(err) {
+		err = errReplayNotExist
+	}
+	return
+}
+
+func (s *localReplayStorage) Remove(id int64, suffix string) (err error) {
+	if err = os.Remove(FilenameForSessionID(id, s.dir) + suffix); err != nil && os.IsNotExist(err) {
+		err = nil
+	}
+	return
+}
@@ -0,0 +1,348 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/types"
+)
+
+const (
+	s3MinPartSize    = 5 * 1024 * 1024
+	s3DefaultRegion  = "us-east-1"
+	s3Algorithm      = "AWS4-HMAC-SHA256"
+	s3TimeFormat     = "20060102T150405Z"
+	s3DefaultTimeout = time.Minute
+)
+
+// s3Error error response of S3-compatible object storage
+type s3Error struct {
+	XMLName    xml.Name `xml:"Error"`
+	StatusCode int      `xml:"-"`
+	Code       string   `xml:"Code"`
+	Message    string   `xml:"Message"`
+}
+
+func (e *s3Error) Error() string {
+	return fmt.Sprintf("s3: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+type s3InitiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type s3CompletedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+	Parts   []s3CompletedPart `xml:"Part"`
+}
+
+// s3ReplayStorage replay files in S3-compatible object storage, replays are written with multipart uploads
+type s3ReplayStorage struct {
+	opts   types.S3Options
+	client *http.Client
+}
+
+func newS3ReplayStorage(opts types.S3Options) (s *s3ReplayStorage, err error) {
+	if len(opts.Endpoint) == 0 || len(opts.Bucket) == 0 {
+		err = errors.New("s3 endpoint and bucket are required")
+		return
+	}
+	if _, err = url.Parse(opts.Endpoint); err != nil {
+		return
+	}
+	opts.Endpoint = strings.TrimSuffix(opts.Endpoint, "/")
+	if len(opts.Region) == 0 {
+		opts.Region = s3DefaultRegion
+	}
+	if opts.PartSize < s3MinPartSize {
+		opts.PartSize = s3MinPartSize
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = s3DefaultTimeout
+	}
+	s = &s3ReplayStorage{opts: opts, client: &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           (&net.Dialer{Timeout: opts.Timeout, KeepAlive: 30 * time.Second}).DialContext,
+			TLSHandshakeTimeout:   opts.Timeout,
+			ResponseHeaderTimeout: opts.Timeout,
+			IdleConnTimeout:       90 * time.Second,
+		},
+	}}
+	return
+}
+
+// key object key of replay file, same layout with local replay files
+func (s *s3ReplayStorage) key(id int64, suffix string) string {
+	return path.Join(s.opts.Prefix, filepath.ToSlash(FilenameForSessionID(id, ""))) + suffix
+}
+
+// s3TimeoutBody response body cancels the request deadline on close
+type s3TimeoutBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b s3TimeoutBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// do send a signed request, non-2xx responses are decoded as s3Error,
+// requests except GET must finish within timeout, GET is only limited until responded, replays are streamed
+func (s *s3ReplayStorage) do(method string, key string, query url.Values, body []byte) (res *http.Response, err error) {
+	u := s.opts.Endpoint + "/" + s.opts.Bucket + "/" + key
+	if len(query) > 0 {
+		u = u + "?" + query.Encode()
+	}
+	var req *http.Request
+	if req, err = http.NewRequest(method, u, bytes.NewReader(body)); err != nil {
+		return
+	}
+	cancel := context.CancelFunc(func() {})
+	if method != http.MethodGet {
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(context.Background(), s.opts.Timeout)
+		req = req.WithContext(ctx)
+	}
+	s3Sign(req, body, s.opts.AccessKey, s.opts.SecretKey, s.opts.Region, time.Now())
+	if res, err = s.client.Do(req); err != nil {
+		cancel()
+		return
+	}
+	res.Body = s3TimeoutBody{ReadCloser: res.Body, cancel: cancel}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		defer res.Body.Close()
+		e := &s3Error{StatusCode: res.StatusCode}
+		buf, _ := ioutil.ReadAll(res.Body)
+		xml.Unmarshal(buf, e)
+		err = e
+		return
+	}
+	return
+}
+
+// doXML send a signed request and decode the response, CompleteMultipartUpload may fail with status 200
+func (s *s3ReplayStorage) doXML(method string, key string, query url.Values, body []byte, out interface{}) (err error) {
+	var res *http.Response
+	if res, err = s.do(method, key, query, body); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var buf []byte
+	if buf, err = ioutil.ReadAll(res.Body); err != nil {
+		return
+	}
+	e := &s3Error{StatusCode: res.StatusCode}
+	if xml.Unmarshal(buf, e) == nil {
+		err = e
+		return
+	}
+	if out != nil {
+		err = xml.Unmarshal(buf, out)
+	}
+	return
+}
+
+func (s *s3ReplayStorage) Create(id int64, suffix string) (w io.WriteCloser, err error) {
+	key := s.key(id, suffix)
+	var r s3InitiateMultipartUploadResult
+	if err = s.doXML(http.MethodPost, key, url.Values{"uploads": []string{""}}, nil, &r); err != nil {
+		return
+	}
+	w = &s3ReplayWriter{s: s, key: key, uploadID: r.UploadID}
+	return
+}
+
+func (s *s3ReplayStorage) Open(id int64, suffix string) (r io.ReadCloser, err error) {
+	var res *http.Response
+	if res, err = s.do(http.MethodGet, s.key(id, suffix), nil, nil); err != nil {
+		if e, ok := err.(*s3Error); ok && e.StatusCode == http.StatusNotFound {
+			err = errReplayNotExist
+		}
+		return
+	}
+	r = res.Body
+	return
+}
+
+func (s *s3ReplayStorage) Remove(id int64, suffix string) (err error) {
+	var res *http.Response
+	if res, err = s.do(http.MethodDelete, s.key(id, suffix), nil, nil); err != nil {
+		if e, ok := err.(*s3Error); ok && e.StatusCode == http.StatusNotFound {
+			err = nil
+		}
+		return
+	}
+	res.Body.Close()
+	return
+}
+
+// s3ReplayWriter buffers and uploads parts, upload is completed on Close, or aborted once anything failed
+type s3ReplayWriter struct {
+	s        *s3ReplayStorage
+	key      string
+	uploadID string
+	buf      bytes.Buffer
+	parts    []s3CompletedPart
+	err      error
+	aborted  bool
+}
+
+func (w *s3ReplayWriter) Write(p []byte) (n int, err error) {
+	if w.err != nil {
+		err = w.err
+		return
+	}
+	n, _ = w.buf.Write(p)
+	if int64(w.buf.Len()) >= w.s.opts.PartSize {
+		if w.err = w.uploadPart(); w.err != nil {
+			err = w.err
+			w.abort()
+		}
+	}
+	return
+}
+
+// abort abort the multipart upload, uploaded parts are discarded
+func (w *s3ReplayWriter) abort() {
+	if w.aborted {
+		return
+	}
+	w.aborted = true
+	w.buf.Reset()
+	res, err := w.s.do(http.MethodDelete, w.key, url.Values{"uploadId": []string{w.uploadID}}, nil)
+	if err != nil {
+		log.Error().Err(err).Str("key", w.key).Str("uploadId", w.uploadID).Msg("failed to abort multipart upload")
+		return
+	}
+	res.Body.Close()
+}
+
+func (w *s3ReplayWriter) uploadPart() (err error) {
+	num := len(w.parts) + 1
+	var res *http.Response
+	if res, err = w.s.do(http.MethodPut, w.key, url.Values{
+		"partNumber": []string{strconv.Itoa(num)},
+		"uploadId":   []string{w.uploadID},
+	}, w.buf.Bytes()); err != nil {
+		return
+	}
+	res.Body.Close()
+	w.parts = append(w.parts, s3CompletedPart{PartNumber: num, ETag: res.Header.Get("ETag")})
+	w.buf.Reset()
+	return
+}
+
+func (w *s3ReplayWriter) Close() (err error) {
+	// the last part can be smaller than the part size, and there is at least one part
+	if w.err == nil && (w.buf.Len() > 0 || len(w.parts) == 0) {
+		w.err = w.uploadPart()
+	}
+	if w.err == nil {
+		var body []byte
+		if body, w.err = xml.Marshal(s3CompleteMultipartUpload{Parts: w.parts}); w.err == nil {
+			w.err = w.s.doXML(http.MethodPost, w.key, url.Values{"uploadId": []string{w.uploadID}}, body, nil)
+		}
+	}
+	if w.err != nil {
+		w.abort()
+	}
+	return w.err
+}
+
+// s3Sign sign the request with AWS Signature Version 4, host and all headers set are signed
+func s3Sign(req *http.Request, body []byte, accessKey, secretKey, region string, t time.Time) {
+	t = t.UTC()
+	date := t.Format(s3TimeFormat)
+	payloadHash := s3Hash(body)
+	req.Header.Set("X-Amz-Date", date)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// canonical headers
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	// canonical query, url.Values.Encode sorts by key
+	query := req.URL.Query()
+	canonicalQuery := strings.Replace(query.Encode(), "+", "%20", -1)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		canonicalQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{t.Format("20060102"), region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{s3Algorithm, date, scope, s3Hash([]byte(canonicalRequest))}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+secretKey), t.Format("20060102"))
+	key = s3HMAC(key, region)
+	key = s3HMAC(key, "s3")
+	key = s3HMAC(key, "aws4_request")
+	signature := hex.EncodeToString(s3HMAC(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", s3Algorithm, accessKey, scope, signedHeaders, signature))
+}
+
+func s3Hash(buf []byte) string {
+	h := sha256.Sum256(buf)
+	return hex.EncodeToString(h[:])
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3EscapePath escape everything but unreserved characters and slashes
+func s3EscapePath(p string) string {
+	var sb strings.Builder
+	for _, c := range []byte(p) {
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
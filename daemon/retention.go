@@ -0,0 +1,228 @@
+package daemon
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
+	"golang.org/x/net/context"
+)
+
+const (
+	replayPurgeInterval = time.Hour
+	replayPurgeBatch    = 100
+)
+
+// retentionRule compiled types.RetentionRule
+type retentionRule struct {
+	account  string
+	selector utils.LabelSelector
+	replays  time.Duration
+}
+
+// retentionPolicy compiled types.RetentionOptions
+type retentionPolicy struct {
+	replays time.Duration
+	rules   []retentionRule
+}
+
+func newRetentionPolicy(opts types.RetentionOptions) (p *retentionPolicy, err error) {
+	p = &retentionPolicy{replays: opts.Replays}
+	for _, r := range opts.Rules {
+		rr := retentionRule{account: strings.TrimSpace(r.Account), replays: r.Replays}
+		if len(strings.TrimSpace(r.LabelSelector)) > 0 {
+			if rr.selector, err = utils.ParseLabelSelector(r.LabelSelector); err != nil {
+				return
+			}
+		}
+		p.rules = append(p.rules, rr)
+	}
+	return
+}
+
+// minimum shortest finite retention, zero if replays are kept forever
+func (p *retentionPolicy) minimum() (m time.Duration) {
+	ds := []time.Duration{p.replays}
+	for _, r := range p.rules {
+		ds = append(ds, r.replays)
+	}
+	for _, d := range ds {
+		if d > 0 && (m == 0 || d < m) {
+			m = d
+		}
+	}
+	return
+}
+
+// retention of replay of the session, nodes are labels of nodes the session accessed, zero for forever
+func (p *retentionPolicy) retention(s models.Session, nodes []map[string]string) time.Duration {
+	for _, r := range p.rules {
+		if len(r.account) > 0 && r.account != s.Account {
+			continue
+		}
+		if len(r.selector) > 0 {
+			var matched bool
+			for _, labels := range nodes {
+				if r.selector.Matches(labels) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		return r.replays
+	}
+	return p.replays
+}
+
+// runReplayPurger periodically purge expired replays, stopped with the grant watcher
+func (d *Daemon) runReplayPurger() {
+	if d.retention.minimum() == 0 {
+		return
+	}
+	t := time.NewTicker(replayPurgeInterval)
+	defer t.Stop()
+	for {
+		d.purgeReplays(time.Now())
+		select {
+		case <-d.grantWatcher.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// purgeReplays purge replays expired at given time, sessions not finished or on legal hold are skipped, sessions below
+// the smallest id left unpurged by the last run are all purged, and not listed again
+func (d *Daemon) purgeReplays(at time.Time) {
+	min := d.retention.minimum()
+	if min == 0 {
+		return
+	}
+	// sessions not expired yet are listed as well, so that none of them is below the watermark
+	until := at.Add(-min).Unix()
+	q := SessionQuery{OnlyRecorded: true, OnlyUnpurged: true, After: d.purgedBefore, Limit: replayPurgeBatch}
+	var purgedBefore int64
+	for {
+		ss, _, err := d.db.Sessions().Query(q)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list sessions for purging")
+			return
+		}
+		if len(ss) > 0 && purgedBefore == 0 {
+			purgedBefore = ss[0].Id + 1
+		}
+		for _, s := range ss {
+			if !d.purgeExpiredReplay(s, until, at) {
+				purgedBefore = s.Id
+			}
+		}
+		if len(ss) < q.Limit {
+			break
+		}
+		q.Before = ss[len(ss)-1].Id
+	}
+	if purgedBefore > 0 {
+		d.purgedBefore = purgedBefore
+	}
+	if err := d.search.Compact(at.Add(-min)); err != nil {
+		log.Error().Err(err).Msg("failed to compact search index")
+	}
+}
+
+// purgeExpiredReplay purge replay of the session if expired at given time, sessions created since until are never expired
+func (d *Daemon) purgeExpiredReplay(s models.Session, until int64, at time.Time) bool {
+	if s.CreatedAt >= until || s.FinishedAt == 0 || s.LegalHold {
+		return false
+	}
+	if !d.isReplayExpired(s, at) {
+		return false
+	}
+	purged, err := d.purgeReplay(s.Id)
+	if err != nil {
+		log.Error().Err(err).Int64("sessionId", s.Id).Msg("failed to purge replay")
+		return false
+	}
+	if purged {
+		log.Info().Int64("sessionId", s.Id).Str("account", s.Account).Msg("replay purged")
+	}
+	return purged
+}
+
+// sessionHostnames hostnames of nodes connected in session, including nodes of lv2 sessions initiated from sandbox
+func (d *Daemon) sessionHostnames(s models.Session) (hostnames []string, err error) {
+	hostnames = []string{}
+	if len(s.Hostname) > 0 {
+		hostnames = append(hostnames, s.Hostname)
+	}
+	var children []models.Session
+	if children, _, err = d.db.Sessions().Query(SessionQuery{ParentId: s.Id}); err != nil {
+		return
+	}
+	for _, c := range children {
+		hostnames = appendUniqueString(hostnames, c.Hostname)
+	}
+	return
+}
+
+// isReplayExpired check retention of the session against labels of nodes accessed
+func (d *Daemon) isReplayExpired(s models.Session, at time.Time) bool {
+	hostnames, err := d.sessionHostnames(s)
+	if err != nil {
+		log.Error().Err(err).Int64("sessionId", s.Id).Msg("failed to list child sessions")
+		return false
+	}
+	nodes := make([]map[string]string, 0, len(hostnames))
+	for _, h := range hostnames {
+		n, err := d.db.Nodes().Get(h)
+		if err != nil {
+			if err == errRecordNotFound {
+				continue
+			}
+			log.Error().Err(err).Str("hostname", h).Msg("failed to get node")
+			return false
+		}
+		nodes = append(nodes, n.Labels)
+	}
+	r := d.retention.retention(s, nodes)
+	return r > 0 && time.Unix(s.CreatedAt, 0).Add(r).Before(at)
+}
+
+// purgeReplay mark the session purged in a transaction requiring no legal hold, then delete replay files and documents
+// in search index, a legal hold placed before the mark keeps the replay, and a purged session can not be held
+func (d *Daemon) purgeReplay(id int64) (purged bool, err error) {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var s models.Session
+		if s, err = db.Sessions().Get(id); err != nil {
+			return
+		}
+		if s.LegalHold || s.PurgedAt > 0 {
+			return
+		}
+		s.PurgedAt = now()
+		if err = db.Sessions().Save(&s); err != nil {
+			return
+		}
+		purged = true
+		return d.audit(context.Background(), db, types.AuditActionSessionPurge, sessionTarget(id), nil, nil)
+	}); err != nil {
+		purged = false
+		return
+	}
+	if !purged {
+		return
+	}
+	// session is already marked purged, files failing to delete are left for manual cleanup
+	for _, suffix := range replaySuffixes {
+		if err = d.replays.Remove(id, suffix); err != nil {
+			return
+		}
+	}
+	err = d.search.DeleteSession(id)
+	return
+}
@@ -0,0 +1,159 @@
+package daemon
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetentionPolicy(t *testing.T) {
+	day := time.Hour * 24
+	p, err := newRetentionPolicy(types.RetentionOptions{
+		Replays: day * 30,
+		Rules: []types.RetentionRule{
+			{Account: "vip"},
+			{Account: "test", LabelSelector: "env=prod", Replays: day * 365},
+			{LabelSelector: "env=dev", Replays: day * 7},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.minimum() != day*7 {
+		t.Fatal("bad minimum", p.minimum())
+	}
+	prod, dev := map[string]string{"env": "prod"}, map[string]string{"env": "dev"}
+	for _, c := range []struct {
+		account  string
+		nodes    []map[string]string
+		expected time.Duration
+	}{
+		{"vip", []map[string]string{dev}, 0},
+		{"test", []map[string]string{dev, prod}, day * 365},
+		{"other", []map[string]string{prod}, day * 30},
+		{"other", []map[string]string{prod, dev}, day * 7},
+		{"test", nil, day * 30},
+	} {
+		if r := p.retention(models.Session{Account: c.account}, c.nodes); r != c.expected {
+			t.Fatal("bad retention", c.account, c.nodes, r)
+		}
+	}
+	if _, err = newRetentionPolicy(types.RetentionOptions{Rules: []types.RetentionRule{{LabelSelector: "=bad"}}}); err == nil {
+		t.Fatal("should fail with bad label selector")
+	}
+}
+
+func TestDaemon_PurgeReplays(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		var err error
+		day := time.Hour * 24
+		if daemon.retention, err = newRetentionPolicy(types.RetentionOptions{
+			Replays: day * 30,
+			Rules: []types.RetentionRule{
+				{Account: "vip"},
+				{LabelSelector: "env=prod", Replays: day * 365},
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		daemon.db.Nodes().Save(&models.Node{Hostname: "prod-1", Labels: map[string]string{"env": "prod"}})
+
+		old, recent := time.Now().Add(-day*60).Unix(), time.Now().Add(-day).Unix()
+		sessions := []*models.Session{
+			{Account: "test", CreatedAt: old, FinishedAt: old},
+			{Account: "test", CreatedAt: old, FinishedAt: old},
+			{Account: "vip", CreatedAt: old, FinishedAt: old},
+			{Account: "test", CreatedAt: old, FinishedAt: old, LegalHold: true},
+			{Account: "test", CreatedAt: recent, FinishedAt: recent},
+			{Account: "test", CreatedAt: old},
+		}
+		for _, s := range sessions {
+			s.IsRecorded = true
+			if err = daemon.db.Sessions().Save(s); err != nil {
+				t.Fatal(err)
+			}
+			filename := FilenameForSessionID(s.Id, daemon.opts.ReplayDir)
+			os.MkdirAll(filepath.Dir(filename), 0750)
+			ioutil.WriteFile(filename, []byte("replay"), 0640)
+		}
+		// lv2 session from the second session accessed a prod node
+		daemon.db.Sessions().Save(&models.Session{Account: "test", Stage: types.SessionStageLv2, Hostname: "prod-1", User: "root", ParentId: sessions[1].Id, CreatedAt: old, FinishedAt: old})
+
+		daemon.purgeReplays(time.Now())
+
+		for i, s := range sessions {
+			ss, err := daemon.db.Sessions().Get(s.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = os.Stat(FilenameForSessionID(s.Id, daemon.opts.ReplayDir))
+			if i == 0 {
+				if ss.PurgedAt == 0 || !os.IsNotExist(err) {
+					t.Fatal("session should be purged", i, ss)
+				}
+			} else if ss.PurgedAt != 0 || err != nil {
+				t.Fatal("session should not be purged", i, ss)
+			}
+		}
+		// sessions below the first one left unpurged are not listed again
+		if daemon.purgedBefore != sessions[1].Id {
+			t.Fatal("bad purge watermark", daemon.purgedBefore)
+		}
+
+		rs, ss := types.NewReplayServiceClient(conn), types.NewSessionServiceClient(conn)
+		s, err := rs.ReadReplay(context.Background(), &types.ReadReplayRequest{SessionId: sessions[0].Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.Recv(); status.Code(err) != codes.FailedPrecondition {
+			t.Fatal("purged replay should not be read", err)
+		}
+		wc, err := rs.WriteReplay(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = wc.Send(&types.ReplayFrame{SessionId: sessions[0].Id, Type: types.ReplayFrameTypeStdout, Payload: []byte("ls\r\n")}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = wc.CloseAndRecv(); status.Code(err) != codes.FailedPrecondition {
+			t.Fatal("purged replay should not be written", err)
+		}
+		if _, err = ss.UpdateSessionLegalHold(context.Background(), &types.UpdateSessionLegalHoldRequest{Id: sessions[0].Id, LegalHold: true}); err == nil {
+			t.Fatal("purged session should not be held")
+		}
+		res, err := ss.UpdateSessionLegalHold(context.Background(), &types.UpdateSessionLegalHoldRequest{Id: sessions[4].Id, LegalHold: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Session.LegalHold {
+			t.Fatal("session should be held", res.Session)
+		}
+		// hold placed after the session is listed for purging keeps the replay
+		if purged, err := daemon.purgeReplay(sessions[4].Id); err != nil || purged {
+			t.Fatal("held session should not be purged", err)
+		}
+		if _, err = os.Stat(FilenameForSessionID(sessions[4].Id, daemon.opts.ReplayDir)); err != nil {
+			t.Fatal("replay of held session should be kept", err)
+		}
+		// released hold is purged by the next run
+		if _, err = ss.UpdateSessionLegalHold(context.Background(), &types.UpdateSessionLegalHoldRequest{Id: sessions[3].Id, LegalHold: false}); err != nil {
+			t.Fatal(err)
+		}
+		daemon.purgeReplays(time.Now())
+		if s, err := daemon.db.Sessions().Get(sessions[3].Id); err != nil || s.PurgedAt == 0 {
+			t.Fatal("released session should be purged", s, err)
+		}
+		if daemon.purgedBefore != sessions[1].Id {
+			t.Fatal("bad purge watermark", daemon.purgedBefore)
+		}
+	})
+}
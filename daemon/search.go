@@ -0,0 +1,61 @@
+package daemon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+// SearchQuery query of replay search
+type SearchQuery struct {
+	// Keyword keyword to search
+	Keyword string
+	// Commands search commands extracted from transcripts instead of contents of replays
+	Commands bool
+	// Match how keyword is matched, one of types.SearchMatch*, defaults to types.SearchMatchTerms
+	Match string
+	// Account only documents of this account
+	Account string
+	// Hostname only documents of sessions connected to this node, documents indexed without hostnames are not matched,
+	// they are reindexed by submitting replays again
+	Hostname string
+	// Since only documents of sessions created at or after this unix time
+	Since int64
+	// Until only documents of sessions created before this unix time
+	Until int64
+	// From number of results to skip
+	From int
+	// Size max number of results
+	Size int
+}
+
+// SearchIndex full-text index of contents and commands of replays
+type SearchIndex interface {
+	// IndexContents index readable contents of replays
+	IndexContents(docs []ReplayIndice) error
+	// IndexCommands index commands of a session, previously indexed commands of the session are replaced
+	IndexCommands(sessionID int64, docs []CommandIndice) error
+	// Search search contents or commands, returns a page of results with highlighted snippets,
+	// and the total number of matched documents, which may be estimated
+	Search(q SearchQuery) ([]*types.ReplaySearchResult, int64, error)
+	// DeleteSession delete all contents and commands of a session
+	DeleteSession(sessionID int64) error
+	// Compact release storage of deleted documents created before given time
+	Compact(before time.Time) error
+	// Close close the index
+	Close() error
+}
+
+// OpenSearchIndex open search index with backend specified in options
+func OpenSearchIndex(opts types.DaemonOptions) (s SearchIndex, err error) {
+	switch opts.SearchBackend {
+	case "", types.SearchBackendElasticsearch:
+		s, err = newElasticsearchIndex(opts.Elasticsearch)
+	case types.SearchBackendEmbedded:
+		s, err = openEmbeddedIndex(opts.SearchIndex)
+	default:
+		err = fmt.Errorf("unknown search backend '%s'", opts.SearchBackend)
+	}
+	return
+}
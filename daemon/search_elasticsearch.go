@@ -0,0 +1,193 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/net/context"
+)
+
+// elasticsearchIndex search index on elasticsearch, documents are stored in daily indices
+type elasticsearchIndex struct {
+	client *elastic.Client
+}
+
+func newElasticsearchIndex(urls []string) (s *elasticsearchIndex, err error) {
+	var client *elastic.Client
+	if client, err = elastic.NewClient(elastic.SetURL(urls...)); err != nil {
+		return
+	}
+	s = &elasticsearchIndex{client: client}
+	return
+}
+
+// dailyIndex name of daily index for documents created at given time
+func dailyIndex(prefix string, t time.Time) string {
+	return fmt.Sprintf("%s%04d-%02d-%02d", prefix, t.Year(), t.Month(), t.Day())
+}
+
+func (s *elasticsearchIndex) IndexContents(docs []ReplayIndice) (err error) {
+	if len(docs) == 0 {
+		return
+	}
+	bulk := s.client.Bulk()
+	for _, d := range docs {
+		bulk = bulk.Add(elastic.NewBulkIndexRequest().Index(dailyIndex(types.ReplayElasticsearchIndexPrefix, d.CreatedAt)).Type("_doc").Doc(d))
+	}
+	_, err = bulk.Do(context.Background())
+	return
+}
+
+func (s *elasticsearchIndex) IndexCommands(sessionID int64, docs []CommandIndice) (err error) {
+	if err = s.deleteByQuery(sessionID, types.CommandElasticsearchIndexPrefix+"*"); err != nil {
+		return
+	}
+	if len(docs) == 0 {
+		return
+	}
+	bulk := s.client.Bulk()
+	for i, d := range docs {
+		bulk = bulk.Add(elastic.NewBulkIndexRequest().Index(dailyIndex(types.CommandElasticsearchIndexPrefix, d.CreatedAt)).Type("_doc").Id(fmt.Sprintf("%d-%d", sessionID, i)).Doc(d))
+	}
+	_, err = bulk.Do(context.Background())
+	return
+}
+
+// searchQuery bool query of keyword and filters, account and hostnames are matched exactly with keyword sub-fields of dynamic mapping
+func (s *elasticsearchIndex) searchQuery(field string, q SearchQuery) elastic.Query {
+	var query elastic.Query
+	switch q.Match {
+	case types.SearchMatchPhrase:
+		query = elastic.NewMatchPhraseQuery(field, q.Keyword)
+	case types.SearchMatchWildcard:
+		// wildcard query is not analyzed, while indexed terms are lower-cased
+		query = elastic.NewWildcardQuery(field, strings.ToLower(q.Keyword))
+	default:
+		query = elastic.NewMatchQuery(field, q.Keyword).Operator("and")
+	}
+	bq := elastic.NewBoolQuery().Must(query)
+	if len(q.Account) > 0 {
+		bq = bq.Filter(elastic.NewTermQuery("account.keyword", q.Account))
+	}
+	if len(q.Hostname) > 0 {
+		bq = bq.Filter(elastic.NewTermQuery("hostnames.keyword", q.Hostname))
+	}
+	if q.Since > 0 || q.Until > 0 {
+		rq := elastic.NewRangeQuery("created_at")
+		if q.Since > 0 {
+			rq = rq.Gte(time.Unix(q.Since, 0).Format(time.RFC3339))
+		}
+		if q.Until > 0 {
+			rq = rq.Lt(time.Unix(q.Until, 0).Format(time.RFC3339))
+		}
+		bq = bq.Filter(rq)
+	}
+	return bq
+}
+
+func (s *elasticsearchIndex) Search(q SearchQuery) (results []*types.ReplaySearchResult, total int64, err error) {
+	// search commands extracted from transcripts, or contents of replays
+	index, field := types.ReplayElasticsearchIndexPrefix+"*", "content"
+	if q.Commands {
+		index, field = types.CommandElasticsearchIndexPrefix+"*", "command"
+	}
+	hl := elastic.NewHighlight().Field(field).Encoder("html").PreTags("<em>").PostTags("</em>")
+	var sres *elastic.SearchResult
+	if sres, err = s.client.Search().
+		Index(index).
+		Query(s.searchQuery(field, q)).
+		Highlight(hl).
+		From(q.From).
+		Size(q.Size).
+		Do(context.Background()); err != nil {
+		return
+	}
+	hits := sres.Hits
+	if hits == nil {
+		err = errRecordNotFound
+		return
+	}
+	total = hits.TotalHits
+	results = []*types.ReplaySearchResult{}
+	for _, h := range hits.Hits {
+		if h == nil {
+			continue
+		}
+		if h.Source == nil {
+			continue
+		}
+		var r *types.ReplaySearchResult
+		if q.Commands {
+			var ci CommandIndice
+			if err = json.Unmarshal(*h.Source, &ci); err != nil {
+				return
+			}
+			r = ci.result()
+		} else {
+			var ri ReplayIndice
+			if err = json.Unmarshal(*h.Source, &ri); err != nil {
+				return
+			}
+			r = ri.result()
+		}
+		r.Highlights = h.Highlight[field]
+		results = append(results, r)
+	}
+	return
+}
+
+func (s *elasticsearchIndex) deleteByQuery(sessionID int64, indices ...string) (err error) {
+	_, err = s.client.DeleteByQuery(indices...).
+		Query(elastic.NewTermQuery("session_id", sessionID)).
+		IgnoreUnavailable(true).
+		AllowNoIndices(true).
+		Do(context.Background())
+	return
+}
+
+func (s *elasticsearchIndex) DeleteSession(sessionID int64) error {
+	return s.deleteByQuery(sessionID, types.ReplayElasticsearchIndexPrefix+"*", types.CommandElasticsearchIndexPrefix+"*")
+}
+
+// Compact delete empty daily indices before given time
+func (s *elasticsearchIndex) Compact(before time.Time) (err error) {
+	var names []string
+	if names, err = s.client.IndexNames(); err != nil {
+		return
+	}
+	for _, name := range names {
+		var prefix string
+		for _, p := range []string{types.ReplayElasticsearchIndexPrefix, types.CommandElasticsearchIndexPrefix} {
+			if strings.HasPrefix(name, p) {
+				prefix = p
+			}
+		}
+		if len(prefix) == 0 {
+			continue
+		}
+		day, err := time.Parse("2006-01-02", strings.TrimPrefix(name, prefix))
+		if err != nil || !day.AddDate(0, 0, 1).Before(before) {
+			continue
+		}
+		var c int64
+		if c, err = s.client.Count(name).Do(context.Background()); err != nil || c > 0 {
+			continue
+		}
+		if _, err = s.client.DeleteIndex(name).Do(context.Background()); err != nil {
+			log.Error().Err(err).Str("index", name).Msg("failed to delete empty index")
+			continue
+		}
+		log.Info().Str("index", name).Msg("empty index deleted")
+	}
+	return
+}
+
+func (s *elasticsearchIndex) Close() error {
+	s.client.Stop()
+	return nil
+}
@@ -0,0 +1,430 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/coreos/bbolt"
+	"github.com/yankeguo/bastion/types"
+)
+
+const (
+	embeddedKindContent   = 'c'
+	embeddedKindCommand   = 'm'
+	embeddedMaxTermLength = 64
+	// embeddedSnippetRadius runes before the first match in highlighted snippet
+	embeddedSnippetRadius = 30
+	// embeddedSnippetLength runes of highlighted snippet
+	embeddedSnippetLength = 100
+	// embeddedMaxCandidates newest candidates verified by a search, older documents are not searched,
+	// same as the default window of total hits tracked by elasticsearch
+	embeddedMaxCandidates = 10000
+)
+
+var (
+	embeddedBucketContents = []byte("contents")
+	embeddedBucketCommands = []byte("commands")
+	// embeddedBucketTerms keys are TERM + 0x00 + KIND + DOC_KEY, values are empty
+	embeddedBucketTerms = []byte("terms")
+)
+
+// embeddedIndex search index in a local bolt file, without elasticsearch,
+// letters and digits are tokenized into words, CJK characters are indexed one by one,
+// phrases are verified with case-insensitive substring match, wildcards are matched against indexed terms
+type embeddedIndex struct {
+	db *bolt.DB
+}
+
+func openEmbeddedIndex(file string) (s *embeddedIndex, err error) {
+	if len(file) == 0 {
+		err = errors.New("missing file of embedded search index")
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+		return
+	}
+	var db *bolt.DB
+	if db, err = bolt.Open(file, 0640, &bolt.Options{Timeout: time.Second * 5}); err != nil {
+		return
+	}
+	if err = db.Update(func(tx *bolt.Tx) (err error) {
+		for _, name := range [][]byte{embeddedBucketContents, embeddedBucketCommands, embeddedBucketTerms} {
+			if _, err = tx.CreateBucketIfNotExists(name); err != nil {
+				return
+			}
+		}
+		return
+	}); err != nil {
+		db.Close()
+		return
+	}
+	s = &embeddedIndex{db: db}
+	return
+}
+
+// embeddedTokenize lower-cased unique terms of text
+func embeddedTokenize(text string) (terms []string) {
+	seen := map[string]bool{}
+	add := func(t string) {
+		if len(t) == 0 || len(t) > embeddedMaxTermLength || seen[t] {
+			return
+		}
+		seen[t] = true
+		terms = append(terms, t)
+	}
+	var word strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case embeddedIsCJK(r):
+			add(word.String())
+			word.Reset()
+			add(string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			add(word.String())
+			word.Reset()
+		}
+	}
+	add(word.String())
+	return
+}
+
+func embeddedIsCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// embeddedIsWord rune continues a word, matches of terms must not be surrounded by these
+func embeddedIsWord(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !embeddedIsCJK(r)
+}
+
+func embeddedDocKey(sessionID int64, seq uint64) []byte {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf, uint64(sessionID))
+	binary.BigEndian.PutUint64(buf[8:], seq)
+	return buf
+}
+
+func embeddedTermKey(term string, kind byte, docKey []byte) []byte {
+	buf := make([]byte, 0, len(term)+2+len(docKey))
+	buf = append(buf, term...)
+	buf = append(buf, 0x00, kind)
+	return append(buf, docKey...)
+}
+
+// embeddedText searchable text of document
+func embeddedText(kind byte, buf []byte) (text string, result *types.ReplaySearchResult, err error) {
+	if kind == embeddedKindCommand {
+		var ci CommandIndice
+		if err = json.Unmarshal(buf, &ci); err != nil {
+			return
+		}
+		return ci.Command, ci.result(), nil
+	}
+	var ri ReplayIndice
+	if err = json.Unmarshal(buf, &ri); err != nil {
+		return
+	}
+	return ri.Content, ri.result(), nil
+}
+
+func embeddedBucket(kind byte) []byte {
+	if kind == embeddedKindCommand {
+		return embeddedBucketCommands
+	}
+	return embeddedBucketContents
+}
+
+func (s *embeddedIndex) put(tx *bolt.Tx, kind byte, key []byte, doc interface{}, text string) (err error) {
+	var buf []byte
+	if buf, err = json.Marshal(doc); err != nil {
+		return
+	}
+	if err = tx.Bucket(embeddedBucket(kind)).Put(key, buf); err != nil {
+		return
+	}
+	terms := tx.Bucket(embeddedBucketTerms)
+	for _, t := range embeddedTokenize(text) {
+		if err = terms.Put(embeddedTermKey(t, kind, key), []byte{}); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// deleteSession delete documents of session with their terms
+func (s *embeddedIndex) deleteSession(tx *bolt.Tx, kind byte, sessionID int64) (err error) {
+	b, terms := tx.Bucket(embeddedBucket(kind)), tx.Bucket(embeddedBucketTerms)
+	prefix := embeddedDocKey(sessionID, 0)[:8]
+	var keys [][]byte
+	c := b.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		var text string
+		if text, _, err = embeddedText(kind, v); err != nil {
+			return
+		}
+		for _, t := range embeddedTokenize(text) {
+			if err = terms.Delete(embeddedTermKey(t, kind, k)); err != nil {
+				return
+			}
+		}
+		keys = append(keys, append([]byte{}, k...))
+	}
+	for _, k := range keys {
+		if err = b.Delete(k); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (s *embeddedIndex) IndexContents(docs []ReplayIndice) error {
+	return s.db.Update(func(tx *bolt.Tx) (err error) {
+		for _, d := range docs {
+			var seq uint64
+			if seq, err = tx.Bucket(embeddedBucketContents).NextSequence(); err != nil {
+				return
+			}
+			if err = s.put(tx, embeddedKindContent, embeddedDocKey(d.SessionId, seq), d, d.Content); err != nil {
+				return
+			}
+		}
+		return
+	})
+}
+
+func (s *embeddedIndex) IndexCommands(sessionID int64, docs []CommandIndice) error {
+	return s.db.Update(func(tx *bolt.Tx) (err error) {
+		if err = s.deleteSession(tx, embeddedKindCommand, sessionID); err != nil {
+			return
+		}
+		for i, d := range docs {
+			if err = s.put(tx, embeddedKindCommand, embeddedDocKey(sessionID, uint64(i)), d, d.Command); err != nil {
+				return
+			}
+		}
+		return
+	})
+}
+
+// postings documents containing all terms
+func (s *embeddedIndex) postings(tx *bolt.Tx, kind byte, terms []string) (docs map[string]bool) {
+	c := tx.Bucket(embeddedBucketTerms).Cursor()
+	for _, t := range terms {
+		prefix := embeddedTermKey(t, kind, nil)
+		found := map[string]bool{}
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			docKey := string(k[len(prefix):])
+			if docs == nil || docs[docKey] {
+				found[docKey] = true
+			}
+		}
+		if docs = found; len(docs) == 0 {
+			return
+		}
+	}
+	return
+}
+
+// wildcard documents containing any term matching the pattern, and the matched terms,
+// terms are scanned from the literal prefix of the pattern
+func (s *embeddedIndex) wildcard(tx *bolt.Tx, kind byte, pattern string) (docs map[string]bool, terms []string) {
+	pattern = strings.ToLower(pattern)
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	re := regexp.MustCompile(expr.String())
+	prefix := []byte(pattern)
+	if i := strings.IndexAny(pattern, "*?"); i >= 0 {
+		prefix = prefix[:i]
+	}
+	docs = map[string]bool{}
+	// leading wildcard scans all terms, refused by validation of requests
+	if len(prefix) == 0 {
+		return
+	}
+	c := tx.Bucket(embeddedBucketTerms).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		i := bytes.IndexByte(k, 0x00)
+		if i < 0 || i+1 >= len(k) || k[i+1] != kind || !re.Match(k[:i]) {
+			continue
+		}
+		docs[string(k[i+2:])] = true
+		terms = appendUniqueString(terms, string(k[:i]))
+	}
+	return
+}
+
+// embeddedMatches checks filters of query
+func embeddedMatches(q SearchQuery, r *types.ReplaySearchResult) bool {
+	if len(q.Account) > 0 && r.Account != q.Account {
+		return false
+	}
+	if len(q.Hostname) > 0 && !containsString(r.Hostnames, q.Hostname) {
+		return false
+	}
+	if q.Since > 0 && r.CreatedAt < q.Since {
+		return false
+	}
+	if q.Until > 0 && r.CreatedAt >= q.Until {
+		return false
+	}
+	return true
+}
+
+// embeddedHighlight html snippet around the first match of needles, all matches in snippet are wrapped with <em>,
+// needles must be lower-cased, matches of words must not be part of longer words
+func embeddedHighlight(text string, needles []string, words bool) []string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, n := range needles {
+		nr := []rune(n)
+		if len(nr) == 0 {
+			continue
+		}
+	search:
+		for i := 0; i+len(nr) <= len(lower); i++ {
+			for j, r := range nr {
+				if lower[i+j] != r {
+					continue search
+				}
+			}
+			if words && ((i > 0 && embeddedIsWord(lower[i-1]) && embeddedIsWord(nr[0])) ||
+				(i+len(nr) < len(lower) && embeddedIsWord(lower[i+len(nr)]) && embeddedIsWord(nr[len(nr)-1]))) {
+				continue
+			}
+			for j := range nr {
+				marked[i+j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+			i += len(nr) - 1
+		}
+	}
+	if first < 0 {
+		return nil
+	}
+	start := first - embeddedSnippetRadius
+	if start < 0 {
+		start = 0
+	}
+	end := start + embeddedSnippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+	var sb strings.Builder
+	for i := start; i < end; i++ {
+		if marked[i] && (i == start || !marked[i-1]) {
+			sb.WriteString("<em>")
+		}
+		sb.WriteString(html.EscapeString(string(runes[i])))
+		if marked[i] && (i+1 == end || !marked[i+1]) {
+			sb.WriteString("</em>")
+		}
+	}
+	return []string{sb.String()}
+}
+
+func (s *embeddedIndex) Search(q SearchQuery) (results []*types.ReplaySearchResult, total int64, err error) {
+	results = []*types.ReplaySearchResult{}
+	kind := byte(embeddedKindContent)
+	if q.Commands {
+		kind = embeddedKindCommand
+	}
+	err = s.db.View(func(tx *bolt.Tx) (err error) {
+		var candidates map[string]bool
+		var needles []string
+		if q.Match == types.SearchMatchWildcard {
+			candidates, needles = s.wildcard(tx, kind, q.Keyword)
+		} else {
+			if needles = embeddedTokenize(q.Keyword); len(needles) == 0 {
+				return
+			}
+			candidates = s.postings(tx, kind, needles)
+		}
+		phrase := strings.ToLower(q.Keyword)
+		if q.Match == types.SearchMatchPhrase {
+			needles = []string{phrase}
+		}
+		// newest sessions first
+		keys := make([]string, 0, len(candidates))
+		for k := range candidates {
+			keys = append(keys, k)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+		if len(keys) > embeddedMaxCandidates {
+			keys = keys[:embeddedMaxCandidates]
+		}
+		b := tx.Bucket(embeddedBucket(kind))
+		for i, k := range keys {
+			// page is filled, remaining candidates are counted without verification
+			if q.Size > 0 && len(results) >= q.Size {
+				total += int64(len(keys) - i)
+				break
+			}
+			var text string
+			var r *types.ReplaySearchResult
+			if text, r, err = embeddedText(kind, b.Get([]byte(k))); err != nil {
+				return
+			}
+			if q.Match == types.SearchMatchPhrase && !strings.Contains(strings.ToLower(text), phrase) {
+				continue
+			}
+			if !embeddedMatches(q, r) {
+				continue
+			}
+			if total++; total <= int64(q.From) {
+				continue
+			}
+			r.Highlights = embeddedHighlight(text, needles, q.Match != types.SearchMatchPhrase)
+			results = append(results, r)
+		}
+		return
+	})
+	return
+}
+
+func (s *embeddedIndex) DeleteSession(sessionID int64) error {
+	return s.db.Update(func(tx *bolt.Tx) (err error) {
+		if err = s.deleteSession(tx, embeddedKindContent, sessionID); err != nil {
+			return
+		}
+		return s.deleteSession(tx, embeddedKindCommand, sessionID)
+	})
+}
+
+// Compact nothing to do, pages freed by deletions are reused by bolt
+func (s *embeddedIndex) Compact(before time.Time) error {
+	return nil
+}
+
+func (s *embeddedIndex) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,224 @@
+package daemon
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEmbeddedTokenize(t *testing.T) {
+	terms := embeddedTokenize("Hello, World! 你好 hello rm -rf /tmp/x1")
+	expected := []string{"hello", "world", "你", "好", "rm", "rf", "tmp", "x1"}
+	if len(terms) != len(expected) {
+		t.Fatal("bad terms", terms)
+	}
+	for i, term := range terms {
+		if term != expected[i] {
+			t.Fatal("bad terms", terms)
+		}
+	}
+}
+
+func TestEmbeddedIndex(t *testing.T) {
+	s, err := openEmbeddedIndex(temporaryFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	createdAt, createdAt2 := time.Unix(1500000000, 0), time.Unix(1500003600, 0)
+	if err = s.IndexContents([]ReplayIndice{
+		{SessionId: 1, Timestamp: 100, Content: "Welcome to Ubuntu\nroot@web-1:~# ", Account: "test", Hostnames: []string{"web-1"}, CreatedAt: createdAt},
+		{SessionId: 2, Timestamp: 200, Content: "root@web-2:~# cat 你好.txt", Account: "test2", Hostnames: []string{"web-2"}, CreatedAt: createdAt2},
+		{SessionId: 2, Timestamp: 300, Content: "ubuntu welcome", Account: "test2", Hostnames: []string{"web-2"}, CreatedAt: createdAt2},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.IndexCommands(2, []CommandIndice{
+		{SessionId: 2, Timestamp: 200, Command: "cat 你好.txt", Account: "test2", CreatedAt: createdAt2},
+		{SessionId: 2, Timestamp: 400, Command: "rm -rf /tmp", Account: "test2", CreatedAt: createdAt2},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	search := func(q SearchQuery, total int64, expected ...uint32) []*types.ReplaySearchResult {
+		res, n, err := s.Search(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != total || len(res) != len(expected) {
+			t.Fatal("bad results", q, n, res)
+		}
+		for i, r := range res {
+			if r.Timestamp != expected[i] {
+				t.Fatal("bad results", q, res)
+			}
+		}
+		return res
+	}
+	// terms in any order are matched
+	search(SearchQuery{Keyword: "welcome to"}, 1, 100)
+	search(SearchQuery{Keyword: "to welcome"}, 1, 100)
+	search(SearchQuery{Keyword: "ubuntu"}, 2, 300, 100)
+	search(SearchQuery{Keyword: "你好"}, 1, 200)
+	search(SearchQuery{Keyword: "rm -rf"}, 0)
+	search(SearchQuery{Keyword: "rm -rf", Commands: true}, 1, 400)
+	search(SearchQuery{Keyword: "--"}, 0)
+
+	// phrases are matched in exact order
+	search(SearchQuery{Keyword: "welcome to", Match: types.SearchMatchPhrase}, 1, 100)
+	search(SearchQuery{Keyword: "to welcome", Match: types.SearchMatchPhrase}, 0)
+	search(SearchQuery{Keyword: "ubuntu welcome", Match: types.SearchMatchPhrase}, 1, 300)
+
+	// wildcards are matched against words
+	search(SearchQuery{Keyword: "UBUN*", Match: types.SearchMatchWildcard}, 2, 300, 100)
+	search(SearchQuery{Keyword: "we?", Match: types.SearchMatchWildcard}, 2, 200, 100)
+	search(SearchQuery{Keyword: "t?p", Match: types.SearchMatchWildcard, Commands: true}, 1, 400)
+	search(SearchQuery{Keyword: "*mp", Match: types.SearchMatchWildcard, Commands: true}, 0)
+
+	// filters and paging
+	search(SearchQuery{Keyword: "ubuntu", Size: 1}, 2, 300)
+	search(SearchQuery{Keyword: "ubuntu", From: 1, Size: 1}, 2, 100)
+	search(SearchQuery{Keyword: "ubuntu", From: 2, Size: 1}, 2)
+	search(SearchQuery{Keyword: "ubuntu", Account: "test2"}, 1, 300)
+	// total is estimated with unverified candidates once the page is filled
+	search(SearchQuery{Keyword: "ubuntu", Account: "test2", Size: 1}, 2, 300)
+	search(SearchQuery{Keyword: "ubuntu", Hostname: "web-1"}, 1, 100)
+	search(SearchQuery{Keyword: "ubuntu", Since: createdAt2.Unix()}, 1, 300)
+	search(SearchQuery{Keyword: "ubuntu", Until: createdAt2.Unix()}, 1, 100)
+
+	// highlights
+	res := search(SearchQuery{Keyword: "to", Hostname: "web-1"}, 1, 100)
+	if len(res[0].Highlights) != 1 || res[0].Highlights[0] != "Welcome <em>to</em> Ubuntu\nroot@web-1:~# " {
+		t.Fatal("bad highlights", res[0].Highlights)
+	}
+	res = search(SearchQuery{Keyword: "cat 你好", Match: types.SearchMatchPhrase}, 1, 200)
+	if len(res[0].Highlights) != 1 || res[0].Highlights[0] != "root@web-2:~# <em>cat 你好</em>.txt" {
+		t.Fatal("bad highlights", res[0].Highlights)
+	}
+	if h := embeddedHighlight("<b>"+strings.Repeat("x", 200)+" rm </b>", []string{"rm"}, true); len(h) != 1 || h[0] != strings.Repeat("x", 29)+" <em>rm</em> &lt;/b&gt;" {
+		t.Fatal("bad highlights", h)
+	}
+
+	// commands of session are replaced
+	if err = s.IndexCommands(2, []CommandIndice{
+		{SessionId: 2, Timestamp: 500, Command: "ls /tmp", Account: "test2", CreatedAt: createdAt2},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	search(SearchQuery{Keyword: "rm", Commands: true}, 0)
+	search(SearchQuery{Keyword: "tmp", Commands: true}, 1, 500)
+
+	if err = s.DeleteSession(2); err != nil {
+		t.Fatal(err)
+	}
+	search(SearchQuery{Keyword: "ubuntu"}, 1, 100)
+	search(SearchQuery{Keyword: "tmp", Commands: true}, 0)
+}
+
+func TestEmbeddedIndex_MaxCandidates(t *testing.T) {
+	s, err := openEmbeddedIndex(temporaryFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	docs := make([]ReplayIndice, 0, embeddedMaxCandidates+5)
+	for i := 0; i < embeddedMaxCandidates+5; i++ {
+		docs = append(docs, ReplayIndice{SessionId: int64(i + 1), Timestamp: uint32(i + 1), Content: "ubuntu", Account: "test"})
+	}
+	if err = s.IndexContents(docs); err != nil {
+		t.Fatal(err)
+	}
+	// only newest candidates are verified
+	res, total, err := s.Search(SearchQuery{Keyword: "ubuntu", From: embeddedMaxCandidates - 1, Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != embeddedMaxCandidates || len(res) != 1 || res[0].SessionId != 6 {
+		t.Fatal("bad results", total, res)
+	}
+}
+
+func TestDaemon_SearchReplayEmbedded(t *testing.T) {
+	// daemon starts without elasticsearch
+	d := New(types.DaemonOptions{
+		DB:            temporaryFile(),
+		Host:          "127.0.0.1",
+		Port:          2992,
+		ReplayDir:     temporaryDir(),
+		SearchBackend: types.SearchBackendEmbedded,
+		SearchIndex:   temporaryFile(),
+		Elasticsearch: []string{"http://127.0.0.1:1"},
+	})
+	go d.Run()
+	defer d.Stop()
+	time.Sleep(time.Second / 2)
+	conn, err := grpc.Dial("127.0.0.1:2992", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sres, err := types.NewSessionServiceClient(conn).CreateSession(context.Background(), &types.CreateSessionRequest{Account: "test", IsRecorded: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := types.NewReplayServiceClient(conn)
+	wc, err := rs.WriteReplay(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, out := range []string{"test@web-1:~$ ", "uname -a\r\n", "Linux web-1 4.15.0\r\n", "test@web-1:~$ "} {
+		if err = wc.Send(&types.ReplayFrame{SessionId: sres.Session.Id, Timestamp: uint32(i * 2000), Type: types.ReplayFrameTypeStdout, Payload: []byte(out)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = wc.CloseAndRecv(); err != nil {
+		t.Fatal(err)
+	}
+	res, err := rs.SearchReplay(context.Background(), &types.SearchReplayRequest{Keyword: "linux"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 || len(res.Results) != 1 || res.Results[0].SessionId != sres.Session.Id || res.Results[0].Account != "test" {
+		t.Fatal("bad results", res.Results)
+	}
+	if len(res.Results[0].Highlights) != 1 || res.Results[0].Highlights[0] != "<em>Linux</em> web-1 4.15.0" {
+		t.Fatal("bad highlights", res.Results[0].Highlights)
+	}
+	if res, err = rs.SearchReplay(context.Background(), &types.SearchReplayRequest{Keyword: "linux", Account: "test2"}); err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 0 || len(res.Results) != 0 {
+		t.Fatal("bad results", res.Results)
+	}
+	if _, err = rs.SearchReplay(context.Background(), &types.SearchReplayRequest{Keyword: "linux", Match: "regexp"}); err == nil {
+		t.Fatal("should fail")
+	}
+	if _, err = rs.SearchReplay(context.Background(), &types.SearchReplayRequest{Keyword: "*nux", Match: types.SearchMatchWildcard}); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("leading wildcard should fail", err)
+	}
+	if _, err = rs.SearchReplay(context.Background(), &types.SearchReplayRequest{Keyword: "linux", From: types.SearchMaxWindow - 10, Size: 20}); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("from beyond max window should fail", err)
+	}
+	// submitted again, documents are replaced
+	if _, err = rs.SubmitReplay(context.Background(), &types.SubmitReplayRequest{SessionId: sres.Session.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if res, err = rs.SearchReplay(context.Background(), &types.SearchReplayRequest{Keyword: "linux"}); err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 || len(res.Results) != 1 {
+		t.Fatal("bad results after submitted again", res.Results)
+	}
+	if res, err = rs.SearchReplay(context.Background(), &types.SearchReplayRequest{Keyword: "uname", Commands: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Results) != 1 || res.Results[0].Command != "uname -a" || res.Results[0].Timestamp != 2000 {
+		t.Fatal("bad results", res.Results)
+	}
+}
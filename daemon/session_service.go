@@ -1,13 +1,18 @@
 package daemon
 
 import (
-	"github.com/asdine/storm"
+	"strconv"
+
 	"github.com/jinzhu/copier"
 	"github.com/yankeguo/bastion/daemon/models"
 	"github.com/yankeguo/bastion/types"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+var errInvalidParentSession = status.Error(codes.InvalidArgument, "parent session should be a lv1 session of the same account")
+
 func (d *Daemon) CreateSession(c context.Context, req *types.CreateSessionRequest) (res *types.CreateSessionResponse, err error) {
 	if err = req.Validate(); err != nil {
 		return
@@ -15,7 +20,20 @@ func (d *Daemon) CreateSession(c context.Context, req *types.CreateSessionReques
 	s := models.Session{}
 	copier.Copy(&s, req)
 	s.CreatedAt = now()
-	if err = d.db.Save(&s); err != nil {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		// lv2 session can only be linked to a lv1 session of the same account
+		if s.ParentId != 0 {
+			var p models.Session
+			if p, err = db.Sessions().Get(s.ParentId); err != nil {
+				return
+			}
+			if p.Account != s.Account || (len(p.Stage) > 0 && p.Stage != types.SessionStageLv1) {
+				err = errInvalidParentSession
+				return
+			}
+		}
+		return db.Sessions().Save(&s)
+	}); err != nil {
 		return
 	}
 	res = &types.CreateSessionResponse{Session: s.ToGRPCSession()}
@@ -27,11 +45,14 @@ func (d *Daemon) FinishSession(c context.Context, req *types.FinishSessionReques
 		return
 	}
 	s := models.Session{}
-	if err = d.db.One("Id", req.Id, &s); err != nil {
+	if s, err = d.db.Sessions().Get(req.Id); err != nil {
+		return
+	}
+	if err = d.authorizeAccount(c, types.PermissionSessionsWrite, s.Account); err != nil {
 		return
 	}
 	s.FinishedAt = now()
-	if err = d.db.Save(&s); err != nil {
+	if err = d.db.Sessions().Save(&s); err != nil {
 		return
 	}
 	res = &types.FinishSessionResponse{Session: s.ToGRPCSession()}
@@ -44,14 +65,17 @@ func (d *Daemon) ListSessions(c context.Context, req *types.ListSessionsRequest)
 	}
 	var sessions []models.Session
 	var total int
-	if err = d.db.Tx(false, func(db *Node) (err error) {
-		if total, err = db.Count(new(models.Session)); err != nil {
-			return
-		}
-		if err = db.All(&sessions, storm.Reverse(), storm.Skip(int(req.Skip)), storm.Limit(int(req.Limit))); err != nil {
-			return
-		}
-		return
+	if sessions, total, err = d.db.Sessions().Query(SessionQuery{
+		Account:      req.Account,
+		Hostname:     req.Hostname,
+		Command:      req.Command,
+		Since:        req.Since,
+		Until:        req.Until,
+		OnlyRecorded: req.OnlyRecorded,
+		OnlyActive:   req.OnlyActive,
+		Before:       req.Cursor,
+		Skip:         int(req.Skip),
+		Limit:        int(req.Limit),
 	}); err != nil {
 		return
 	}
@@ -65,14 +89,48 @@ func (d *Daemon) ListSessions(c context.Context, req *types.ListSessionsRequest)
 		Total:    int32(total),
 		Sessions: ret,
 	}
+	// a full page may have more sessions after, id of the last session is the cursor of next page
+	if len(ret) == int(req.Limit) {
+		res.NextCursor = ret[len(ret)-1].Id
+	}
 	return
 }
 
 func (d *Daemon) GetSession(c context.Context, req *types.GetSessionRequest) (res *types.GetSessionResponse, err error) {
 	s := models.Session{}
-	if err = d.db.One("Id", req.Id, &s); err != nil {
+	if s, err = d.db.Sessions().Get(req.Id); err != nil {
 		return
 	}
 	res = &types.GetSessionResponse{Session: s.ToGRPCSession()}
 	return
 }
+
+func (d *Daemon) UpdateSessionLegalHold(c context.Context, req *types.UpdateSessionLegalHoldRequest) (res *types.UpdateSessionLegalHoldResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var before, after models.Session
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		if before, err = db.Sessions().Get(req.Id); err != nil {
+			return
+		}
+		if before.PurgedAt > 0 && req.LegalHold {
+			err = errReplayPurged
+			return
+		}
+		after = before
+		after.LegalHold = req.LegalHold
+		if err = db.Sessions().Save(&after); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionSessionLegalHold, sessionTarget(req.Id), before.ToGRPCSession(), after.ToGRPCSession())
+	}); err != nil {
+		return
+	}
+	res = &types.UpdateSessionLegalHoldResponse{Session: after.ToGRPCSession()}
+	return
+}
+
+func sessionTarget(id int64) string {
+	return "session:" + strconv.FormatInt(id, 10)
+}
@@ -58,5 +58,97 @@ func TestDaemon_CreateFinishListSessions(t *testing.T) {
 			t.Fatal("failed 3")
 		}
 		t.Log(res3)
+
+		// cursor pagination with filters
+		res4, err := ss.ListSessions(context.Background(), &types.ListSessionsRequest{
+			Account: "test0003",
+			Limit:   60,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res4.Total != 100 || len(res4.Sessions) != 60 || res4.NextCursor != res4.Sessions[59].Id {
+			t.Fatal("bad first page", res4.Total, len(res4.Sessions), res4.NextCursor)
+		}
+		res5, err := ss.ListSessions(context.Background(), &types.ListSessionsRequest{
+			Account: "test0003",
+			Limit:   60,
+			Cursor:  res4.NextCursor,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res5.Sessions) != 40 || res5.NextCursor != 0 || res5.Sessions[0].Id >= res4.NextCursor {
+			t.Fatal("bad second page", len(res5.Sessions), res5.NextCursor)
+		}
+		res6, err := ss.ListSessions(context.Background(), &types.ListSessionsRequest{
+			OnlyActive: true,
+			Limit:      10,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res6.Total != 0 {
+			t.Fatal("all sessions are finished", res6.Total)
+		}
+	})
+}
+
+func TestDaemon_CreateSessionWithParent(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		ss := types.NewSessionServiceClient(conn)
+
+		res, err := ss.CreateSession(context.Background(), &types.CreateSessionRequest{
+			Account:        "test",
+			ClientAddr:     "10.0.0.1:54321",
+			ClientVersion:  "SSH-2.0-OpenSSH_7.4",
+			KeyFingerprint: "SHA256:test",
+			PtyTerm:        "xterm",
+			PtyWidth:       80,
+			PtyHeight:      24,
+			SshdInstance:   "bastion-1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Session.Stage != types.SessionStageLv1 || res.Session.PtyWidth != 80 || res.Session.SshdInstance != "bastion-1" {
+			t.Fatal("bad lv1 session", res.Session)
+		}
+
+		if _, err = ss.CreateSession(context.Background(), &types.CreateSessionRequest{
+			Account: "test",
+			Stage:   types.SessionStageLv2,
+		}); err == nil {
+			t.Fatal("lv2 session should have hostname and user")
+		}
+
+		res2, err := ss.CreateSession(context.Background(), &types.CreateSessionRequest{
+			Account:  "test",
+			Stage:    types.SessionStageLv2,
+			Hostname: "test-node",
+			User:     "root",
+			ParentId: res.Session.Id,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		gRes, err := ss.GetSession(context.Background(), &types.GetSessionRequest{Id: res2.Session.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gRes.Session.ParentId != res.Session.Id || gRes.Session.Hostname != "test-node" || gRes.Session.User != "root" {
+			t.Fatal("bad lv2 session", gRes.Session)
+		}
+
+		// parent should be a lv1 session of the same account
+		for _, req := range []*types.CreateSessionRequest{
+			{Account: "other", Stage: types.SessionStageLv2, Hostname: "test-node", User: "root", ParentId: res.Session.Id},
+			{Account: "test", Stage: types.SessionStageLv2, Hostname: "test-node", User: "root", ParentId: res2.Session.Id},
+			{Account: "test", Stage: types.SessionStageLv2, Hostname: "test-node", User: "root", ParentId: 9999},
+		} {
+			if _, err = ss.CreateSession(context.Background(), req); err == nil {
+				t.Fatal("should fail with bad parent", req)
+			}
+		}
 	})
 }
@@ -0,0 +1,255 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errRecordNotFound       = status.Error(codes.InvalidArgument, "record not found")
+	errInternal             = status.Error(codes.Internal, "internal error")
+	errAuditEventAppendOnly = status.Error(codes.FailedPrecondition, "audit events are append-only")
+)
+
+func errDuplicatedField(key string) error {
+	return status.Errorf(codes.AlreadyExists, "duplicated field '%s'", key)
+}
+
+// Store persistent storage of all models
+type Store interface {
+	Repositories
+
+	// Tx run cb in a transaction, changes are discarded if cb returns error,
+	// cb may run again if the transaction is aborted by a concurrent one, side effects must be idempotent
+	Tx(writable bool, cb func(db Repositories) error) error
+
+	// Export iterate records of all models in a consistent view, records are pointers to models
+	Export(cb func(record interface{}) error) error
+
+	// Import save records with their ids kept in a single transaction, ids created later continue from imported ids,
+	// existing audit events are never overwritten, cb is invoked in the same transaction after records are saved
+	Import(records []interface{}, cb func(db Repositories) error) error
+
+	Close() error
+}
+
+// Repositories repositories of all models
+type Repositories interface {
+	Users() UserRepository
+	Nodes() NodeRepository
+	Keys() KeyRepository
+	Grants() GrantRepository
+	Sessions() SessionRepository
+	Tokens() TokenRepository
+	MasterKeys() MasterKeyRepository
+	Volumes() VolumeRepository
+	VolumeMembers() VolumeMemberRepository
+	Transfers() TransferRepository
+	Groups() GroupRepository
+	GroupMembers() GroupMemberRepository
+	AccessRequests() AccessRequestRepository
+	AccessRequestEvents() AccessRequestEventRepository
+	AuditEvents() AuditEventRepository
+}
+
+// Get methods of repositories return errRecordNotFound if record not found, Delete methods ignore missing records
+
+type UserRepository interface {
+	Get(account string) (models.User, error)
+	List() ([]models.User, error)
+	Save(u *models.User) error
+}
+
+type NodeRepository interface {
+	Get(hostname string) (models.Node, error)
+	List() ([]models.Node, error)
+	Save(n *models.Node) error
+	Delete(hostname string) error
+}
+
+type KeyRepository interface {
+	Get(fingerprint string) (models.Key, error)
+	ListByAccount(account string) ([]models.Key, error)
+	Save(k *models.Key) error
+	Delete(fingerprint string) error
+	DeleteByAccountAndSource(account string, source string) error
+}
+
+type GrantRepository interface {
+	Get(id string) (models.Grant, error)
+	List() ([]models.Grant, error)
+	ListByAccount(account string) ([]models.Grant, error)
+	ListByGroup(group string) ([]models.Grant, error)
+	Save(g *models.Grant) error
+	Delete(id string) error
+}
+
+// SessionQuery conditions of sessions, empty fields are ignored
+type SessionQuery struct {
+	Account  string
+	Hostname string
+	// Command case-insensitive substring of command
+	Command      string
+	Since        int64
+	Until        int64
+	OnlyRecorded bool
+	OnlyActive   bool
+	OnlyUnpurged bool
+	// ParentId lv2 sessions initiated from the lv1 session
+	ParentId int64
+	// Before cursor, only sessions with smaller id are returned
+	Before int64
+	// After only sessions with id not less than it are returned
+	After int64
+	Skip  int
+	Limit int
+}
+
+type SessionRepository interface {
+	Get(id int64) (models.Session, error)
+	Count() (int, error)
+	// Query sessions newest first, total is the count of all matched sessions regardless of skip and limit,
+	// only counted without cursor, pages after the first one keep the total of the first page
+	Query(q SessionQuery) (ss []models.Session, total int, err error)
+	// Save save the session, id is assigned if zero
+	Save(s *models.Session) error
+}
+
+type TokenRepository interface {
+	List() ([]models.Token, error)
+	Get(id int64) (models.Token, error)
+	GetByToken(token string) (models.Token, error)
+	ListByAccount(account string) ([]models.Token, error)
+	// Save save the token, id is assigned if zero
+	Save(t *models.Token) error
+	Delete(id int64) error
+}
+
+type MasterKeyRepository interface {
+	List() ([]models.MasterKey, error)
+	Save(k *models.MasterKey) error
+	Delete(fingerprint string) error
+}
+
+type VolumeRepository interface {
+	Get(name string) (models.Volume, error)
+	List() ([]models.Volume, error)
+	Save(v *models.Volume) error
+	Delete(name string) error
+}
+
+type VolumeMemberRepository interface {
+	Get(id string) (models.VolumeMember, error)
+	ListByVolume(volume string) ([]models.VolumeMember, error)
+	// ListByName members by account of user or name of group, kind should be checked
+	ListByName(name string) ([]models.VolumeMember, error)
+	Save(m *models.VolumeMember) error
+	Delete(id string) error
+}
+
+type TransferRepository interface {
+	// List transfers newest first
+	List() ([]models.Transfer, error)
+	ListBySession(sessionID int64) ([]models.Transfer, error)
+	// ListByAccount transfers of the account newest first
+	ListByAccount(account string) ([]models.Transfer, error)
+	// Save save the transfer, id is assigned if zero
+	Save(t *models.Transfer) error
+}
+
+type GroupRepository interface {
+	Get(name string) (models.Group, error)
+	List() ([]models.Group, error)
+	Save(g *models.Group) error
+	Delete(name string) error
+}
+
+type GroupMemberRepository interface {
+	Get(id string) (models.GroupMember, error)
+	ListByGroup(group string) ([]models.GroupMember, error)
+	ListByAccount(account string) ([]models.GroupMember, error)
+	Save(m *models.GroupMember) error
+	Delete(id string) error
+}
+
+type AccessRequestRepository interface {
+	Get(id int64) (models.AccessRequest, error)
+	List() ([]models.AccessRequest, error)
+	ListByAccount(account string) ([]models.AccessRequest, error)
+	ListByStatus(status string) ([]models.AccessRequest, error)
+	// Save save the access request, id is assigned if zero
+	Save(r *models.AccessRequest) error
+}
+
+type AccessRequestEventRepository interface {
+	ListByRequest(requestID int64) ([]models.AccessRequestEvent, error)
+	// Save save the event, id is assigned if zero
+	Save(e *models.AccessRequestEvent) error
+}
+
+// AuditEventQuery conditions of audit events, empty fields are ignored
+type AuditEventQuery struct {
+	Actor  string
+	Action string
+	Target string
+	Source string
+	Since  int64
+	Until  int64
+	Skip   int
+	Limit  int
+}
+
+type AuditEventRepository interface {
+	// Query audit events newest first, total is the count of all matched events regardless of skip and limit
+	Query(q AuditEventQuery) (es []models.AuditEvent, total int, err error)
+	// Append append the event with a new id, audit events are append-only and never overwritten
+	Append(e *models.AuditEvent) error
+}
+
+// OpenStore open the store configured in options, database schema is migrated
+func OpenStore(opts types.DaemonOptions) (s Store, err error) {
+	switch opts.DBDriver {
+	case "", types.DBDriverBolt:
+		var ss *stormStore
+		if ss, err = openStormStore(opts.DB); err != nil {
+			return
+		}
+		s = ss
+	case types.DBDriverSQLite, types.DBDriverPostgres:
+		var ss *sqlStore
+		if ss, err = openSQLStore(opts.DBDriver, opts.DB); err != nil {
+			return
+		}
+		s = ss
+	default:
+		err = fmt.Errorf("unknown database driver '%s'", opts.DBDriver)
+	}
+	return
+}
+
+// Migrate copy all records from src to dst, dst must be empty
+func Migrate(src Store, dst Store) (count int, err error) {
+	errNotEmpty := errors.New("destination database is not empty")
+	if err = dst.Export(func(record interface{}) error {
+		return errNotEmpty
+	}); err != nil {
+		return
+	}
+	var records []interface{}
+	if err = src.Export(func(record interface{}) error {
+		records = append(records, record)
+		return nil
+	}); err != nil {
+		return
+	}
+	if err = dst.Import(records, nil); err != nil {
+		return
+	}
+	count = len(records)
+	return
+}
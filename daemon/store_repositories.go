@@ -0,0 +1,489 @@
+package daemon
+
+import (
+	"reflect"
+
+	"github.com/yankeguo/bastion/daemon/models"
+)
+
+const (
+	recordOpEq  = "="
+	recordOpGte = ">="
+	recordOpLt  = "<"
+	// recordOpContains case-insensitive substring of a string field
+	recordOpContains = "contains"
+)
+
+// recordFilter condition on a field, field must be the id, an indexed field or a sql column of the model
+type recordFilter struct {
+	field string
+	op    string
+	value interface{}
+}
+
+// recordQuery filters and order of records, records are ordered by id
+type recordQuery struct {
+	filters []recordFilter
+	reverse bool
+	skip    int
+	limit   int
+}
+
+func whereEq(field string, value interface{}) recordQuery {
+	return recordQuery{filters: []recordFilter{{field: field, op: recordOpEq, value: value}}}
+}
+
+// recordStore backend of repositories, models are stored as structs with storm tags describing id and indexed fields
+type recordStore interface {
+	// one find a record by id or an indexed field, returns errRecordNotFound if not found
+	one(field string, value interface{}, to interface{}) error
+	// find records matching the query, to is a pointer to slice of model
+	find(q recordQuery, to interface{}) error
+	// count records matching the query, data is a pointer to model
+	count(q recordQuery, data interface{}) (int, error)
+	// save the record, increment id is assigned if zero
+	save(data interface{}) error
+	// delete the record by id, missing record is ignored
+	delete(data interface{}) error
+}
+
+// exportRecords iterate all records of all models
+func exportRecords(s recordStore, cb func(record interface{}) error) (err error) {
+	for _, m := range models.AllModels {
+		l := reflect.New(reflect.SliceOf(reflect.TypeOf(m).Elem()))
+		if err = s.find(recordQuery{}, l.Interface()); err != nil {
+			return
+		}
+		for i := 0; i < l.Elem().Len(); i++ {
+			if err = cb(l.Elem().Index(i).Addr().Interface()); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// importRecord save the record with id kept, refuses to overwrite an existing audit event
+func importRecord(s recordStore, record interface{}) (err error) {
+	if e, ok := record.(*models.AuditEvent); ok {
+		if err = s.one("Id", e.Id, &models.AuditEvent{}); err == nil {
+			return errAuditEventAppendOnly
+		} else if err != errRecordNotFound {
+			return
+		}
+	}
+	return s.save(record)
+}
+
+// repositories implements Repositories with a recordStore
+type repositories struct {
+	s recordStore
+}
+
+func (r repositories) Users() UserRepository                   { return userRepository(r) }
+func (r repositories) Nodes() NodeRepository                   { return nodeRepository(r) }
+func (r repositories) Keys() KeyRepository                     { return keyRepository(r) }
+func (r repositories) Grants() GrantRepository                 { return grantRepository(r) }
+func (r repositories) Sessions() SessionRepository             { return sessionRepository(r) }
+func (r repositories) Tokens() TokenRepository                 { return tokenRepository(r) }
+func (r repositories) MasterKeys() MasterKeyRepository         { return masterKeyRepository(r) }
+func (r repositories) Volumes() VolumeRepository               { return volumeRepository(r) }
+func (r repositories) VolumeMembers() VolumeMemberRepository   { return volumeMemberRepository(r) }
+func (r repositories) Transfers() TransferRepository           { return transferRepository(r) }
+func (r repositories) Groups() GroupRepository                 { return groupRepository(r) }
+func (r repositories) GroupMembers() GroupMemberRepository     { return groupMemberRepository(r) }
+func (r repositories) AccessRequests() AccessRequestRepository { return accessRequestRepository(r) }
+func (r repositories) AccessRequestEvents() AccessRequestEventRepository {
+	return accessRequestEventRepository(r)
+}
+func (r repositories) AuditEvents() AuditEventRepository { return auditEventRepository(r) }
+
+type userRepository repositories
+
+func (r userRepository) Get(account string) (u models.User, err error) {
+	err = r.s.one("Account", account, &u)
+	return
+}
+
+func (r userRepository) List() (us []models.User, err error) {
+	err = r.s.find(recordQuery{}, &us)
+	return
+}
+
+func (r userRepository) Save(u *models.User) error {
+	return r.s.save(u)
+}
+
+type nodeRepository repositories
+
+func (r nodeRepository) Get(hostname string) (n models.Node, err error) {
+	err = r.s.one("Hostname", hostname, &n)
+	return
+}
+
+func (r nodeRepository) List() (ns []models.Node, err error) {
+	err = r.s.find(recordQuery{}, &ns)
+	return
+}
+
+func (r nodeRepository) Save(n *models.Node) error {
+	return r.s.save(n)
+}
+
+func (r nodeRepository) Delete(hostname string) error {
+	return r.s.delete(&models.Node{Hostname: hostname})
+}
+
+type keyRepository repositories
+
+func (r keyRepository) Get(fingerprint string) (k models.Key, err error) {
+	err = r.s.one("Fingerprint", fingerprint, &k)
+	return
+}
+
+func (r keyRepository) ListByAccount(account string) (ks []models.Key, err error) {
+	err = r.s.find(whereEq("Account", account), &ks)
+	return
+}
+
+func (r keyRepository) Save(k *models.Key) error {
+	return r.s.save(k)
+}
+
+func (r keyRepository) Delete(fingerprint string) error {
+	return r.s.delete(&models.Key{Fingerprint: fingerprint})
+}
+
+func (r keyRepository) DeleteByAccountAndSource(account string, source string) (err error) {
+	var ks []models.Key
+	if err = r.s.find(whereEq("Account", account), &ks); err != nil {
+		return
+	}
+	for _, k := range ks {
+		if k.Source != source {
+			continue
+		}
+		if err = r.s.delete(&k); err != nil {
+			return
+		}
+	}
+	return
+}
+
+type grantRepository repositories
+
+func (r grantRepository) Get(id string) (g models.Grant, err error) {
+	err = r.s.one("Id", id, &g)
+	return
+}
+
+func (r grantRepository) List() (gs []models.Grant, err error) {
+	err = r.s.find(recordQuery{}, &gs)
+	return
+}
+
+func (r grantRepository) ListByAccount(account string) (gs []models.Grant, err error) {
+	err = r.s.find(whereEq("Account", account), &gs)
+	return
+}
+
+func (r grantRepository) ListByGroup(group string) (gs []models.Grant, err error) {
+	err = r.s.find(whereEq("Group", group), &gs)
+	return
+}
+
+func (r grantRepository) Save(g *models.Grant) error {
+	return r.s.save(g)
+}
+
+func (r grantRepository) Delete(id string) error {
+	return r.s.delete(&models.Grant{Id: id})
+}
+
+type sessionRepository repositories
+
+func (r sessionRepository) Get(id int64) (s models.Session, err error) {
+	err = r.s.one("Id", id, &s)
+	return
+}
+
+func (r sessionRepository) Count() (int, error) {
+	return r.s.count(recordQuery{}, &models.Session{})
+}
+
+func (r sessionRepository) Query(sq SessionQuery) (ss []models.Session, total int, err error) {
+	q := recordQuery{reverse: true}
+	for _, f := range []recordFilter{
+		{field: "Account", op: recordOpEq, value: sq.Account},
+		{field: "Hostname", op: recordOpEq, value: sq.Hostname},
+		{field: "Command", op: recordOpContains, value: sq.Command},
+	} {
+		if len(f.value.(string)) > 0 {
+			q.filters = append(q.filters, f)
+		}
+	}
+	if sq.Since > 0 {
+		q.filters = append(q.filters, recordFilter{field: "CreatedAt", op: recordOpGte, value: sq.Since})
+	}
+	if sq.Until > 0 {
+		q.filters = append(q.filters, recordFilter{field: "CreatedAt", op: recordOpLt, value: sq.Until})
+	}
+	if sq.OnlyRecorded {
+		q.filters = append(q.filters, recordFilter{field: "IsRecorded", op: recordOpEq, value: true})
+	}
+	if sq.OnlyActive {
+		q.filters = append(q.filters, recordFilter{field: "FinishedAt", op: recordOpEq, value: int64(0)})
+	}
+	if sq.OnlyUnpurged {
+		q.filters = append(q.filters, recordFilter{field: "PurgedAt", op: recordOpEq, value: int64(0)})
+	}
+	if sq.ParentId > 0 {
+		q.filters = append(q.filters, recordFilter{field: "ParentId", op: recordOpEq, value: sq.ParentId})
+	}
+	if sq.After > 0 {
+		q.filters = append(q.filters, recordFilter{field: "Id", op: recordOpGte, value: sq.After})
+	}
+	// total is only counted for the first page, cursor does not affect it
+	if sq.Before > 0 {
+		q.filters = append(q.filters, recordFilter{field: "Id", op: recordOpLt, value: sq.Before})
+	} else if total, err = r.s.count(q, &models.Session{}); err != nil {
+		return
+	}
+	q.skip, q.limit = sq.Skip, sq.Limit
+	err = r.s.find(q, &ss)
+	return
+}
+
+func (r sessionRepository) Save(s *models.Session) error {
+	return r.s.save(s)
+}
+
+type tokenRepository repositories
+
+func (r tokenRepository) List() (ts []models.Token, err error) {
+	err = r.s.find(recordQuery{}, &ts)
+	return
+}
+
+func (r tokenRepository) Get(id int64) (t models.Token, err error) {
+	err = r.s.one("Id", id, &t)
+	return
+}
+
+func (r tokenRepository) GetByToken(token string) (t models.Token, err error) {
+	err = r.s.one("Token", token, &t)
+	return
+}
+
+func (r tokenRepository) ListByAccount(account string) (ts []models.Token, err error) {
+	err = r.s.find(whereEq("Account", account), &ts)
+	return
+}
+
+func (r tokenRepository) Save(t *models.Token) error {
+	return r.s.save(t)
+}
+
+func (r tokenRepository) Delete(id int64) error {
+	return r.s.delete(&models.Token{Id: id})
+}
+
+type masterKeyRepository repositories
+
+func (r masterKeyRepository) List() (ks []models.MasterKey, err error) {
+	err = r.s.find(recordQuery{}, &ks)
+	return
+}
+
+func (r masterKeyRepository) Save(k *models.MasterKey) error {
+	return r.s.save(k)
+}
+
+func (r masterKeyRepository) Delete(fingerprint string) error {
+	return r.s.delete(&models.MasterKey{Fingerprint: fingerprint})
+}
+
+type volumeRepository repositories
+
+func (r volumeRepository) Get(name string) (v models.Volume, err error) {
+	err = r.s.one("Name", name, &v)
+	return
+}
+
+func (r volumeRepository) List() (vs []models.Volume, err error) {
+	err = r.s.find(recordQuery{}, &vs)
+	return
+}
+
+func (r volumeRepository) Save(v *models.Volume) error {
+	return r.s.save(v)
+}
+
+func (r volumeRepository) Delete(name string) error {
+	return r.s.delete(&models.Volume{Name: name})
+}
+
+type volumeMemberRepository repositories
+
+func (r volumeMemberRepository) Get(id string) (m models.VolumeMember, err error) {
+	err = r.s.one("Id", id, &m)
+	return
+}
+
+func (r volumeMemberRepository) ListByVolume(volume string) (ms []models.VolumeMember, err error) {
+	err = r.s.find(whereEq("Volume", volume), &ms)
+	return
+}
+
+func (r volumeMemberRepository) ListByName(name string) (ms []models.VolumeMember, err error) {
+	err = r.s.find(whereEq("Name", name), &ms)
+	return
+}
+
+func (r volumeMemberRepository) Save(m *models.VolumeMember) error {
+	return r.s.save(m)
+}
+
+func (r volumeMemberRepository) Delete(id string) error {
+	return r.s.delete(&models.VolumeMember{Id: id})
+}
+
+type transferRepository repositories
+
+func (r transferRepository) List() (ts []models.Transfer, err error) {
+	err = r.s.find(recordQuery{reverse: true}, &ts)
+	return
+}
+
+func (r transferRepository) ListBySession(sessionID int64) (ts []models.Transfer, err error) {
+	err = r.s.find(whereEq("SessionId", sessionID), &ts)
+	return
+}
+
+func (r transferRepository) ListByAccount(account string) (ts []models.Transfer, err error) {
+	q := whereEq("Account", account)
+	q.reverse = true
+	err = r.s.find(q, &ts)
+	return
+}
+
+func (r transferRepository) Save(t *models.Transfer) error {
+	return r.s.save(t)
+}
+
+type groupRepository repositories
+
+func (r groupRepository) Get(name string) (g models.Group, err error) {
+	err = r.s.one("Name", name, &g)
+	return
+}
+
+func (r groupRepository) List() (gs []models.Group, err error) {
+	err = r.s.find(recordQuery{}, &gs)
+	return
+}
+
+func (r groupRepository) Save(g *models.Group) error {
+	return r.s.save(g)
+}
+
+func (r groupRepository) Delete(name string) error {
+	return r.s.delete(&models.Group{Name: name})
+}
+
+type groupMemberRepository repositories
+
+func (r groupMemberRepository) Get(id string) (m models.GroupMember, err error) {
+	err = r.s.one("Id", id, &m)
+	return
+}
+
+func (r groupMemberRepository) ListByGroup(group string) (ms []models.GroupMember, err error) {
+	err = r.s.find(whereEq("Group", group), &ms)
+	return
+}
+
+func (r groupMemberRepository) ListByAccount(account string) (ms []models.GroupMember, err error) {
+	err = r.s.find(whereEq("Account", account), &ms)
+	return
+}
+
+func (r groupMemberRepository) Save(m *models.GroupMember) error {
+	return r.s.save(m)
+}
+
+func (r groupMemberRepository) Delete(id string) error {
+	return r.s.delete(&models.GroupMember{Id: id})
+}
+
+type accessRequestRepository repositories
+
+func (r accessRequestRepository) Get(id int64) (ar models.AccessRequest, err error) {
+	err = r.s.one("Id", id, &ar)
+	return
+}
+
+func (r accessRequestRepository) List() (rs []models.AccessRequest, err error) {
+	err = r.s.find(recordQuery{}, &rs)
+	return
+}
+
+func (r accessRequestRepository) ListByAccount(account string) (rs []models.AccessRequest, err error) {
+	err = r.s.find(whereEq("Account", account), &rs)
+	return
+}
+
+func (r accessRequestRepository) ListByStatus(status string) (rs []models.AccessRequest, err error) {
+	err = r.s.find(whereEq("Status", status), &rs)
+	return
+}
+
+func (r accessRequestRepository) Save(ar *models.AccessRequest) error {
+	return r.s.save(ar)
+}
+
+type accessRequestEventRepository repositories
+
+func (r accessRequestEventRepository) ListByRequest(requestID int64) (es []models.AccessRequestEvent, err error) {
+	err = r.s.find(whereEq("RequestId", requestID), &es)
+	return
+}
+
+func (r accessRequestEventRepository) Save(e *models.AccessRequestEvent) error {
+	return r.s.save(e)
+}
+
+type auditEventRepository repositories
+
+func (r auditEventRepository) Query(aq AuditEventQuery) (es []models.AuditEvent, total int, err error) {
+	q := recordQuery{reverse: true}
+	for _, f := range []recordFilter{
+		{field: "Actor", op: recordOpEq, value: aq.Actor},
+		{field: "Action", op: recordOpEq, value: aq.Action},
+		{field: "Target", op: recordOpEq, value: aq.Target},
+		{field: "Source", op: recordOpEq, value: aq.Source},
+	} {
+		if len(f.value.(string)) > 0 {
+			q.filters = append(q.filters, f)
+		}
+	}
+	if aq.Since > 0 {
+		q.filters = append(q.filters, recordFilter{field: "CreatedAt", op: recordOpGte, value: aq.Since})
+	}
+	if aq.Until > 0 {
+		q.filters = append(q.filters, recordFilter{field: "CreatedAt", op: recordOpLt, value: aq.Until})
+	}
+	if total, err = r.s.count(q, &models.AuditEvent{}); err != nil {
+		return
+	}
+	q.skip, q.limit = aq.Skip, aq.Limit
+	err = r.s.find(q, &es)
+	return
+}
+
+func (r auditEventRepository) Append(e *models.AuditEvent) error {
+	if e.Id != 0 {
+		return errAuditEventAppendOnly
+	}
+	return r.s.save(e)
+}
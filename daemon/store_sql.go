@@ -0,0 +1,588 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// sqlTxMaxRetries retries of transactions aborted by serialization failures of postgres
+	sqlTxMaxRetries = 5
+	sqlTxRetryDelay = 20 * time.Millisecond
+)
+
+func transformSQLError(err *error) {
+	if err == nil {
+	} else if *err == nil {
+	} else if *err == sql.ErrNoRows {
+		*err = errRecordNotFound
+	} else if pe, ok := (*err).(*pq.Error); ok && pe.Code.Class() == "40" {
+		// serialization failure or deadlock with a concurrent transaction, retried by Tx
+		*err = status.Error(codes.Aborted, pe.Error())
+	} else if _, ok := status.FromError(*err); ok {
+	} else {
+		*err = status.Error(codes.Internal, (*err).Error())
+	}
+}
+
+// sqlDialect differences between sqlite3 and postgres, statements are written with '?' placeholders
+type sqlDialect struct {
+	driver string
+	// autoIncrement column definition of increment id
+	autoIncrement string
+	// returning insert returns id with "RETURNING", instead of LastInsertId
+	returning bool
+}
+
+var (
+	sqlDialectSQLite = sqlDialect{
+		driver:        types.DBDriverSQLite,
+		autoIncrement: "INTEGER PRIMARY KEY AUTOINCREMENT",
+	}
+	sqlDialectPostgres = sqlDialect{
+		driver:        types.DBDriverPostgres,
+		autoIncrement: "BIGSERIAL PRIMARY KEY",
+		returning:     true,
+	}
+)
+
+// rebind replace '?' placeholders with '$n' for postgres
+func (d sqlDialect) rebind(query string) string {
+	if d.driver != types.DBDriverPostgres {
+		return query
+	}
+	buf := &bytes.Buffer{}
+	var n int
+	for _, c := range query {
+		if c == '?' {
+			n++
+			buf.WriteString("$" + strconv.Itoa(n))
+		} else {
+			buf.WriteRune(c)
+		}
+	}
+	return buf.String()
+}
+
+// sqlColumn a column mapped from the id or an indexed field of model
+type sqlColumn struct {
+	field     string
+	name      string
+	kind      reflect.Kind
+	index     int
+	unique    bool
+	increment bool
+	// plain column without index
+	plain bool
+}
+
+func (c sqlColumn) definition(d sqlDialect, id bool) string {
+	if id && c.increment {
+		return quoteIdent(c.name) + " " + d.autoIncrement
+	}
+	typ, zero := "TEXT", "''"
+	if c.kind == reflect.Int64 {
+		typ, zero = "BIGINT", "0"
+	} else if c.kind == reflect.Bool {
+		typ, zero = "BOOLEAN", "FALSE"
+	}
+	if id {
+		return quoteIdent(c.name) + " " + typ + " PRIMARY KEY"
+	}
+	return quoteIdent(c.name) + " " + typ + " NOT NULL DEFAULT " + zero
+}
+
+// sqlTable table of a model, id and indexed fields are columns, the whole record is kept in column "data" as JSON,
+// fields tagged with `sql:"column"` are columns without index, for filters can not be served by index
+type sqlTable struct {
+	name    string
+	typ     reflect.Type
+	id      sqlColumn
+	columns []sqlColumn
+}
+
+func newSQLTable(t reflect.Type) (st *sqlTable, err error) {
+	st = &sqlTable{name: "bastion_" + snakeCase(t.Name()), typ: t}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("storm")
+		if len(tag) == 0 && f.Tag.Get("sql") != "column" {
+			continue
+		}
+		c := sqlColumn{field: f.Name, name: snakeCase(f.Name), kind: f.Type.Kind(), index: i}
+		if c.kind != reflect.String && c.kind != reflect.Int64 && c.kind != reflect.Bool {
+			err = fmt.Errorf("unsupported type of field %s.%s", t.Name(), f.Name)
+			return
+		}
+		tags := strings.Split(tag, ",")
+		switch tags[0] {
+		case "id":
+			c.increment = len(tags) > 1 && tags[1] == "increment"
+			st.id = c
+		case "unique":
+			c.unique = true
+			st.columns = append(st.columns, c)
+		case "index":
+			st.columns = append(st.columns, c)
+		case "":
+			c.plain = true
+			st.columns = append(st.columns, c)
+		}
+	}
+	if len(st.id.name) == 0 {
+		err = fmt.Errorf("missing id field of %s", t.Name())
+	}
+	return
+}
+
+func (st *sqlTable) column(field string) (c sqlColumn, err error) {
+	if field == st.id.field {
+		return st.id, nil
+	}
+	for _, c = range st.columns {
+		if c.field == field {
+			return
+		}
+	}
+	err = fmt.Errorf("field %s.%s is not indexed", st.typ.Name(), field)
+	return
+}
+
+// sqlQuerier either *sql.DB or *sql.Tx
+type sqlQuerier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// sqlStore Store backed by sqlite3 or postgres
+type sqlStore struct {
+	repositories
+	db      *sql.DB
+	dialect sqlDialect
+	tables  map[reflect.Type]*sqlTable
+}
+
+func openSQLStore(driver string, dsn string) (s *sqlStore, err error) {
+	s = &sqlStore{dialect: sqlDialectPostgres, tables: map[reflect.Type]*sqlTable{}}
+	if driver == types.DBDriverSQLite {
+		s.dialect = sqlDialectSQLite
+		// ensure database directory for plain file path
+		if !strings.HasPrefix(dsn, "file:") && !strings.HasPrefix(dsn, ":memory:") {
+			os.MkdirAll(filepath.Dir(dsn), 0750)
+		}
+		// writable transactions lock the database immediately, concurrent writers wait instead of failing
+		if !strings.Contains(dsn, "?") {
+			dsn = dsn + "?_txlock=immediate&_busy_timeout=5000"
+		}
+	}
+	if s.db, err = sql.Open(driver, dsn); err != nil {
+		return
+	}
+	if driver == types.DBDriverSQLite {
+		// sqlite allows a single writer, also required by in-memory database
+		s.db.SetMaxOpenConns(1)
+	}
+	for _, m := range models.AllModels {
+		var st *sqlTable
+		if st, err = newSQLTable(reflect.TypeOf(m).Elem()); err != nil {
+			s.db.Close()
+			return
+		}
+		s.tables[st.typ] = st
+	}
+	s.repositories = repositories{s: s.records(s.db)}
+	if err = s.migrate(); err != nil {
+		s.db.Close()
+		return
+	}
+	return
+}
+
+func (s *sqlStore) records(q sqlQuerier) sqlRecords {
+	return sqlRecords{q: q, dialect: s.dialect, tables: s.tables}
+}
+
+// migrate create missing tables, columns and indexes, records are saved again to fill newly added columns
+func (s *sqlStore) migrate() (err error) {
+	for _, m := range models.AllModels {
+		st := s.tables[reflect.TypeOf(m).Elem()]
+		defs := []string{st.id.definition(s.dialect, true)}
+		for _, c := range st.columns {
+			defs = append(defs, c.definition(s.dialect, false))
+		}
+		defs = append(defs, `"data" TEXT NOT NULL`)
+		if _, err = s.db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", quoteIdent(st.name), strings.Join(defs, ", "))); err != nil {
+			return
+		}
+		// find existing columns
+		var rows *sql.Rows
+		if rows, err = s.db.Query(fmt.Sprintf("SELECT * FROM %s LIMIT 0", quoteIdent(st.name))); err != nil {
+			return
+		}
+		var names []string
+		names, err = rows.Columns()
+		rows.Close()
+		if err != nil {
+			return
+		}
+		existing := map[string]bool{}
+		for _, name := range names {
+			existing[name] = true
+		}
+		var added bool
+		for _, c := range st.columns {
+			if existing[c.name] {
+				continue
+			}
+			added = true
+			if _, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", quoteIdent(st.name), c.definition(s.dialect, false))); err != nil {
+				return
+			}
+		}
+		if added {
+			if err = s.Tx(true, func(db Repositories) (err error) {
+				rs := db.(repositories).s
+				l := reflect.New(reflect.SliceOf(st.typ))
+				if err = rs.find(recordQuery{}, l.Interface()); err != nil {
+					return
+				}
+				for i := 0; i < l.Elem().Len(); i++ {
+					if err = rs.save(l.Elem().Index(i).Addr().Interface()); err != nil {
+						return
+					}
+				}
+				return
+			}); err != nil {
+				return
+			}
+		}
+		// indexes are created after columns filled, unique constraints are enforced by indexes
+		for _, c := range st.columns {
+			if c.plain {
+				continue
+			}
+			unique := ""
+			if c.unique {
+				unique = "UNIQUE "
+			}
+			if _, err = s.db.Exec(fmt.Sprintf("CREATE %sINDEX IF NOT EXISTS %s ON %s (%s)", unique, quoteIdent("idx_"+st.name+"_"+c.name), quoteIdent(st.name), quoteIdent(c.name))); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+// Tx run cb in a transaction, retried with the same cb if aborted by a concurrent transaction
+func (s *sqlStore) Tx(writable bool, cb func(Repositories) error) (err error) {
+	for i := 0; ; i++ {
+		if err = s.tx(writable, cb); status.Code(err) != codes.Aborted || i == sqlTxMaxRetries {
+			return
+		}
+		time.Sleep(sqlTxRetryDelay * time.Duration(i+1))
+	}
+}
+
+func (s *sqlStore) tx(writable bool, cb func(Repositories) error) (err error) {
+	var tx *sql.Tx
+	if writable && s.dialect.driver == types.DBDriverPostgres {
+		// multiple daemons may share the database, writable transactions must not interleave
+		tx, err = s.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	} else {
+		tx, err = s.db.Begin()
+	}
+	if err != nil {
+		transformSQLError(&err)
+		return
+	}
+	defer tx.Rollback()
+	if err = cb(repositories{s: s.records(tx)}); err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		transformSQLError(&err)
+		return
+	}
+	return
+}
+
+func (s *sqlStore) Export(cb func(record interface{}) error) error {
+	return s.Tx(false, func(db Repositories) error {
+		return exportRecords(db.(repositories).s, cb)
+	})
+}
+
+func (s *sqlStore) Import(records []interface{}, cb func(db Repositories) error) error {
+	return s.Tx(true, func(db Repositories) (err error) {
+		rs := db.(repositories).s.(sqlRecords)
+		for _, r := range records {
+			if err = importRecord(rs, r); err != nil {
+				return
+			}
+		}
+		// sqlite keeps AUTOINCREMENT sequence past explicitly inserted ids,
+		// postgres sequences are not advanced by explicitly inserted ids
+		if s.dialect.driver == types.DBDriverPostgres {
+			for _, st := range s.tables {
+				if !st.id.increment {
+					continue
+				}
+				if _, err = rs.q.Exec(fmt.Sprintf(
+					"SELECT setval(pg_get_serial_sequence('%s', '%s'), COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)",
+					st.name, st.id.name, quoteIdent(st.id.name), quoteIdent(st.name),
+				)); err != nil {
+					transformSQLError(&err)
+					return
+				}
+			}
+		}
+		if cb != nil {
+			err = cb(db)
+		}
+		return
+	})
+}
+
+// sqlRecords recordStore on a database or a transaction
+type sqlRecords struct {
+	q       sqlQuerier
+	dialect sqlDialect
+	tables  map[reflect.Type]*sqlTable
+}
+
+func (s sqlRecords) table(t reflect.Type) (st *sqlTable, err error) {
+	if st = s.tables[t]; st == nil {
+		err = fmt.Errorf("unknown model %s", t.Name())
+	}
+	return
+}
+
+// where build WHERE clause and arguments of filters
+func (s sqlRecords) where(st *sqlTable, filters []recordFilter) (clause string, args []interface{}, err error) {
+	if len(filters) == 0 {
+		return
+	}
+	conds := make([]string, 0, len(filters))
+	for _, f := range filters {
+		var c sqlColumn
+		if c, err = st.column(f.field); err != nil {
+			return
+		}
+		if f.op == recordOpContains {
+			conds = append(conds, "LOWER("+quoteIdent(c.name)+") LIKE ? ESCAPE '\\'")
+			args = append(args, "%"+likeEscaper.Replace(strings.ToLower(f.value.(string)))+"%")
+			continue
+		}
+		conds = append(conds, quoteIdent(c.name)+" "+f.op+" ?")
+		args = append(args, f.value)
+	}
+	clause = " WHERE " + strings.Join(conds, " AND ")
+	return
+}
+
+// scan decode a row of id and data into v
+func (s sqlRecords) scan(st *sqlTable, sc interface {
+	Scan(dest ...interface{}) error
+}, v reflect.Value) (err error) {
+	id := reflect.New(st.typ.Field(st.id.index).Type)
+	var data string
+	if err = sc.Scan(id.Interface(), &data); err != nil {
+		return
+	}
+	if err = json.Unmarshal([]byte(data), v.Addr().Interface()); err != nil {
+		return
+	}
+	// id assigned by database is not included in data
+	v.Field(st.id.index).Set(id.Elem())
+	return
+}
+
+func (s sqlRecords) one(field string, value interface{}, to interface{}) (err error) {
+	defer transformSQLError(&err)
+	v := reflect.ValueOf(to).Elem()
+	var st *sqlTable
+	if st, err = s.table(v.Type()); err != nil {
+		return
+	}
+	var clause string
+	var args []interface{}
+	if clause, args, err = s.where(st, []recordFilter{{field: field, op: recordOpEq, value: value}}); err != nil {
+		return
+	}
+	query := fmt.Sprintf("SELECT %s, \"data\" FROM %s%s", quoteIdent(st.id.name), quoteIdent(st.name), clause)
+	err = s.scan(st, s.q.QueryRow(s.dialect.rebind(query), args...), v)
+	return
+}
+
+func (s sqlRecords) find(rq recordQuery, to interface{}) (err error) {
+	defer transformSQLError(&err)
+	l := reflect.ValueOf(to).Elem()
+	var st *sqlTable
+	if st, err = s.table(l.Type().Elem()); err != nil {
+		return
+	}
+	var clause string
+	var args []interface{}
+	if clause, args, err = s.where(st, rq.filters); err != nil {
+		return
+	}
+	query := fmt.Sprintf("SELECT %s, \"data\" FROM %s%s ORDER BY %s", quoteIdent(st.id.name), quoteIdent(st.name), clause, quoteIdent(st.id.name))
+	if rq.reverse {
+		query += " DESC"
+	}
+	if rq.limit > 0 || rq.skip > 0 {
+		limit := rq.limit
+		if limit <= 0 {
+			limit = 1<<31 - 1
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, rq.skip)
+	}
+	var rows *sql.Rows
+	if rows, err = s.q.Query(s.dialect.rebind(query), args...); err != nil {
+		return
+	}
+	defer rows.Close()
+	ret := reflect.MakeSlice(l.Type(), 0, 0)
+	for rows.Next() {
+		v := reflect.New(st.typ).Elem()
+		if err = s.scan(st, rows, v); err != nil {
+			return
+		}
+		ret = reflect.Append(ret, v)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	l.Set(ret)
+	return
+}
+
+func (s sqlRecords) count(rq recordQuery, data interface{}) (c int, err error) {
+	defer transformSQLError(&err)
+	var st *sqlTable
+	if st, err = s.table(reflect.TypeOf(data).Elem()); err != nil {
+		return
+	}
+	var clause string
+	var args []interface{}
+	if clause, args, err = s.where(st, rq.filters); err != nil {
+		return
+	}
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s%s", quoteIdent(st.name), clause)
+	err = s.q.QueryRow(s.dialect.rebind(query), args...).Scan(&c)
+	return
+}
+
+func (s sqlRecords) save(data interface{}) (err error) {
+	defer transformSQLError(&err)
+	v := reflect.ValueOf(data).Elem()
+	var st *sqlTable
+	if st, err = s.table(v.Type()); err != nil {
+		return
+	}
+	var buf []byte
+	if buf, err = json.Marshal(data); err != nil {
+		return
+	}
+	var names, holders, sets []string
+	var args []interface{}
+	for _, c := range st.columns {
+		names, holders = append(names, quoteIdent(c.name)), append(holders, "?")
+		sets = append(sets, quoteIdent(c.name)+" = ?")
+		args = append(args, v.Field(c.index).Interface())
+	}
+	names, holders = append(names, `"data"`), append(holders, "?")
+	sets = append(sets, `"data" = ?`)
+	args = append(args, string(buf))
+	idValue := v.Field(st.id.index)
+	if !st.id.increment || idValue.Int() != 0 {
+		// update existing record, or insert with the id, upsert is avoided since sqlite checks unique indexes before the conflict target
+		var res sql.Result
+		query := fmt.Sprintf("UPDATE %s SET %s WHERE %s = ?", quoteIdent(st.name), strings.Join(sets, ", "), quoteIdent(st.id.name))
+		if res, err = s.q.Exec(s.dialect.rebind(query), append(args, idValue.Interface())...); err != nil {
+			return
+		}
+		var n int64
+		if n, err = res.RowsAffected(); err != nil || n > 0 {
+			return
+		}
+		query = fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, %s)", quoteIdent(st.name), quoteIdent(st.id.name), strings.Join(names, ", "), strings.Join(holders, ", "))
+		_, err = s.q.Exec(s.dialect.rebind(query), append([]interface{}{idValue.Interface()}, args...)...)
+		return
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdent(st.name), strings.Join(names, ", "), strings.Join(holders, ", "))
+	// assign increment id
+	var id int64
+	if s.dialect.returning {
+		query += " RETURNING " + quoteIdent(st.id.name)
+		if err = s.q.QueryRow(s.dialect.rebind(query), args...).Scan(&id); err != nil {
+			return
+		}
+	} else {
+		var res sql.Result
+		if res, err = s.q.Exec(s.dialect.rebind(query), args...); err != nil {
+			return
+		}
+		if id, err = res.LastInsertId(); err != nil {
+			return
+		}
+	}
+	idValue.SetInt(id)
+	return
+}
+
+func (s sqlRecords) delete(data interface{}) (err error) {
+	defer transformSQLError(&err)
+	v := reflect.ValueOf(data).Elem()
+	var st *sqlTable
+	if st, err = s.table(v.Type()); err != nil {
+		return
+	}
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s = ?", quoteIdent(st.name), quoteIdent(st.id.name))
+	_, err = s.q.Exec(s.dialect.rebind(query), v.Field(st.id.index).Interface())
+	return
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func quoteIdent(name string) string {
+	return `"` + name + `"`
+}
+
+// snakeCase convert field name to snake case, "SessionId" to "session_id"
+func snakeCase(s string) string {
+	buf := &bytes.Buffer{}
+	for i, c := range s {
+		if unicode.IsUpper(c) {
+			if i > 0 {
+				buf.WriteRune('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		buf.WriteRune(c)
+	}
+	return buf.String()
+}
@@ -0,0 +1,541 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/index"
+	"github.com/asdine/storm/q"
+	"github.com/coreos/bbolt"
+	"github.com/yankeguo/bastion/daemon/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func transformStormError(err *error) {
+	if err == nil {
+	} else if *err == nil {
+	} else if *err == storm.ErrNotFound {
+		*err = errRecordNotFound
+	} else if _, ok := status.FromError(*err); ok {
+	} else {
+		*err = status.Error(codes.Internal, (*err).Error())
+	}
+	return
+}
+
+// stormStore Store backed by a bolt file, using storm
+type stormStore struct {
+	repositories
+	db *storm.DB
+}
+
+func openStormStore(file string) (s *stormStore, err error) {
+	// ensure database directory
+	os.MkdirAll(filepath.Dir(file), 0750)
+	// open db
+	var db *storm.DB
+	if db, err = storm.Open(file); err != nil {
+		return
+	}
+	// migrate database
+	for _, m := range models.AllModels {
+		if err = db.Init(m); err != nil {
+			db.Close()
+			return
+		}
+	}
+	if err = migrateStormIndexes(db); err != nil {
+		db.Close()
+		return
+	}
+	s = &stormStore{repositories: repositories{s: stormRecords{node: db, db: db.Bolt}}, db: db}
+	return
+}
+
+const stormMigrationsBucket = "__bastion_migrations"
+
+// stormIndexMigrations models with indexes added after records were saved, existing records are re-indexed once
+var stormIndexMigrations = []struct {
+	name  string
+	model interface{}
+}{
+	{name: "session-indexes-v1", model: &models.Session{}},
+	{name: "session-indexes-v2", model: &models.Session{}},
+}
+
+func migrateStormIndexes(db *storm.DB) (err error) {
+	for _, m := range stormIndexMigrations {
+		var done bool
+		if err = db.Get(stormMigrationsBucket, m.name, &done); err != nil && err != storm.ErrNotFound {
+			return
+		}
+		if done {
+			continue
+		}
+		if err = db.ReIndex(m.model); err != nil {
+			return
+		}
+		if err = db.Set(stormMigrationsBucket, m.name, true); err != nil {
+			return
+		}
+	}
+	err = nil
+	return
+}
+
+func (s *stormStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *stormStore) Tx(writable bool, cb func(Repositories) error) (err error) {
+	var tx *bolt.Tx
+	if tx, err = s.db.Bolt.Begin(writable); err != nil {
+		transformStormError(&err)
+		return
+	}
+	defer tx.Rollback()
+	if err = cb(repositories{s: stormRecords{node: s.db.WithTransaction(tx), tx: tx}}); err != nil {
+		return
+	}
+	if writable {
+		if err = tx.Commit(); err != nil {
+			transformStormError(&err)
+			return
+		}
+	}
+	return
+}
+
+func (s *stormStore) Export(cb func(record interface{}) error) error {
+	return s.Tx(false, func(db Repositories) error {
+		return exportRecords(db.(repositories).s, cb)
+	})
+}
+
+// WriteTo write a consistent snapshot of the whole database
+func (s *stormStore) WriteTo(w io.Writer) (n int64, err error) {
+	err = s.db.Bolt.View(func(tx *bolt.Tx) (err error) {
+		n, err = tx.WriteTo(w)
+		return
+	})
+	transformStormError(&err)
+	return
+}
+
+func (s *stormStore) Import(records []interface{}, cb func(db Repositories) error) (err error) {
+	err = s.db.Bolt.Update(func(tx *bolt.Tx) (err error) {
+		rs := stormRecords{node: s.db.WithTransaction(tx), tx: tx}
+		for _, r := range records {
+			if err = importRecord(rs, r); err != nil {
+				return
+			}
+			if err = raiseIncrementCounter(tx, r); err != nil {
+				return
+			}
+		}
+		if cb != nil {
+			err = cb(repositories{s: rs})
+		}
+		return
+	})
+	transformStormError(&err)
+	return
+}
+
+// raiseIncrementCounter raise the storm increment counter of the record bucket to id of the record
+func raiseIncrementCounter(tx *bolt.Tx, data interface{}) (err error) {
+	v := reflect.Indirect(reflect.ValueOf(data))
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("storm")
+		if !strings.HasPrefix(tag, "id") || !strings.Contains(tag, "increment") {
+			continue
+		}
+		// storm keeps counters in the metadata bucket of every model bucket, as big endian int64
+		b := tx.Bucket([]byte(t.Name()))
+		if b == nil {
+			return
+		}
+		if b = b.Bucket([]byte("__storm_metadata")); b == nil {
+			return
+		}
+		key := []byte(f.Name + "counter")
+		id := v.Field(i).Int()
+		if raw := b.Get(key); raw != nil {
+			var counter int64
+			if err = binary.Read(bytes.NewReader(raw), binary.BigEndian, &counter); err != nil {
+				return
+			}
+			if counter >= id {
+				return
+			}
+		}
+		buf := &bytes.Buffer{}
+		binary.Write(buf, binary.BigEndian, id)
+		return b.Put(key, buf.Bytes())
+	}
+	return
+}
+
+// stormRecords recordStore on a storm node, either the database or a transaction
+type stormRecords struct {
+	node storm.Node
+	// db bolt database of the node, used if not in a transaction
+	db *bolt.DB
+	// tx bolt transaction of the node, nil if not in a transaction
+	tx *bolt.Tx
+}
+
+// view run fn in the transaction of the node, or in a new read-only transaction
+func (s stormRecords) view(fn func(tx *bolt.Tx) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+	return s.db.View(fn)
+}
+
+func (s stormRecords) one(field string, value interface{}, to interface{}) (err error) {
+	err = s.node.One(field, value, to)
+	transformStormError(&err)
+	return
+}
+
+func (s stormRecords) options(rq recordQuery) []func(*index.Options) {
+	var opts []func(*index.Options)
+	if rq.reverse {
+		opts = append(opts, storm.Reverse())
+	}
+	if rq.skip > 0 {
+		opts = append(opts, storm.Skip(rq.skip))
+	}
+	if rq.limit > 0 {
+		opts = append(opts, storm.Limit(rq.limit))
+	}
+	return opts
+}
+
+func (s stormRecords) matchers(rq recordQuery) []q.Matcher {
+	ms := make([]q.Matcher, 0, len(rq.filters))
+	for _, f := range rq.filters {
+		switch f.op {
+		case recordOpGte:
+			ms = append(ms, q.Gte(f.field, f.value))
+		case recordOpLt:
+			ms = append(ms, q.Lt(f.field, f.value))
+		case recordOpContains:
+			ms = append(ms, q.Re(f.field, "(?i)"+regexp.QuoteMeta(f.value.(string))))
+		default:
+			ms = append(ms, q.Eq(f.field, f.value))
+		}
+	}
+	return ms
+}
+
+// stormIndexScan range of an index to find candidates of a query
+type stormIndexScan struct {
+	field    string
+	eq       bool
+	value    interface{}
+	min, max int64
+}
+
+// isStormIndexed check if the field of model is indexed by storm
+func isStormIndexed(t reflect.Type, field string) bool {
+	f, ok := t.FieldByName(field)
+	if !ok {
+		return false
+	}
+	tag := f.Tag.Get("storm")
+	return tag == "index" || tag == "unique"
+}
+
+// indexScan choose the index to scan for candidates of a query, equality of a non-zero value is preferred,
+// then range of an int64 field, then equality of any value, false if none of the filters is indexed
+func (s stormRecords) indexScan(rq recordQuery, t reflect.Type) (is stormIndexScan, ok bool) {
+	var rng, eq *stormIndexScan
+	for _, f := range rq.filters {
+		if !isStormIndexed(t, f.field) {
+			continue
+		}
+		switch f.op {
+		case recordOpEq:
+			if v := reflect.ValueOf(f.value); v.Kind() != reflect.Bool && v.Interface() != reflect.Zero(v.Type()).Interface() {
+				return stormIndexScan{field: f.field, eq: true, value: f.value}, true
+			}
+			if eq == nil {
+				eq = &stormIndexScan{field: f.field, eq: true, value: f.value}
+			}
+		case recordOpGte, recordOpLt:
+			v, isInt := f.value.(int64)
+			if !isInt || (rng != nil && rng.field != f.field) {
+				continue
+			}
+			if rng == nil {
+				rng = &stormIndexScan{field: f.field, max: math.MaxInt64}
+			}
+			// storm ranges are inclusive, exact bounds are matched later
+			if f.op == recordOpGte {
+				rng.min = v
+			} else {
+				rng.max = v
+			}
+		}
+	}
+	if rng != nil {
+		return *rng, true
+	}
+	if eq != nil {
+		return *eq, true
+	}
+	return
+}
+
+// scan find all records matching the query in id order, candidates are found by index and matched against all filters,
+// records are fully scanned only if none of the filters is indexed
+func (s stormRecords) scan(rq recordQuery, t reflect.Type) (rs reflect.Value, err error) {
+	rs = reflect.New(reflect.SliceOf(t))
+	is, ok := s.indexScan(rq, t)
+	if !ok {
+		err = s.node.Select(s.matchers(rq)...).Find(rs.Interface())
+	} else if is.eq {
+		err = s.node.Find(is.field, is.value, rs.Interface())
+	} else {
+		err = s.node.Range(is.field, is.min, is.max, rs.Interface())
+	}
+	if err == storm.ErrNotFound {
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+	rs = rs.Elem()
+	if !ok {
+		return
+	}
+	m := q.And(s.matchers(rq)...)
+	matched := reflect.MakeSlice(rs.Type(), 0, rs.Len())
+	for i := 0; i < rs.Len(); i++ {
+		var yes bool
+		if yes, err = m.Match(rs.Index(i).Addr().Interface()); err != nil {
+			return
+		}
+		if yes {
+			matched = reflect.Append(matched, rs.Index(i))
+		}
+	}
+	// records found by index are ordered by the indexed value
+	id := stormIDField(t)
+	sort.SliceStable(matched.Interface(), func(i, j int) bool {
+		vi, vj := matched.Index(i).Field(id), matched.Index(j).Field(id)
+		if vi.Kind() == reflect.String {
+			return vi.String() < vj.String()
+		}
+		return vi.Int() < vj.Int()
+	})
+	rs = matched
+	return
+}
+
+// stormIndexKey key of a value in storm list index, only non-zero strings and int64 are supported
+func stormIndexKey(value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), len(v) > 0
+	case int64:
+		return stormIDKey(nil, v), v != 0
+	}
+	return nil, false
+}
+
+// stormIDKey append big endian int64 id to prefix, the same as storm encodes ids in keys
+func stormIDKey(prefix []byte, id int64) []byte {
+	k := make([]byte, len(prefix)+8)
+	copy(k, prefix)
+	binary.BigEndian.PutUint64(k[len(prefix):], uint64(id))
+	return k
+}
+
+// walk find a page of records matching the query by walking int64 ids in query order, starting from the id bound
+// of the filters, ids are walked in the index of an equality filter if any, the walk stops once the page is full
+func (s stormRecords) walk(rq recordQuery, t reflect.Type) (rs reflect.Value, err error) {
+	rs = reflect.MakeSlice(reflect.SliceOf(t), 0, rq.limit)
+	idField := t.Field(stormIDField(t)).Name
+	// min inclusive, max exclusive
+	min, max := int64(0), int64(math.MaxInt64)
+	var indexed string
+	var prefix []byte
+	for _, f := range rq.filters {
+		if f.field == idField {
+			if v, ok := f.value.(int64); ok && f.op == recordOpGte && v > min {
+				min = v
+			} else if ok && f.op == recordOpLt && v < max {
+				max = v
+			}
+			continue
+		}
+		if f.op != recordOpEq || len(indexed) > 0 || !isStormIndexed(t, f.field) {
+			continue
+		}
+		if v, ok := stormIndexKey(f.value); ok {
+			// storm list index keys are value, "__" and id
+			indexed, prefix = f.field, append(v, '_', '_')
+		}
+	}
+	if min >= max {
+		return
+	}
+	m := q.And(s.matchers(rq)...)
+	skip := rq.skip
+	err = s.view(func(tx *bolt.Tx) (err error) {
+		b := tx.Bucket([]byte(t.Name()))
+		if b == nil {
+			return
+		}
+		kb := b
+		if len(indexed) > 0 {
+			if kb = b.Bucket([]byte("__storm_index_" + indexed)); kb == nil {
+				return
+			}
+		}
+		c := kb.Cursor()
+		var k, v []byte
+		next := c.Next
+		if rq.reverse {
+			next = c.Prev
+			if k, _ = c.Seek(stormIDKey(prefix, max)); k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		} else {
+			k, v = c.Seek(stormIDKey(prefix, min))
+		}
+		for ; k != nil && bytes.HasPrefix(k, prefix); k, v = next() {
+			// nested buckets and keys of longer values sharing the prefix
+			if v == nil || len(k) != len(prefix)+8 {
+				continue
+			}
+			if id := int64(binary.BigEndian.Uint64(k[len(prefix):])); id < min || id >= max {
+				break
+			}
+			if len(indexed) > 0 {
+				if v = b.Get(v); v == nil {
+					continue
+				}
+			}
+			r := reflect.New(t)
+			if err = s.node.Codec().Unmarshal(v, r.Interface()); err != nil {
+				return
+			}
+			var yes bool
+			if yes, err = m.Match(r.Interface()); err != nil {
+				return
+			}
+			if !yes {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if rs = reflect.Append(rs, r.Elem()); rq.limit > 0 && rs.Len() >= rq.limit {
+				break
+			}
+		}
+		return
+	})
+	return
+}
+
+// stormIDField index of the id field of model
+func stormIDField(t reflect.Type) int {
+	for i := 0; i < t.NumField(); i++ {
+		if strings.HasPrefix(t.Field(i).Tag.Get("storm"), "id") {
+			return i
+		}
+	}
+	return 0
+}
+
+func (s stormRecords) find(rq recordQuery, to interface{}) (err error) {
+	if len(rq.filters) == 0 {
+		err = s.node.All(to, s.options(rq)...)
+		if err == storm.ErrNotFound {
+			err = nil
+		}
+		transformStormError(&err)
+		return
+	}
+	t := reflect.TypeOf(to).Elem().Elem()
+	var rs reflect.Value
+	// pages of int64 id models are walked in id order, instead of loading all candidates
+	if rq.limit > 0 && t.Field(stormIDField(t)).Type.Kind() == reflect.Int64 {
+		if rs, err = s.walk(rq, t); err != nil {
+			transformStormError(&err)
+			return
+		}
+		reflect.ValueOf(to).Elem().Set(rs)
+		return
+	}
+	if rs, err = s.scan(rq, t); err != nil {
+		transformStormError(&err)
+		return
+	}
+	n := rs.Len()
+	ret := reflect.MakeSlice(rs.Type(), 0, n)
+	for i := 0; i < n; i++ {
+		if i < rq.skip {
+			continue
+		}
+		if rq.limit > 0 && ret.Len() >= rq.limit {
+			break
+		}
+		if rq.reverse {
+			ret = reflect.Append(ret, rs.Index(n-1-i))
+		} else {
+			ret = reflect.Append(ret, rs.Index(i))
+		}
+	}
+	reflect.ValueOf(to).Elem().Set(ret)
+	return
+}
+
+func (s stormRecords) count(rq recordQuery, data interface{}) (c int, err error) {
+	if len(rq.filters) == 0 {
+		c, err = s.node.Count(data)
+		transformStormError(&err)
+		return
+	}
+	var rs reflect.Value
+	if rs, err = s.scan(rq, reflect.TypeOf(data).Elem()); err != nil {
+		transformStormError(&err)
+		return
+	}
+	c = rs.Len()
+	return
+}
+
+func (s stormRecords) save(data interface{}) (err error) {
+	err = s.node.Save(data)
+	transformStormError(&err)
+	return
+}
+
+func (s stormRecords) delete(data interface{}) (err error) {
+	if err = s.node.DeleteStruct(data); err == storm.ErrNotFound {
+		err = nil
+	}
+	transformStormError(&err)
+	return
+}
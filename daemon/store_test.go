@@ -0,0 +1,292 @@
+package daemon
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+)
+
+// postgresTestDSN connection string of postgres for tests in a new schema, from BASTION_TEST_POSTGRES like
+// "postgres://bastion@127.0.0.1/bastion_test?sslmode=disable", tests against postgres are skipped if empty
+func postgresTestDSN(t *testing.T) (dsn string, cleanup func()) {
+	base := os.Getenv("BASTION_TEST_POSTGRES")
+	if len(base) == 0 {
+		t.Skip("BASTION_TEST_POSTGRES not set")
+	}
+	db, err := sql.Open(types.DBDriverPostgres, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	schema := "bnktest" + hex.EncodeToString(buf)
+	if _, err = db.Exec("CREATE SCHEMA " + schema); err != nil {
+		db.Close()
+		t.Fatal(err)
+	}
+	cleanup = func() {
+		db.Exec("DROP SCHEMA " + schema + " CASCADE")
+		db.Close()
+	}
+	if strings.Contains(base, "://") {
+		sep := "?"
+		if strings.Contains(base, "?") {
+			sep = "&"
+		}
+		dsn = base + sep + "search_path=" + schema
+	} else {
+		dsn = base + " search_path=" + schema
+	}
+	return
+}
+
+func withStores(t *testing.T, cb func(*testing.T, Store)) {
+	for _, driver := range []string{types.DBDriverBolt, types.DBDriverSQLite, types.DBDriverPostgres} {
+		t.Run(driver, func(t *testing.T) {
+			dsn := temporaryFile()
+			defer os.Remove(dsn)
+			if driver == types.DBDriverPostgres {
+				var cleanup func()
+				dsn, cleanup = postgresTestDSN(t)
+				defer cleanup()
+			}
+			s, err := OpenStore(types.DaemonOptions{DBDriver: driver, DB: dsn})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			cb(t, s)
+		})
+	}
+}
+
+func TestStore_ConcurrentTx(t *testing.T) {
+	withStores(t, func(t *testing.T, s Store) {
+		if err := s.Users().Save(&models.User{Account: "test1"}); err != nil {
+			t.Fatal(err)
+		}
+		// concurrent read-modify-write, serialization failures of postgres are retried
+		wg := &sync.WaitGroup{}
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- s.Tx(true, func(db Repositories) (err error) {
+					var u models.User
+					if u, err = db.Users().Get("test1"); err != nil {
+						return
+					}
+					u.Nickname += "x"
+					return db.Users().Save(&u)
+				})
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if u, err := s.Users().Get("test1"); err != nil || u.Nickname != "xxxxxxxxxx" {
+			t.Fatal("bad concurrent updates", u.Nickname, err)
+		}
+	})
+}
+
+func TestStore_Repositories(t *testing.T) {
+	withStores(t, func(t *testing.T, s Store) {
+		if _, err := s.Users().Get("test1"); err != errRecordNotFound {
+			t.Fatal("should not found", err)
+		}
+		s.Users().Save(&models.User{Account: "test1", Nickname: "Test 1"})
+		s.Users().Save(&models.User{Account: "test2"})
+		u, err := s.Users().Get("test1")
+		if err != nil || u.Nickname != "Test 1" {
+			t.Fatal("bad user", u, err)
+		}
+		u.Nickname = "Test One"
+		s.Users().Save(&u)
+		if us, err := s.Users().List(); err != nil || len(us) != 2 || us[0].Nickname != "Test One" {
+			t.Fatal("bad users", us, err)
+		}
+
+		s.Keys().Save(&models.Key{Fingerprint: "fp1", Account: "test1", Source: types.KeySourceSandbox})
+		s.Keys().Save(&models.Key{Fingerprint: "fp2", Account: "test1", Source: types.KeySourceManual})
+		s.Keys().Save(&models.Key{Fingerprint: "fp3", Account: "test2", Source: types.KeySourceSandbox})
+		if err = s.Keys().DeleteByAccountAndSource("test1", types.KeySourceSandbox); err != nil {
+			t.Fatal(err)
+		}
+		if ks, err := s.Keys().ListByAccount("test1"); err != nil || len(ks) != 1 || ks[0].Fingerprint != "fp2" {
+			t.Fatal("bad keys", ks, err)
+		}
+		if err = s.Keys().Delete("fp-missing"); err != nil {
+			t.Fatal("should ignore missing record", err)
+		}
+
+		for i := 0; i < 5; i++ {
+			ss := models.Session{Account: "test1", Command: fmt.Sprintf("Cmd_%d 100%%", i), CreatedAt: int64(i * 100), IsRecorded: i%2 == 0}
+			if err = s.Sessions().Save(&ss); err != nil || ss.Id != int64(i+1) {
+				t.Fatal("bad session id", ss.Id, err)
+			}
+		}
+		if c, err := s.Sessions().Count(); err != nil || c != 5 {
+			t.Fatal("bad count", c, err)
+		}
+		if ss, total, err := s.Sessions().Query(SessionQuery{Skip: 1, Limit: 2}); err != nil || total != 5 || len(ss) != 2 || ss[0].Id != 4 || ss[1].Id != 3 {
+			t.Fatal("bad sessions", ss, err)
+		}
+		if ss, total, err := s.Sessions().Query(SessionQuery{OnlyRecorded: true}); err != nil || total != 3 || len(ss) != 3 || ss[0].Id != 5 {
+			t.Fatal("bad recorded sessions", ss, total, err)
+		}
+		if ss, total, err := s.Sessions().Query(SessionQuery{Command: "cmd_3 100%", Since: 100, Until: 400}); err != nil || total != 1 || len(ss) != 1 || ss[0].Id != 4 {
+			t.Fatal("bad sessions by command", ss, total, err)
+		}
+		if ss, total, err := s.Sessions().Query(SessionQuery{Command: "cmd%3"}); err != nil || total != 0 || len(ss) != 0 {
+			t.Fatal("like wildcards should be escaped", ss, total, err)
+		}
+		if ss, total, err := s.Sessions().Query(SessionQuery{Account: "test1", OnlyActive: true, Before: 3}); err != nil || total != 0 || len(ss) != 2 || ss[0].Id != 2 {
+			t.Fatal("bad sessions before cursor, total is only counted for the first page", ss, total, err)
+		}
+		// sessions found by time range are still ordered by id
+		s.Sessions().Save(&models.Session{Account: "test2", CreatedAt: 50})
+		if ss, total, err := s.Sessions().Query(SessionQuery{Since: 50, Until: 250, Limit: 2}); err != nil || total != 3 || len(ss) != 2 || ss[0].Id != 6 || ss[1].Id != 3 {
+			t.Fatal("bad sessions by time range", ss, total, err)
+		}
+		if ss, total, err := s.Sessions().Query(SessionQuery{Account: "test1", Since: 50, Skip: 1}); err != nil || total != 4 || len(ss) != 3 || ss[0].Id != 4 {
+			t.Fatal("bad sessions by account and time range", ss, total, err)
+		}
+		// pages after the cursor
+		for _, c := range []struct {
+			q   SessionQuery
+			ids []int64
+		}{
+			{q: SessionQuery{Before: 5, Limit: 2}, ids: []int64{4, 3}},
+			{q: SessionQuery{Account: "test1", Before: 5, Limit: 2}, ids: []int64{4, 3}},
+			{q: SessionQuery{Account: "test1", Before: 3, Limit: 5}, ids: []int64{2, 1}},
+			{q: SessionQuery{Account: "test1", OnlyRecorded: true, Before: 5, Skip: 1, Limit: 5}, ids: []int64{1}},
+			{q: SessionQuery{Account: "test2", Before: 6, Limit: 5}, ids: []int64{}},
+			{q: SessionQuery{Until: 250, Before: 7, Limit: 3}, ids: []int64{6, 3, 2}},
+			{q: SessionQuery{After: 3, Before: 6, Limit: 5}, ids: []int64{5, 4, 3}},
+			{q: SessionQuery{Account: "test1", After: 4, Limit: 5}, ids: []int64{5, 4}},
+		} {
+			check := func(db Repositories) error {
+				ss, _, err := db.Sessions().Query(c.q)
+				if err != nil || len(ss) != len(c.ids) {
+					t.Fatal("bad sessions of page", c.q, ss, err)
+				}
+				for i, id := range c.ids {
+					if ss[i].Id != id {
+						t.Fatal("bad sessions of page", c.q, ss)
+					}
+				}
+				return nil
+			}
+			check(s)
+			if err = s.Tx(false, check); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		tk := models.Token{Account: "test1", Token: "secret"}
+		s.Tokens().Save(&tk)
+		if tk2, err := s.Tokens().GetByToken("secret"); err != nil || tk2.Id != tk.Id {
+			t.Fatal("bad token", tk2, err)
+		}
+
+		s.AuditEvents().Append(&models.AuditEvent{Actor: "test1", Action: "a", CreatedAt: 100})
+		s.AuditEvents().Append(&models.AuditEvent{Actor: "test1", Action: "b", CreatedAt: 200})
+		s.AuditEvents().Append(&models.AuditEvent{Actor: "test2", Action: "a", CreatedAt: 300})
+		es, total, err := s.AuditEvents().Query(AuditEventQuery{Actor: "test1", Since: 100, Until: 300, Limit: 1})
+		if err != nil || total != 2 || len(es) != 1 || es[0].Action != "b" {
+			t.Fatal("bad audit events", es, total, err)
+		}
+		// existing audit events are never overwritten
+		es[0].Action = "c"
+		if err = s.AuditEvents().Append(&es[0]); err == nil {
+			t.Fatal("audit event should not be overwritten")
+		}
+	})
+}
+
+func TestStore_Tx(t *testing.T) {
+	withStores(t, func(t *testing.T, s Store) {
+		errAbort := errRecordNotFound
+		if err := s.Tx(true, func(db Repositories) error {
+			db.Users().Save(&models.User{Account: "test1"})
+			if _, err := db.Users().Get("test1"); err != nil {
+				t.Fatal("should see changes in transaction", err)
+			}
+			return errAbort
+		}); err != errAbort {
+			t.Fatal("should return error of callback", err)
+		}
+		if _, err := s.Users().Get("test1"); err != errRecordNotFound {
+			t.Fatal("should discard changes", err)
+		}
+	})
+}
+
+func TestStore_ImportMigrate(t *testing.T) {
+	withStores(t, func(t *testing.T, s Store) {
+		s.Users().Save(&models.User{Account: "test1"})
+		if err := s.Import([]interface{}{
+			&models.Session{Id: 10, Account: "test1"},
+			&models.Grant{Id: "test1$*$root", Account: "test1", HostnamePattern: "*", User: "root"},
+			&models.AuditEvent{Id: 5, Action: types.AuditActionUserCreate},
+		}, func(db Repositories) error {
+			return db.AuditEvents().Append(&models.AuditEvent{Action: types.AuditActionNodePut})
+		}); err != nil {
+			t.Fatal(err)
+		}
+		ss := models.Session{Account: "test1"}
+		if err := s.Sessions().Save(&ss); err != nil || ss.Id != 11 {
+			t.Fatal("bad session id after import", ss.Id, err)
+		}
+		if es, _, err := s.AuditEvents().Query(AuditEventQuery{}); err != nil || len(es) != 2 || es[0].Id != 6 || es[0].Action != types.AuditActionNodePut {
+			t.Fatal("bad audit events after import", es, err)
+		}
+		if err := s.Import([]interface{}{
+			&models.AuditEvent{Id: 5, Action: types.AuditActionUserUpdate},
+		}, nil); err != errAuditEventAppendOnly {
+			t.Fatal("should not overwrite audit event", err)
+		}
+
+		for _, driver := range []string{types.DBDriverBolt, types.DBDriverSQLite} {
+			file := temporaryFile()
+			dst, err := OpenStore(types.DaemonOptions{DBDriver: driver, DB: file})
+			if err != nil {
+				t.Fatal(err)
+			}
+			count, err := Migrate(s, dst)
+			if err != nil || count != 6 {
+				t.Fatal("bad migrated count", count, err)
+			}
+			if gs, err := dst.Grants().ListByAccount("test1"); err != nil || len(gs) != 1 {
+				t.Fatal("bad migrated grants", gs, err)
+			}
+			if _, err = Migrate(s, dst); err == nil {
+				t.Fatal("should not migrate to non-empty database")
+			}
+			// reopening migrates schema again
+			dst.Close()
+			if dst, err = OpenStore(types.DaemonOptions{DBDriver: driver, DB: file}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = dst.Users().Get("test1"); err != nil {
+				t.Fatal("bad reopened database", err)
+			}
+			dst.Close()
+			os.Remove(file)
+		}
+	})
+}
@@ -1,9 +1,16 @@
 package daemon
 
 import (
+	"github.com/rs/zerolog/log"
 	"github.com/yankeguo/bastion/daemon/models"
 	"github.com/yankeguo/bastion/types"
 	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+const (
+	tokenSweepInterval = time.Minute
 )
 
 func (d *Daemon) CreateToken(c context.Context, req *types.CreateTokenRequest) (res *types.CreateTokenResponse, err error) {
@@ -11,23 +18,41 @@ func (d *Daemon) CreateToken(c context.Context, req *types.CreateTokenRequest) (
 		return
 	}
 	t := models.Token{}
-	if err = d.db.Tx(true, func(db *Node) (err error) {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
 		var u models.User
-		if err = db.One("Account", req.Account, &u); err != nil {
+		if u, err = db.Users().Get(req.Account); err != nil {
 			return
 		}
 		if u.IsBlocked {
 			err = errUserBlocked
 			return
 		}
+		if req.Kind == types.TokenKindPersonal {
+			var ts []models.Token
+			if ts, err = db.Tokens().ListByAccount(u.Account); err != nil {
+				return
+			}
+			for _, et := range ts {
+				if et.Kind == types.TokenKindPersonal && et.Name == req.Name {
+					err = errDuplicatedField("name")
+					return
+				}
+			}
+		}
 		t.Account = u.Account
 		t.Description = req.Description
+		t.Kind = req.Kind
+		t.Name = req.Name
+		t.Scopes = req.Scopes
+		t.ExpiresAt = req.ExpiresAt
+		t.IdleTimeout = req.IdleTimeout
 		t.Token = newToken()
 		t.CreatedAt = now()
-		if err = db.Save(&t); err != nil {
+		if err = db.Tokens().Save(&t); err != nil {
 			return
 		}
-		return
+		// the secret is never audited
+		return d.audit(c, db, types.AuditActionTokenCreate, tokenTarget(t.Id), nil, t.ToGRPCTokenSecure())
 	}); err != nil {
 		return
 	}
@@ -41,14 +66,17 @@ func (d *Daemon) GetToken(c context.Context, req *types.GetTokenRequest) (res *t
 	}
 	t := models.Token{}
 	if len(req.Token) > 0 {
-		if err = d.db.One("Token", req.Token, &t); err != nil {
+		if t, err = d.db.Tokens().GetByToken(req.Token); err != nil {
 			return
 		}
 	} else {
-		if err = d.db.One("Id", req.Id, &t); err != nil {
+		if t, err = d.db.Tokens().Get(req.Id); err != nil {
 			return
 		}
 	}
+	if err = d.authorizeAccount(c, types.PermissionUsersRead, t.Account); err != nil {
+		return
+	}
 	res = &types.GetTokenResponse{Token: t.ToGRPCTokenSecure()}
 	return
 }
@@ -59,18 +87,18 @@ func (d *Daemon) TouchToken(c context.Context, req *types.TouchTokenRequest) (re
 	}
 
 	t := models.Token{}
-	if err = d.db.Tx(true, func(db *Node) (err error) {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
 		if len(req.Token) > 0 {
-			if err = db.One("Token", req.Token, &t); err != nil {
+			if t, err = db.Tokens().GetByToken(req.Token); err != nil {
 				return
 			}
 		} else {
-			if err = d.db.One("Id", req.Id, &t); err != nil {
+			if t, err = db.Tokens().Get(req.Id); err != nil {
 				return
 			}
 		}
 		t.ViewedAt = now()
-		if err = db.Save(&t); err != nil {
+		if err = db.Tokens().Save(&t); err != nil {
 			return
 		}
 		return
@@ -87,7 +115,7 @@ func (d *Daemon) ListTokens(c context.Context, req *types.ListTokensRequest) (re
 		return
 	}
 	var ts []models.Token
-	if err = d.db.Find("Account", req.Account, &ts); err != nil {
+	if ts, err = d.db.Tokens().ListByAccount(req.Account); err != nil {
 		return
 	}
 	ret := make([]*types.Token, 0, len(ts))
@@ -103,9 +131,62 @@ func (d *Daemon) DeleteToken(c context.Context, req *types.DeleteTokenRequest) (
 	if err = req.Validate(); err != nil {
 		return
 	}
-	if err = d.db.DeleteStruct(&models.Token{Id: req.Id}); err != nil {
+	t := models.Token{}
+	if t, err = d.db.Tokens().Get(req.Id); err != nil {
+		return
+	}
+	if err = d.authorizeAccount(c, types.PermissionUsersWrite, t.Account); err != nil {
+		return
+	}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var before models.Token
+		if before, err = db.Tokens().Get(req.Id); err != nil {
+			return
+		}
+		if err = db.Tokens().Delete(req.Id); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionTokenDelete, tokenTarget(req.Id), before.ToGRPCTokenSecure(), nil)
+	}); err != nil {
 		return
 	}
 	res = &types.DeleteTokenResponse{}
 	return
 }
+
+func tokenTarget(id int64) string {
+	return "token:" + strconv.FormatInt(id, 10)
+}
+
+// runTokenSweeper periodically delete expired tokens, stopped with the grant watcher
+func (d *Daemon) runTokenSweeper() {
+	t := time.NewTicker(tokenSweepInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-d.grantWatcher.Done():
+			return
+		case <-t.C:
+		}
+		d.sweepTokens()
+	}
+}
+
+func (d *Daemon) sweepTokens() {
+	ts, err := d.db.Tokens().List()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list tokens for sweeping")
+		return
+	}
+	n := now()
+	for _, t := range ts {
+		if !t.ToGRPCToken().IsExpired(n) {
+			continue
+		}
+		if err = d.db.Tokens().Delete(t.Id); err != nil {
+			log.Error().Err(err).Int64("id", t.Id).Msg("failed to delete expired token")
+			continue
+		}
+		log.Debug().Int64("id", t.Id).Str("account", t.Account).Msg("expired token deleted")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/yankeguo/bastion/types"
 	"google.golang.org/grpc"
+	"strings"
 	"testing"
 	"time"
 )
@@ -83,5 +84,88 @@ func TestDaemon_CreateGetTouchListDeleteToken(t *testing.T) {
 		if len(res4.Tokens) != 0 {
 			t.Fatal("failed 5")
 		}
+
+		// token is audited without the secret
+		as := types.NewAuditServiceClient(conn)
+		for _, action := range []string{types.AuditActionTokenCreate, types.AuditActionTokenDelete} {
+			res5, err := as.ListAuditEvents(context.Background(), &types.ListAuditEventsRequest{Action: action})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res5.AuditEvents) != 1 || res5.AuditEvents[0].Target != tokenTarget(id) {
+				t.Fatal("bad audit events", action, res5.AuditEvents)
+			}
+			e := res5.AuditEvents[0]
+			if len(e.Before+e.After) == 0 || strings.Contains(e.Before+e.After, token) {
+				t.Fatal("bad audit event", e)
+			}
+		}
+	})
+}
+
+func TestDaemon_PersonalTokenAndSweep(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		ts := types.NewTokenServiceClient(conn)
+		us := types.NewUserServiceClient(conn)
+
+		us.CreateUser(context.Background(), &types.CreateUserRequest{
+			Account:  "test",
+			Password: "qwertyqwerty",
+		})
+
+		res, err := ts.CreateToken(context.Background(), &types.CreateTokenRequest{Account: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Token.Kind != types.TokenKindSession || !res.Token.HasScope(types.TokenScopeAdmin) {
+			t.Fatal("session token should default to admin scope", res.Token)
+		}
+		if _, err = ts.CreateToken(context.Background(), &types.CreateTokenRequest{Account: "test", Kind: types.TokenKindPersonal, Scopes: []string{types.TokenScopeReplays}}); err == nil {
+			t.Fatal("personal token should require name")
+		}
+		if _, err = ts.CreateToken(context.Background(), &types.CreateTokenRequest{Account: "test", Kind: types.TokenKindPersonal, Name: "ci"}); err == nil {
+			t.Fatal("personal token should require scopes")
+		}
+		if _, err = ts.CreateToken(context.Background(), &types.CreateTokenRequest{Account: "test", Kind: types.TokenKindPersonal, Name: "ci", Scopes: []string{"unknown"}}); err == nil {
+			t.Fatal("unknown scope should be rejected")
+		}
+		res2, err := ts.CreateToken(context.Background(), &types.CreateTokenRequest{
+			Account: "test",
+			Kind:    types.TokenKindPersonal,
+			Name:    "ci",
+			Scopes:  []string{types.TokenScopeReadOnly, types.TokenScopeReplays},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res2.Token.HasScope(types.TokenScopeAdmin) || !res2.Token.HasScope(types.TokenScopeReplays) {
+			t.Fatal("bad scopes", res2.Token)
+		}
+		if _, err = ts.CreateToken(context.Background(), &types.CreateTokenRequest{Account: "test", Kind: types.TokenKindPersonal, Name: "ci", Scopes: []string{types.TokenScopeReplays}}); err == nil {
+			t.Fatal("duplicated name should be rejected")
+		}
+
+		// expired by expires_at and by idle timeout
+		n := time.Now().Unix()
+		if _, err = ts.CreateToken(context.Background(), &types.CreateTokenRequest{Account: "test", ExpiresAt: n - 1}); err != nil {
+			t.Fatal(err)
+		}
+		res3, err := ts.CreateToken(context.Background(), &types.CreateTokenRequest{Account: "test", IdleTimeout: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res3.Token.IsExpired(n) || !res3.Token.IsExpired(n+1) {
+			t.Fatal("bad idle timeout", res3.Token)
+		}
+		time.Sleep(time.Second * 2)
+		daemon.sweepTokens()
+
+		res4, err := ts.ListTokens(context.Background(), &types.ListTokensRequest{Account: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res4.Tokens) != 2 || res4.Tokens[0].Id != res.Token.Id || res4.Tokens[1].Id != res2.Token.Id {
+			t.Fatal("expired tokens should be swept", res4.Tokens)
+		}
 	})
 }
@@ -0,0 +1,45 @@
+package daemon
+
+import (
+	"github.com/jinzhu/copier"
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/net/context"
+)
+
+func (d *Daemon) CreateTransfer(c context.Context, req *types.CreateTransferRequest) (res *types.CreateTransferResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	t := models.Transfer{}
+	copier.Copy(&t, req)
+	t.CreatedAt = now()
+	if err = d.db.Transfers().Save(&t); err != nil {
+		return
+	}
+	res = &types.CreateTransferResponse{Transfer: t.ToGRPCTransfer()}
+	return
+}
+
+func (d *Daemon) ListTransfers(c context.Context, req *types.ListTransfersRequest) (res *types.ListTransfersResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var ts []models.Transfer
+	if req.SessionId != 0 {
+		ts, err = d.db.Transfers().ListBySession(req.SessionId)
+	} else if len(req.Account) > 0 {
+		ts, err = d.db.Transfers().ListByAccount(req.Account)
+	} else {
+		ts, err = d.db.Transfers().List()
+	}
+	if err != nil {
+		return
+	}
+	ret := make([]*types.Transfer, 0, len(ts))
+	for _, t := range ts {
+		ret = append(ret, t.ToGRPCTransfer())
+	}
+	res = &types.ListTransfersResponse{Transfers: ret}
+	return
+}
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"context"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+func TestDaemon_CreateListTransfers(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		ts := types.NewTransferServiceClient(conn)
+
+		_, err := ts.CreateTransfer(context.Background(), &types.CreateTransferRequest{
+			Account:   "test",
+			Source:    types.TransferSourceWeb,
+			Direction: types.TransferDirectionUpload,
+			Filename:  "/root/hello.txt",
+			Size:      5,
+			Sha256:    "bad",
+		})
+		if err == nil {
+			t.Fatal("failed 1")
+		}
+		res, err := ts.CreateTransfer(context.Background(), &types.CreateTransferRequest{
+			Account:   "test",
+			Source:    types.TransferSourceWeb,
+			Direction: types.TransferDirectionUpload,
+			Filename:  "/root/hello.txt",
+			Mode:      0644,
+			Size:      5,
+			Sha256:    "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Transfer.Id == 0 || res.Transfer.CreatedAt == 0 {
+			t.Fatal("failed 2")
+		}
+		if _, err = ts.CreateTransfer(context.Background(), &types.CreateTransferRequest{
+			Account:   "test2",
+			SessionId: 12,
+			Source:    types.TransferSourceSCP,
+			Direction: types.TransferDirectionDownload,
+			Filename:  "/root/hello.txt",
+			Size:      5,
+			Sha256:    "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		}); err != nil {
+			t.Fatal(err)
+		}
+		res2, err := ts.ListTransfers(context.Background(), &types.ListTransfersRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res2.Transfers) != 2 || res2.Transfers[0].Account != "test2" {
+			t.Fatal("failed 3")
+		}
+		res2, err = ts.ListTransfers(context.Background(), &types.ListTransfersRequest{Account: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res2.Transfers) != 1 || res2.Transfers[0].Mode != 0644 {
+			t.Fatal("failed 4")
+		}
+		res2, err = ts.ListTransfers(context.Background(), &types.ListTransfersRequest{SessionId: 12})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res2.Transfers) != 1 || res2.Transfers[0].Account != "test2" {
+			t.Fatal("failed 5")
+		}
+	})
+}
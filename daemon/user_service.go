@@ -18,7 +18,7 @@ var (
 
 func (d *Daemon) ListUsers(c context.Context, req *types.ListUsersRequest) (res *types.ListUsersResponse, err error) {
 	var users []models.User
-	if err = d.db.All(&users); err != nil {
+	if users, err = d.db.Users().List(); err != nil {
 		return
 	}
 	ret := make([]*types.User, 0, len(users))
@@ -37,9 +37,12 @@ func (d *Daemon) CreateUser(c context.Context, req *types.CreateUserRequest) (re
 
 	// inside a transaction
 	u := models.User{}
-	err = d.db.Tx(true, func(db *Node) (err error) {
+	err = d.db.Tx(true, func(db Repositories) (err error) {
 		// find existing
-		if err = db.CheckDuplicated("User", "account", req.Account); err != nil {
+		if _, err = db.Users().Get(req.Account); err == nil {
+			err = errDuplicatedField("account")
+			return
+		} else if err != errRecordNotFound {
 			return
 		}
 		// assign values
@@ -52,10 +55,10 @@ func (d *Daemon) CreateUser(c context.Context, req *types.CreateUserRequest) (re
 		// assign created_at / updated_at and save
 		u.CreatedAt = now()
 		u.UpdatedAt = u.CreatedAt
-		if err = db.Save(&u); err != nil {
+		if err = db.Users().Save(&u); err != nil {
 			return
 		}
-		return
+		return d.audit(c, db, types.AuditActionUserCreate, "user:"+u.Account, nil, u.ToGRPCUser())
 	})
 	// return if err != nil
 	if err != nil {
@@ -71,15 +74,15 @@ func (d *Daemon) TouchUser(c context.Context, req *types.TouchUserRequest) (res
 		return
 	}
 	u := models.User{}
-	if err = d.db.Tx(true, func(db *Node) (err error) {
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
 		// find by account
-		if err = db.One("Account", req.Account, &u); err != nil {
+		if u, err = db.Users().Get(req.Account); err != nil {
 			return
 		}
 		// update viewed_at
 		u.ViewedAt = now()
 		// save
-		if err = db.Save(&u); err != nil {
+		if err = db.Users().Save(&u); err != nil {
 			return
 		}
 		return
@@ -96,35 +99,54 @@ func (d *Daemon) UpdateUser(c context.Context, req *types.UpdateUserRequest) (re
 	if err = req.Validate(); err != nil {
 		return
 	}
-	// find user by account
-	u := models.User{}
-	if err = d.db.One("Account", req.Account, &u); err != nil {
-		return
-	}
-	// update user
-	if req.UpdateIsBlocked {
-		u.IsBlocked = req.IsBlocked
-		// clear password failed when unblocking a user
-		if !req.IsBlocked {
-			u.PasswordFailed = 0
-		}
-	}
-	if req.UpdateIsAdmin {
-		u.IsAdmin = req.IsAdmin
-	}
-	if req.UpdateNickname {
-		u.Nickname = req.Nickname
-	}
+	// generate password digest outside of transaction, bcrypt is slow
+	var digest string
 	if req.UpdatePassword {
-		if u.PasswordDigest, err = bcryptGenerate(req.Password); err != nil {
+		if digest, err = bcryptGenerate(req.Password); err != nil {
 			err = errInternal
 			return
 		}
 	}
-	// update updated_at
-	u.UpdatedAt = now()
-	// save
-	if err = d.db.Save(&u); err != nil {
+	u := models.User{}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		// find user by account
+		if u, err = db.Users().Get(req.Account); err != nil {
+			return
+		}
+		before := u.ToGRPCUser()
+		// update user
+		if req.UpdateIsBlocked {
+			u.IsBlocked = req.IsBlocked
+			// clear password failed when unblocking a user
+			if !req.IsBlocked {
+				u.PasswordFailed = 0
+			}
+		}
+		if req.UpdateIsAdmin {
+			u.IsAdmin = req.IsAdmin
+		}
+		if req.UpdateNickname {
+			u.Nickname = req.Nickname
+		}
+		if req.UpdateRoles {
+			u.Roles = req.Roles
+		}
+		if req.UpdatePassword {
+			u.PasswordDigest = digest
+		}
+		// update updated_at
+		u.UpdatedAt = now()
+		// save
+		if err = db.Users().Save(&u); err != nil {
+			return
+		}
+		// password digest is not included in audit values, mark the password change in action instead
+		action := types.AuditActionUserUpdate
+		if req.UpdatePassword {
+			action = types.AuditActionUserUpdatePassword
+		}
+		return d.audit(c, db, action, "user:"+u.Account, before, u.ToGRPCUser())
+	}); err != nil {
 		return
 	}
 	// build response
@@ -135,7 +157,7 @@ func (d *Daemon) UpdateUser(c context.Context, req *types.UpdateUserRequest) (re
 func (d *Daemon) AuthenticateUser(c context.Context, req *types.AuthenticateUserRequest) (res *types.AuthenticateUserResponse, err error) {
 	u := models.User{}
 	// find by account
-	if err = d.db.One("Account", req.Account, &u); err != nil {
+	if u, err = d.db.Users().Get(req.Account); err != nil {
 		return
 	}
 	// check blocked
@@ -153,14 +175,14 @@ func (d *Daemon) AuthenticateUser(c context.Context, req *types.AuthenticateUser
 			log.Debug().Str("account", u.Account).Int64("failed", u.PasswordFailed).Msg("blocked due to failed too much")
 			u.IsBlocked = true
 		}
-		d.db.Save(&u)
+		d.db.Users().Save(&u)
 		return
 	}
 	// clear PasswordFailed
 	if u.PasswordFailed > 0 {
 		u.PasswordFailed = 0
 		log.Debug().Str("account", u.Account).Int64("failed", u.PasswordFailed).Msg("failed cleared")
-		d.db.Save(&u)
+		d.db.Users().Save(&u)
 	}
 	// build response
 	res = &types.AuthenticateUserResponse{User: u.ToGRPCUser()}
@@ -172,9 +194,13 @@ func (d *Daemon) GetUser(c context.Context, req *types.GetUserRequest) (res *typ
 		return
 	}
 	u := models.User{}
-	if err = d.db.One("Account", req.Account, &u); err != nil {
+	if u, err = d.db.Users().Get(req.Account); err != nil {
+		return
+	}
+	var roles []string
+	if roles, err = d.effectiveRoles(u); err != nil {
 		return
 	}
-	res = &types.GetUserResponse{User: u.ToGRPCUser()}
+	res = &types.GetUserResponse{User: u.ToGRPCUser(), EffectiveRoles: roles}
 	return
 }
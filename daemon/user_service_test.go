@@ -82,7 +82,7 @@ func TestDaemon_TouchUser(t *testing.T) {
 			IsBlocked: true,
 		}
 		u.PasswordDigest, _ = bcryptGenerate("qwerty")
-		daemon.db.Save(&u)
+		daemon.db.Users().Save(&u)
 		res, err := c.TouchUser(context.Background(), &types.TouchUserRequest{
 			Account: "testuser",
 		})
@@ -150,7 +150,7 @@ func TestDaemon_AuthenticateUser(t *testing.T) {
 			Account: "testuser",
 		}
 		u.PasswordDigest, _ = bcryptGenerate("qwerty")
-		daemon.db.Save(&u)
+		daemon.db.Users().Save(&u)
 		_, err = c.AuthenticateUser(context.Background(), &types.AuthenticateUserRequest{
 			Account:  "testuser",
 			Password: "abcdef",
@@ -4,11 +4,9 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"encoding/hex"
-	"fmt"
 	"path/filepath"
 	"time"
 
-	"github.com/olivere/elastic"
 	"github.com/rs/zerolog/log"
 	"github.com/yankeguo/bastion/types"
 	"github.com/yankeguo/bastion/utils"
@@ -65,27 +63,57 @@ type ReplayIndice struct {
 	Timestamp uint32    `json:"timestamp"`
 	Content   string    `json:"content"`
 	Account   string    `json:"account"`
+	Hostnames []string  `json:"hostnames"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+func (r ReplayIndice) result() *types.ReplaySearchResult {
+	return &types.ReplaySearchResult{
+		SessionId: r.SessionId,
+		Timestamp: r.Timestamp,
+		Account:   r.Account,
+		Hostnames: r.Hostnames,
+		CreatedAt: r.CreatedAt.Unix(),
+	}
+}
+
+type CommandIndice struct {
+	SessionId int64     `json:"session_id"`
+	Timestamp uint32    `json:"timestamp"`
+	Command   string    `json:"command"`
+	Account   string    `json:"account"`
+	Hostnames []string  `json:"hostnames"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (c CommandIndice) result() *types.ReplaySearchResult {
+	return &types.ReplaySearchResult{
+		SessionId: c.SessionId,
+		Timestamp: c.Timestamp,
+		Account:   c.Account,
+		Hostnames: c.Hostnames,
+		CreatedAt: c.CreatedAt.Unix(),
+		Command:   c.Command,
+	}
+}
+
 type ReplaySubmitter struct {
 	Account   string
 	SessionId int64
 	CreatedAt time.Time
-	Index     string
-	EsClient  *elastic.Client
+	Hostnames []string
+	Search    SearchIndex
 	timestamp uint32
 	cache     string
 	batch     []ReplayIndice
 }
 
-func NewReplaySubmitter(createdAt time.Time, sessionId int64, account string, esClient *elastic.Client) (r *ReplaySubmitter) {
+func NewReplaySubmitter(createdAt time.Time, sessionId int64, account string, search SearchIndex) (r *ReplaySubmitter) {
 	r = &ReplaySubmitter{
 		SessionId: sessionId,
 		CreatedAt: createdAt,
 		Account:   account,
-		Index:     fmt.Sprintf("%s%04d-%02d-%02d", types.ReplayElasticsearchIndexPrefix, createdAt.Year(), createdAt.Month(), createdAt.Day()),
-		EsClient:  esClient,
+		Search:    search,
 		batch:     []ReplayIndice{},
 	}
 	return
@@ -95,11 +123,7 @@ func (r *ReplaySubmitter) submitBatch() (err error) {
 	if len(r.batch) == 0 {
 		return
 	}
-	bulk := r.EsClient.Bulk()
-	for _, d := range r.batch {
-		bulk = bulk.Add(elastic.NewBulkIndexRequest().Index(r.Index).Type("_doc").Doc(d))
-	}
-	_, err = bulk.Do(context.Background())
+	err = r.Search.IndexContents(r.batch)
 	r.batch = []ReplayIndice{}
 	return
 }
@@ -120,6 +144,7 @@ func (r *ReplaySubmitter) Add(f types.ReplayFrame) (err error) {
 			Content:   r.cache,
 			CreatedAt: r.CreatedAt,
 			Account:   r.Account,
+			Hostnames: r.Hostnames,
 		})
 		r.timestamp = f.Timestamp
 		r.cache = ""
@@ -140,6 +165,7 @@ func (r *ReplaySubmitter) Close() (err error) {
 			Content:   r.cache,
 			CreatedAt: r.CreatedAt,
 			Account:   r.Account,
+			Hostnames: r.Hostnames,
 		})
 	}
 	if err = r.submitBatch(); err != nil {
@@ -0,0 +1,198 @@
+package daemon
+
+import (
+	"sort"
+
+	"github.com/jinzhu/copier"
+	"github.com/yankeguo/bastion/daemon/models"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/net/context"
+)
+
+func (d *Daemon) ListVolumes(c context.Context, req *types.ListVolumesRequest) (res *types.ListVolumesResponse, err error) {
+	var vs []models.Volume
+	if vs, err = d.db.Volumes().List(); err != nil {
+		return
+	}
+	ret := make([]*types.Volume, 0, len(vs))
+	for _, v := range vs {
+		ret = append(ret, v.ToGRPCVolume())
+	}
+	res = &types.ListVolumesResponse{Volumes: ret}
+	return
+}
+
+func (d *Daemon) PutVolume(c context.Context, req *types.PutVolumeRequest) (res *types.PutVolumeResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	v := models.Volume{}
+	var before *types.Volume
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		// keep created_at of existing volume
+		if v, err = db.Volumes().Get(req.Name); err != nil {
+			if err != errRecordNotFound {
+				return
+			}
+			v = models.Volume{Name: req.Name, CreatedAt: now()}
+		} else {
+			before = v.ToGRPCVolume()
+		}
+		v.Description = req.Description
+		if err = db.Volumes().Save(&v); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionVolumePut, "volume:"+v.Name, before, v.ToGRPCVolume())
+	}); err != nil {
+		return
+	}
+	res = &types.PutVolumeResponse{Volume: v.ToGRPCVolume()}
+	return
+}
+
+func (d *Daemon) DeleteVolume(c context.Context, req *types.DeleteVolumeRequest) (res *types.DeleteVolumeResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var before *types.Volume
+		v := models.Volume{}
+		if v, err = db.Volumes().Get(req.Name); err == nil {
+			before = v.ToGRPCVolume()
+		} else if err != errRecordNotFound {
+			return
+		}
+		// delete members first
+		var ms []models.VolumeMember
+		if ms, err = db.VolumeMembers().ListByVolume(req.Name); err != nil {
+			return
+		}
+		for _, m := range ms {
+			if err = db.VolumeMembers().Delete(m.Id); err != nil {
+				return
+			}
+		}
+		if err = db.Volumes().Delete(req.Name); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionVolumeDelete, "volume:"+req.Name, before, nil)
+	}); err != nil {
+		return
+	}
+	res = &types.DeleteVolumeResponse{}
+	return
+}
+
+func (d *Daemon) ListVolumeMembers(c context.Context, req *types.ListVolumeMembersRequest) (res *types.ListVolumeMembersResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var ms []models.VolumeMember
+	if ms, err = d.db.VolumeMembers().ListByVolume(req.Volume); err != nil {
+		return
+	}
+	ret := make([]*types.VolumeMember, 0, len(ms))
+	for _, m := range ms {
+		ret = append(ret, m.ToGRPCVolumeMember())
+	}
+	res = &types.ListVolumeMembersResponse{Members: ret}
+	return
+}
+
+func (d *Daemon) PutVolumeMember(c context.Context, req *types.PutVolumeMemberRequest) (res *types.PutVolumeMemberResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	m := models.VolumeMember{}
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		// ensure volume exists
+		if _, err = db.Volumes().Get(req.Volume); err != nil {
+			return
+		}
+		copier.Copy(&m, req)
+		m.Id = m.BuildId()
+		m.CreatedAt = now()
+		if err = db.VolumeMembers().Save(&m); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionVolumeMemberPut, "volume_member:"+m.Id, nil, m.ToGRPCVolumeMember())
+	}); err != nil {
+		return
+	}
+	res = &types.PutVolumeMemberResponse{Member: m.ToGRPCVolumeMember()}
+	return
+}
+
+func (d *Daemon) DeleteVolumeMember(c context.Context, req *types.DeleteVolumeMemberRequest) (res *types.DeleteVolumeMemberResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	m := models.VolumeMember{}
+	copier.Copy(&m, req)
+	m.Id = m.BuildId()
+	if err = d.db.Tx(true, func(db Repositories) (err error) {
+		var before *types.VolumeMember
+		var o models.VolumeMember
+		if o, err = db.VolumeMembers().Get(m.Id); err == nil {
+			m, before = o, o.ToGRPCVolumeMember()
+		} else if err != errRecordNotFound {
+			return
+		}
+		if err = db.VolumeMembers().Delete(m.Id); err != nil {
+			return
+		}
+		return d.audit(c, db, types.AuditActionVolumeMemberDelete, "volume_member:"+m.Id, before, nil)
+	}); err != nil {
+		return
+	}
+	res = &types.DeleteVolumeMemberResponse{}
+	return
+}
+
+func (d *Daemon) ListVolumeMounts(c context.Context, req *types.ListVolumeMountsRequest) (res *types.ListVolumeMountsResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	var ms []models.VolumeMember
+	if ms, err = d.db.VolumeMembers().ListByName(req.Account); err != nil {
+		return
+	}
+	// user and group members share the Name index, kind must be checked
+	ums := make([]models.VolumeMember, 0, len(ms))
+	for _, m := range ms {
+		if m.Kind == types.VolumeMemberKindUser {
+			ums = append(ums, m)
+		}
+	}
+	var names []string
+	if names, err = d.listGroupNames(req.Account); err != nil {
+		return
+	}
+	for _, name := range names {
+		var gms []models.VolumeMember
+		if gms, err = d.db.VolumeMembers().ListByName(name); err != nil {
+			return
+		}
+		for _, m := range gms {
+			if m.Kind == types.VolumeMemberKindGroup {
+				ums = append(ums, m)
+			}
+		}
+	}
+	// read-write membership wins over read-only membership
+	mounts := map[string]bool{}
+	for _, m := range ums {
+		if ro, ok := mounts[m.Volume]; !ok || ro {
+			mounts[m.Volume] = m.IsReadOnly
+		}
+	}
+	ret := make([]*types.VolumeMount, 0, len(mounts))
+	for v, ro := range mounts {
+		ret = append(ret, &types.VolumeMount{Volume: v, IsReadOnly: ro})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Volume < ret[j].Volume
+	})
+	res = &types.ListVolumeMountsResponse{Mounts: ret}
+	return
+}
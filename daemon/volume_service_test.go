@@ -0,0 +1,90 @@
+package daemon
+
+import (
+	"context"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+func TestDaemon_PutListDeleteVolumeMembers(t *testing.T) {
+	withDaemon(t, func(t *testing.T, daemon *Daemon, conn *grpc.ClientConn) {
+		vs := types.NewVolumeServiceClient(conn)
+
+		_, err := vs.PutVolumeMember(context.Background(), &types.PutVolumeMemberRequest{
+			Volume: "dba",
+			Name:   "test",
+		})
+		if err == nil {
+			t.Fatal("failed 1")
+		}
+		if _, err = vs.PutVolume(context.Background(), &types.PutVolumeRequest{Name: "dba", Description: "dba team"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = vs.PutVolume(context.Background(), &types.PutVolumeRequest{Name: "ops"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = vs.PutVolume(context.Background(), &types.PutVolumeRequest{Name: "../etc"}); err == nil {
+			t.Fatal("failed 2")
+		}
+		res, err := vs.ListVolumes(context.Background(), &types.ListVolumesRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Volumes) != 2 {
+			t.Fatal("failed 3")
+		}
+		if _, err = vs.PutVolumeMember(context.Background(), &types.PutVolumeMemberRequest{
+			Volume:     "dba",
+			Name:       "test",
+			IsReadOnly: true,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = vs.PutVolumeMember(context.Background(), &types.PutVolumeMemberRequest{
+			Volume: "ops",
+			Name:   "test",
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = vs.PutVolumeMember(context.Background(), &types.PutVolumeMemberRequest{
+			Volume: "ops",
+			Name:   "test2",
+		}); err != nil {
+			t.Fatal(err)
+		}
+		res2, err := vs.ListVolumeMounts(context.Background(), &types.ListVolumeMountsRequest{Account: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res2.Mounts) != 2 {
+			t.Fatal("failed 4")
+		}
+		if res2.Mounts[0].Volume != "dba" || !res2.Mounts[0].IsReadOnly || res2.Mounts[1].Volume != "ops" || res2.Mounts[1].IsReadOnly {
+			t.Fatal("failed 5")
+		}
+		if _, err = vs.DeleteVolumeMember(context.Background(), &types.DeleteVolumeMemberRequest{
+			Volume: "ops",
+			Name:   "test",
+		}); err != nil {
+			t.Fatal(err)
+		}
+		res3, err := vs.ListVolumeMembers(context.Background(), &types.ListVolumeMembersRequest{Volume: "ops"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res3.Members) != 1 || res3.Members[0].Name != "test2" {
+			t.Fatal("failed 6")
+		}
+		if _, err = vs.DeleteVolume(context.Background(), &types.DeleteVolumeRequest{Name: "dba"}); err != nil {
+			t.Fatal(err)
+		}
+		res2, err = vs.ListVolumeMounts(context.Background(), &types.ListVolumeMountsRequest{Account: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res2.Mounts) != 0 {
+			t.Fatal("failed 7")
+		}
+	})
+}
@@ -0,0 +1,443 @@
+package ldapsync
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"gopkg.in/ldap.v2"
+)
+
+const (
+	DefaultUserFilter           = "(objectClass=posixAccount)"
+	DefaultAccountAttribute     = "uid"
+	DefaultNicknameAttribute    = "cn"
+	DefaultKeyAttribute         = "sshPublicKey"
+	DefaultGroupFilter          = "(objectClass=groupOfNames)"
+	DefaultGroupNameAttribute   = "cn"
+	DefaultGroupMemberAttribute = "member"
+
+	groupDescription = "synchronized from LDAP"
+)
+
+var errEmptyDirectory = errors.New("no user found in LDAP directory, refuse to block all users synchronized from LDAP")
+
+// Options options of LDAP synchronization
+type Options struct {
+	// URL ldap://HOST:PORT or ldaps://HOST:PORT
+	URL string
+	// StartTLS upgrade ldap:// connection with StartTLS
+	StartTLS bool
+	// InsecureSkipVerify skip verification of server certificate
+	InsecureSkipVerify bool
+	// BindDN and BindPassword credentials for searching, anonymous if empty
+	BindDN       string
+	BindPassword string
+
+	// UserBaseDN base DN of users
+	UserBaseDN string
+	// UserFilter default to "(objectClass=posixAccount)"
+	UserFilter string
+	// AccountAttribute attribute of account, default to "uid"
+	AccountAttribute string
+	// NicknameAttribute attribute of nickname, default to "cn"
+	NicknameAttribute string
+	// KeyAttribute attribute of ssh public keys, default to "sshPublicKey"
+	KeyAttribute string
+
+	// GroupBaseDN base DN of groups, groups are not synchronized if empty
+	GroupBaseDN string
+	// GroupFilter default to "(objectClass=groupOfNames)"
+	GroupFilter string
+	// GroupNameAttribute attribute of group name, default to "cn"
+	GroupNameAttribute string
+	// GroupMemberAttribute attribute of members, DN of users like "member" or accounts like "memberUid", default to "member"
+	GroupMemberAttribute string
+}
+
+func defaultStr(s *string, d string) {
+	if len(*s) == 0 {
+		*s = d
+	}
+}
+
+// Fix fill default values
+func (o *Options) Fix() {
+	defaultStr(&o.UserFilter, DefaultUserFilter)
+	defaultStr(&o.AccountAttribute, DefaultAccountAttribute)
+	defaultStr(&o.NicknameAttribute, DefaultNicknameAttribute)
+	defaultStr(&o.KeyAttribute, DefaultKeyAttribute)
+	defaultStr(&o.GroupFilter, DefaultGroupFilter)
+	defaultStr(&o.GroupNameAttribute, DefaultGroupNameAttribute)
+	defaultStr(&o.GroupMemberAttribute, DefaultGroupMemberAttribute)
+}
+
+// User user found in directory
+type User struct {
+	Account  string
+	Nickname string
+	// Keys ssh public keys in authorized_keys format
+	Keys []string
+}
+
+// Directory users and groups found in directory
+type Directory struct {
+	Users []User
+	// Groups accounts of members keyed by group name
+	Groups map[string][]string
+}
+
+func dial(opts Options) (conn *ldap.Conn, err error) {
+	var u *url.URL
+	if u, err = url.Parse(opts.URL); err != nil {
+		return
+	}
+	tc := &tls.Config{ServerName: u.Hostname(), InsecureSkipVerify: opts.InsecureSkipVerify}
+	switch u.Scheme {
+	case "ldap":
+		if conn, err = ldap.Dial("tcp", u.Host); err != nil {
+			return
+		}
+		if opts.StartTLS {
+			if err = conn.StartTLS(tc); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	case "ldaps":
+		if conn, err = ldap.DialTLS("tcp", u.Host, tc); err != nil {
+			return
+		}
+	default:
+		err = errors.New("invalid ldap url, should be ldap://HOST:PORT or ldaps://HOST:PORT")
+	}
+	return
+}
+
+// Fetch query users and groups from directory, entries with invalid account or group name are ignored
+func Fetch(opts Options) (dir Directory, err error) {
+	opts.Fix()
+	var conn *ldap.Conn
+	if conn, err = dial(opts); err != nil {
+		return
+	}
+	defer conn.Close()
+	if len(opts.BindDN) > 0 {
+		if err = conn.Bind(opts.BindDN, opts.BindPassword); err != nil {
+			return
+		}
+	}
+	// search users
+	var sr *ldap.SearchResult
+	if sr, err = conn.SearchWithPaging(ldap.NewSearchRequest(
+		opts.UserBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		opts.UserFilter, []string{opts.AccountAttribute, opts.NicknameAttribute, opts.KeyAttribute}, nil,
+	), 500); err != nil {
+		return
+	}
+	// account by normalized DN, for groups with DN members
+	accounts := map[string]string{}
+	for _, e := range sr.Entries {
+		u := User{
+			Account:  attributeValue(e, opts.AccountAttribute),
+			Nickname: attributeValue(e, opts.NicknameAttribute),
+			Keys:     attributeValues(e, opts.KeyAttribute),
+		}
+		if !types.UserAccountPattern.MatchString(u.Account) {
+			log.Warn().Str("dn", e.DN).Str("account", u.Account).Msg("invalid account, user ignored")
+			continue
+		}
+		for len(u.Nickname) > types.UserNicknameMaxLength {
+			r := []rune(u.Nickname)
+			u.Nickname = string(r[:len(r)-1])
+		}
+		accounts[normalizeDN(e.DN)] = u.Account
+		dir.Users = append(dir.Users, u)
+	}
+	dir.Groups = map[string][]string{}
+	if len(opts.GroupBaseDN) == 0 {
+		return
+	}
+	// search groups
+	if sr, err = conn.SearchWithPaging(ldap.NewSearchRequest(
+		opts.GroupBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		opts.GroupFilter, []string{opts.GroupNameAttribute, opts.GroupMemberAttribute}, nil,
+	), 500); err != nil {
+		return
+	}
+	for _, e := range sr.Entries {
+		name := attributeValue(e, opts.GroupNameAttribute)
+		if !types.GroupNamePattern.MatchString(name) {
+			log.Warn().Str("dn", e.DN).Str("name", name).Msg("invalid group name, group ignored")
+			continue
+		}
+		members := make([]string, 0)
+		for _, m := range attributeValues(e, opts.GroupMemberAttribute) {
+			// members are either DN of users or accounts
+			if strings.Contains(m, "=") {
+				if account, ok := accounts[normalizeDN(m)]; ok {
+					members = append(members, account)
+				}
+			} else {
+				members = append(members, m)
+			}
+		}
+		dir.Groups[name] = members
+	}
+	return
+}
+
+// attributeValues values of attribute, attribute names are case insensitive
+func attributeValues(e *ldap.Entry, name string) []string {
+	for _, a := range e.Attributes {
+		if strings.EqualFold(a.Name, name) {
+			return a.Values
+		}
+	}
+	return nil
+}
+
+func attributeValue(e *ldap.Entry, name string) string {
+	if vs := attributeValues(e, name); len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+// normalizeDN lower cased DN without spaces around separators, for comparison
+func normalizeDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(dn)
+	}
+	rdns := make([]string, 0, len(parsed.RDNs))
+	for _, rdn := range parsed.RDNs {
+		as := make([]string, 0, len(rdn.Attributes))
+		for _, a := range rdn.Attributes {
+			as = append(as, strings.ToLower(a.Type)+"="+strings.ToLower(a.Value))
+		}
+		rdns = append(rdns, strings.Join(as, "+"))
+	}
+	return strings.Join(rdns, ",")
+}
+
+func randomPassword() string {
+	buf := make([]byte, 24)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// Sync mirror directory into daemon, only users, keys and group memberships with source "ldap" are changed,
+// users missing in directory are blocked
+func Sync(conn *grpc.ClientConn, dir Directory) (err error) {
+	ctx := context.Background()
+	us, ks, gs := types.NewUserServiceClient(conn), types.NewKeyServiceClient(conn), types.NewGroupServiceClient(conn)
+
+	var lur *types.ListUsersResponse
+	if lur, err = us.ListUsers(ctx, &types.ListUsersRequest{}); err != nil {
+		return
+	}
+	existing := map[string]*types.User{}
+	for _, u := range lur.Users {
+		existing[u.Account] = u
+	}
+	if len(dir.Users) == 0 {
+		for _, u := range lur.Users {
+			if u.Source == types.UserSourceLDAP && !u.IsBlocked {
+				err = errEmptyDirectory
+				return
+			}
+		}
+	}
+
+	// create or update users, accounts created manually are left alone
+	active := map[string]bool{}
+	synced := map[string]User{}
+	for _, du := range dir.Users {
+		u := existing[du.Account]
+		if u == nil {
+			log.Info().Str("account", du.Account).Msg("create user")
+			var cur *types.CreateUserResponse
+			if cur, err = us.CreateUser(ctx, &types.CreateUserRequest{
+				Account:  du.Account,
+				Password: randomPassword(),
+				Nickname: du.Nickname,
+				Source:   types.UserSourceLDAP,
+			}); err != nil {
+				log.Error().Err(err).Str("account", du.Account).Msg("failed to create user")
+				err = nil
+				continue
+			}
+			u = cur.User
+		} else if u.Source != types.UserSourceLDAP {
+			log.Debug().Str("account", du.Account).Msg("user not created by ldap, ignored")
+			continue
+		} else if len(du.Nickname) > 0 && du.Nickname != u.Nickname {
+			log.Info().Str("account", du.Account).Str("nickname", du.Nickname).Msg("update user nickname")
+			if _, err = us.UpdateUser(ctx, &types.UpdateUserRequest{
+				Account:        du.Account,
+				UpdateNickname: true,
+				Nickname:       du.Nickname,
+			}); err != nil {
+				log.Error().Err(err).Str("account", du.Account).Msg("failed to update user")
+				err = nil
+			}
+		}
+		if u.IsBlocked {
+			log.Warn().Str("account", du.Account).Msg("user is blocked, unblock manually if needed")
+		}
+		active[du.Account] = true
+		synced[du.Account] = du
+	}
+
+	// block users removed from directory
+	for _, u := range lur.Users {
+		if u.Source != types.UserSourceLDAP {
+			continue
+		}
+		if _, ok := synced[u.Account]; ok {
+			continue
+		}
+		synced[u.Account] = User{Account: u.Account}
+		if u.IsBlocked {
+			continue
+		}
+		log.Info().Str("account", u.Account).Msg("block user removed from directory")
+		if _, err = us.UpdateUser(ctx, &types.UpdateUserRequest{
+			Account:         u.Account,
+			UpdateIsBlocked: true,
+			IsBlocked:       true,
+		}); err != nil {
+			log.Error().Err(err).Str("account", u.Account).Msg("failed to block user")
+			err = nil
+		}
+	}
+
+	// synchronize keys, keys of removed users are deleted
+	for _, du := range synced {
+		if err = syncKeys(ctx, ks, du); err != nil {
+			return
+		}
+	}
+
+	// synchronize group memberships
+	return syncGroups(ctx, gs, dir.Groups, active)
+}
+
+func syncKeys(ctx context.Context, ks types.KeyServiceClient, du User) (err error) {
+	keys := map[string]string{}
+	for _, k := range du.Keys {
+		pk, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(k))
+		if err != nil {
+			log.Warn().Err(err).Str("account", du.Account).Msg("invalid ssh public key, ignored")
+			continue
+		}
+		if len(comment) == 0 {
+			comment = "ldap"
+		}
+		keys[ssh.FingerprintSHA256(pk)] = comment
+	}
+	var lkr *types.ListKeysResponse
+	if lkr, err = ks.ListKeys(ctx, &types.ListKeysRequest{Account: du.Account}); err != nil {
+		return
+	}
+	for _, k := range lkr.Keys {
+		if _, ok := keys[k.Fingerprint]; ok {
+			delete(keys, k.Fingerprint)
+			continue
+		}
+		if k.Source != types.KeySourceLDAP {
+			continue
+		}
+		log.Info().Str("account", du.Account).Str("fingerprint", k.Fingerprint).Msg("delete key")
+		if _, err = ks.DeleteKey(ctx, &types.DeleteKeyRequest{Fingerprint: k.Fingerprint}); err != nil {
+			log.Error().Err(err).Str("account", du.Account).Str("fingerprint", k.Fingerprint).Msg("failed to delete key")
+			err = nil
+		}
+	}
+	for fp, name := range keys {
+		log.Info().Str("account", du.Account).Str("fingerprint", fp).Msg("create key")
+		if _, err = ks.CreateKey(ctx, &types.CreateKeyRequest{
+			Account:     du.Account,
+			Fingerprint: fp,
+			Name:        name,
+			Source:      types.KeySourceLDAP,
+		}); err != nil {
+			log.Error().Err(err).Str("account", du.Account).Str("fingerprint", fp).Msg("failed to create key")
+			err = nil
+		}
+	}
+	return
+}
+
+func syncGroups(ctx context.Context, gs types.GroupServiceClient, groups map[string][]string, active map[string]bool) (err error) {
+	var lgr *types.ListGroupsResponse
+	if lgr, err = gs.ListGroups(ctx, &types.ListGroupsRequest{}); err != nil {
+		return
+	}
+	names := make([]string, 0, len(groups)+len(lgr.Groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	existing := map[string]bool{}
+	for _, g := range lgr.Groups {
+		existing[g.Name] = true
+		// memberships of groups removed from directory are removed as well
+		if _, ok := groups[g.Name]; !ok {
+			names = append(names, g.Name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		// only active users synchronized from directory are members
+		members := map[string]bool{}
+		for _, account := range groups[name] {
+			if active[account] {
+				members[account] = true
+			}
+		}
+		if !existing[name] {
+			log.Info().Str("group", name).Msg("create group")
+			if _, err = gs.PutGroup(ctx, &types.PutGroupRequest{Name: name, Description: groupDescription}); err != nil {
+				log.Error().Err(err).Str("group", name).Msg("failed to create group")
+				err = nil
+				continue
+			}
+		}
+		var lmr *types.ListGroupMembersResponse
+		if lmr, err = gs.ListGroupMembers(ctx, &types.ListGroupMembersRequest{Group: name}); err != nil {
+			return
+		}
+		for _, m := range lmr.Members {
+			if members[m.Account] {
+				delete(members, m.Account)
+				continue
+			}
+			if m.Source != types.GroupMemberSourceLDAP {
+				continue
+			}
+			log.Info().Str("group", name).Str("account", m.Account).Msg("remove group member")
+			if _, err = gs.DeleteGroupMember(ctx, &types.DeleteGroupMemberRequest{Group: name, Account: m.Account}); err != nil {
+				log.Error().Err(err).Str("group", name).Str("account", m.Account).Msg("failed to remove group member")
+				err = nil
+			}
+		}
+		for account := range members {
+			log.Info().Str("group", name).Str("account", account).Msg("add group member")
+			if _, err = gs.PutGroupMember(ctx, &types.PutGroupMemberRequest{Group: name, Account: account, Source: types.GroupMemberSourceLDAP}); err != nil {
+				log.Error().Err(err).Str("group", name).Str("account", account).Msg("failed to add group member")
+				err = nil
+			}
+		}
+	}
+	return
+}
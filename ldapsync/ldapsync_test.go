@@ -0,0 +1,295 @@
+package ldapsync
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yankeguo/bastion/daemon"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"gopkg.in/asn1-ber.v1"
+	"gopkg.in/ldap.v2"
+)
+
+// testEntry entry of testServer, attribute names are lower cased
+type testEntry struct {
+	dn    string
+	attrs map[string][]string
+}
+
+// testServer minimal in-process LDAP server, supports simple bind and search with and, or, not, equality and present filters
+type testServer struct {
+	l        net.Listener
+	bindDN   string
+	password string
+	entries  []testEntry
+}
+
+func newTestServer(t *testing.T, bindDN string, password string) *testServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testServer{l: l, bindDN: bindDN, password: password}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	return s
+}
+
+func (s *testServer) URL() string {
+	return "ldap://" + s.l.Addr().String()
+}
+
+func (s *testServer) Close() {
+	s.l.Close()
+}
+
+func (s *testServer) serve(c net.Conn) {
+	defer c.Close()
+	for {
+		p, err := ber.ReadPacket(c)
+		if err != nil || len(p.Children) < 2 {
+			return
+		}
+		id := p.Children[0].Value.(int64)
+		op := p.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			code := ldap.LDAPResultSuccess
+			if op.Children[1].Value.(string) != s.bindDN || op.Children[2].Data.String() != s.password {
+				code = ldap.LDAPResultInvalidCredentials
+			}
+			c.Write(testResponse(id, ldap.ApplicationBindResponse, code).Bytes())
+		case ldap.ApplicationSearchRequest:
+			base := strings.ToLower(op.Children[0].Value.(string))
+			for _, e := range s.entries {
+				if !strings.HasSuffix(strings.ToLower(e.dn), base) || !testMatch(op.Children[6], e) {
+					continue
+				}
+				c.Write(testEntryResponse(id, e).Bytes())
+			}
+			c.Write(testResponse(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes())
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func testMatch(f *ber.Packet, e testEntry) bool {
+	switch f.Tag {
+	case ldap.FilterAnd:
+		for _, c := range f.Children {
+			if !testMatch(c, e) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, c := range f.Children {
+			if testMatch(c, e) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !testMatch(f.Children[0], e)
+	case ldap.FilterEqualityMatch:
+		for _, v := range e.attrs[strings.ToLower(f.Children[0].Value.(string))] {
+			if strings.EqualFold(v, f.Children[1].Value.(string)) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(e.attrs[strings.ToLower(f.Data.String())]) > 0
+	}
+	return false
+}
+
+func testMessage(id int64, op *ber.Packet) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	p.AppendChild(op)
+	return p
+}
+
+func testResponse(id int64, tag ber.Tag, code int) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return testMessage(id, op)
+}
+
+func testEntryResponse(id int64, e testEntry) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "Object Name"))
+	as := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for k, vs := range e.attrs {
+		a := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		a.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, k, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range vs {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		a.AppendChild(set)
+		as.AppendChild(a)
+	}
+	op.AppendChild(as)
+	return testMessage(id, op)
+}
+
+func testPublicKey(t *testing.T) (string, string) {
+	k, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := ssh.NewPublicKey(&k.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pk))), ssh.FingerprintSHA256(pk)
+}
+
+func temporaryFile() string {
+	buf := make([]byte, 8, 8)
+	rand.Read(buf)
+	return filepath.Join(os.TempDir(), "bnktestdb"+hex.EncodeToString(buf)+".bolt")
+}
+
+func withDaemon(t *testing.T, cb func(*testing.T, *grpc.ClientConn)) {
+	d := daemon.New(types.DaemonOptions{
+		DB:        temporaryFile(),
+		Host:      "127.0.0.1",
+		Port:      2996,
+		ReplayDir: os.TempDir(),
+	})
+	go d.Run()
+	defer d.Stop()
+	time.Sleep(time.Second / 2)
+	c, err := grpc.Dial("127.0.0.1:2996", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	cb(t, c)
+}
+
+func TestSync(t *testing.T) {
+	withDaemon(t, func(t *testing.T, conn *grpc.ClientConn) {
+		us, ks, gs := types.NewUserServiceClient(conn), types.NewKeyServiceClient(conn), types.NewGroupServiceClient(conn)
+		ctx := context.Background()
+
+		s := newTestServer(t, "cn=admin,dc=example,dc=com", "secret")
+		defer s.Close()
+		key1, fp1 := testPublicKey(t)
+		key2, fp2 := testPublicKey(t)
+		s.entries = []testEntry{
+			{dn: "uid=alice,ou=people,dc=example,dc=com", attrs: map[string][]string{
+				"objectclass": {"posixAccount"}, "uid": {"alice"}, "cn": {"Alice"}, "sshpublickey": {key1},
+			}},
+			{dn: "uid=bobby,ou=people,dc=example,dc=com", attrs: map[string][]string{
+				"objectclass": {"posixAccount"}, "uid": {"bobby"}, "cn": {"Bobby"},
+			}},
+			{dn: "uid=carol,ou=people,dc=example,dc=com", attrs: map[string][]string{
+				"objectclass": {"posixAccount"}, "uid": {"carol"}, "sshpublickey": {key2},
+			}},
+			{dn: "cn=ops,ou=groups,dc=example,dc=com", attrs: map[string][]string{
+				"objectclass": {"groupOfNames"}, "cn": {"ops"}, "member": {"uid=alice,ou=people,dc=example,dc=com", "UID=Bobby, OU=People,DC=example,DC=com", "uid=carol,ou=people,dc=example,dc=com"},
+			}},
+		}
+		opts := Options{
+			URL:          s.URL(),
+			BindDN:       "cn=admin,dc=example,dc=com",
+			BindPassword: "secret",
+			UserBaseDN:   "ou=people,dc=example,dc=com",
+			GroupBaseDN:  "ou=groups,dc=example,dc=com",
+		}
+
+		// carol exists manually, should be left alone
+		us.CreateUser(ctx, &types.CreateUserRequest{Account: "carol", Password: "qwerty", Nickname: "Carol M"})
+
+		dir, err := Fetch(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dir.Users) != 3 || len(dir.Groups["ops"]) != 3 {
+			t.Fatal("bad directory", dir)
+		}
+		if err = Sync(conn, dir); err != nil {
+			t.Fatal(err)
+		}
+		res1, err := us.GetUser(ctx, &types.GetUserRequest{Account: "alice"})
+		if err != nil || res1.User.Source != types.UserSourceLDAP || res1.User.Nickname != "Alice" {
+			t.Fatal("bad synced user", res1, err)
+		}
+		res2, err := ks.ListKeys(ctx, &types.ListKeysRequest{Account: "alice"})
+		if err != nil || len(res2.Keys) != 1 || res2.Keys[0].Fingerprint != fp1 || res2.Keys[0].Source != types.KeySourceLDAP {
+			t.Fatal("bad synced keys", res2, err)
+		}
+		res3, err := us.GetUser(ctx, &types.GetUserRequest{Account: "carol"})
+		if err != nil || res3.User.Source != types.UserSourceManual || res3.User.Nickname != "Carol M" {
+			t.Fatal("manual user should be left alone", res3, err)
+		}
+		if res4, _ := ks.ListKeys(ctx, &types.ListKeysRequest{Account: "carol"}); len(res4.Keys) != 0 {
+			t.Fatal("keys of manual user should be left alone", res4)
+		}
+		res5, err := gs.ListGroupMembers(ctx, &types.ListGroupMembersRequest{Group: "ops"})
+		if err != nil || len(res5.Members) != 2 {
+			t.Fatal("bad synced group members", res5, err)
+		}
+
+		// manual membership and key are kept, removed user is blocked
+		gs.PutGroupMember(ctx, &types.PutGroupMemberRequest{Group: "ops", Account: "carol"})
+		ks.CreateKey(ctx, &types.CreateKeyRequest{Account: "bobby", Fingerprint: fp2, Name: "manual"})
+		s.entries = append(s.entries[:1], s.entries[2:]...)
+		s.entries[0].attrs["sshpublickey"] = nil
+		if dir, err = Fetch(opts); err != nil {
+			t.Fatal(err)
+		}
+		if err = Sync(conn, dir); err != nil {
+			t.Fatal(err)
+		}
+		res6, err := us.GetUser(ctx, &types.GetUserRequest{Account: "bobby"})
+		if err != nil || !res6.User.IsBlocked {
+			t.Fatal("removed user should be blocked", res6, err)
+		}
+		if res7, _ := ks.ListKeys(ctx, &types.ListKeysRequest{Account: "bobby"}); len(res7.Keys) != 1 || res7.Keys[0].Source != types.KeySourceManual {
+			t.Fatal("manual key should be kept", res7)
+		}
+		if res8, _ := ks.ListKeys(ctx, &types.ListKeysRequest{Account: "alice"}); len(res8.Keys) != 0 {
+			t.Fatal("removed key should be deleted", res8)
+		}
+		res9, err := gs.ListGroupMembers(ctx, &types.ListGroupMembersRequest{Group: "ops"})
+		if err != nil || len(res9.Members) != 2 || res9.Members[0].Account == "bobby" || res9.Members[1].Account == "bobby" {
+			t.Fatal("bad group members after removal", res9, err)
+		}
+
+		// never block everyone on an empty result
+		if err = Sync(conn, Directory{}); err != errEmptyDirectory {
+			t.Fatal("should refuse empty directory", err)
+		}
+
+		// bad credentials
+		opts.BindPassword = "wrong"
+		if _, err = Fetch(opts); err == nil {
+			t.Fatal("should fail with bad credentials")
+		}
+	})
+}
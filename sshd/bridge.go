@@ -11,6 +11,9 @@ import (
 	"golang.org/x/crypto/ssh"
 	"io"
 	"net"
+	"path"
+	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -21,7 +24,7 @@ func handleLv1RawIPDirectTCPIPChannel(conn *ssh.ServerConn, sc ssh.Channel, addr
 	defer sc.Close()
 	// dial direct ip
 	var c net.Conn
-	if c, err = net.Dial("tcp", fmt.Sprintf("%s:%d", address, port)); err != nil {
+	if c, err = net.Dial("tcp", net.JoinHostPort(address, strconv.Itoa(port))); err != nil {
 		ELog(conn).Str("channel", ChannelTypeDirectTCPIP).Err(err).Msg("failed to dial direct IP ssh tunnel connection")
 		return
 	}
@@ -55,15 +58,16 @@ func handleLv1DirectTCPIPChannel(conn *ssh.ServerConn, sc ssh.Channel, tp *Tunne
 	return
 }
 
-func handleLv1SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan *ssh.Request, sb sandbox.Sandbox, account string, ss types.SessionServiceClient, rs types.ReplayServiceClient) (err error) {
+func handleLv1SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan *ssh.Request, sb sandbox.Sandbox, sReq *types.CreateSessionRequest, ss types.SessionServiceClient, rs types.ReplayServiceClient, ts types.TransferServiceClient) (err error) {
 	ILog(conn).Str("channel", ChannelTypeSession).Msg("channel opened")
 	defer ILog(conn).Str("channel", ChannelTypeSession).Err(err).Msg("channel finished")
 	// remember to close channel
 	defer sc.Close()
+	account := sReq.Account
 	// variables
 	cmd, cmdReady, cmdMissing, cmdCond := "", false, false, sync.NewCond(&sync.Mutex{})
 	env := make([]string, 0)
-	pty, ptyCols, ptyRows, term, wch := false, uint32(0), uint32(0), "", make(chan sandbox.Window, 4)
+	pty, term, wch := false, "", make(chan sandbox.Window, 4)
 	// remember to close wch/rwch
 	defer close(wch)
 	// range all requests
@@ -77,7 +81,8 @@ func handleLv1SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan
 					ELog(conn).Str("channel", ChannelTypeSession).Str("request", req.Type).Err(err).Msg("failed to decode payload")
 					continue
 				}
-				pty, ptyCols, ptyRows, term = true, pl.Cols, pl.Rows, pl.Term
+				pty, term = true, pl.Term
+				sReq.PtyTerm, sReq.PtyWidth, sReq.PtyHeight = pl.Term, int32(pl.Cols), int32(pl.Rows)
 				wch <- sandbox.Window{
 					Width:  uint(pl.Cols),
 					Height: uint(pl.Rows),
@@ -157,19 +162,16 @@ func handleLv1SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan
 	// determine should be recorded
 	isRecorded := shouldCommandBeRecorded(cmds)
 	// start session
+	sReq.Command, sReq.IsRecorded = cmd, isRecorded
 	var sRes *types.CreateSessionResponse
-	if sRes, err = ss.CreateSession(context.Background(), &types.CreateSessionRequest{
-		Account:    account,
-		Command:    cmd,
-		IsRecorded: isRecorded,
-	}); err != nil {
+	if sRes, err = ss.CreateSession(context.Background(), sReq); err != nil {
 		ELog(conn).Err(err).Msg("failed to create session")
 		return
 	}
 	ILog(conn).Int64("sessionId", sRes.Session.Id).Msg("session allocated")
-	// build the exec options
+	// build the exec options, session id is exposed for lv2 sessions initiated from sandbox
 	opts := sandbox.ExecAttachOptions{
-		Env:     env,
+		Env:     append(env, fmt.Sprintf("%s=%d", types.SessionEnvParentID, sRes.Session.Id)),
 		Command: cmds,
 		Stdin:   sc,
 		Stdout:  sc,
@@ -180,6 +182,11 @@ func handleLv1SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan
 		opts.Term = term
 		opts.WindowChan = wch
 	}
+	// parse scp protocol for transfer logs, the payload is not recorded
+	if scp, ok := utils.ParseSCPCommand(cmds); ok {
+		ILog(conn).Int64("sessionId", sRes.Session.Id).Msg("session is scp, transfers are logged")
+		startTransferLogging(conn, &opts, scp, sRes.Session.Id, account, ts)
+	}
 	// wrap options if isRecorded
 	if isRecorded {
 		ILog(conn).Int64("sessionId", sRes.Session.Id).Msg("session is recorded")
@@ -200,12 +207,38 @@ func handleLv1SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan
 	return
 }
 
-func handleLv2SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan *ssh.Request, tc ssh.Channel, trchan <-chan *ssh.Request, user string) (err error) {
+func handleLv2SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan *ssh.Request, tc ssh.Channel, trchan <-chan *ssh.Request, sReq *types.CreateSessionRequest, ss types.SessionServiceClient) (err error) {
 	ILog(conn).Str("channel", ChannelTypeSession).Msg("channel opened")
 	defer ILog(conn).Str("channel", ChannelTypeSession).Err(err).Msg("channel finished")
 	// remember to close channels
 	defer sc.Close()
 	defer tc.Close()
+	user := sReq.User
+	// session is created once on "exec" or "shell" request, finished after channel closed
+	sidch, created := make(chan int64, 1), false
+	defer func() {
+		select {
+		case sid := <-sidch:
+			ss.FinishSession(context.Background(), &types.FinishSessionRequest{Id: sid})
+			ILog(conn).Int64("sessionId", sid).Msg("session finished")
+		default:
+		}
+	}()
+	createSession := func(cmd string) bool {
+		if created {
+			return true
+		}
+		sReq.Command = cmd
+		sRes, err := ss.CreateSession(context.Background(), sReq)
+		if err != nil {
+			ELog(conn).Err(err).Msg("failed to create session")
+			return false
+		}
+		ILog(conn).Int64("sessionId", sRes.Session.Id).Int64("parentSessionId", sRes.Session.ParentId).Msg("session allocated")
+		created = true
+		sidch <- sRes.Session.Id
+		return true
+	}
 	// stream stdin, stdout, stderr, srchan <-> trchan
 	wr := &sync.WaitGroup{}
 	wr.Add(3)
@@ -215,17 +248,44 @@ func handleLv2SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan
 			DLog(conn).Str("channel", ChannelTypeSession).Str("request", req.Type).Msg("request received from user")
 			// modify request with sudo
 			switch req.Type {
+			case RequestTypePtyReq:
+				var pl PtyRequestPayload
+				if ssh.Unmarshal(req.Payload, &pl) == nil {
+					sReq.PtyTerm, sReq.PtyWidth, sReq.PtyHeight = pl.Term, int32(pl.Cols), int32(pl.Rows)
+				}
+			case RequestTypeEnv:
+				// parent session id sent from sandbox, not forwarded
+				var pl EnvRequestPayload
+				if ssh.Unmarshal(req.Payload, &pl) == nil && pl.Name == types.SessionEnvParentID {
+					sReq.ParentId, _ = strconv.ParseInt(pl.Value, 10, 64)
+					if req.WantReply {
+						req.Reply(true, nil)
+					}
+					continue
+				}
 			case RequestTypeExec:
 				var pl ExecRequestPayload
 				if err = ssh.Unmarshal(req.Payload, &pl); err != nil {
 					ELog(conn).Str("channel", ChannelTypeSession).Str("request", req.Type).Err(err).Msg("failed to decode payload")
 					continue
 				}
+				if !createSession(pl.Command) {
+					if req.WantReply {
+						req.Reply(false, nil)
+					}
+					continue
+				}
 				// switch user
 				pl.Command = commandSwitchUser(user, pl.Command)
 				// change payload
 				req.Payload = ssh.Marshal(&pl)
 			case RequestTypeShell:
+				if !createSession("") {
+					if req.WantReply {
+						req.Reply(false, nil)
+					}
+					continue
+				}
 				pl := ExecRequestPayload{
 					Command: commandSwitchUser(user, ""),
 				}
@@ -271,3 +331,35 @@ func handleLv2SessionChannel(conn *ssh.ServerConn, sc ssh.Channel, srchan <-chan
 	wr.Wait()
 	return
 }
+
+func startTransferLogging(conn *ssh.ServerConn, opts *sandbox.ExecAttachOptions, scp utils.SCPCommand, sessionID int64, account string, ts types.TransferServiceClient) {
+	direction := types.TransferDirectionDownload
+	if scp.IsSink {
+		direction = types.TransferDirectionUpload
+	}
+	p := utils.NewSCPParser(func(f utils.SCPFile) {
+		filename := scp.ResolvePath(f.Name)
+		// relative paths are relative to sandbox /root
+		if !path.IsAbs(filename) {
+			filename = path.Join("/root", strings.TrimPrefix(filename, "~"))
+		}
+		if _, err := ts.CreateTransfer(context.Background(), &types.CreateTransferRequest{
+			Account:   account,
+			SessionId: sessionID,
+			Source:    types.TransferSourceSCP,
+			Direction: direction,
+			Filename:  filename,
+			Mode:      f.Mode,
+			Size:      f.Size,
+			Sha256:    f.SHA256,
+		}); err != nil {
+			ELog(conn).Int64("sessionId", sessionID).Str("filename", filename).Err(err).Msg("failed to create transfer")
+		}
+	})
+	// files are sent by client with "scp -t", and by sandbox with "scp -f"
+	if scp.IsSink {
+		opts.Stdin = io.TeeReader(opts.Stdin, p)
+	} else {
+		opts.Stdout = io.MultiWriter(opts.Stdout, p)
+	}
+}
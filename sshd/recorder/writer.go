@@ -3,6 +3,7 @@ package recorder
 import (
 	"github.com/rs/zerolog/log"
 	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
 	"io"
 	"time"
 )
@@ -46,8 +47,8 @@ func (fw *FrameWriter) WriteFrame(f *types.ReplayFrame) (err error) {
 		fw.last = f
 		return
 	}
-	// if cached frame is 100ms ago, or different frame type, send the cached frame and cache the new frame
-	if diffUint32(f.Timestamp, fw.last.Timestamp) > 100 || f.Type != fw.last.Type {
+	// if cached frame is 100ms ago, or different frame type, or too large to concat, send the cached frame and cache the new frame
+	if diffUint32(f.Timestamp, fw.last.Timestamp) > 100 || f.Type != fw.last.Type || len(fw.last.Payload)+len(f.Payload) > utils.ReplayFrameMaxPayload {
 		err = fw.client.Send(fw.last)
 		fw.last = cloneFrame(f)
 		return
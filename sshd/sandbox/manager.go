@@ -10,11 +10,17 @@ package sandbox
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
+	"regexp"
+	"sort"
+	"strings"
 	"sync"
 
+	"github.com/rs/zerolog/log"
+
 	"github.com/docker/docker/client"
 
 	dockerTypes "github.com/docker/docker/api/types"
@@ -31,9 +37,32 @@ func GetContainerName(account string) string {
 	return fmt.Sprintf("sandbox-%s", account)
 }
 
+// Mount a shared volume to mount under /shared
+type Mount struct {
+	Volume     string
+	IsReadOnly bool
+}
+
+// containerNameFilters filters by exact container name, docker matches name filter as a regexp against "/name"
+func containerNameFilters(name string) filters.Args {
+	fts := filters.NewArgs()
+	fts.Add("name", "^/"+regexp.QuoteMeta(name)+"$")
+	return fts
+}
+
+// isNameConflict check if container creation failed for the name already in use
+func isNameConflict(err error) bool {
+	return strings.Contains(err.Error(), "is already in use")
+}
+
+// ErrSandboxNotFound sandbox not created yet
+var ErrSandboxNotFound = errors.New("sandbox not found, connect with ssh to create one")
+
 // Manager manager interface
 type Manager interface {
-	FindOrCreate(account string) (Sandbox, error)
+	Find(account string) (Sandbox, error)
+	FindRunning(account string) (Sandbox, error)
+	FindOrCreate(account string, mounts []Mount) (Sandbox, error)
 }
 
 type manager struct {
@@ -55,29 +84,138 @@ func NewManager(cfg types.SSHDOptions) (m Manager, err error) {
 	}, nil
 }
 
-// FindOrCreate find or create a sandbox
-func (m *manager) FindOrCreate(account string) (s Sandbox, err error) {
+// Find find an existing sandbox, start it if not running
+func (m *manager) Find(account string) (s Sandbox, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	name := GetContainerName(account)
+	fts := containerNameFilters(name)
+	var list []dockerTypes.Container
+	if list, err = m.client.ContainerList(context.Background(), dockerTypes.ContainerListOptions{All: true, Filters: fts}); err != nil {
+		return
+	}
+	if len(list) == 0 {
+		err = ErrSandboxNotFound
+		return
+	}
+	s = &sandbox{
+		name:   name,
+		client: m.client,
+	}
+	if list[0].State != "running" {
+		if err = s.Start(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// FindRunning find a running sandbox, ErrSandboxNotFound is returned if not running
+func (m *manager) FindRunning(account string) (s Sandbox, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	name := GetContainerName(account)
+	fts := containerNameFilters(name)
+	fts.Add("status", "running")
+	var list []dockerTypes.Container
+	if list, err = m.client.ContainerList(context.Background(), dockerTypes.ContainerListOptions{Filters: fts}); err != nil {
+		return
+	}
+	if len(list) == 0 {
+		err = ErrSandboxNotFound
+		return
+	}
+	s = &sandbox{
+		name:   name,
+		client: m.client,
+	}
+	return
+}
+
+// binds build docker binds for user dir and shared volumes, sorted for comparison
+func (m *manager) binds(uDir string, mounts []Mount) (binds []string, err error) {
+	binds = []string{fmt.Sprintf("%s:/root", uDir)}
+	for _, mt := range mounts {
+		vDir := path.Join(m.Config.SandboxDir, "shared", mt.Volume)
+		if err = os.MkdirAll(vDir, dirPerm); err != nil {
+			return
+		}
+		bind := fmt.Sprintf("%s:/shared/%s", vDir, mt.Volume)
+		if mt.IsReadOnly {
+			bind = bind + ":ro"
+		}
+		binds = append(binds, bind)
+	}
+	sort.Strings(binds)
+	return
+}
+
+// hasRunningExec check if any exec is still running in the container
+func (m *manager) hasRunningExec(execIDs []string) bool {
+	for _, id := range execIDs {
+		if ei, err := m.client.ContainerExecInspect(context.Background(), id); err == nil && ei.Running {
+			return true
+		}
+	}
+	return false
+}
+
+func equalBinds(b1, b2 []string) bool {
+	if len(b1) != len(b2) {
+		return false
+	}
+	b2 = append([]string{}, b2...)
+	sort.Strings(b2)
+	for i := range b1 {
+		if b1[i] != b2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// FindOrCreate find or create a sandbox, re-create the sandbox if shared volumes changed and no exec is running
+func (m *manager) FindOrCreate(account string, mounts []Mount) (s Sandbox, err error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 	name := GetContainerName(account)
 	// ensure dir
 	uDir := path.Join(m.Config.SandboxDir, name)
-	sDir := path.Join(m.Config.SandboxDir, "shared")
 	if err = os.MkdirAll(uDir, dirPerm); err != nil {
 		return
 	}
-	if err = os.MkdirAll(sDir, dirPerm); err != nil {
+	var binds []string
+	if binds, err = m.binds(uDir, mounts); err != nil {
 		return
 	}
 	// find containers
-	fts := filters.NewArgs()
-	fts.Add("name", name)
+	fts := containerNameFilters(name)
 	var list []dockerTypes.Container
 	if list, err = m.client.ContainerList(context.Background(), dockerTypes.ContainerListOptions{All: true, Filters: fts}); err != nil {
 		return
 	}
 	var running bool
 	var existed bool
+	var recreated bool
+	// reconcile shared volumes of existing container
+	if len(list) > 0 {
+		var cj dockerTypes.ContainerJSON
+		if cj, err = m.client.ContainerInspect(context.Background(), list[0].ID); err != nil {
+			return
+		}
+		if cj.HostConfig != nil && !equalBinds(binds, cj.HostConfig.Binds) {
+			if m.hasRunningExec(cj.ExecIDs) {
+				log.Info().Str("containerName", name).Msg("shared volumes changed, but sandbox is still in use")
+			} else {
+				log.Info().Str("containerName", name).Strs("binds", binds).Msg("shared volumes changed, re-creating sandbox")
+				if err = m.client.ContainerRemove(context.Background(), list[0].ID, dockerTypes.ContainerRemoveOptions{Force: true}); err != nil {
+					return
+				}
+				list = nil
+				recreated = true
+			}
+		}
+	}
 	// create if not found
 	if len(list) == 0 {
 		if _, err = m.client.ContainerCreate(
@@ -87,10 +225,7 @@ func (m *manager) FindOrCreate(account string) (s Sandbox, err error) {
 				Image:    m.Config.SandboxImage,
 			},
 			&container.HostConfig{
-				Binds: []string{
-					fmt.Sprintf("%s:/root", uDir),
-					fmt.Sprintf("%s:/shared", sDir),
-				},
+				Binds: binds,
 				RestartPolicy: container.RestartPolicy{
 					Name: "always",
 				},
@@ -102,7 +237,13 @@ func (m *manager) FindOrCreate(account string) (s Sandbox, err error) {
 			&network.NetworkingConfig{},
 			name,
 		); err != nil {
-			return
+			// sshd and web share the docker daemon but not the mutex, the other one may have just created it
+			if !isNameConflict(err) {
+				return
+			}
+			log.Info().Str("containerName", name).Msg("sandbox created concurrently, using the existing one")
+			err = nil
+			existed = true
 		}
 	} else {
 		existed = true
@@ -119,8 +260,8 @@ func (m *manager) FindOrCreate(account string) (s Sandbox, err error) {
 			return
 		}
 	}
-	// create ssh keys
-	if !existed {
+	// create ssh keys, re-created sandbox keeps the keys in /root
+	if !existed && !recreated {
 		if err = s.GenerateSSHKey(); err != nil {
 			return
 		}
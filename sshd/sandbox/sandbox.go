@@ -9,12 +9,14 @@
 package sandbox
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"fmt"
 	"github.com/rs/zerolog/log"
 	"io"
 	"os"
+	"path"
 	"strings"
 	"sync"
 	"syscall"
@@ -57,6 +59,8 @@ type Sandbox interface {
 	GetSSHPublicKey() (string, error)
 	ExecScript(sc string) (string, string, error)
 	ExecAttach(opts ExecAttachOptions) error
+	CopyTo(dir string, name string, mode int64, size int64, r io.Reader) error
+	CopyFrom(file string) (io.ReadCloser, *tar.Header, error)
 }
 
 type sandbox struct {
@@ -187,6 +191,62 @@ func (s *sandbox) ExecAttach(opts ExecAttachOptions) (err error) {
 	return
 }
 
+// CopyTo copy a single file into dir of the sandbox, using docker archive copy
+func (s *sandbox) CopyTo(dir string, name string, mode int64, size int64, r io.Reader) (err error) {
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		var err error
+		if err = tw.WriteHeader(&tar.Header{
+			Name:     path.Base(name),
+			Mode:     mode,
+			Size:     size,
+			ModTime:  time.Now(),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err = io.CopyN(tw, r, size); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(tw.Close())
+	}()
+	err = s.client.CopyToContainer(context.Background(), s.name, dir, pr, dockerTypes.CopyToContainerOptions{})
+	pr.Close()
+	return
+}
+
+type tarFileReader struct {
+	*tar.Reader
+	c io.Closer
+}
+
+func (t tarFileReader) Close() error {
+	return t.c.Close()
+}
+
+// CopyFrom copy a single regular file out of the sandbox, using docker archive copy
+func (s *sandbox) CopyFrom(file string) (rc io.ReadCloser, h *tar.Header, err error) {
+	var r io.ReadCloser
+	if r, _, err = s.client.CopyFromContainer(context.Background(), s.name, file); err != nil {
+		return
+	}
+	tr := tar.NewReader(r)
+	if h, err = tr.Next(); err != nil {
+		r.Close()
+		return
+	}
+	if h.Typeflag != tar.TypeReg && h.Typeflag != tar.TypeRegA {
+		r.Close()
+		err = fmt.Errorf("%s is not a regular file", file)
+		return
+	}
+	rc = tarFileReader{Reader: tr, c: r}
+	return
+}
+
 func (s *sandbox) signalExecIfNotExited(execId string, sig os.Signal) (err error) {
 	var eiRes dockerTypes.ContainerExecInspect
 	if eiRes, err = s.client.ContainerExecInspect(context.Background(), execId); err != nil {
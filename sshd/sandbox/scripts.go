@@ -20,7 +20,7 @@ echo "注意事项:
 1. 沙箱环境互相隔离，可以自由使用 root 权限
 2. 系统自动将 id_rsa.pub 公钥文件同步到数据库，并自动更新 .ssh/config 文件
 3. /root 为持久目录，存放在其他位置的文件不保证可以持久保存
-4. /shared 为共享目录，与其他用户共享访问
+4. /shared 下为团队共享目录，按成员关系挂载，只读目录无法写入
 5. 建议使用 tmux 等会话保持工具
 " > /root/README
 
@@ -46,23 +46,38 @@ rm -f /root/.ssh/config
 # create new .ssh/config
 {{if .Entries}}
 {{range .Entries}}
-echo "Host {{.Name}}" >> /root/.ssh/config
+{{if .Comment}}echo "# {{.Comment}}" >> /root/.ssh/config{{end}}
+echo "Host {{.Name}}{{range .Aliases}} {{.}}{{end}}" >> /root/.ssh/config
 echo "HostName {{.Host}}" >> /root/.ssh/config
 echo "Port {{.Port}}" >> /root/.ssh/config
 echo "User {{.User}}" >> /root/.ssh/config
-echo "" >> /root/.ssh/config
+{{range .SendEnv}}echo "SendEnv {{.}}" >> /root/.ssh/config
+{{end}}echo "" >> /root/.ssh/config
 {{end}}
 {{else}}
 echo "" > /root/.ssh/config
 {{end}}
 `
 
+const tplSSHKnownHosts = `#!/bin/bash
+mkdir -p /root/.ssh
+touch /root/.ssh/known_hosts
+
+# replace existing entries of bastion endpoint
+ssh-keygen -R "{{.Host}}" -f /root/.ssh/known_hosts > /dev/null 2>&1
+rm -f /root/.ssh/known_hosts.old
+echo "{{.Host}} {{.Key}}" >> /root/.ssh/known_hosts
+`
+
 // SSHEntry a entry in ssh_config
 type SSHEntry struct {
-	Name string
-	Host string
-	Port uint
-	User string
+	Name    string
+	Aliases []string
+	Comment string
+	Host    string
+	Port    uint
+	User    string
+	SendEnv []string
 }
 
 func createScript(name string, tmpl string, data map[string]interface{}) string {
@@ -85,3 +100,15 @@ func ScriptSeedSSHConfig(entries []SSHEntry) string {
 		},
 	)
 }
+
+// ScriptSeedSSHKnownHosts create a script for seeding .ssh/known_hosts with host key of bastion endpoint
+func ScriptSeedSSHKnownHosts(host string, key string) string {
+	return createScript(
+		"seed-ssh-known-hosts",
+		tplSSHKnownHosts,
+		map[string]interface{}{
+			"Host": host,
+			"Key":  key,
+		},
+	)
+}
@@ -10,8 +10,12 @@ import (
 	"golang.org/x/crypto/ssh"
 	"google.golang.org/grpc"
 	"net"
+	"strings"
+	"time"
 )
 
+const grantWatchRetryInterval = time.Second * 5
+
 type SSHD struct {
 	opts            types.SSHDOptions
 	listener        net.Listener
@@ -27,6 +31,7 @@ type SSHD struct {
 	nodeService      types.NodeServiceClient
 	grantService     types.GrantServiceClient
 	masterKeyService types.MasterKeyServiceClient
+	transferService  types.TransferServiceClient
 
 	sandboxManager sandbox.Manager
 }
@@ -43,7 +48,11 @@ func (s *SSHD) initSandboxManager() (err error) {
 }
 
 func (s *SSHD) initRPCConn() (err error) {
-	if s.rpcConn, err = grpc.Dial(s.opts.DaemonEndpoint, grpc.WithInsecure()); err != nil {
+	var dos []grpc.DialOption
+	if dos, err = types.DialOptions(types.SourceSSHD, "", s.opts.TLS); err != nil {
+		return
+	}
+	if s.rpcConn, err = grpc.Dial(s.opts.DaemonEndpoint, dos...); err != nil {
 		return
 	}
 	s.sessionService = types.NewSessionServiceClient(s.rpcConn)
@@ -53,6 +62,7 @@ func (s *SSHD) initRPCConn() (err error) {
 	s.nodeService = types.NewNodeServiceClient(s.rpcConn)
 	s.grantService = types.NewGrantServiceClient(s.rpcConn)
 	s.masterKeyService = types.NewMasterKeyServiceClient(s.rpcConn)
+	s.transferService = types.NewTransferServiceClient(s.rpcConn)
 	return
 }
 
@@ -151,6 +161,7 @@ func (s *SSHD) initSSHServerConfig() (err error) {
 						extKeyUser:     tu,
 						extKeyAddress:  nRes.Node.Address,
 						extKeyHostname: nRes.Node.Hostname,
+						extKeyKey:      fp,
 						extKeyStage:    stageLv2,
 					},
 				}
@@ -165,6 +176,7 @@ func (s *SSHD) initSSHServerConfig() (err error) {
 				ms = &ssh.Permissions{
 					Extensions: map[string]string{
 						extKeyAccount: uRes.User.Account,
+						extKeyKey:     fp,
 						extKeyStage:   stageLv1,
 					},
 				}
@@ -181,15 +193,26 @@ func (s *SSHD) initListener() (err error) {
 	return
 }
 
-func (s *SSHD) submitClientSigners() (err error) {
-	mks := make([]*types.MasterKey, 0, len(s.clientSigners))
+// checkClientSigners warn client signers not registered as master keys, master keys are set by operator with
+// "bastionadmin master-keys set", sshd is not allowed to change them
+func (s *SSHD) checkClientSigners() (err error) {
+	var res *types.ListMasterKeysResponse
+	if res, err = s.masterKeyService.ListMasterKeys(context.Background(), &types.ListMasterKeysRequest{}); err != nil {
+		return
+	}
 	for _, cs := range s.clientSigners {
-		mks = append(mks, &types.MasterKey{
-			Fingerprint: string(ssh.FingerprintSHA256(cs.PublicKey())),
-			PublicKey:   string(ssh.MarshalAuthorizedKey(cs.PublicKey())),
-		})
+		fp := ssh.FingerprintSHA256(cs.PublicKey())
+		found := false
+		for _, mk := range res.MasterKeys {
+			if mk.Fingerprint == fp {
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Warn().Str("fingerprint", fp).Msg("client key is not registered as master key, register it with 'bastionadmin master-keys set'")
+		}
 	}
-	_, err = s.masterKeyService.UpdateAllMasterKeys(context.Background(), &types.UpdateAllMasterKeysRequest{MasterKeys: mks})
 	return
 }
 
@@ -202,8 +225,8 @@ func (s *SSHD) OverrideKeys() (err error) {
 	if err = s.initRPCConn(); err != nil {
 		return
 	}
-	// submit client signers to daemon
-	if err = s.submitClientSigners(); err != nil {
+	// check client signers are registered as master keys
+	if err = s.checkClientSigners(); err != nil {
 		return
 	}
 	// list all nodes
@@ -255,10 +278,12 @@ func (s *SSHD) Run() (err error) {
 	if err = s.initRPCConn(); err != nil {
 		return
 	}
-	// submit client signers to daemon
-	if err = s.submitClientSigners(); err != nil {
+	// check client signers are registered as master keys
+	if err = s.checkClientSigners(); err != nil {
 		return
 	}
+	// watch grants for running sandboxes
+	go s.watchGrants()
 	// init sshServerConfig, must after host signer and rpcConn
 	if err = s.initSSHServerConfig(); err != nil {
 		return
@@ -299,7 +324,7 @@ func (s *SSHD) handleConnection(c net.Conn) {
 	return
 }
 
-func (s *SSHD) updateSandboxPublicKey(sb sandbox.Sandbox, account string) (err error) {
+func updateSandboxPublicKey(ks types.KeyServiceClient, sb sandbox.Sandbox, account string) (err error) {
 	var ak string
 	if ak, err = sb.GetSSHPublicKey(); err != nil {
 		return
@@ -309,7 +334,7 @@ func (s *SSHD) updateSandboxPublicKey(sb sandbox.Sandbox, account string) (err e
 		return
 	}
 	fp := ssh.FingerprintSHA256(pk)
-	_, err = s.keyService.CreateKey(context.Background(), &types.CreateKeyRequest{
+	_, err = ks.CreateKey(context.Background(), &types.CreateKeyRequest{
 		Name:        "sandbox",
 		Account:     account,
 		Fingerprint: fp,
@@ -318,28 +343,139 @@ func (s *SSHD) updateSandboxPublicKey(sb sandbox.Sandbox, account string) (err e
 	return
 }
 
-func (s *SSHD) updateSandboxSSHConfig(sb sandbox.Sandbox, account string) (err error) {
+func listSandboxMounts(vs types.VolumeServiceClient, account string) (mounts []sandbox.Mount, err error) {
+	var vRes *types.ListVolumeMountsResponse
+	if vRes, err = vs.ListVolumeMounts(context.Background(), &types.ListVolumeMountsRequest{Account: account}); err != nil {
+		return
+	}
+	mounts = make([]sandbox.Mount, 0, len(vRes.Mounts))
+	for _, m := range vRes.Mounts {
+		mounts = append(mounts, sandbox.Mount{Volume: m.Volume, IsReadOnly: m.IsReadOnly})
+	}
+	return
+}
+
+func updateSandboxSSHConfig(gs types.GrantServiceClient, opts types.SSHDOptions, hostKey ssh.PublicKey, sb sandbox.Sandbox, account string) (err error) {
 	var riRes *types.ListGrantItemsResponse
-	if riRes, err = s.grantService.ListGrantItems(context.Background(), &types.ListGrantItemsRequest{Account: account}); err != nil {
+	if riRes, err = gs.ListGrantItems(context.Background(), &types.ListGrantItemsRequest{Account: account}); err != nil {
 		return
 	}
+	// count users per hostname, hostname is an alias if only one user is granted
+	hc := map[string]int{}
+	for _, ri := range riRes.GrantItems {
+		if ri.User != types.GrantUserTunnel {
+			hc[ri.Hostname]++
+		}
+	}
 	se := make([]sandbox.SSHEntry, 0)
 	for _, ri := range riRes.GrantItems {
 		// skip the special tunnel user
 		if ri.User == types.GrantUserTunnel {
 			continue
 		}
-		se = append(se, sandbox.SSHEntry{
-			Name: fmt.Sprintf("%s-%s", ri.Hostname, ri.User),
-			Host: s.opts.SandboxEndpoint,
-			Port: uint(s.opts.Port),
-			User: fmt.Sprintf("%s@%s", ri.User, ri.Hostname),
-		})
-	}
-	_, _, err = sb.ExecScript(sandbox.ScriptSeedSSHConfig(se))
+		e := sandbox.SSHEntry{
+			Name:    SSHEntryName(ri.Hostname, ri.User),
+			Comment: "never expires",
+			Host:    opts.SandboxEndpoint,
+			Port:    uint(opts.Port),
+			User:    fmt.Sprintf("%s@%s", ri.User, ri.Hostname),
+			SendEnv: []string{types.SessionEnvParentID},
+		}
+		if hc[ri.Hostname] == 1 {
+			e.Aliases = []string{ri.Hostname}
+		}
+		if ri.ExpiredAt > 0 {
+			e.Comment = "expires at " + time.Unix(ri.ExpiredAt, 0).Format(time.RFC3339)
+		}
+		se = append(se, e)
+	}
+	if _, _, err = sb.ExecScript(sandbox.ScriptSeedSSHConfig(se)); err != nil {
+		return
+	}
+	// write known_hosts for the bastion endpoint
+	if hostKey != nil {
+		host := opts.SandboxEndpoint
+		if opts.Port != 22 {
+			host = fmt.Sprintf("[%s]:%d", opts.SandboxEndpoint, opts.Port)
+		}
+		_, _, err = sb.ExecScript(sandbox.ScriptSeedSSHKnownHosts(host, strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey)))))
+	}
+	return
+}
+
+// SSHEntryName name of the sandbox ssh config entry for a granted hostname and user
+func SSHEntryName(hostname, user string) string {
+	return fmt.Sprintf("%s-%s", hostname, user)
+}
+
+// PrepareSandbox find or create the lv1 sandbox of an account, with shared volumes mounted, sandbox key registered, ssh config and known_hosts seeded
+func PrepareSandbox(opts types.SSHDOptions, m sandbox.Manager, conn *grpc.ClientConn, account string) (sb sandbox.Sandbox, err error) {
+	// list shared volumes
+	var mounts []sandbox.Mount
+	if mounts, err = listSandboxMounts(types.NewVolumeServiceClient(conn), account); err != nil {
+		err = errors.Wrap(err, "failed to list shared volumes")
+		return
+	}
+	// find or create the sandbox
+	if sb, err = m.FindOrCreate(account, mounts); err != nil {
+		err = errors.Wrap(err, "failed to find or create the sandbox")
+		return
+	}
+	// load public key from sandbox /root/.ssh/id_rsa.pub
+	if ierr := updateSandboxPublicKey(types.NewKeyServiceClient(conn), sb, account); ierr != nil {
+		log.Error().Str("account", account).Err(ierr).Msg("failed to extract sandbox public key")
+	}
+	// write sandbox /root/.ssh/config and /root/.ssh/known_hosts
+	var hostKey ssh.PublicKey
+	if hs, ierr := loadSSHPrivateKeyFile(opts.HostKey); ierr != nil {
+		log.Error().Str("account", account).Err(ierr).Msg("failed to load host key")
+	} else {
+		hostKey = hs.PublicKey()
+	}
+	if ierr := updateSandboxSSHConfig(types.NewGrantServiceClient(conn), opts, hostKey, sb, account); ierr != nil {
+		log.Error().Str("account", account).Err(ierr).Msg("failed to write ssh config to sandbox")
+	}
 	return
 }
 
+// watchGrants rewrite ssh config of running sandboxes when grants changed, reconnect on failure
+func (s *SSHD) watchGrants() {
+	for {
+		if err := s.watchGrantsOnce(); err != nil {
+			log.Error().Err(err).Msg("failed to watch grants, retrying")
+		}
+		time.Sleep(grantWatchRetryInterval)
+	}
+}
+
+func (s *SSHD) watchGrantsOnce() (err error) {
+	var wc types.GrantService_WatchGrantsClient
+	if wc, err = s.grantService.WatchGrants(context.Background(), &types.WatchGrantsRequest{}); err != nil {
+		return
+	}
+	for {
+		var res *types.WatchGrantsResponse
+		if res, err = wc.Recv(); err != nil {
+			return
+		}
+		for _, account := range res.Accounts {
+			var sb sandbox.Sandbox
+			var ierr error
+			if sb, ierr = s.sandboxManager.FindRunning(account); ierr != nil {
+				if ierr != sandbox.ErrSandboxNotFound {
+					log.Error().Err(ierr).Str("account", account).Msg("failed to find running sandbox")
+				}
+				continue
+			}
+			if ierr = updateSandboxSSHConfig(s.grantService, s.opts, s.hostSigner.PublicKey(), sb, account); ierr != nil {
+				log.Error().Err(ierr).Str("account", account).Msg("failed to refresh ssh config of sandbox")
+				continue
+			}
+			log.Info().Str("account", account).Msg("ssh config of sandbox refreshed")
+		}
+	}
+}
+
 func (s *SSHD) handleLv1Connection(conn *ssh.ServerConn, ncchan <-chan ssh.NewChannel, grchan <-chan *ssh.Request) (err error) {
 	// remember to close the connection
 	defer conn.Close()
@@ -410,19 +546,11 @@ func (s *SSHD) handleLv1Connection(conn *ssh.ServerConn, ncchan <-chan ssh.NewCh
 		} else if nc.ChannelType() == ChannelTypeSession {
 			// find or create the sandbox
 			var sb sandbox.Sandbox
-			if sb, err = s.sandboxManager.FindOrCreate(account); err != nil {
-				nc.Reject(ssh.ConnectionFailed, "internal error: failed to find or create the sandbox")
-				ELog(conn).Str("channel", nc.ChannelType()).Err(err).Msg("failed to find or create the sandbox")
+			if sb, err = PrepareSandbox(s.opts, s.sandboxManager, s.rpcConn, account); err != nil {
+				nc.Reject(ssh.ConnectionFailed, "internal error: failed to prepare the sandbox")
+				ELog(conn).Str("channel", nc.ChannelType()).Err(err).Msg("failed to prepare the sandbox")
 				continue
 			}
-			// load public key from sandbox /root/.ssh/id_rsa.pub
-			if err = s.updateSandboxPublicKey(sb, account); err != nil {
-				ELog(conn).Str("channel", nc.ChannelType()).Err(err).Msg("failed to extract sandbox public key")
-			}
-			// write sandbox /root/.ssh/config
-			if err = s.updateSandboxSSHConfig(sb, account); err != nil {
-				ELog(conn).Str("channel", nc.ChannelType()).Err(err).Msg("failed to write ssh config to sandbox")
-			}
 			// accept the new channel
 			var sc ssh.Channel
 			var srchan <-chan *ssh.Request
@@ -430,7 +558,7 @@ func (s *SSHD) handleLv1Connection(conn *ssh.ServerConn, ncchan <-chan ssh.NewCh
 				ELog(conn).Str("channel", nc.ChannelType()).Err(err).Msg("failed to accept new channel")
 				continue
 			}
-			go handleLv1SessionChannel(conn, sc, srchan, sb, account, s.sessionService, s.replayService)
+			go handleLv1SessionChannel(conn, sc, srchan, sb, s.sessionRequest(conn), s.sessionService, s.replayService, s.transferService)
 		} else {
 			ELog(conn).Str("channel", nc.ChannelType()).Msg("unsupported channel type")
 			nc.Reject(ssh.UnknownChannelType, "error: only channel type 'session' and 'direct-tcpip' is allowed")
@@ -443,7 +571,6 @@ func (s *SSHD) handleLv1Connection(conn *ssh.ServerConn, ncchan <-chan ssh.NewCh
 func (s *SSHD) handleLv2Connection(conn *ssh.ServerConn, ncchan <-chan ssh.NewChannel, grchan <-chan *ssh.Request) (err error) {
 	defer conn.Close()
 	// extract connection parameters
-	user := conn.Permissions.Extensions[extKeyUser]
 	address := conn.Permissions.Extensions[extKeyAddress]
 	// no global requests is allowed in LV2 connection
 	go discardRequests(grchan)
@@ -483,11 +610,30 @@ func (s *SSHD) handleLv2Connection(conn *ssh.ServerConn, ncchan <-chan ssh.NewCh
 			continue
 		}
 		// bridge channels
-		go handleLv2SessionChannel(conn, sc, srchan, tc, trchan, user)
+		go handleLv2SessionChannel(conn, sc, srchan, tc, trchan, s.sessionRequest(conn), s.sessionService)
 	}
 	return
 }
 
+// sessionRequest create session request pre-filled with stage, target, client and key of the connection
+func (s *SSHD) sessionRequest(conn *ssh.ServerConn) *types.CreateSessionRequest {
+	ext := conn.Permissions.Extensions
+	req := &types.CreateSessionRequest{
+		Account:        ext[extKeyAccount],
+		Stage:          types.SessionStageLv1,
+		ClientAddr:     conn.RemoteAddr().String(),
+		ClientVersion:  string(conn.ClientVersion()),
+		KeyFingerprint: ext[extKeyKey],
+		SshdInstance:   s.opts.Instance,
+	}
+	if ext[extKeyStage] == stageLv2 {
+		req.Stage = types.SessionStageLv2
+		req.Hostname = ext[extKeyHostname]
+		req.User = ext[extKeyUser]
+	}
+	return req
+}
+
 func (s *SSHD) Shutdown() {
 	if s.listener != nil {
 		s.listener.Close()
@@ -24,6 +24,7 @@ const (
 	extKeyHostname = "bastion-hostname"
 	extKeyUser     = "bastion-user"
 	extKeyAddress  = "bastion-address"
+	extKeyKey      = "bastion-key"
 
 	stagePre = "pre"
 	stageLv1 = "lv1"
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+
+	"google.golang.org/grpc/credentials"
+)
+
+func loadCertPool(file string) (p *x509.CertPool, err error) {
+	var buf []byte
+	if buf, err = ioutil.ReadFile(file); err != nil {
+		return
+	}
+	p = x509.NewCertPool()
+	if !p.AppendCertsFromPEM(buf) {
+		err = errors.New("no certificate found in " + file)
+	}
+	return
+}
+
+// ServerCredentials credentials of daemon, client certificates signed by the CA are required
+func (o TLSOptions) ServerCredentials() (c credentials.TransportCredentials, err error) {
+	var cert tls.Certificate
+	if cert, err = tls.LoadX509KeyPair(o.Cert, o.Key); err != nil {
+		return
+	}
+	var pool *x509.CertPool
+	if pool, err = loadCertPool(o.CA); err != nil {
+		return
+	}
+	c = credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+	return
+}
+
+// ClientCredentials credentials of daemon clients, certificate of daemon is verified with the CA
+func (o TLSOptions) ClientCredentials() (c credentials.TransportCredentials, err error) {
+	var cert tls.Certificate
+	if cert, err = tls.LoadX509KeyPair(o.Cert, o.Key); err != nil {
+		return
+	}
+	var pool *x509.CertPool
+	if pool, err = loadCertPool(o.CA); err != nil {
+		return
+	}
+	c = credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   o.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	})
+	return
+}
@@ -30,6 +30,10 @@ type User struct {
 	CreatedAt            int64    `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt            int64    `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	ViewedAt             int64    `protobuf:"varint,8,opt,name=viewed_at,json=viewedAt,proto3" json:"viewed_at,omitempty"`
+	Roles                []string `protobuf:"bytes,9,rep,name=roles,proto3" json:"roles,omitempty"`
+	Source               string   `protobuf:"bytes,10,opt,name=source,proto3" json:"source,omitempty"`
+	OidcIssuer           string   `protobuf:"bytes,11,opt,name=oidc_issuer,json=oidcIssuer,proto3" json:"oidc_issuer,omitempty"`
+	OidcSubject          string   `protobuf:"bytes,12,opt,name=oidc_subject,json=oidcSubject,proto3" json:"oidc_subject,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -109,6 +113,34 @@ func (m *User) GetViewedAt() int64 {
 	return 0
 }
 
+func (m *User) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *User) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *User) GetOidcIssuer() string {
+	if m != nil {
+		return m.OidcIssuer
+	}
+	return ""
+}
+
+func (m *User) GetOidcSubject() string {
+	if m != nil {
+		return m.OidcSubject
+	}
+	return ""
+}
+
 type ListUsersRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -184,6 +216,9 @@ type CreateUserRequest struct {
 	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	Nickname             string   `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
 	IsAdmin              bool     `protobuf:"varint,4,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	Source               string   `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	OidcIssuer           string   `protobuf:"bytes,6,opt,name=oidc_issuer,json=oidcIssuer,proto3" json:"oidc_issuer,omitempty"`
+	OidcSubject          string   `protobuf:"bytes,7,opt,name=oidc_subject,json=oidcSubject,proto3" json:"oidc_subject,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -242,6 +277,27 @@ func (m *CreateUserRequest) GetIsAdmin() bool {
 	return false
 }
 
+func (m *CreateUserRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *CreateUserRequest) GetOidcIssuer() string {
+	if m != nil {
+		return m.OidcIssuer
+	}
+	return ""
+}
+
+func (m *CreateUserRequest) GetOidcSubject() string {
+	if m != nil {
+		return m.OidcSubject
+	}
+	return ""
+}
+
 type CreateUserResponse struct {
 	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -369,6 +425,8 @@ type UpdateUserRequest struct {
 	IsAdmin              bool     `protobuf:"varint,7,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
 	UpdateIsBlocked      bool     `protobuf:"varint,8,opt,name=update_is_blocked,json=updateIsBlocked,proto3" json:"update_is_blocked,omitempty"`
 	IsBlocked            bool     `protobuf:"varint,9,opt,name=is_blocked,json=isBlocked,proto3" json:"is_blocked,omitempty"`
+	UpdateRoles          bool     `protobuf:"varint,10,opt,name=update_roles,json=updateRoles,proto3" json:"update_roles,omitempty"`
+	Roles                []string `protobuf:"bytes,11,rep,name=roles,proto3" json:"roles,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -462,6 +520,20 @@ func (m *UpdateUserRequest) GetIsBlocked() bool {
 	return false
 }
 
+func (m *UpdateUserRequest) GetUpdateRoles() bool {
+	if m != nil {
+		return m.UpdateRoles
+	}
+	return false
+}
+
+func (m *UpdateUserRequest) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
 type UpdateUserResponse struct {
 	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -627,7 +699,9 @@ func (m *GetUserRequest) GetAccount() string {
 }
 
 type GetUserResponse struct {
-	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	User *User `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// effective roles, including roles of groups, "super-admin" for legacy is_admin
+	EffectiveRoles       []string `protobuf:"bytes,2,rep,name=effective_roles,json=effectiveRoles,proto3" json:"effective_roles,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -665,17 +739,25 @@ func (m *GetUserResponse) GetUser() *User {
 	return nil
 }
 
+func (m *GetUserResponse) GetEffectiveRoles() []string {
+	if m != nil {
+		return m.EffectiveRoles
+	}
+	return nil
+}
+
 type Node struct {
-	Hostname             string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	User                 string   `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
-	CreatedAt            int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ViewedAt             int64    `protobuf:"varint,6,opt,name=viewed_at,json=viewedAt,proto3" json:"viewed_at,omitempty"`
-	IsKeyManaged         bool     `protobuf:"varint,7,opt,name=is_key_managed,json=isKeyManaged,proto3" json:"is_key_managed,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Hostname             string            `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	User                 string            `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Address              string            `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Source               string            `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	CreatedAt            int64             `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ViewedAt             int64             `protobuf:"varint,6,opt,name=viewed_at,json=viewedAt,proto3" json:"viewed_at,omitempty"`
+	IsKeyManaged         bool              `protobuf:"varint,7,opt,name=is_key_managed,json=isKeyManaged,proto3" json:"is_key_managed,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Node) Reset()         { *m = Node{} }
@@ -752,6 +834,13 @@ func (m *Node) GetIsKeyManaged() bool {
 	return false
 }
 
+func (m *Node) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type ListNodesRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -823,13 +912,14 @@ func (m *ListNodesResponse) GetNodes() []*Node {
 }
 
 type PutNodeRequest struct {
-	Hostname             string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	User                 string   `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Hostname             string            `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	User                 string            `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Address              string            `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Source               string            `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *PutNodeRequest) Reset()         { *m = PutNodeRequest{} }
@@ -885,6 +975,13 @@ func (m *PutNodeRequest) GetSource() string {
 	return ""
 }
 
+func (m *PutNodeRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type PutNodeResponse struct {
 	Node                 *Node    `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1151,12 +1248,14 @@ func (m *TouchNodeResponse) GetNode() *Node {
 }
 
 type UpdateNodeRequest struct {
-	Hostname             string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	UpdateIsKeyManaged   bool     `protobuf:"varint,2,opt,name=update_is_key_managed,json=updateIsKeyManaged,proto3" json:"update_is_key_managed,omitempty"`
-	IsKeyManaged         bool     `protobuf:"varint,3,opt,name=is_key_managed,json=isKeyManaged,proto3" json:"is_key_managed,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Hostname             string            `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	UpdateIsKeyManaged   bool              `protobuf:"varint,2,opt,name=update_is_key_managed,json=updateIsKeyManaged,proto3" json:"update_is_key_managed,omitempty"`
+	IsKeyManaged         bool              `protobuf:"varint,3,opt,name=is_key_managed,json=isKeyManaged,proto3" json:"is_key_managed,omitempty"`
+	UpdateLabels         bool              `protobuf:"varint,4,opt,name=update_labels,json=updateLabels,proto3" json:"update_labels,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *UpdateNodeRequest) Reset()         { *m = UpdateNodeRequest{} }
@@ -1205,6 +1304,20 @@ func (m *UpdateNodeRequest) GetIsKeyManaged() bool {
 	return false
 }
 
+func (m *UpdateNodeRequest) GetUpdateLabels() bool {
+	if m != nil {
+		return m.UpdateLabels
+	}
+	return false
+}
+
+func (m *UpdateNodeRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type UpdateNodeResponse struct {
 	Node                 *Node    `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1922,6 +2035,8 @@ type Grant struct {
 	User                 string   `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
 	ExpiredAt            int64    `protobuf:"varint,4,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
 	CreatedAt            int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Group                string   `protobuf:"bytes,6,opt,name=group,proto3" json:"group,omitempty"`
+	LabelSelector        string   `protobuf:"bytes,7,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1987,6 +2102,20 @@ func (m *Grant) GetCreatedAt() int64 {
 	return 0
 }
 
+func (m *Grant) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *Grant) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
 type GrantItem struct {
 	Hostname             string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
 	User                 string   `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
@@ -2047,6 +2176,8 @@ type PutGrantRequest struct {
 	HostnamePattern      string   `protobuf:"bytes,2,opt,name=hostname_pattern,json=hostnamePattern,proto3" json:"hostname_pattern,omitempty"`
 	User                 string   `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
 	ExpiredAt            int64    `protobuf:"varint,4,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	Group                string   `protobuf:"bytes,5,opt,name=group,proto3" json:"group,omitempty"`
+	LabelSelector        string   `protobuf:"bytes,6,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2105,6 +2236,20 @@ func (m *PutGrantRequest) GetExpiredAt() int64 {
 	return 0
 }
 
+func (m *PutGrantRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *PutGrantRequest) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
 type PutGrantResponse struct {
 	Grant                *Grant   `protobuf:"bytes,1,opt,name=grant,proto3" json:"grant,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2146,6 +2291,7 @@ func (m *PutGrantResponse) GetGrant() *Grant {
 
 type ListGrantsRequest struct {
 	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Group                string   `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2183,6 +2329,13 @@ func (m *ListGrantsRequest) GetAccount() string {
 	return ""
 }
 
+func (m *ListGrantsRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
 type ListGrantsResponse struct {
 	Grants               []*Grant `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants,omitempty"`
 	Now                  int64    `protobuf:"varint,2,opt,name=now,proto3" json:"now,omitempty"`
@@ -2312,6 +2465,8 @@ type DeleteGrantRequest struct {
 	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	HostnamePattern      string   `protobuf:"bytes,2,opt,name=hostname_pattern,json=hostnamePattern,proto3" json:"hostname_pattern,omitempty"`
 	User                 string   `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Group                string   `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`
+	LabelSelector        string   `protobuf:"bytes,5,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2363,6 +2518,20 @@ func (m *DeleteGrantRequest) GetUser() string {
 	return ""
 }
 
+func (m *DeleteGrantRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *DeleteGrantRequest) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
 type DeleteGrantResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -2488,6 +2657,76 @@ func (m *CheckGrantResponse) GetOk() bool {
 	return false
 }
 
+type WatchGrantsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchGrantsRequest) Reset()         { *m = WatchGrantsRequest{} }
+func (m *WatchGrantsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchGrantsRequest) ProtoMessage()    {}
+func (*WatchGrantsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{54}
+}
+
+func (m *WatchGrantsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchGrantsRequest.Unmarshal(m, b)
+}
+func (m *WatchGrantsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchGrantsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchGrantsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchGrantsRequest.Merge(m, src)
+}
+func (m *WatchGrantsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchGrantsRequest.Size(m)
+}
+func (m *WatchGrantsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchGrantsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchGrantsRequest proto.InternalMessageInfo
+
+type WatchGrantsResponse struct {
+	Accounts             []string `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchGrantsResponse) Reset()         { *m = WatchGrantsResponse{} }
+func (m *WatchGrantsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchGrantsResponse) ProtoMessage()    {}
+func (*WatchGrantsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{55}
+}
+
+func (m *WatchGrantsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchGrantsResponse.Unmarshal(m, b)
+}
+func (m *WatchGrantsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchGrantsResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchGrantsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchGrantsResponse.Merge(m, src)
+}
+func (m *WatchGrantsResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchGrantsResponse.Size(m)
+}
+func (m *WatchGrantsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchGrantsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchGrantsResponse proto.InternalMessageInfo
+
+func (m *WatchGrantsResponse) GetAccounts() []string {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
 type Session struct {
 	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Account              string   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
@@ -2495,6 +2734,23 @@ type Session struct {
 	CreatedAt            int64    `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	FinishedAt           int64    `protobuf:"varint,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
 	IsRecorded           bool     `protobuf:"varint,6,opt,name=is_recorded,json=isRecorded,proto3" json:"is_recorded,omitempty"`
+	Stage                string   `protobuf:"bytes,7,opt,name=stage,proto3" json:"stage,omitempty"`
+	Hostname             string   `protobuf:"bytes,8,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	User                 string   `protobuf:"bytes,9,opt,name=user,proto3" json:"user,omitempty"`
+	ClientAddr           string   `protobuf:"bytes,10,opt,name=client_addr,json=clientAddr,proto3" json:"client_addr,omitempty"`
+	ClientVersion        string   `protobuf:"bytes,11,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	KeyFingerprint       string   `protobuf:"bytes,12,opt,name=key_fingerprint,json=keyFingerprint,proto3" json:"key_fingerprint,omitempty"`
+	PtyTerm              string   `protobuf:"bytes,13,opt,name=pty_term,json=ptyTerm,proto3" json:"pty_term,omitempty"`
+	PtyWidth             int32    `protobuf:"varint,14,opt,name=pty_width,json=ptyWidth,proto3" json:"pty_width,omitempty"`
+	PtyHeight            int32    `protobuf:"varint,15,opt,name=pty_height,json=ptyHeight,proto3" json:"pty_height,omitempty"`
+	SshdInstance         string   `protobuf:"bytes,16,opt,name=sshd_instance,json=sshdInstance,proto3" json:"sshd_instance,omitempty"`
+	ParentId             int64    `protobuf:"varint,17,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	LegalHold            bool     `protobuf:"varint,18,opt,name=legal_hold,json=legalHold,proto3" json:"legal_hold,omitempty"`
+	PurgedAt             int64    `protobuf:"varint,19,opt,name=purged_at,json=purgedAt,proto3" json:"purged_at,omitempty"`
+	ReplayDigest         string   `protobuf:"bytes,20,opt,name=replay_digest,json=replayDigest,proto3" json:"replay_digest,omitempty"`
+	ReplayFrames         int64    `protobuf:"varint,21,opt,name=replay_frames,json=replayFrames,proto3" json:"replay_frames,omitempty"`
+	ReplaySignature      string   `protobuf:"bytes,22,opt,name=replay_signature,json=replaySignature,proto3" json:"replay_signature,omitempty"`
+	ReplaySigner         string   `protobuf:"bytes,23,opt,name=replay_signer,json=replaySigner,proto3" json:"replay_signer,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2504,7 +2760,7 @@ func (m *Session) Reset()         { *m = Session{} }
 func (m *Session) String() string { return proto.CompactTextString(m) }
 func (*Session) ProtoMessage()    {}
 func (*Session) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{54}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{56}
 }
 
 func (m *Session) XXX_Unmarshal(b []byte) error {
@@ -2567,10 +2823,140 @@ func (m *Session) GetIsRecorded() bool {
 	return false
 }
 
+func (m *Session) GetStage() string {
+	if m != nil {
+		return m.Stage
+	}
+	return ""
+}
+
+func (m *Session) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *Session) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Session) GetClientAddr() string {
+	if m != nil {
+		return m.ClientAddr
+	}
+	return ""
+}
+
+func (m *Session) GetClientVersion() string {
+	if m != nil {
+		return m.ClientVersion
+	}
+	return ""
+}
+
+func (m *Session) GetKeyFingerprint() string {
+	if m != nil {
+		return m.KeyFingerprint
+	}
+	return ""
+}
+
+func (m *Session) GetPtyTerm() string {
+	if m != nil {
+		return m.PtyTerm
+	}
+	return ""
+}
+
+func (m *Session) GetPtyWidth() int32 {
+	if m != nil {
+		return m.PtyWidth
+	}
+	return 0
+}
+
+func (m *Session) GetPtyHeight() int32 {
+	if m != nil {
+		return m.PtyHeight
+	}
+	return 0
+}
+
+func (m *Session) GetSshdInstance() string {
+	if m != nil {
+		return m.SshdInstance
+	}
+	return ""
+}
+
+func (m *Session) GetParentId() int64 {
+	if m != nil {
+		return m.ParentId
+	}
+	return 0
+}
+
+func (m *Session) GetLegalHold() bool {
+	if m != nil {
+		return m.LegalHold
+	}
+	return false
+}
+
+func (m *Session) GetPurgedAt() int64 {
+	if m != nil {
+		return m.PurgedAt
+	}
+	return 0
+}
+
+func (m *Session) GetReplayDigest() string {
+	if m != nil {
+		return m.ReplayDigest
+	}
+	return ""
+}
+
+func (m *Session) GetReplayFrames() int64 {
+	if m != nil {
+		return m.ReplayFrames
+	}
+	return 0
+}
+
+func (m *Session) GetReplaySignature() string {
+	if m != nil {
+		return m.ReplaySignature
+	}
+	return ""
+}
+
+func (m *Session) GetReplaySigner() string {
+	if m != nil {
+		return m.ReplaySigner
+	}
+	return ""
+}
+
 type CreateSessionRequest struct {
 	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	Command              string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
 	IsRecorded           bool     `protobuf:"varint,3,opt,name=is_recorded,json=isRecorded,proto3" json:"is_recorded,omitempty"`
+	Stage                string   `protobuf:"bytes,4,opt,name=stage,proto3" json:"stage,omitempty"`
+	Hostname             string   `protobuf:"bytes,5,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	User                 string   `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`
+	ClientAddr           string   `protobuf:"bytes,7,opt,name=client_addr,json=clientAddr,proto3" json:"client_addr,omitempty"`
+	ClientVersion        string   `protobuf:"bytes,8,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	KeyFingerprint       string   `protobuf:"bytes,9,opt,name=key_fingerprint,json=keyFingerprint,proto3" json:"key_fingerprint,omitempty"`
+	PtyTerm              string   `protobuf:"bytes,10,opt,name=pty_term,json=ptyTerm,proto3" json:"pty_term,omitempty"`
+	PtyWidth             int32    `protobuf:"varint,11,opt,name=pty_width,json=ptyWidth,proto3" json:"pty_width,omitempty"`
+	PtyHeight            int32    `protobuf:"varint,12,opt,name=pty_height,json=ptyHeight,proto3" json:"pty_height,omitempty"`
+	SshdInstance         string   `protobuf:"bytes,13,opt,name=sshd_instance,json=sshdInstance,proto3" json:"sshd_instance,omitempty"`
+	ParentId             int64    `protobuf:"varint,14,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2580,7 +2966,7 @@ func (m *CreateSessionRequest) Reset()         { *m = CreateSessionRequest{} }
 func (m *CreateSessionRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateSessionRequest) ProtoMessage()    {}
 func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{55}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{57}
 }
 
 func (m *CreateSessionRequest) XXX_Unmarshal(b []byte) error {
@@ -2622,10 +3008,87 @@ func (m *CreateSessionRequest) GetIsRecorded() bool {
 	return false
 }
 
-type CreateSessionResponse struct {
-	Session              *Session `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
+func (m *CreateSessionRequest) GetStage() string {
+	if m != nil {
+		return m.Stage
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetClientAddr() string {
+	if m != nil {
+		return m.ClientAddr
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetClientVersion() string {
+	if m != nil {
+		return m.ClientVersion
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetKeyFingerprint() string {
+	if m != nil {
+		return m.KeyFingerprint
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetPtyTerm() string {
+	if m != nil {
+		return m.PtyTerm
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetPtyWidth() int32 {
+	if m != nil {
+		return m.PtyWidth
+	}
+	return 0
+}
+
+func (m *CreateSessionRequest) GetPtyHeight() int32 {
+	if m != nil {
+		return m.PtyHeight
+	}
+	return 0
+}
+
+func (m *CreateSessionRequest) GetSshdInstance() string {
+	if m != nil {
+		return m.SshdInstance
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetParentId() int64 {
+	if m != nil {
+		return m.ParentId
+	}
+	return 0
+}
+
+type CreateSessionResponse struct {
+	Session              *Session `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
@@ -2633,7 +3096,7 @@ func (m *CreateSessionResponse) Reset()         { *m = CreateSessionResponse{} }
 func (m *CreateSessionResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateSessionResponse) ProtoMessage()    {}
 func (*CreateSessionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{56}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{58}
 }
 
 func (m *CreateSessionResponse) XXX_Unmarshal(b []byte) error {
@@ -2672,7 +3135,7 @@ func (m *FinishSessionRequest) Reset()         { *m = FinishSessionRequest{} }
 func (m *FinishSessionRequest) String() string { return proto.CompactTextString(m) }
 func (*FinishSessionRequest) ProtoMessage()    {}
 func (*FinishSessionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{57}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{59}
 }
 
 func (m *FinishSessionRequest) XXX_Unmarshal(b []byte) error {
@@ -2711,7 +3174,7 @@ func (m *FinishSessionResponse) Reset()         { *m = FinishSessionResponse{} }
 func (m *FinishSessionResponse) String() string { return proto.CompactTextString(m) }
 func (*FinishSessionResponse) ProtoMessage()    {}
 func (*FinishSessionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{58}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{60}
 }
 
 func (m *FinishSessionResponse) XXX_Unmarshal(b []byte) error {
@@ -2742,6 +3205,14 @@ func (m *FinishSessionResponse) GetSession() *Session {
 type ListSessionsRequest struct {
 	Skip                 int32    `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
 	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Account              string   `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Hostname             string   `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Since                int64    `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`
+	Until                int64    `protobuf:"varint,6,opt,name=until,proto3" json:"until,omitempty"`
+	Command              string   `protobuf:"bytes,7,opt,name=command,proto3" json:"command,omitempty"`
+	OnlyRecorded         bool     `protobuf:"varint,8,opt,name=only_recorded,json=onlyRecorded,proto3" json:"only_recorded,omitempty"`
+	OnlyActive           bool     `protobuf:"varint,9,opt,name=only_active,json=onlyActive,proto3" json:"only_active,omitempty"`
+	Cursor               int64    `protobuf:"varint,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2751,7 +3222,7 @@ func (m *ListSessionsRequest) Reset()         { *m = ListSessionsRequest{} }
 func (m *ListSessionsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListSessionsRequest) ProtoMessage()    {}
 func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{59}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{61}
 }
 
 func (m *ListSessionsRequest) XXX_Unmarshal(b []byte) error {
@@ -2786,11 +3257,68 @@ func (m *ListSessionsRequest) GetLimit() int32 {
 	return 0
 }
 
+func (m *ListSessionsRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *ListSessionsRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *ListSessionsRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *ListSessionsRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *ListSessionsRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *ListSessionsRequest) GetOnlyRecorded() bool {
+	if m != nil {
+		return m.OnlyRecorded
+	}
+	return false
+}
+
+func (m *ListSessionsRequest) GetOnlyActive() bool {
+	if m != nil {
+		return m.OnlyActive
+	}
+	return false
+}
+
+func (m *ListSessionsRequest) GetCursor() int64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
 type ListSessionsResponse struct {
 	Skip                 int32      `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
 	Limit                int32      `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Total                int32      `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	Sessions             []*Session `protobuf:"bytes,4,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	NextCursor           int64      `protobuf:"varint,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
@@ -2800,7 +3328,7 @@ func (m *ListSessionsResponse) Reset()         { *m = ListSessionsResponse{} }
 func (m *ListSessionsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListSessionsResponse) ProtoMessage()    {}
 func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{60}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{62}
 }
 
 func (m *ListSessionsResponse) XXX_Unmarshal(b []byte) error {
@@ -2849,6 +3377,13 @@ func (m *ListSessionsResponse) GetSessions() []*Session {
 	return nil
 }
 
+func (m *ListSessionsResponse) GetNextCursor() int64 {
+	if m != nil {
+		return m.NextCursor
+	}
+	return 0
+}
+
 type GetSessionRequest struct {
 	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2860,7 +3395,7 @@ func (m *GetSessionRequest) Reset()         { *m = GetSessionRequest{} }
 func (m *GetSessionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetSessionRequest) ProtoMessage()    {}
 func (*GetSessionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{61}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{63}
 }
 
 func (m *GetSessionRequest) XXX_Unmarshal(b []byte) error {
@@ -2899,7 +3434,7 @@ func (m *GetSessionResponse) Reset()         { *m = GetSessionResponse{} }
 func (m *GetSessionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetSessionResponse) ProtoMessage()    {}
 func (*GetSessionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{62}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{64}
 }
 
 func (m *GetSessionResponse) XXX_Unmarshal(b []byte) error {
@@ -2927,6 +3462,92 @@ func (m *GetSessionResponse) GetSession() *Session {
 	return nil
 }
 
+type UpdateSessionLegalHoldRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	LegalHold            bool     `protobuf:"varint,2,opt,name=legal_hold,json=legalHold,proto3" json:"legal_hold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateSessionLegalHoldRequest) Reset()         { *m = UpdateSessionLegalHoldRequest{} }
+func (m *UpdateSessionLegalHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateSessionLegalHoldRequest) ProtoMessage()    {}
+func (*UpdateSessionLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{65}
+}
+
+func (m *UpdateSessionLegalHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateSessionLegalHoldRequest.Unmarshal(m, b)
+}
+func (m *UpdateSessionLegalHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateSessionLegalHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateSessionLegalHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateSessionLegalHoldRequest.Merge(m, src)
+}
+func (m *UpdateSessionLegalHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateSessionLegalHoldRequest.Size(m)
+}
+func (m *UpdateSessionLegalHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateSessionLegalHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateSessionLegalHoldRequest proto.InternalMessageInfo
+
+func (m *UpdateSessionLegalHoldRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *UpdateSessionLegalHoldRequest) GetLegalHold() bool {
+	if m != nil {
+		return m.LegalHold
+	}
+	return false
+}
+
+type UpdateSessionLegalHoldResponse struct {
+	Session              *Session `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateSessionLegalHoldResponse) Reset()         { *m = UpdateSessionLegalHoldResponse{} }
+func (m *UpdateSessionLegalHoldResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateSessionLegalHoldResponse) ProtoMessage()    {}
+func (*UpdateSessionLegalHoldResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{66}
+}
+
+func (m *UpdateSessionLegalHoldResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateSessionLegalHoldResponse.Unmarshal(m, b)
+}
+func (m *UpdateSessionLegalHoldResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateSessionLegalHoldResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateSessionLegalHoldResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateSessionLegalHoldResponse.Merge(m, src)
+}
+func (m *UpdateSessionLegalHoldResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateSessionLegalHoldResponse.Size(m)
+}
+func (m *UpdateSessionLegalHoldResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateSessionLegalHoldResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateSessionLegalHoldResponse proto.InternalMessageInfo
+
+func (m *UpdateSessionLegalHoldResponse) GetSession() *Session {
+	if m != nil {
+		return m.Session
+	}
+	return nil
+}
+
 type Token struct {
 	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Account              string   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
@@ -2934,6 +3555,11 @@ type Token struct {
 	Description          string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
 	CreatedAt            int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	ViewedAt             int64    `protobuf:"varint,6,opt,name=viewed_at,json=viewedAt,proto3" json:"viewed_at,omitempty"`
+	Kind                 string   `protobuf:"bytes,7,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name                 string   `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes               []string `protobuf:"bytes,9,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	IdleTimeout          int64    `protobuf:"varint,11,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2943,7 +3569,7 @@ func (m *Token) Reset()         { *m = Token{} }
 func (m *Token) String() string { return proto.CompactTextString(m) }
 func (*Token) ProtoMessage()    {}
 func (*Token) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{63}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{67}
 }
 
 func (m *Token) XXX_Unmarshal(b []byte) error {
@@ -3006,9 +3632,49 @@ func (m *Token) GetViewedAt() int64 {
 	return 0
 }
 
+func (m *Token) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Token) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Token) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *Token) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *Token) GetIdleTimeout() int64 {
+	if m != nil {
+		return m.IdleTimeout
+	}
+	return 0
+}
+
 type CreateTokenRequest struct {
 	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Kind                 string   `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name                 string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes               []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	IdleTimeout          int64    `protobuf:"varint,7,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3018,7 +3684,7 @@ func (m *CreateTokenRequest) Reset()         { *m = CreateTokenRequest{} }
 func (m *CreateTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateTokenRequest) ProtoMessage()    {}
 func (*CreateTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{64}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{68}
 }
 
 func (m *CreateTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -3053,6 +3719,41 @@ func (m *CreateTokenRequest) GetDescription() string {
 	return ""
 }
 
+func (m *CreateTokenRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *CreateTokenRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateTokenRequest) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *CreateTokenRequest) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *CreateTokenRequest) GetIdleTimeout() int64 {
+	if m != nil {
+		return m.IdleTimeout
+	}
+	return 0
+}
+
 type CreateTokenResponse struct {
 	Token                *Token   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -3064,7 +3765,7 @@ func (m *CreateTokenResponse) Reset()         { *m = CreateTokenResponse{} }
 func (m *CreateTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateTokenResponse) ProtoMessage()    {}
 func (*CreateTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{65}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{69}
 }
 
 func (m *CreateTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -3104,7 +3805,7 @@ func (m *GetTokenRequest) Reset()         { *m = GetTokenRequest{} }
 func (m *GetTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTokenRequest) ProtoMessage()    {}
 func (*GetTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{66}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{70}
 }
 
 func (m *GetTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -3150,7 +3851,7 @@ func (m *GetTokenResponse) Reset()         { *m = GetTokenResponse{} }
 func (m *GetTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*GetTokenResponse) ProtoMessage()    {}
 func (*GetTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{67}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{71}
 }
 
 func (m *GetTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -3190,7 +3891,7 @@ func (m *TouchTokenRequest) Reset()         { *m = TouchTokenRequest{} }
 func (m *TouchTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*TouchTokenRequest) ProtoMessage()    {}
 func (*TouchTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{68}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{72}
 }
 
 func (m *TouchTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -3236,7 +3937,7 @@ func (m *TouchTokenResponse) Reset()         { *m = TouchTokenResponse{} }
 func (m *TouchTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*TouchTokenResponse) ProtoMessage()    {}
 func (*TouchTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{69}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{73}
 }
 
 func (m *TouchTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -3275,7 +3976,7 @@ func (m *ListTokensRequest) Reset()         { *m = ListTokensRequest{} }
 func (m *ListTokensRequest) String() string { return proto.CompactTextString(m) }
 func (*ListTokensRequest) ProtoMessage()    {}
 func (*ListTokensRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{70}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{74}
 }
 
 func (m *ListTokensRequest) XXX_Unmarshal(b []byte) error {
@@ -3314,7 +4015,7 @@ func (m *ListTokensResponse) Reset()         { *m = ListTokensResponse{} }
 func (m *ListTokensResponse) String() string { return proto.CompactTextString(m) }
 func (*ListTokensResponse) ProtoMessage()    {}
 func (*ListTokensResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{71}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{75}
 }
 
 func (m *ListTokensResponse) XXX_Unmarshal(b []byte) error {
@@ -3353,7 +4054,7 @@ func (m *DeleteTokenRequest) Reset()         { *m = DeleteTokenRequest{} }
 func (m *DeleteTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteTokenRequest) ProtoMessage()    {}
 func (*DeleteTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{72}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{76}
 }
 
 func (m *DeleteTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -3391,7 +4092,7 @@ func (m *DeleteTokenResponse) Reset()         { *m = DeleteTokenResponse{} }
 func (m *DeleteTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteTokenResponse) ProtoMessage()    {}
 func (*DeleteTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{73}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{77}
 }
 
 func (m *DeleteTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -3426,7 +4127,7 @@ func (m *ReplayFrame) Reset()         { *m = ReplayFrame{} }
 func (m *ReplayFrame) String() string { return proto.CompactTextString(m) }
 func (*ReplayFrame) ProtoMessage()    {}
 func (*ReplayFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{74}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{78}
 }
 
 func (m *ReplayFrame) XXX_Unmarshal(b []byte) error {
@@ -3480,6 +4181,9 @@ type ReplaySearchResult struct {
 	Timestamp            uint32   `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Account              string   `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
 	CreatedAt            int64    `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Command              string   `protobuf:"bytes,5,opt,name=command,proto3" json:"command,omitempty"`
+	Hostnames            []string `protobuf:"bytes,6,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	Highlights           []string `protobuf:"bytes,7,rep,name=highlights,proto3" json:"highlights,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3489,7 +4193,7 @@ func (m *ReplaySearchResult) Reset()         { *m = ReplaySearchResult{} }
 func (m *ReplaySearchResult) String() string { return proto.CompactTextString(m) }
 func (*ReplaySearchResult) ProtoMessage()    {}
 func (*ReplaySearchResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{75}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{79}
 }
 
 func (m *ReplaySearchResult) XXX_Unmarshal(b []byte) error {
@@ -3538,17 +4242,109 @@ func (m *ReplaySearchResult) GetCreatedAt() int64 {
 	return 0
 }
 
-type WriteReplayResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+func (m *ReplaySearchResult) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *ReplaySearchResult) GetHostnames() []string {
+	if m != nil {
+		return m.Hostnames
+	}
+	return nil
+}
+
+func (m *ReplaySearchResult) GetHighlights() []string {
+	if m != nil {
+		return m.Highlights
+	}
+	return nil
+}
+
+type TranscriptEntry struct {
+	Timestamp            uint32   `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Prompt               string   `protobuf:"bytes,2,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Command              string   `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Output               string   `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`
+	OutputTruncated      bool     `protobuf:"varint,5,opt,name=output_truncated,json=outputTruncated,proto3" json:"output_truncated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TranscriptEntry) Reset()         { *m = TranscriptEntry{} }
+func (m *TranscriptEntry) String() string { return proto.CompactTextString(m) }
+func (*TranscriptEntry) ProtoMessage()    {}
+func (*TranscriptEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{80}
+}
+
+func (m *TranscriptEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TranscriptEntry.Unmarshal(m, b)
+}
+func (m *TranscriptEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TranscriptEntry.Marshal(b, m, deterministic)
+}
+func (m *TranscriptEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TranscriptEntry.Merge(m, src)
+}
+func (m *TranscriptEntry) XXX_Size() int {
+	return xxx_messageInfo_TranscriptEntry.Size(m)
+}
+func (m *TranscriptEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_TranscriptEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TranscriptEntry proto.InternalMessageInfo
+
+func (m *TranscriptEntry) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *TranscriptEntry) GetPrompt() string {
+	if m != nil {
+		return m.Prompt
+	}
+	return ""
+}
+
+func (m *TranscriptEntry) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *TranscriptEntry) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *TranscriptEntry) GetOutputTruncated() bool {
+	if m != nil {
+		return m.OutputTruncated
+	}
+	return false
+}
+
+type WriteReplayResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
 func (m *WriteReplayResponse) Reset()         { *m = WriteReplayResponse{} }
 func (m *WriteReplayResponse) String() string { return proto.CompactTextString(m) }
 func (*WriteReplayResponse) ProtoMessage()    {}
 func (*WriteReplayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{76}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{81}
 }
 
 func (m *WriteReplayResponse) XXX_Unmarshal(b []byte) error {
@@ -3580,7 +4376,7 @@ func (m *ReadReplayRequest) Reset()         { *m = ReadReplayRequest{} }
 func (m *ReadReplayRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadReplayRequest) ProtoMessage()    {}
 func (*ReadReplayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{77}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{82}
 }
 
 func (m *ReadReplayRequest) XXX_Unmarshal(b []byte) error {
@@ -3619,7 +4415,7 @@ func (m *SubmitReplayRequest) Reset()         { *m = SubmitReplayRequest{} }
 func (m *SubmitReplayRequest) String() string { return proto.CompactTextString(m) }
 func (*SubmitReplayRequest) ProtoMessage()    {}
 func (*SubmitReplayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{78}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{83}
 }
 
 func (m *SubmitReplayRequest) XXX_Unmarshal(b []byte) error {
@@ -3657,7 +4453,7 @@ func (m *SubmitReplayResponse) Reset()         { *m = SubmitReplayResponse{} }
 func (m *SubmitReplayResponse) String() string { return proto.CompactTextString(m) }
 func (*SubmitReplayResponse) ProtoMessage()    {}
 func (*SubmitReplayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{79}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{84}
 }
 
 func (m *SubmitReplayResponse) XXX_Unmarshal(b []byte) error {
@@ -3680,6 +4476,14 @@ var xxx_messageInfo_SubmitReplayResponse proto.InternalMessageInfo
 
 type SearchReplayRequest struct {
 	Keyword              string   `protobuf:"bytes,1,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	Commands             bool     `protobuf:"varint,2,opt,name=commands,proto3" json:"commands,omitempty"`
+	Match                string   `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`
+	Account              string   `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`
+	Hostname             string   `protobuf:"bytes,5,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Since                int64    `protobuf:"varint,6,opt,name=since,proto3" json:"since,omitempty"`
+	Until                int64    `protobuf:"varint,7,opt,name=until,proto3" json:"until,omitempty"`
+	From                 int64    `protobuf:"varint,8,opt,name=from,proto3" json:"from,omitempty"`
+	Size                 int64    `protobuf:"varint,9,opt,name=size,proto3" json:"size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3689,7 +4493,7 @@ func (m *SearchReplayRequest) Reset()         { *m = SearchReplayRequest{} }
 func (m *SearchReplayRequest) String() string { return proto.CompactTextString(m) }
 func (*SearchReplayRequest) ProtoMessage()    {}
 func (*SearchReplayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{80}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{85}
 }
 
 func (m *SearchReplayRequest) XXX_Unmarshal(b []byte) error {
@@ -3717,8 +4521,65 @@ func (m *SearchReplayRequest) GetKeyword() string {
 	return ""
 }
 
+func (m *SearchReplayRequest) GetCommands() bool {
+	if m != nil {
+		return m.Commands
+	}
+	return false
+}
+
+func (m *SearchReplayRequest) GetMatch() string {
+	if m != nil {
+		return m.Match
+	}
+	return ""
+}
+
+func (m *SearchReplayRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *SearchReplayRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *SearchReplayRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *SearchReplayRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *SearchReplayRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *SearchReplayRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 type SearchReplayResponse struct {
 	Results              []*ReplaySearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Total                int64                 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -3728,7 +4589,7 @@ func (m *SearchReplayResponse) Reset()         { *m = SearchReplayResponse{} }
 func (m *SearchReplayResponse) String() string { return proto.CompactTextString(m) }
 func (*SearchReplayResponse) ProtoMessage()    {}
 func (*SearchReplayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ec90cbc4aa12fc6, []int{81}
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{86}
 }
 
 func (m *SearchReplayResponse) XXX_Unmarshal(b []byte) error {
@@ -3756,282 +4617,3529 @@ func (m *SearchReplayResponse) GetResults() []*ReplaySearchResult {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*User)(nil), "types.User")
-	proto.RegisterType((*ListUsersRequest)(nil), "types.ListUsersRequest")
-	proto.RegisterType((*ListUsersResponse)(nil), "types.ListUsersResponse")
-	proto.RegisterType((*CreateUserRequest)(nil), "types.CreateUserRequest")
-	proto.RegisterType((*CreateUserResponse)(nil), "types.CreateUserResponse")
-	proto.RegisterType((*TouchUserRequest)(nil), "types.TouchUserRequest")
-	proto.RegisterType((*TouchUserResponse)(nil), "types.TouchUserResponse")
-	proto.RegisterType((*UpdateUserRequest)(nil), "types.UpdateUserRequest")
-	proto.RegisterType((*UpdateUserResponse)(nil), "types.UpdateUserResponse")
-	proto.RegisterType((*AuthenticateUserRequest)(nil), "types.AuthenticateUserRequest")
-	proto.RegisterType((*AuthenticateUserResponse)(nil), "types.AuthenticateUserResponse")
-	proto.RegisterType((*GetUserRequest)(nil), "types.GetUserRequest")
-	proto.RegisterType((*GetUserResponse)(nil), "types.GetUserResponse")
-	proto.RegisterType((*Node)(nil), "types.Node")
-	proto.RegisterType((*ListNodesRequest)(nil), "types.ListNodesRequest")
-	proto.RegisterType((*ListNodesResponse)(nil), "types.ListNodesResponse")
-	proto.RegisterType((*PutNodeRequest)(nil), "types.PutNodeRequest")
-	proto.RegisterType((*PutNodeResponse)(nil), "types.PutNodeResponse")
-	proto.RegisterType((*DeleteNodeRequest)(nil), "types.DeleteNodeRequest")
-	proto.RegisterType((*DeleteNodeResponse)(nil), "types.DeleteNodeResponse")
-	proto.RegisterType((*GetNodeRequest)(nil), "types.GetNodeRequest")
-	proto.RegisterType((*GetNodeResponse)(nil), "types.GetNodeResponse")
-	proto.RegisterType((*TouchNodeRequest)(nil), "types.TouchNodeRequest")
-	proto.RegisterType((*TouchNodeResponse)(nil), "types.TouchNodeResponse")
-	proto.RegisterType((*UpdateNodeRequest)(nil), "types.UpdateNodeRequest")
-	proto.RegisterType((*UpdateNodeResponse)(nil), "types.UpdateNodeResponse")
-	proto.RegisterType((*Key)(nil), "types.Key")
-	proto.RegisterType((*ListKeysRequest)(nil), "types.ListKeysRequest")
-	proto.RegisterType((*ListKeysResponse)(nil), "types.ListKeysResponse")
-	proto.RegisterType((*CreateKeyRequest)(nil), "types.CreateKeyRequest")
-	proto.RegisterType((*CreateKeyResponse)(nil), "types.CreateKeyResponse")
-	proto.RegisterType((*DeleteKeyRequest)(nil), "types.DeleteKeyRequest")
-	proto.RegisterType((*DeleteKeyResponse)(nil), "types.DeleteKeyResponse")
-	proto.RegisterType((*GetKeyRequest)(nil), "types.GetKeyRequest")
-	proto.RegisterType((*GetKeyResponse)(nil), "types.GetKeyResponse")
-	proto.RegisterType((*TouchKeyRequest)(nil), "types.TouchKeyRequest")
-	proto.RegisterType((*TouchKeyResponse)(nil), "types.TouchKeyResponse")
-	proto.RegisterType((*MasterKey)(nil), "types.MasterKey")
-	proto.RegisterType((*ListMasterKeysRequest)(nil), "types.ListMasterKeysRequest")
-	proto.RegisterType((*ListMasterKeysResponse)(nil), "types.ListMasterKeysResponse")
-	proto.RegisterType((*UpdateAllMasterKeysRequest)(nil), "types.UpdateAllMasterKeysRequest")
-	proto.RegisterType((*UpdateAllMasterKeysResponse)(nil), "types.UpdateAllMasterKeysResponse")
-	proto.RegisterType((*Grant)(nil), "types.Grant")
-	proto.RegisterType((*GrantItem)(nil), "types.GrantItem")
-	proto.RegisterType((*PutGrantRequest)(nil), "types.PutGrantRequest")
-	proto.RegisterType((*PutGrantResponse)(nil), "types.PutGrantResponse")
-	proto.RegisterType((*ListGrantsRequest)(nil), "types.ListGrantsRequest")
-	proto.RegisterType((*ListGrantsResponse)(nil), "types.ListGrantsResponse")
-	proto.RegisterType((*ListGrantItemsRequest)(nil), "types.ListGrantItemsRequest")
-	proto.RegisterType((*ListGrantItemsResponse)(nil), "types.ListGrantItemsResponse")
-	proto.RegisterType((*DeleteGrantRequest)(nil), "types.DeleteGrantRequest")
-	proto.RegisterType((*DeleteGrantResponse)(nil), "types.DeleteGrantResponse")
-	proto.RegisterType((*CheckGrantRequest)(nil), "types.CheckGrantRequest")
-	proto.RegisterType((*CheckGrantResponse)(nil), "types.CheckGrantResponse")
-	proto.RegisterType((*Session)(nil), "types.Session")
-	proto.RegisterType((*CreateSessionRequest)(nil), "types.CreateSessionRequest")
-	proto.RegisterType((*CreateSessionResponse)(nil), "types.CreateSessionResponse")
-	proto.RegisterType((*FinishSessionRequest)(nil), "types.FinishSessionRequest")
-	proto.RegisterType((*FinishSessionResponse)(nil), "types.FinishSessionResponse")
-	proto.RegisterType((*ListSessionsRequest)(nil), "types.ListSessionsRequest")
-	proto.RegisterType((*ListSessionsResponse)(nil), "types.ListSessionsResponse")
-	proto.RegisterType((*GetSessionRequest)(nil), "types.GetSessionRequest")
-	proto.RegisterType((*GetSessionResponse)(nil), "types.GetSessionResponse")
-	proto.RegisterType((*Token)(nil), "types.Token")
-	proto.RegisterType((*CreateTokenRequest)(nil), "types.CreateTokenRequest")
-	proto.RegisterType((*CreateTokenResponse)(nil), "types.CreateTokenResponse")
-	proto.RegisterType((*GetTokenRequest)(nil), "types.GetTokenRequest")
-	proto.RegisterType((*GetTokenResponse)(nil), "types.GetTokenResponse")
-	proto.RegisterType((*TouchTokenRequest)(nil), "types.TouchTokenRequest")
-	proto.RegisterType((*TouchTokenResponse)(nil), "types.TouchTokenResponse")
-	proto.RegisterType((*ListTokensRequest)(nil), "types.ListTokensRequest")
-	proto.RegisterType((*ListTokensResponse)(nil), "types.ListTokensResponse")
-	proto.RegisterType((*DeleteTokenRequest)(nil), "types.DeleteTokenRequest")
-	proto.RegisterType((*DeleteTokenResponse)(nil), "types.DeleteTokenResponse")
-	proto.RegisterType((*ReplayFrame)(nil), "types.ReplayFrame")
-	proto.RegisterType((*ReplaySearchResult)(nil), "types.ReplaySearchResult")
-	proto.RegisterType((*WriteReplayResponse)(nil), "types.WriteReplayResponse")
-	proto.RegisterType((*ReadReplayRequest)(nil), "types.ReadReplayRequest")
-	proto.RegisterType((*SubmitReplayRequest)(nil), "types.SubmitReplayRequest")
-	proto.RegisterType((*SubmitReplayResponse)(nil), "types.SubmitReplayResponse")
-	proto.RegisterType((*SearchReplayRequest)(nil), "types.SearchReplayRequest")
-	proto.RegisterType((*SearchReplayResponse)(nil), "types.SearchReplayResponse")
+func (m *SearchReplayResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
 }
 
-func init() { proto.RegisterFile("daemon.proto", fileDescriptor_3ec90cbc4aa12fc6) }
+type GetTranscriptRequest struct {
+	SessionId            int64    `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
-var fileDescriptor_3ec90cbc4aa12fc6 = []byte{
-	// 2244 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0x4b, 0x8f, 0x1b, 0xc7,
-	0x11, 0xe6, 0xf0, 0xb1, 0x24, 0x8b, 0xfb, 0x20, 0x7b, 0xb9, 0xbb, 0x14, 0xa5, 0xb5, 0xd6, 0x1d,
-	0xc1, 0xd9, 0x28, 0xce, 0xda, 0x4b, 0x29, 0x76, 0xec, 0xc0, 0x8e, 0x37, 0x0a, 0x24, 0x08, 0xbb,
-	0x96, 0x84, 0x91, 0x85, 0x00, 0x01, 0x12, 0x62, 0xc4, 0xe9, 0x68, 0x07, 0x7c, 0x0c, 0x33, 0x33,
-	0xb4, 0xcc, 0x9c, 0x82, 0x9c, 0x92, 0x5c, 0x73, 0xcf, 0x2d, 0x40, 0x72, 0xf1, 0x2f, 0xc8, 0x2d,
-	0x08, 0x02, 0xe4, 0x96, 0x5f, 0x91, 0x6b, 0x7e, 0x42, 0xd0, 0xcf, 0xe9, 0xee, 0x19, 0x71, 0x87,
-	0x76, 0xe4, 0x1b, 0xa7, 0xaa, 0xbb, 0xaa, 0xfa, 0xeb, 0xaa, 0xea, 0xaa, 0x02, 0x61, 0xd3, 0xf7,
-	0xc8, 0x34, 0x9c, 0x9d, 0xcc, 0xa3, 0x30, 0x09, 0x51, 0x2d, 0x59, 0xce, 0x49, 0x8c, 0xff, 0xed,
-	0x40, 0xf5, 0x59, 0x4c, 0x22, 0xd4, 0x83, 0xba, 0x37, 0x1a, 0x85, 0x8b, 0x59, 0xd2, 0x2b, 0x1f,
-	0x39, 0xc7, 0x4d, 0x57, 0x7e, 0xa2, 0x3e, 0x34, 0x66, 0xc1, 0x68, 0x3c, 0xf3, 0xa6, 0xa4, 0x57,
-	0x61, 0x2c, 0xf5, 0x8d, 0xae, 0x41, 0x23, 0x88, 0x87, 0x9e, 0x3f, 0x0d, 0x66, 0xbd, 0xea, 0x91,
-	0x73, 0xdc, 0x70, 0xeb, 0x41, 0x7c, 0x46, 0x3f, 0xd1, 0x21, 0x40, 0x10, 0x0f, 0x9f, 0x4f, 0xc2,
-	0xd1, 0x98, 0xf8, 0xbd, 0x1a, 0x63, 0x36, 0x83, 0xf8, 0xc7, 0x9c, 0x40, 0xd9, 0xa3, 0x88, 0x78,
-	0x09, 0xf1, 0x87, 0x5e, 0xd2, 0xdb, 0x38, 0x72, 0x8e, 0x2b, 0x6e, 0x53, 0x50, 0xce, 0x12, 0xca,
-	0x5e, 0xcc, 0x7d, 0xc9, 0xae, 0x73, 0xb6, 0xa0, 0x9c, 0x25, 0xe8, 0x3a, 0x34, 0x3f, 0x0f, 0xc8,
-	0x4b, 0xce, 0x6d, 0x30, 0x6e, 0x83, 0x13, 0xce, 0x12, 0x8c, 0xa0, 0x7d, 0x11, 0xc4, 0x09, 0x3d,
-	0x56, 0xec, 0x92, 0x5f, 0x2d, 0x48, 0x9c, 0xe0, 0xf7, 0xa0, 0xa3, 0xd1, 0xe2, 0x79, 0x38, 0x8b,
-	0x09, 0x7a, 0x13, 0x6a, 0x0b, 0x4a, 0xe8, 0x39, 0x47, 0x95, 0xe3, 0xd6, 0xa0, 0x75, 0xc2, 0x30,
-	0x39, 0xa1, 0x8b, 0x5c, 0xce, 0xc1, 0xbf, 0x71, 0xa0, 0x73, 0x8f, 0x59, 0xc5, 0xa8, 0x5c, 0x9a,
-	0x0e, 0x96, 0x93, 0x01, 0x6b, 0xee, 0xc5, 0xf1, 0xcb, 0x30, 0xf2, 0x05, 0x8e, 0xea, 0xfb, 0x2b,
-	0x02, 0x89, 0xbf, 0x0f, 0x48, 0xb7, 0x40, 0xd8, 0x7e, 0x13, 0xaa, 0xd4, 0x42, 0xa6, 0xdf, 0x32,
-	0x9d, 0x31, 0xf0, 0xdb, 0xd0, 0xfe, 0x2c, 0x5c, 0x8c, 0x2e, 0x0b, 0xd9, 0x8d, 0xef, 0x42, 0x47,
-	0x5b, 0x5d, 0x54, 0xc7, 0x3f, 0xca, 0xd0, 0x79, 0xc6, 0x2e, 0xa5, 0x18, 0x3a, 0xdf, 0x86, 0x1d,
-	0x7e, 0x87, 0x43, 0x05, 0x44, 0x99, 0x1d, 0x76, 0x9b, 0x93, 0x1f, 0x49, 0x38, 0x56, 0x41, 0x95,
-	0x0a, 0x51, 0x48, 0x57, 0x75, 0x21, 0x4f, 0x34, 0xbc, 0xd5, 0x8a, 0x9a, 0x75, 0x17, 0x6f, 0x29,
-	0x21, 0x0a, 0xf6, 0x0d, 0x26, 0x64, 0x8b, 0x93, 0x1f, 0x0a, 0x2f, 0xd6, 0xef, 0xa5, 0x6e, 0x3a,
-	0xf8, 0x6d, 0xe8, 0xa4, 0x22, 0xa4, 0x9f, 0x37, 0xd8, 0x9a, 0x1d, 0x29, 0x44, 0xf3, 0x76, 0x6d,
-	0x51, 0xd3, 0x0a, 0x06, 0x7a, 0xc5, 0x3a, 0x8c, 0x45, 0xe1, 0x7f, 0x0c, 0x07, 0x67, 0x8b, 0xe4,
-	0x92, 0xcc, 0x92, 0x60, 0xf4, 0xff, 0xf0, 0x50, 0xfc, 0x43, 0xe8, 0x65, 0x05, 0x16, 0xb5, 0xe6,
-	0x36, 0x6c, 0x3f, 0x20, 0x49, 0x31, 0x77, 0x1b, 0xc0, 0x8e, 0x5a, 0x5b, 0x54, 0xfe, 0xbf, 0x1c,
-	0xa8, 0x3e, 0x0a, 0x7d, 0xe6, 0x1c, 0x97, 0x61, 0x9c, 0x30, 0xe7, 0xe0, 0x72, 0xd5, 0x37, 0x42,
-	0x42, 0x0a, 0x3f, 0x19, 0xfb, 0xcd, 0xcc, 0xf0, 0xfd, 0x88, 0xc4, 0xb1, 0xf0, 0x25, 0xf9, 0x89,
-	0xf6, 0x61, 0x23, 0x0e, 0x17, 0xd1, 0x88, 0x30, 0x0f, 0x6a, 0xba, 0xe2, 0xcb, 0x4a, 0x4e, 0x35,
-	0x3b, 0x39, 0x19, 0xd9, 0x67, 0xc3, 0xcc, 0x3e, 0xe8, 0x16, 0x6c, 0x07, 0xf1, 0x70, 0x4c, 0x96,
-	0xc3, 0xa9, 0x37, 0xf3, 0x5e, 0x10, 0x5f, 0xf8, 0xcd, 0x66, 0x10, 0x9f, 0x93, 0xe5, 0xa7, 0x9c,
-	0x26, 0x73, 0x14, 0x3d, 0x8f, 0x9d, 0xa3, 0x04, 0x2d, 0xcd, 0x51, 0x33, 0x4a, 0xb0, 0x72, 0x14,
-	0x5d, 0xe4, 0x72, 0x0e, 0x8e, 0x60, 0xfb, 0xc9, 0x82, 0x6d, 0x93, 0xc0, 0xbf, 0x76, 0x84, 0xe8,
-	0x05, 0x2a, 0x9d, 0xe9, 0x05, 0x52, 0x7b, 0xac, 0x0b, 0x64, 0x4b, 0x18, 0x03, 0xbf, 0x03, 0x9d,
-	0x9f, 0x90, 0x09, 0x49, 0x48, 0x41, 0x53, 0x71, 0x17, 0x90, 0xbe, 0x81, 0xeb, 0xc1, 0x6f, 0x33,
-	0x3f, 0x2b, 0x2a, 0x83, 0x7b, 0xda, 0x7a, 0x86, 0x9e, 0x88, 0xd4, 0x59, 0x54, 0x87, 0x4c, 0x9e,
-	0xeb, 0x69, 0xf9, 0x9d, 0x23, 0x93, 0x67, 0xd1, 0xab, 0x3b, 0x85, 0xbd, 0x34, 0xe3, 0xe8, 0x1e,
-	0xc6, 0x93, 0x28, 0x92, 0x59, 0x27, 0xf5, 0xb3, 0x1c, 0x6f, 0xac, 0xe4, 0x78, 0xa3, 0xca, 0x3f,
-	0xeb, 0x9d, 0xe0, 0xaf, 0x0e, 0x54, 0xce, 0xc9, 0x12, 0x1d, 0x41, 0xeb, 0x97, 0xc1, 0xec, 0x05,
-	0x89, 0xe6, 0x51, 0xa0, 0x62, 0x5d, 0x27, 0xad, 0xa8, 0x2e, 0x10, 0x54, 0xb5, 0x2c, 0xcf, 0x7e,
-	0xbf, 0x8e, 0xb0, 0xc4, 0xdf, 0x85, 0x1d, 0x1a, 0x5c, 0xe7, 0x64, 0x19, 0x17, 0x49, 0x4f, 0xed,
-	0x74, 0xb1, 0x40, 0xe3, 0x0d, 0xa8, 0x8e, 0xc9, 0x52, 0xc6, 0x21, 0x08, 0x34, 0xce, 0xc9, 0xd2,
-	0x65, 0x74, 0xfc, 0x6b, 0x68, 0xf3, 0x67, 0x9a, 0x92, 0x84, 0x86, 0x6f, 0x08, 0x18, 0x7c, 0x2a,
-	0x8b, 0x14, 0xa6, 0x5b, 0x18, 0x7c, 0x03, 0x2a, 0x63, 0xb2, 0x14, 0xb7, 0xa7, 0xdb, 0x4b, 0xc9,
-	0xf8, 0x2e, 0xb4, 0x79, 0x6c, 0xad, 0x63, 0x2e, 0xde, 0x95, 0x21, 0xac, 0x29, 0xc2, 0xa7, 0xb0,
-	0xf5, 0x80, 0x24, 0x6b, 0xc9, 0x39, 0x61, 0x31, 0x5c, 0xdc, 0xda, 0x3b, 0xb0, 0xc3, 0x22, 0x6c,
-	0x2d, 0x25, 0xef, 0x8a, 0x30, 0x2e, 0xae, 0xe6, 0x02, 0x9a, 0x9f, 0x7a, 0x71, 0x42, 0xa2, 0x62,
-	0x5e, 0x7d, 0x08, 0x30, 0x5f, 0x3c, 0x9f, 0x04, 0x23, 0x1a, 0x60, 0xe2, 0xfe, 0x9a, 0x9c, 0x72,
-	0x4e, 0x96, 0xf8, 0x00, 0xf6, 0xa8, 0x17, 0x29, 0x89, 0x2a, 0xd1, 0x9f, 0xc3, 0xbe, 0xcd, 0x10,
-	0xe6, 0x9d, 0x42, 0x6b, 0xca, 0xa8, 0x43, 0xcd, 0xd7, 0xda, 0xc2, 0x4c, 0xb5, 0xde, 0x85, 0xa9,
-	0xda, 0x8a, 0x1f, 0x43, 0x9f, 0xc7, 0xee, 0xd9, 0x64, 0x92, 0x51, 0xf5, 0x55, 0x04, 0x1e, 0xc2,
-	0xf5, 0x5c, 0x81, 0xe2, 0xb6, 0xff, 0xe4, 0x40, 0xed, 0x41, 0xe4, 0xcd, 0x56, 0xd5, 0x18, 0xdf,
-	0x81, 0xb6, 0x4c, 0x5a, 0xc3, 0xb9, 0x97, 0x24, 0x24, 0x9a, 0x09, 0x78, 0x76, 0x24, 0xfd, 0x09,
-	0x27, 0xab, 0xe7, 0xa8, 0xa2, 0x3d, 0x47, 0x87, 0x00, 0xe4, 0x8b, 0x79, 0x10, 0xf1, 0x48, 0xae,
-	0xf2, 0x38, 0x17, 0x14, 0xde, 0x1b, 0xac, 0x48, 0x03, 0xf8, 0x67, 0xd0, 0x64, 0xf6, 0x3d, 0x4c,
-	0xc8, 0x74, 0xed, 0x97, 0xd0, 0x54, 0x5d, 0xb1, 0x54, 0xe3, 0xdf, 0x3b, 0xec, 0xdd, 0x63, 0xf2,
-	0xaf, 0x2e, 0xb5, 0x5e, 0x2b, 0x0c, 0xf8, 0x3d, 0x68, 0xa7, 0xa6, 0x08, 0xff, 0xc1, 0x50, 0x7b,
-	0x41, 0x09, 0xc2, 0xc1, 0x37, 0xc5, 0x45, 0xf3, 0x45, 0x9c, 0x85, 0xbf, 0xc7, 0xcb, 0x0c, 0x46,
-	0x2b, 0x90, 0x0b, 0x2f, 0x00, 0xe9, 0xcb, 0x85, 0xa2, 0x5b, 0xb0, 0xc1, 0xa4, 0x49, 0x97, 0x32,
-	0x35, 0x09, 0x1e, 0x6a, 0x43, 0x65, 0x16, 0xbe, 0x64, 0x67, 0xae, 0xb8, 0xf4, 0x27, 0x3e, 0xe5,
-	0x31, 0xa1, 0x2e, 0xa8, 0x80, 0x01, 0x22, 0x5a, 0xf4, 0x2d, 0x69, 0xb4, 0x30, 0x45, 0xc3, 0x80,
-	0x92, 0x2d, 0xe7, 0x56, 0xeb, 0x5d, 0x78, 0xa1, 0xb6, 0xe2, 0xa9, 0x2c, 0x29, 0xbe, 0x91, 0x2b,
-	0xc4, 0x7b, 0xb0, 0x6b, 0xa8, 0x13, 0x31, 0xf4, 0x73, 0xe8, 0xdc, 0xbb, 0x24, 0xa3, 0x71, 0x41,
-	0x23, 0x74, 0x27, 0x2e, 0xbf, 0xc2, 0x89, 0x75, 0xad, 0xb7, 0x00, 0xe9, 0xe2, 0x05, 0x5a, 0xdb,
-	0x50, 0x0e, 0xc7, 0x4c, 0x74, 0xc3, 0x2d, 0x87, 0x63, 0xfc, 0xa5, 0x03, 0xf5, 0xa7, 0x24, 0x8e,
-	0x83, 0x70, 0x46, 0x79, 0x81, 0xcf, 0x78, 0x15, 0xb7, 0x1c, 0xf8, 0x2b, 0x9e, 0xa5, 0x1e, 0xd4,
-	0x47, 0xe1, 0x74, 0xea, 0xcd, 0x7c, 0x59, 0x2a, 0x8a, 0x4f, 0x2b, 0x2c, 0xab, 0xf6, 0xeb, 0x7c,
-	0x93, 0xa5, 0xd3, 0x20, 0xbe, 0xd4, 0xc3, 0x16, 0x24, 0x89, 0x2f, 0x08, 0xe2, 0x61, 0x44, 0x46,
-	0x61, 0xe4, 0x13, 0x5f, 0xb4, 0x63, 0x10, 0xc4, 0xae, 0xa0, 0xe0, 0x31, 0x74, 0xf9, 0x2b, 0x27,
-	0xac, 0xbe, 0x1a, 0x38, 0xcd, 0xd8, 0xb2, 0x69, 0xac, 0xa5, 0xac, 0x92, 0x51, 0x76, 0x06, 0x7b,
-	0x96, 0x32, 0x01, 0xe3, 0x31, 0xd4, 0x63, 0x4e, 0x12, 0x41, 0xb6, 0x2d, 0x1c, 0x4e, 0x2e, 0x94,
-	0x6c, 0xfc, 0x16, 0x74, 0xef, 0xb3, 0xe3, 0x59, 0xf6, 0x5a, 0x60, 0x53, 0x55, 0xd6, 0xba, 0xb5,
-	0x55, 0xfd, 0x08, 0x76, 0x69, 0x8c, 0x08, 0xba, 0x0a, 0x2a, 0x04, 0xd5, 0x78, 0x1c, 0xcc, 0xd9,
-	0xee, 0x9a, 0xcb, 0x7e, 0xa3, 0x2e, 0xd4, 0x26, 0xc1, 0x34, 0xe0, 0x17, 0x5b, 0x73, 0xf9, 0x07,
-	0xfe, 0xad, 0x03, 0x5d, 0x53, 0x82, 0xb0, 0xa1, 0xb0, 0x08, 0x4a, 0x4d, 0xc2, 0xc4, 0x9b, 0x30,
-	0x30, 0x6b, 0x2e, 0xff, 0x40, 0xb7, 0xa1, 0x21, 0x8c, 0x8c, 0x7b, 0x55, 0x16, 0xa0, 0xf6, 0x21,
-	0x14, 0x1f, 0x7f, 0x0b, 0x3a, 0x0f, 0x48, 0x72, 0x05, 0x5a, 0x1f, 0x03, 0xd2, 0x17, 0xad, 0x0d,
-	0xd5, 0x9f, 0x1d, 0xa8, 0x7d, 0x16, 0x8e, 0xc9, 0x3a, 0x4e, 0xcf, 0x8e, 0x36, 0x26, 0x33, 0xe1,
-	0xf2, 0xfc, 0x83, 0x16, 0x08, 0x3e, 0x89, 0x47, 0x51, 0x30, 0x4f, 0xa8, 0x5e, 0x5e, 0x92, 0xe9,
-	0xa4, 0xaf, 0x55, 0xb0, 0x3e, 0x91, 0x63, 0x1f, 0x66, 0xec, 0xd5, 0xbe, 0x6e, 0x59, 0x53, 0xce,
-	0x58, 0x83, 0x3f, 0x80, 0x5d, 0x43, 0x62, 0xfa, 0x66, 0xf0, 0xc3, 0x99, 0x6f, 0x06, 0x5f, 0xc4,
-	0x59, 0xf8, 0x7d, 0xd6, 0x45, 0x19, 0x96, 0xd8, 0xe8, 0x29, 0x8c, 0xca, 0x1a, 0x46, 0xf4, 0x91,
-	0x4a, 0x37, 0xae, 0xa1, 0xf0, 0x03, 0xd1, 0x52, 0x19, 0x2a, 0xbb, 0xfa, 0x46, 0x75, 0x0d, 0xdc,
-	0x90, 0xb2, 0x72, 0x90, 0x1f, 0x00, 0xd2, 0xb7, 0xae, 0xa1, 0x54, 0xbc, 0x8c, 0x8c, 0x56, 0xe0,
-	0x61, 0xfa, 0x90, 0xbf, 0x8c, 0x72, 0x79, 0xfa, 0x32, 0x32, 0x69, 0xf6, 0xcb, 0xc8, 0x35, 0x09,
-	0x1e, 0x4d, 0xd1, 0xfc, 0x61, 0x58, 0x85, 0x69, 0xfa, 0x7c, 0x18, 0x67, 0xc1, 0x5f, 0x40, 0xcb,
-	0x25, 0xf3, 0x89, 0xb7, 0xbc, 0x1f, 0xd1, 0x27, 0xe0, 0x10, 0x40, 0x38, 0xf7, 0x50, 0xed, 0x6e,
-	0x0a, 0xca, 0x43, 0x1f, 0xdd, 0x80, 0x66, 0x12, 0x4c, 0x49, 0x9c, 0x78, 0xd3, 0x39, 0x83, 0x69,
-	0xcb, 0x4d, 0x09, 0x34, 0xbe, 0xa9, 0x7d, 0xcc, 0xb3, 0xb7, 0x5c, 0xf6, 0x9b, 0x1e, 0x79, 0xee,
-	0x2d, 0x27, 0xa1, 0xc7, 0x27, 0x6b, 0x9b, 0xae, 0xfc, 0xc4, 0x7f, 0x70, 0x00, 0x71, 0xd5, 0x4f,
-	0x89, 0x17, 0x8d, 0x2e, 0x5d, 0x12, 0x2f, 0x26, 0xc9, 0xd7, 0xb3, 0x40, 0x03, 0xb8, 0x62, 0xba,
-	0xf4, 0xea, 0x17, 0x85, 0xa2, 0xf3, 0xd3, 0x28, 0x48, 0x08, 0x37, 0x48, 0xa1, 0x33, 0x80, 0x8e,
-	0x4b, 0x3c, 0x5f, 0x52, 0x39, 0xb2, 0xab, 0x2d, 0xc4, 0x77, 0x61, 0xf7, 0xe9, 0xe2, 0xf9, 0x34,
-	0x48, 0xd6, 0xda, 0xb5, 0x0f, 0x5d, 0x73, 0x97, 0xb0, 0xe0, 0x1d, 0xd8, 0x95, 0xf0, 0xe8, 0xd2,
-	0x7a, 0x50, 0x1f, 0x93, 0x25, 0x1b, 0xbc, 0x09, 0x4f, 0x12, 0x9f, 0xf8, 0x1c, 0xba, 0xe6, 0x06,
-	0xe1, 0x4b, 0x77, 0xa0, 0x1e, 0x31, 0x84, 0xa5, 0x33, 0x5d, 0x13, 0xce, 0x94, 0xbd, 0x03, 0x57,
-	0xae, 0x1c, 0x7c, 0x59, 0x81, 0xd6, 0xb3, 0x98, 0x44, 0x4f, 0x49, 0xf4, 0x79, 0x30, 0x22, 0xe8,
-	0x13, 0x68, 0xaa, 0xd1, 0x37, 0x3a, 0x10, 0x02, 0xec, 0x01, 0x79, 0xbf, 0x97, 0x65, 0x88, 0xd3,
-	0x94, 0xd0, 0x3d, 0x80, 0x74, 0x02, 0x8d, 0xe4, 0xca, 0xcc, 0x58, 0xbc, 0x7f, 0x2d, 0x87, 0xa3,
-	0x84, 0x7c, 0x02, 0x4d, 0x35, 0x61, 0x56, 0x66, 0xd8, 0x13, 0x6a, 0x65, 0x46, 0x66, 0x18, 0xcd,
-	0xcd, 0x48, 0xa7, 0xa4, 0xca, 0x8c, 0xcc, 0xfc, 0x59, 0x99, 0x91, 0x1d, 0xa9, 0xe2, 0x12, 0x7a,
-	0x06, 0x6d, 0x7b, 0xc4, 0x89, 0xde, 0x10, 0x1b, 0x5e, 0x31, 0x4c, 0xed, 0xdf, 0x7c, 0x25, 0x5f,
-	0x89, 0xfd, 0x10, 0xea, 0x62, 0xa0, 0x89, 0xf6, 0x64, 0x01, 0x6a, 0x0c, 0x43, 0xfb, 0xfb, 0x36,
-	0x59, 0xee, 0x1d, 0xfc, 0xb1, 0x02, 0xad, 0x47, 0xa1, 0x4f, 0xac, 0x0b, 0x63, 0x73, 0x40, 0xe3,
-	0xc2, 0xf4, 0x69, 0xa1, 0x71, 0x61, 0xc6, 0xc8, 0x90, 0x5b, 0x23, 0xa6, 0x73, 0xca, 0x1a, 0x73,
-	0x42, 0xa8, 0xac, 0xb1, 0x86, 0x78, 0x1c, 0xe5, 0x74, 0xe8, 0xa6, 0x50, 0xce, 0x0c, 0xee, 0x14,
-	0xca, 0x39, 0x13, 0x3a, 0x09, 0x87, 0x61, 0x80, 0x39, 0xb3, 0xd3, 0xe1, 0xb0, 0xf6, 0x4a, 0x47,
-	0x61, 0xbb, 0x0d, 0x47, 0xd1, 0xf7, 0xf7, 0xb2, 0x8c, 0xac, 0xa3, 0x18, 0x47, 0xc8, 0xcc, 0xda,
-	0x2c, 0x47, 0x31, 0x85, 0x0c, 0xfe, 0x59, 0x06, 0x38, 0x27, 0x4b, 0x79, 0x29, 0x1f, 0x41, 0x43,
-	0x8e, 0x84, 0xd0, 0xbe, 0x06, 0xbd, 0xd6, 0x6c, 0xf7, 0x0f, 0x32, 0x74, 0xfd, 0x50, 0x6a, 0x42,
-	0xa3, 0x0e, 0x65, 0xcf, 0x8b, 0xfa, 0xbd, 0x2c, 0x43, 0x97, 0xa0, 0x46, 0x2f, 0x4a, 0x82, 0x3d,
-	0xc2, 0xe9, 0xf7, 0xb2, 0x0c, 0x25, 0xe1, 0x7d, 0xd8, 0xe0, 0x43, 0x17, 0xd4, 0x4d, 0xc1, 0xd7,
-	0xf6, 0xee, 0x59, 0x54, 0xb5, 0xf1, 0x23, 0x68, 0xc8, 0x41, 0x8a, 0x3a, 0xbb, 0x35, 0x8e, 0xe9,
-	0x1f, 0x64, 0xe8, 0x0a, 0xc9, 0xbf, 0x3b, 0xd0, 0x56, 0x83, 0x04, 0x89, 0xe7, 0x63, 0xd8, 0x36,
-	0x67, 0x20, 0xe8, 0x86, 0x86, 0x5e, 0x66, 0x90, 0xd1, 0x3f, 0x7c, 0x05, 0x57, 0x19, 0xf9, 0x0b,
-	0xd8, 0xcd, 0x19, 0x5b, 0xa0, 0x37, 0x8d, 0x3b, 0xce, 0x9b, 0x91, 0xf4, 0xf1, 0xaa, 0x25, 0xea,
-	0x14, 0xff, 0x2d, 0xc3, 0x26, 0x6b, 0xa8, 0x34, 0x8f, 0x90, 0xfd, 0x37, 0xd2, 0xc2, 0x49, 0xef,
-	0xe9, 0x14, 0x2a, 0x76, 0xa3, 0xce, 0x9d, 0x34, 0xed, 0xab, 0x91, 0x1e, 0xcd, 0x46, 0x67, 0xae,
-	0x9c, 0x34, 0xdb, 0x84, 0xe3, 0x12, 0xba, 0x0f, 0x2d, 0xad, 0xbf, 0x44, 0x66, 0x4c, 0x1a, 0x96,
-	0xf4, 0xf3, 0x58, 0x46, 0x86, 0x57, 0x1d, 0x63, 0x9a, 0xe1, 0xed, 0x1e, 0x35, 0xcd, 0xf0, 0x99,
-	0xf6, 0x12, 0x97, 0xe4, 0x95, 0xa6, 0x8d, 0xba, 0x71, 0xa5, 0x99, 0x96, 0xdf, 0xb8, 0xd2, 0x6c,
-	0x77, 0x8f, 0x4b, 0x83, 0xbf, 0x95, 0x61, 0x5b, 0xd4, 0xef, 0x12, 0xf4, 0x0b, 0xd8, 0x32, 0xda,
-	0x32, 0x74, 0xdd, 0x08, 0x19, 0xb3, 0x77, 0xe8, 0xdf, 0xc8, 0x67, 0x2a, 0x8b, 0x2f, 0x60, 0xcb,
-	0xe8, 0xbc, 0x94, 0xb4, 0xbc, 0xbe, 0x4d, 0x49, 0xcb, 0x6d, 0xd6, 0x70, 0x09, 0x3d, 0x84, 0x4d,
-	0xbd, 0x85, 0x42, 0x7d, 0xed, 0x7c, 0x56, 0x67, 0xd6, 0xbf, 0x9e, 0xcb, 0xd3, 0xef, 0x23, 0x6d,
-	0x72, 0xd4, 0x7d, 0x64, 0x9a, 0x23, 0x75, 0x1f, 0xd9, 0x8e, 0x08, 0x97, 0x06, 0xff, 0x29, 0xc3,
-	0x26, 0x2b, 0x1c, 0x25, 0x78, 0xf7, 0xa1, 0xa5, 0x35, 0x00, 0xc8, 0x7c, 0xae, 0xf5, 0x42, 0x54,
-	0x79, 0x4b, 0x4e, 0xbf, 0xc0, 0xf3, 0x81, 0x2c, 0xea, 0x91, 0x96, 0xc7, 0x0d, 0x09, 0x07, 0x19,
-	0xba, 0x7e, 0xb8, 0xb4, 0x40, 0x47, 0x46, 0x22, 0x37, 0x44, 0x5c, 0xcb, 0xe1, 0xd8, 0xe1, 0xc3,
-	0x8b, 0x6f, 0x23, 0x7c, 0x8c, 0xf2, 0xdd, 0x08, 0x1f, 0xb3, 0x52, 0xd7, 0xc3, 0xc7, 0x04, 0x24,
-	0x5b, 0x99, 0x5b, 0xe1, 0x63, 0x19, 0x33, 0xf8, 0x4b, 0x19, 0xb6, 0x64, 0x49, 0xc6, 0xa1, 0x3e,
-	0x83, 0x96, 0x56, 0x9b, 0x22, 0x64, 0xd4, 0x6d, 0xac, 0x6c, 0x57, 0x22, 0xf3, 0x6a, 0xd8, 0xd2,
-	0xb1, 0x83, 0x3e, 0x06, 0x48, 0xeb, 0x58, 0x75, 0xc2, 0x4c, 0x69, 0xdb, 0xcf, 0x91, 0x8d, 0x4b,
-	0xef, 0x3a, 0xd4, 0x1d, 0xf5, 0xea, 0x54, 0xb9, 0x63, 0x4e, 0xa1, 0xab, 0xdc, 0x31, 0xb7, 0x9c,
-	0x65, 0x9e, 0xad, 0xd7, 0xa7, 0xa9, 0xa8, 0x6c, 0x95, 0x9b, 0x8a, 0xca, 0x29, 0x68, 0x71, 0xe9,
-	0xf9, 0x06, 0xfb, 0xfb, 0xc9, 0x9d, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x71, 0x03, 0x74, 0xb7,
-	0x8e, 0x22, 0x00, 0x00,
+func (m *GetTranscriptRequest) Reset()         { *m = GetTranscriptRequest{} }
+func (m *GetTranscriptRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTranscriptRequest) ProtoMessage()    {}
+func (*GetTranscriptRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{87}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *GetTranscriptRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTranscriptRequest.Unmarshal(m, b)
+}
+func (m *GetTranscriptRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTranscriptRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTranscriptRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTranscriptRequest.Merge(m, src)
+}
+func (m *GetTranscriptRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTranscriptRequest.Size(m)
+}
+func (m *GetTranscriptRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTranscriptRequest.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_GetTranscriptRequest proto.InternalMessageInfo
 
-// UserServiceClient is the client API for UserService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type UserServiceClient interface {
-	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
-	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
-	TouchUser(ctx context.Context, in *TouchUserRequest, opts ...grpc.CallOption) (*TouchUserResponse, error)
-	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
-	AuthenticateUser(ctx context.Context, in *AuthenticateUserRequest, opts ...grpc.CallOption) (*AuthenticateUserResponse, error)
-	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+func (m *GetTranscriptRequest) GetSessionId() int64 {
+	if m != nil {
+		return m.SessionId
+	}
+	return 0
 }
 
-type userServiceClient struct {
-	cc *grpc.ClientConn
+type GetTranscriptResponse struct {
+	Entries              []*TranscriptEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func NewUserServiceClient(cc *grpc.ClientConn) UserServiceClient {
-	return &userServiceClient{cc}
+func (m *GetTranscriptResponse) Reset()         { *m = GetTranscriptResponse{} }
+func (m *GetTranscriptResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTranscriptResponse) ProtoMessage()    {}
+func (*GetTranscriptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{88}
 }
 
-func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
-	out := new(ListUsersResponse)
-	err := c.cc.Invoke(ctx, "/types.UserService/ListUsers", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetTranscriptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTranscriptResponse.Unmarshal(m, b)
+}
+func (m *GetTranscriptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTranscriptResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTranscriptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTranscriptResponse.Merge(m, src)
+}
+func (m *GetTranscriptResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTranscriptResponse.Size(m)
+}
+func (m *GetTranscriptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTranscriptResponse.DiscardUnknown(m)
 }
 
-func (c *userServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
-	out := new(CreateUserResponse)
-	err := c.cc.Invoke(ctx, "/types.UserService/CreateUser", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetTranscriptResponse proto.InternalMessageInfo
+
+func (m *GetTranscriptResponse) GetEntries() []*TranscriptEntry {
+	if m != nil {
+		return m.Entries
 	}
-	return out, nil
+	return nil
+}
+
+type VerifyReplayRequest struct {
+	SessionId            int64    `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyReplayRequest) Reset()         { *m = VerifyReplayRequest{} }
+func (m *VerifyReplayRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyReplayRequest) ProtoMessage()    {}
+func (*VerifyReplayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{89}
+}
+
+func (m *VerifyReplayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyReplayRequest.Unmarshal(m, b)
+}
+func (m *VerifyReplayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyReplayRequest.Marshal(b, m, deterministic)
+}
+func (m *VerifyReplayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyReplayRequest.Merge(m, src)
+}
+func (m *VerifyReplayRequest) XXX_Size() int {
+	return xxx_messageInfo_VerifyReplayRequest.Size(m)
+}
+func (m *VerifyReplayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyReplayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyReplayRequest proto.InternalMessageInfo
+
+func (m *VerifyReplayRequest) GetSessionId() int64 {
+	if m != nil {
+		return m.SessionId
+	}
+	return 0
+}
+
+type VerifyReplayResponse struct {
+	SessionId            int64    `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Result               string   `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Digest               string   `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	Frames               int64    `protobuf:"varint,5,opt,name=frames,proto3" json:"frames,omitempty"`
+	Signer               string   `protobuf:"bytes,6,opt,name=signer,proto3" json:"signer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyReplayResponse) Reset()         { *m = VerifyReplayResponse{} }
+func (m *VerifyReplayResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyReplayResponse) ProtoMessage()    {}
+func (*VerifyReplayResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{90}
+}
+
+func (m *VerifyReplayResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyReplayResponse.Unmarshal(m, b)
+}
+func (m *VerifyReplayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyReplayResponse.Marshal(b, m, deterministic)
+}
+func (m *VerifyReplayResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyReplayResponse.Merge(m, src)
+}
+func (m *VerifyReplayResponse) XXX_Size() int {
+	return xxx_messageInfo_VerifyReplayResponse.Size(m)
+}
+func (m *VerifyReplayResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyReplayResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyReplayResponse proto.InternalMessageInfo
+
+func (m *VerifyReplayResponse) GetSessionId() int64 {
+	if m != nil {
+		return m.SessionId
+	}
+	return 0
+}
+
+func (m *VerifyReplayResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *VerifyReplayResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *VerifyReplayResponse) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *VerifyReplayResponse) GetFrames() int64 {
+	if m != nil {
+		return m.Frames
+	}
+	return 0
+}
+
+func (m *VerifyReplayResponse) GetSigner() string {
+	if m != nil {
+		return m.Signer
+	}
+	return ""
+}
+
+type Volume struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Volume) Reset()         { *m = Volume{} }
+func (m *Volume) String() string { return proto.CompactTextString(m) }
+func (*Volume) ProtoMessage()    {}
+func (*Volume) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{91}
+}
+
+func (m *Volume) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Volume.Unmarshal(m, b)
+}
+func (m *Volume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Volume.Marshal(b, m, deterministic)
+}
+func (m *Volume) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Volume.Merge(m, src)
+}
+func (m *Volume) XXX_Size() int {
+	return xxx_messageInfo_Volume.Size(m)
+}
+func (m *Volume) XXX_DiscardUnknown() {
+	xxx_messageInfo_Volume.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Volume proto.InternalMessageInfo
+
+func (m *Volume) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Volume) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Volume) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+type VolumeMember struct {
+	Volume               string   `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	IsReadOnly           bool     `protobuf:"varint,4,opt,name=is_read_only,json=isReadOnly,proto3" json:"is_read_only,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeMember) Reset()         { *m = VolumeMember{} }
+func (m *VolumeMember) String() string { return proto.CompactTextString(m) }
+func (*VolumeMember) ProtoMessage()    {}
+func (*VolumeMember) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{92}
+}
+
+func (m *VolumeMember) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeMember.Unmarshal(m, b)
+}
+func (m *VolumeMember) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeMember.Marshal(b, m, deterministic)
+}
+func (m *VolumeMember) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeMember.Merge(m, src)
+}
+func (m *VolumeMember) XXX_Size() int {
+	return xxx_messageInfo_VolumeMember.Size(m)
+}
+func (m *VolumeMember) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeMember.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeMember proto.InternalMessageInfo
+
+func (m *VolumeMember) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+func (m *VolumeMember) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *VolumeMember) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VolumeMember) GetIsReadOnly() bool {
+	if m != nil {
+		return m.IsReadOnly
+	}
+	return false
+}
+
+func (m *VolumeMember) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+type VolumeMount struct {
+	Volume               string   `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	IsReadOnly           bool     `protobuf:"varint,2,opt,name=is_read_only,json=isReadOnly,proto3" json:"is_read_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeMount) Reset()         { *m = VolumeMount{} }
+func (m *VolumeMount) String() string { return proto.CompactTextString(m) }
+func (*VolumeMount) ProtoMessage()    {}
+func (*VolumeMount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{93}
+}
+
+func (m *VolumeMount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeMount.Unmarshal(m, b)
+}
+func (m *VolumeMount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeMount.Marshal(b, m, deterministic)
+}
+func (m *VolumeMount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeMount.Merge(m, src)
+}
+func (m *VolumeMount) XXX_Size() int {
+	return xxx_messageInfo_VolumeMount.Size(m)
+}
+func (m *VolumeMount) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeMount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeMount proto.InternalMessageInfo
+
+func (m *VolumeMount) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+func (m *VolumeMount) GetIsReadOnly() bool {
+	if m != nil {
+		return m.IsReadOnly
+	}
+	return false
+}
+
+type ListVolumesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListVolumesRequest) Reset()         { *m = ListVolumesRequest{} }
+func (m *ListVolumesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListVolumesRequest) ProtoMessage()    {}
+func (*ListVolumesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{94}
+}
+
+func (m *ListVolumesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumesRequest.Unmarshal(m, b)
+}
+func (m *ListVolumesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListVolumesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumesRequest.Merge(m, src)
+}
+func (m *ListVolumesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListVolumesRequest.Size(m)
+}
+func (m *ListVolumesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumesRequest proto.InternalMessageInfo
+
+type ListVolumesResponse struct {
+	Volumes              []*Volume `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListVolumesResponse) Reset()         { *m = ListVolumesResponse{} }
+func (m *ListVolumesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListVolumesResponse) ProtoMessage()    {}
+func (*ListVolumesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{95}
+}
+
+func (m *ListVolumesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumesResponse.Unmarshal(m, b)
+}
+func (m *ListVolumesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListVolumesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumesResponse.Merge(m, src)
+}
+func (m *ListVolumesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListVolumesResponse.Size(m)
+}
+func (m *ListVolumesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumesResponse proto.InternalMessageInfo
+
+func (m *ListVolumesResponse) GetVolumes() []*Volume {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+type PutVolumeRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutVolumeRequest) Reset()         { *m = PutVolumeRequest{} }
+func (m *PutVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*PutVolumeRequest) ProtoMessage()    {}
+func (*PutVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{96}
+}
+
+func (m *PutVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutVolumeRequest.Unmarshal(m, b)
+}
+func (m *PutVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutVolumeRequest.Marshal(b, m, deterministic)
+}
+func (m *PutVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutVolumeRequest.Merge(m, src)
+}
+func (m *PutVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_PutVolumeRequest.Size(m)
+}
+func (m *PutVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutVolumeRequest proto.InternalMessageInfo
+
+func (m *PutVolumeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PutVolumeRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type PutVolumeResponse struct {
+	Volume               *Volume  `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutVolumeResponse) Reset()         { *m = PutVolumeResponse{} }
+func (m *PutVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*PutVolumeResponse) ProtoMessage()    {}
+func (*PutVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{97}
+}
+
+func (m *PutVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutVolumeResponse.Unmarshal(m, b)
+}
+func (m *PutVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutVolumeResponse.Marshal(b, m, deterministic)
+}
+func (m *PutVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutVolumeResponse.Merge(m, src)
+}
+func (m *PutVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_PutVolumeResponse.Size(m)
+}
+func (m *PutVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutVolumeResponse proto.InternalMessageInfo
+
+func (m *PutVolumeResponse) GetVolume() *Volume {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+type DeleteVolumeRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteVolumeRequest) Reset()         { *m = DeleteVolumeRequest{} }
+func (m *DeleteVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteVolumeRequest) ProtoMessage()    {}
+func (*DeleteVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{98}
+}
+
+func (m *DeleteVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteVolumeRequest.Unmarshal(m, b)
+}
+func (m *DeleteVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteVolumeRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteVolumeRequest.Merge(m, src)
+}
+func (m *DeleteVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteVolumeRequest.Size(m)
+}
+func (m *DeleteVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteVolumeRequest proto.InternalMessageInfo
+
+func (m *DeleteVolumeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteVolumeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteVolumeResponse) Reset()         { *m = DeleteVolumeResponse{} }
+func (m *DeleteVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteVolumeResponse) ProtoMessage()    {}
+func (*DeleteVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{99}
+}
+
+func (m *DeleteVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteVolumeResponse.Unmarshal(m, b)
+}
+func (m *DeleteVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteVolumeResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteVolumeResponse.Merge(m, src)
+}
+func (m *DeleteVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteVolumeResponse.Size(m)
+}
+func (m *DeleteVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteVolumeResponse proto.InternalMessageInfo
+
+type ListVolumeMembersRequest struct {
+	Volume               string   `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListVolumeMembersRequest) Reset()         { *m = ListVolumeMembersRequest{} }
+func (m *ListVolumeMembersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListVolumeMembersRequest) ProtoMessage()    {}
+func (*ListVolumeMembersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{100}
+}
+
+func (m *ListVolumeMembersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumeMembersRequest.Unmarshal(m, b)
+}
+func (m *ListVolumeMembersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumeMembersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListVolumeMembersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumeMembersRequest.Merge(m, src)
+}
+func (m *ListVolumeMembersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListVolumeMembersRequest.Size(m)
+}
+func (m *ListVolumeMembersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumeMembersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumeMembersRequest proto.InternalMessageInfo
+
+func (m *ListVolumeMembersRequest) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+type ListVolumeMembersResponse struct {
+	Members              []*VolumeMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListVolumeMembersResponse) Reset()         { *m = ListVolumeMembersResponse{} }
+func (m *ListVolumeMembersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListVolumeMembersResponse) ProtoMessage()    {}
+func (*ListVolumeMembersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{101}
+}
+
+func (m *ListVolumeMembersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumeMembersResponse.Unmarshal(m, b)
+}
+func (m *ListVolumeMembersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumeMembersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListVolumeMembersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumeMembersResponse.Merge(m, src)
+}
+func (m *ListVolumeMembersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListVolumeMembersResponse.Size(m)
+}
+func (m *ListVolumeMembersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumeMembersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumeMembersResponse proto.InternalMessageInfo
+
+func (m *ListVolumeMembersResponse) GetMembers() []*VolumeMember {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type PutVolumeMemberRequest struct {
+	Volume               string   `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	IsReadOnly           bool     `protobuf:"varint,4,opt,name=is_read_only,json=isReadOnly,proto3" json:"is_read_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutVolumeMemberRequest) Reset()         { *m = PutVolumeMemberRequest{} }
+func (m *PutVolumeMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*PutVolumeMemberRequest) ProtoMessage()    {}
+func (*PutVolumeMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{102}
+}
+
+func (m *PutVolumeMemberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutVolumeMemberRequest.Unmarshal(m, b)
+}
+func (m *PutVolumeMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutVolumeMemberRequest.Marshal(b, m, deterministic)
+}
+func (m *PutVolumeMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutVolumeMemberRequest.Merge(m, src)
+}
+func (m *PutVolumeMemberRequest) XXX_Size() int {
+	return xxx_messageInfo_PutVolumeMemberRequest.Size(m)
+}
+func (m *PutVolumeMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutVolumeMemberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutVolumeMemberRequest proto.InternalMessageInfo
+
+func (m *PutVolumeMemberRequest) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+func (m *PutVolumeMemberRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *PutVolumeMemberRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PutVolumeMemberRequest) GetIsReadOnly() bool {
+	if m != nil {
+		return m.IsReadOnly
+	}
+	return false
+}
+
+type PutVolumeMemberResponse struct {
+	Member               *VolumeMember `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PutVolumeMemberResponse) Reset()         { *m = PutVolumeMemberResponse{} }
+func (m *PutVolumeMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*PutVolumeMemberResponse) ProtoMessage()    {}
+func (*PutVolumeMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{103}
+}
+
+func (m *PutVolumeMemberResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutVolumeMemberResponse.Unmarshal(m, b)
+}
+func (m *PutVolumeMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutVolumeMemberResponse.Marshal(b, m, deterministic)
+}
+func (m *PutVolumeMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutVolumeMemberResponse.Merge(m, src)
+}
+func (m *PutVolumeMemberResponse) XXX_Size() int {
+	return xxx_messageInfo_PutVolumeMemberResponse.Size(m)
+}
+func (m *PutVolumeMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutVolumeMemberResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutVolumeMemberResponse proto.InternalMessageInfo
+
+func (m *PutVolumeMemberResponse) GetMember() *VolumeMember {
+	if m != nil {
+		return m.Member
+	}
+	return nil
+}
+
+type DeleteVolumeMemberRequest struct {
+	Volume               string   `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteVolumeMemberRequest) Reset()         { *m = DeleteVolumeMemberRequest{} }
+func (m *DeleteVolumeMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteVolumeMemberRequest) ProtoMessage()    {}
+func (*DeleteVolumeMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{104}
+}
+
+func (m *DeleteVolumeMemberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteVolumeMemberRequest.Unmarshal(m, b)
+}
+func (m *DeleteVolumeMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteVolumeMemberRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteVolumeMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteVolumeMemberRequest.Merge(m, src)
+}
+func (m *DeleteVolumeMemberRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteVolumeMemberRequest.Size(m)
+}
+func (m *DeleteVolumeMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteVolumeMemberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteVolumeMemberRequest proto.InternalMessageInfo
+
+func (m *DeleteVolumeMemberRequest) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+func (m *DeleteVolumeMemberRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *DeleteVolumeMemberRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteVolumeMemberResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteVolumeMemberResponse) Reset()         { *m = DeleteVolumeMemberResponse{} }
+func (m *DeleteVolumeMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteVolumeMemberResponse) ProtoMessage()    {}
+func (*DeleteVolumeMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{105}
+}
+
+func (m *DeleteVolumeMemberResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteVolumeMemberResponse.Unmarshal(m, b)
+}
+func (m *DeleteVolumeMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteVolumeMemberResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteVolumeMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteVolumeMemberResponse.Merge(m, src)
+}
+func (m *DeleteVolumeMemberResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteVolumeMemberResponse.Size(m)
+}
+func (m *DeleteVolumeMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteVolumeMemberResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteVolumeMemberResponse proto.InternalMessageInfo
+
+type ListVolumeMountsRequest struct {
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListVolumeMountsRequest) Reset()         { *m = ListVolumeMountsRequest{} }
+func (m *ListVolumeMountsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListVolumeMountsRequest) ProtoMessage()    {}
+func (*ListVolumeMountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{106}
+}
+
+func (m *ListVolumeMountsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumeMountsRequest.Unmarshal(m, b)
+}
+func (m *ListVolumeMountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumeMountsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListVolumeMountsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumeMountsRequest.Merge(m, src)
+}
+func (m *ListVolumeMountsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListVolumeMountsRequest.Size(m)
+}
+func (m *ListVolumeMountsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumeMountsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumeMountsRequest proto.InternalMessageInfo
+
+func (m *ListVolumeMountsRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+type ListVolumeMountsResponse struct {
+	Mounts               []*VolumeMount `protobuf:"bytes,1,rep,name=mounts,proto3" json:"mounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListVolumeMountsResponse) Reset()         { *m = ListVolumeMountsResponse{} }
+func (m *ListVolumeMountsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListVolumeMountsResponse) ProtoMessage()    {}
+func (*ListVolumeMountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{107}
+}
+
+func (m *ListVolumeMountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumeMountsResponse.Unmarshal(m, b)
+}
+func (m *ListVolumeMountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumeMountsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListVolumeMountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumeMountsResponse.Merge(m, src)
+}
+func (m *ListVolumeMountsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListVolumeMountsResponse.Size(m)
+}
+func (m *ListVolumeMountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumeMountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumeMountsResponse proto.InternalMessageInfo
+
+func (m *ListVolumeMountsResponse) GetMounts() []*VolumeMount {
+	if m != nil {
+		return m.Mounts
+	}
+	return nil
+}
+
+type Transfer struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Account              string   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	SessionId            int64    `protobuf:"varint,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Direction            string   `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	Filename             string   `protobuf:"bytes,6,opt,name=filename,proto3" json:"filename,omitempty"`
+	Mode                 uint32   `protobuf:"varint,7,opt,name=mode,proto3" json:"mode,omitempty"`
+	Size                 int64    `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256               string   `protobuf:"bytes,9,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Transfer) Reset()         { *m = Transfer{} }
+func (m *Transfer) String() string { return proto.CompactTextString(m) }
+func (*Transfer) ProtoMessage()    {}
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{108}
+}
+
+func (m *Transfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Transfer.Unmarshal(m, b)
+}
+func (m *Transfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Transfer.Marshal(b, m, deterministic)
+}
+func (m *Transfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transfer.Merge(m, src)
+}
+func (m *Transfer) XXX_Size() int {
+	return xxx_messageInfo_Transfer.Size(m)
+}
+func (m *Transfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transfer proto.InternalMessageInfo
+
+func (m *Transfer) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Transfer) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *Transfer) GetSessionId() int64 {
+	if m != nil {
+		return m.SessionId
+	}
+	return 0
+}
+
+func (m *Transfer) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Transfer) GetDirection() string {
+	if m != nil {
+		return m.Direction
+	}
+	return ""
+}
+
+func (m *Transfer) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *Transfer) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *Transfer) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Transfer) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+func (m *Transfer) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+type CreateTransferRequest struct {
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	SessionId            int64    `protobuf:"varint,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Source               string   `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Direction            string   `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"`
+	Filename             string   `protobuf:"bytes,5,opt,name=filename,proto3" json:"filename,omitempty"`
+	Mode                 uint32   `protobuf:"varint,6,opt,name=mode,proto3" json:"mode,omitempty"`
+	Size                 int64    `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256               string   `protobuf:"bytes,8,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateTransferRequest) Reset()         { *m = CreateTransferRequest{} }
+func (m *CreateTransferRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateTransferRequest) ProtoMessage()    {}
+func (*CreateTransferRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{109}
+}
+
+func (m *CreateTransferRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTransferRequest.Unmarshal(m, b)
+}
+func (m *CreateTransferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTransferRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateTransferRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTransferRequest.Merge(m, src)
+}
+func (m *CreateTransferRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateTransferRequest.Size(m)
+}
+func (m *CreateTransferRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTransferRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTransferRequest proto.InternalMessageInfo
+
+func (m *CreateTransferRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *CreateTransferRequest) GetSessionId() int64 {
+	if m != nil {
+		return m.SessionId
+	}
+	return 0
+}
+
+func (m *CreateTransferRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *CreateTransferRequest) GetDirection() string {
+	if m != nil {
+		return m.Direction
+	}
+	return ""
+}
+
+func (m *CreateTransferRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *CreateTransferRequest) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *CreateTransferRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *CreateTransferRequest) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+type CreateTransferResponse struct {
+	Transfer             *Transfer `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CreateTransferResponse) Reset()         { *m = CreateTransferResponse{} }
+func (m *CreateTransferResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateTransferResponse) ProtoMessage()    {}
+func (*CreateTransferResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{110}
+}
+
+func (m *CreateTransferResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTransferResponse.Unmarshal(m, b)
+}
+func (m *CreateTransferResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTransferResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateTransferResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTransferResponse.Merge(m, src)
+}
+func (m *CreateTransferResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateTransferResponse.Size(m)
+}
+func (m *CreateTransferResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTransferResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTransferResponse proto.InternalMessageInfo
+
+func (m *CreateTransferResponse) GetTransfer() *Transfer {
+	if m != nil {
+		return m.Transfer
+	}
+	return nil
+}
+
+type ListTransfersRequest struct {
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	SessionId            int64    `protobuf:"varint,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTransfersRequest) Reset()         { *m = ListTransfersRequest{} }
+func (m *ListTransfersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTransfersRequest) ProtoMessage()    {}
+func (*ListTransfersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{111}
+}
+
+func (m *ListTransfersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTransfersRequest.Unmarshal(m, b)
+}
+func (m *ListTransfersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTransfersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTransfersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTransfersRequest.Merge(m, src)
+}
+func (m *ListTransfersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTransfersRequest.Size(m)
+}
+func (m *ListTransfersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTransfersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTransfersRequest proto.InternalMessageInfo
+
+func (m *ListTransfersRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *ListTransfersRequest) GetSessionId() int64 {
+	if m != nil {
+		return m.SessionId
+	}
+	return 0
+}
+
+type ListTransfersResponse struct {
+	Transfers            []*Transfer `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListTransfersResponse) Reset()         { *m = ListTransfersResponse{} }
+func (m *ListTransfersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTransfersResponse) ProtoMessage()    {}
+func (*ListTransfersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{112}
+}
+
+func (m *ListTransfersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTransfersResponse.Unmarshal(m, b)
+}
+func (m *ListTransfersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTransfersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTransfersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTransfersResponse.Merge(m, src)
+}
+func (m *ListTransfersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTransfersResponse.Size(m)
+}
+func (m *ListTransfersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTransfersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTransfersResponse proto.InternalMessageInfo
+
+func (m *ListTransfersResponse) GetTransfers() []*Transfer {
+	if m != nil {
+		return m.Transfers
+	}
+	return nil
+}
+
+type Group struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Roles                []string `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Group) Reset()         { *m = Group{} }
+func (m *Group) String() string { return proto.CompactTextString(m) }
+func (*Group) ProtoMessage()    {}
+func (*Group) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{113}
+}
+
+func (m *Group) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Group.Unmarshal(m, b)
+}
+func (m *Group) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Group.Marshal(b, m, deterministic)
+}
+func (m *Group) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Group.Merge(m, src)
+}
+func (m *Group) XXX_Size() int {
+	return xxx_messageInfo_Group.Size(m)
+}
+func (m *Group) XXX_DiscardUnknown() {
+	xxx_messageInfo_Group.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Group proto.InternalMessageInfo
+
+func (m *Group) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Group) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Group) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Group) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+type GroupMember struct {
+	Group                string   `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Account              string   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupMember) Reset()         { *m = GroupMember{} }
+func (m *GroupMember) String() string { return proto.CompactTextString(m) }
+func (*GroupMember) ProtoMessage()    {}
+func (*GroupMember) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{114}
+}
+
+func (m *GroupMember) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupMember.Unmarshal(m, b)
+}
+func (m *GroupMember) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupMember.Marshal(b, m, deterministic)
+}
+func (m *GroupMember) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupMember.Merge(m, src)
+}
+func (m *GroupMember) XXX_Size() int {
+	return xxx_messageInfo_GroupMember.Size(m)
+}
+func (m *GroupMember) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupMember.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupMember proto.InternalMessageInfo
+
+func (m *GroupMember) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *GroupMember) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *GroupMember) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *GroupMember) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type ListGroupsRequest struct {
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGroupsRequest) Reset()         { *m = ListGroupsRequest{} }
+func (m *ListGroupsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGroupsRequest) ProtoMessage()    {}
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{115}
+}
+
+func (m *ListGroupsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGroupsRequest.Unmarshal(m, b)
+}
+func (m *ListGroupsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGroupsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListGroupsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGroupsRequest.Merge(m, src)
+}
+func (m *ListGroupsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListGroupsRequest.Size(m)
+}
+func (m *ListGroupsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGroupsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGroupsRequest proto.InternalMessageInfo
+
+func (m *ListGroupsRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+type ListGroupsResponse struct {
+	Groups               []*Group `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGroupsResponse) Reset()         { *m = ListGroupsResponse{} }
+func (m *ListGroupsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGroupsResponse) ProtoMessage()    {}
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{116}
+}
+
+func (m *ListGroupsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGroupsResponse.Unmarshal(m, b)
+}
+func (m *ListGroupsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGroupsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListGroupsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGroupsResponse.Merge(m, src)
+}
+func (m *ListGroupsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListGroupsResponse.Size(m)
+}
+func (m *ListGroupsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGroupsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGroupsResponse proto.InternalMessageInfo
+
+func (m *ListGroupsResponse) GetGroups() []*Group {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+type PutGroupRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	UpdateRoles          bool     `protobuf:"varint,3,opt,name=update_roles,json=updateRoles,proto3" json:"update_roles,omitempty"`
+	Roles                []string `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutGroupRequest) Reset()         { *m = PutGroupRequest{} }
+func (m *PutGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*PutGroupRequest) ProtoMessage()    {}
+func (*PutGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{117}
+}
+
+func (m *PutGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutGroupRequest.Unmarshal(m, b)
+}
+func (m *PutGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutGroupRequest.Marshal(b, m, deterministic)
+}
+func (m *PutGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutGroupRequest.Merge(m, src)
+}
+func (m *PutGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_PutGroupRequest.Size(m)
+}
+func (m *PutGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutGroupRequest proto.InternalMessageInfo
+
+func (m *PutGroupRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PutGroupRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *PutGroupRequest) GetUpdateRoles() bool {
+	if m != nil {
+		return m.UpdateRoles
+	}
+	return false
+}
+
+func (m *PutGroupRequest) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+type PutGroupResponse struct {
+	Group                *Group   `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutGroupResponse) Reset()         { *m = PutGroupResponse{} }
+func (m *PutGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*PutGroupResponse) ProtoMessage()    {}
+func (*PutGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{118}
+}
+
+func (m *PutGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutGroupResponse.Unmarshal(m, b)
+}
+func (m *PutGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutGroupResponse.Marshal(b, m, deterministic)
+}
+func (m *PutGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutGroupResponse.Merge(m, src)
+}
+func (m *PutGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_PutGroupResponse.Size(m)
+}
+func (m *PutGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutGroupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutGroupResponse proto.InternalMessageInfo
+
+func (m *PutGroupResponse) GetGroup() *Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+type DeleteGroupRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteGroupRequest) Reset()         { *m = DeleteGroupRequest{} }
+func (m *DeleteGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteGroupRequest) ProtoMessage()    {}
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{119}
+}
+
+func (m *DeleteGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteGroupRequest.Unmarshal(m, b)
+}
+func (m *DeleteGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteGroupRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteGroupRequest.Merge(m, src)
+}
+func (m *DeleteGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteGroupRequest.Size(m)
+}
+func (m *DeleteGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteGroupRequest proto.InternalMessageInfo
+
+func (m *DeleteGroupRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteGroupResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteGroupResponse) Reset()         { *m = DeleteGroupResponse{} }
+func (m *DeleteGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteGroupResponse) ProtoMessage()    {}
+func (*DeleteGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{120}
+}
+
+func (m *DeleteGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteGroupResponse.Unmarshal(m, b)
+}
+func (m *DeleteGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteGroupResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteGroupResponse.Merge(m, src)
+}
+func (m *DeleteGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteGroupResponse.Size(m)
+}
+func (m *DeleteGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteGroupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteGroupResponse proto.InternalMessageInfo
+
+type ListGroupMembersRequest struct {
+	Group                string   `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGroupMembersRequest) Reset()         { *m = ListGroupMembersRequest{} }
+func (m *ListGroupMembersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGroupMembersRequest) ProtoMessage()    {}
+func (*ListGroupMembersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{121}
+}
+
+func (m *ListGroupMembersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGroupMembersRequest.Unmarshal(m, b)
+}
+func (m *ListGroupMembersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGroupMembersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListGroupMembersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGroupMembersRequest.Merge(m, src)
+}
+func (m *ListGroupMembersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListGroupMembersRequest.Size(m)
+}
+func (m *ListGroupMembersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGroupMembersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGroupMembersRequest proto.InternalMessageInfo
+
+func (m *ListGroupMembersRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+type ListGroupMembersResponse struct {
+	Members              []*GroupMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListGroupMembersResponse) Reset()         { *m = ListGroupMembersResponse{} }
+func (m *ListGroupMembersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGroupMembersResponse) ProtoMessage()    {}
+func (*ListGroupMembersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{122}
+}
+
+func (m *ListGroupMembersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGroupMembersResponse.Unmarshal(m, b)
+}
+func (m *ListGroupMembersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGroupMembersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListGroupMembersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGroupMembersResponse.Merge(m, src)
+}
+func (m *ListGroupMembersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListGroupMembersResponse.Size(m)
+}
+func (m *ListGroupMembersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGroupMembersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGroupMembersResponse proto.InternalMessageInfo
+
+func (m *ListGroupMembersResponse) GetMembers() []*GroupMember {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type PutGroupMemberRequest struct {
+	Group                string   `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Account              string   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	Source               string   `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutGroupMemberRequest) Reset()         { *m = PutGroupMemberRequest{} }
+func (m *PutGroupMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*PutGroupMemberRequest) ProtoMessage()    {}
+func (*PutGroupMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{123}
+}
+
+func (m *PutGroupMemberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutGroupMemberRequest.Unmarshal(m, b)
+}
+func (m *PutGroupMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutGroupMemberRequest.Marshal(b, m, deterministic)
+}
+func (m *PutGroupMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutGroupMemberRequest.Merge(m, src)
+}
+func (m *PutGroupMemberRequest) XXX_Size() int {
+	return xxx_messageInfo_PutGroupMemberRequest.Size(m)
+}
+func (m *PutGroupMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutGroupMemberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutGroupMemberRequest proto.InternalMessageInfo
+
+func (m *PutGroupMemberRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *PutGroupMemberRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *PutGroupMemberRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type PutGroupMemberResponse struct {
+	Member               *GroupMember `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PutGroupMemberResponse) Reset()         { *m = PutGroupMemberResponse{} }
+func (m *PutGroupMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*PutGroupMemberResponse) ProtoMessage()    {}
+func (*PutGroupMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{124}
+}
+
+func (m *PutGroupMemberResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutGroupMemberResponse.Unmarshal(m, b)
+}
+func (m *PutGroupMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutGroupMemberResponse.Marshal(b, m, deterministic)
+}
+func (m *PutGroupMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutGroupMemberResponse.Merge(m, src)
+}
+func (m *PutGroupMemberResponse) XXX_Size() int {
+	return xxx_messageInfo_PutGroupMemberResponse.Size(m)
+}
+func (m *PutGroupMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutGroupMemberResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutGroupMemberResponse proto.InternalMessageInfo
+
+func (m *PutGroupMemberResponse) GetMember() *GroupMember {
+	if m != nil {
+		return m.Member
+	}
+	return nil
+}
+
+type DeleteGroupMemberRequest struct {
+	Group                string   `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Account              string   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteGroupMemberRequest) Reset()         { *m = DeleteGroupMemberRequest{} }
+func (m *DeleteGroupMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteGroupMemberRequest) ProtoMessage()    {}
+func (*DeleteGroupMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{125}
+}
+
+func (m *DeleteGroupMemberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteGroupMemberRequest.Unmarshal(m, b)
+}
+func (m *DeleteGroupMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteGroupMemberRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteGroupMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteGroupMemberRequest.Merge(m, src)
+}
+func (m *DeleteGroupMemberRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteGroupMemberRequest.Size(m)
+}
+func (m *DeleteGroupMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteGroupMemberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteGroupMemberRequest proto.InternalMessageInfo
+
+func (m *DeleteGroupMemberRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *DeleteGroupMemberRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+type DeleteGroupMemberResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteGroupMemberResponse) Reset()         { *m = DeleteGroupMemberResponse{} }
+func (m *DeleteGroupMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteGroupMemberResponse) ProtoMessage()    {}
+func (*DeleteGroupMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{126}
+}
+
+func (m *DeleteGroupMemberResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteGroupMemberResponse.Unmarshal(m, b)
+}
+func (m *DeleteGroupMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteGroupMemberResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteGroupMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteGroupMemberResponse.Merge(m, src)
+}
+func (m *DeleteGroupMemberResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteGroupMemberResponse.Size(m)
+}
+func (m *DeleteGroupMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteGroupMemberResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteGroupMemberResponse proto.InternalMessageInfo
+
+type AccessRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Account              string   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	HostnamePattern      string   `protobuf:"bytes,3,opt,name=hostname_pattern,json=hostnamePattern,proto3" json:"hostname_pattern,omitempty"`
+	User                 string   `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	Duration             int64    `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	Justification        string   `protobuf:"bytes,6,opt,name=justification,proto3" json:"justification,omitempty"`
+	Status               string   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Reviewer             string   `protobuf:"bytes,8,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	ReviewComment        string   `protobuf:"bytes,9,opt,name=review_comment,json=reviewComment,proto3" json:"review_comment,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReviewedAt           int64    `protobuf:"varint,11,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	GrantExpiredAt       int64    `protobuf:"varint,12,opt,name=grant_expired_at,json=grantExpiredAt,proto3" json:"grant_expired_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccessRequest) Reset()         { *m = AccessRequest{} }
+func (m *AccessRequest) String() string { return proto.CompactTextString(m) }
+func (*AccessRequest) ProtoMessage()    {}
+func (*AccessRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{127}
+}
+
+func (m *AccessRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessRequest.Unmarshal(m, b)
+}
+func (m *AccessRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessRequest.Marshal(b, m, deterministic)
+}
+func (m *AccessRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessRequest.Merge(m, src)
+}
+func (m *AccessRequest) XXX_Size() int {
+	return xxx_messageInfo_AccessRequest.Size(m)
+}
+func (m *AccessRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessRequest proto.InternalMessageInfo
+
+func (m *AccessRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *AccessRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *AccessRequest) GetHostnamePattern() string {
+	if m != nil {
+		return m.HostnamePattern
+	}
+	return ""
+}
+
+func (m *AccessRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *AccessRequest) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *AccessRequest) GetJustification() string {
+	if m != nil {
+		return m.Justification
+	}
+	return ""
+}
+
+func (m *AccessRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *AccessRequest) GetReviewer() string {
+	if m != nil {
+		return m.Reviewer
+	}
+	return ""
+}
+
+func (m *AccessRequest) GetReviewComment() string {
+	if m != nil {
+		return m.ReviewComment
+	}
+	return ""
+}
+
+func (m *AccessRequest) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *AccessRequest) GetReviewedAt() int64 {
+	if m != nil {
+		return m.ReviewedAt
+	}
+	return 0
+}
+
+func (m *AccessRequest) GetGrantExpiredAt() int64 {
+	if m != nil {
+		return m.GrantExpiredAt
+	}
+	return 0
+}
+
+type AccessRequestEvent struct {
+	RequestId            int64    `protobuf:"varint,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Action               string   `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Actor                string   `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Comment              string   `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccessRequestEvent) Reset()         { *m = AccessRequestEvent{} }
+func (m *AccessRequestEvent) String() string { return proto.CompactTextString(m) }
+func (*AccessRequestEvent) ProtoMessage()    {}
+func (*AccessRequestEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{128}
+}
+
+func (m *AccessRequestEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessRequestEvent.Unmarshal(m, b)
+}
+func (m *AccessRequestEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessRequestEvent.Marshal(b, m, deterministic)
+}
+func (m *AccessRequestEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessRequestEvent.Merge(m, src)
+}
+func (m *AccessRequestEvent) XXX_Size() int {
+	return xxx_messageInfo_AccessRequestEvent.Size(m)
+}
+func (m *AccessRequestEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessRequestEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessRequestEvent proto.InternalMessageInfo
+
+func (m *AccessRequestEvent) GetRequestId() int64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *AccessRequestEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AccessRequestEvent) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *AccessRequestEvent) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *AccessRequestEvent) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+type CreateAccessRequestRequest struct {
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	HostnamePattern      string   `protobuf:"bytes,2,opt,name=hostname_pattern,json=hostnamePattern,proto3" json:"hostname_pattern,omitempty"`
+	User                 string   `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Duration             int64    `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	Justification        string   `protobuf:"bytes,5,opt,name=justification,proto3" json:"justification,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAccessRequestRequest) Reset()         { *m = CreateAccessRequestRequest{} }
+func (m *CreateAccessRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAccessRequestRequest) ProtoMessage()    {}
+func (*CreateAccessRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{129}
+}
+
+func (m *CreateAccessRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAccessRequestRequest.Unmarshal(m, b)
+}
+func (m *CreateAccessRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAccessRequestRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAccessRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccessRequestRequest.Merge(m, src)
+}
+func (m *CreateAccessRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAccessRequestRequest.Size(m)
+}
+func (m *CreateAccessRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccessRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccessRequestRequest proto.InternalMessageInfo
+
+func (m *CreateAccessRequestRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *CreateAccessRequestRequest) GetHostnamePattern() string {
+	if m != nil {
+		return m.HostnamePattern
+	}
+	return ""
+}
+
+func (m *CreateAccessRequestRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *CreateAccessRequestRequest) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *CreateAccessRequestRequest) GetJustification() string {
+	if m != nil {
+		return m.Justification
+	}
+	return ""
+}
+
+type CreateAccessRequestResponse struct {
+	AccessRequest        *AccessRequest `protobuf:"bytes,1,opt,name=access_request,json=accessRequest,proto3" json:"access_request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CreateAccessRequestResponse) Reset()         { *m = CreateAccessRequestResponse{} }
+func (m *CreateAccessRequestResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateAccessRequestResponse) ProtoMessage()    {}
+func (*CreateAccessRequestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{130}
+}
+
+func (m *CreateAccessRequestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAccessRequestResponse.Unmarshal(m, b)
+}
+func (m *CreateAccessRequestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAccessRequestResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateAccessRequestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccessRequestResponse.Merge(m, src)
+}
+func (m *CreateAccessRequestResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateAccessRequestResponse.Size(m)
+}
+func (m *CreateAccessRequestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccessRequestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccessRequestResponse proto.InternalMessageInfo
+
+func (m *CreateAccessRequestResponse) GetAccessRequest() *AccessRequest {
+	if m != nil {
+		return m.AccessRequest
+	}
+	return nil
+}
+
+type ListAccessRequestsRequest struct {
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAccessRequestsRequest) Reset()         { *m = ListAccessRequestsRequest{} }
+func (m *ListAccessRequestsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAccessRequestsRequest) ProtoMessage()    {}
+func (*ListAccessRequestsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{131}
+}
+
+func (m *ListAccessRequestsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAccessRequestsRequest.Unmarshal(m, b)
+}
+func (m *ListAccessRequestsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAccessRequestsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAccessRequestsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccessRequestsRequest.Merge(m, src)
+}
+func (m *ListAccessRequestsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAccessRequestsRequest.Size(m)
+}
+func (m *ListAccessRequestsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccessRequestsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccessRequestsRequest proto.InternalMessageInfo
+
+func (m *ListAccessRequestsRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *ListAccessRequestsRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type ListAccessRequestsResponse struct {
+	AccessRequests       []*AccessRequest `protobuf:"bytes,1,rep,name=access_requests,json=accessRequests,proto3" json:"access_requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListAccessRequestsResponse) Reset()         { *m = ListAccessRequestsResponse{} }
+func (m *ListAccessRequestsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAccessRequestsResponse) ProtoMessage()    {}
+func (*ListAccessRequestsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{132}
+}
+
+func (m *ListAccessRequestsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAccessRequestsResponse.Unmarshal(m, b)
+}
+func (m *ListAccessRequestsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAccessRequestsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAccessRequestsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccessRequestsResponse.Merge(m, src)
+}
+func (m *ListAccessRequestsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAccessRequestsResponse.Size(m)
+}
+func (m *ListAccessRequestsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccessRequestsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccessRequestsResponse proto.InternalMessageInfo
+
+func (m *ListAccessRequestsResponse) GetAccessRequests() []*AccessRequest {
+	if m != nil {
+		return m.AccessRequests
+	}
+	return nil
+}
+
+type GetAccessRequestRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccessRequestRequest) Reset()         { *m = GetAccessRequestRequest{} }
+func (m *GetAccessRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccessRequestRequest) ProtoMessage()    {}
+func (*GetAccessRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{133}
+}
+
+func (m *GetAccessRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccessRequestRequest.Unmarshal(m, b)
+}
+func (m *GetAccessRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccessRequestRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAccessRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccessRequestRequest.Merge(m, src)
+}
+func (m *GetAccessRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAccessRequestRequest.Size(m)
+}
+func (m *GetAccessRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccessRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccessRequestRequest proto.InternalMessageInfo
+
+func (m *GetAccessRequestRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetAccessRequestResponse struct {
+	AccessRequest        *AccessRequest        `protobuf:"bytes,1,opt,name=access_request,json=accessRequest,proto3" json:"access_request,omitempty"`
+	Events               []*AccessRequestEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetAccessRequestResponse) Reset()         { *m = GetAccessRequestResponse{} }
+func (m *GetAccessRequestResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAccessRequestResponse) ProtoMessage()    {}
+func (*GetAccessRequestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{134}
+}
+
+func (m *GetAccessRequestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccessRequestResponse.Unmarshal(m, b)
+}
+func (m *GetAccessRequestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccessRequestResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAccessRequestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccessRequestResponse.Merge(m, src)
+}
+func (m *GetAccessRequestResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAccessRequestResponse.Size(m)
+}
+func (m *GetAccessRequestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccessRequestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccessRequestResponse proto.InternalMessageInfo
+
+func (m *GetAccessRequestResponse) GetAccessRequest() *AccessRequest {
+	if m != nil {
+		return m.AccessRequest
+	}
+	return nil
+}
+
+func (m *GetAccessRequestResponse) GetEvents() []*AccessRequestEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type ReviewAccessRequestRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reviewer             string   `protobuf:"bytes,2,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	Comment              string   `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReviewAccessRequestRequest) Reset()         { *m = ReviewAccessRequestRequest{} }
+func (m *ReviewAccessRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*ReviewAccessRequestRequest) ProtoMessage()    {}
+func (*ReviewAccessRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{135}
+}
+
+func (m *ReviewAccessRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReviewAccessRequestRequest.Unmarshal(m, b)
+}
+func (m *ReviewAccessRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReviewAccessRequestRequest.Marshal(b, m, deterministic)
+}
+func (m *ReviewAccessRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReviewAccessRequestRequest.Merge(m, src)
+}
+func (m *ReviewAccessRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_ReviewAccessRequestRequest.Size(m)
+}
+func (m *ReviewAccessRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReviewAccessRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReviewAccessRequestRequest proto.InternalMessageInfo
+
+func (m *ReviewAccessRequestRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ReviewAccessRequestRequest) GetReviewer() string {
+	if m != nil {
+		return m.Reviewer
+	}
+	return ""
+}
+
+func (m *ReviewAccessRequestRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type ReviewAccessRequestResponse struct {
+	AccessRequest        *AccessRequest `protobuf:"bytes,1,opt,name=access_request,json=accessRequest,proto3" json:"access_request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ReviewAccessRequestResponse) Reset()         { *m = ReviewAccessRequestResponse{} }
+func (m *ReviewAccessRequestResponse) String() string { return proto.CompactTextString(m) }
+func (*ReviewAccessRequestResponse) ProtoMessage()    {}
+func (*ReviewAccessRequestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{136}
+}
+
+func (m *ReviewAccessRequestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReviewAccessRequestResponse.Unmarshal(m, b)
+}
+func (m *ReviewAccessRequestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReviewAccessRequestResponse.Marshal(b, m, deterministic)
+}
+func (m *ReviewAccessRequestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReviewAccessRequestResponse.Merge(m, src)
+}
+func (m *ReviewAccessRequestResponse) XXX_Size() int {
+	return xxx_messageInfo_ReviewAccessRequestResponse.Size(m)
+}
+func (m *ReviewAccessRequestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReviewAccessRequestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReviewAccessRequestResponse proto.InternalMessageInfo
+
+func (m *ReviewAccessRequestResponse) GetAccessRequest() *AccessRequest {
+	if m != nil {
+		return m.AccessRequest
+	}
+	return nil
+}
+
+type CancelAccessRequestRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Account              string   `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelAccessRequestRequest) Reset()         { *m = CancelAccessRequestRequest{} }
+func (m *CancelAccessRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelAccessRequestRequest) ProtoMessage()    {}
+func (*CancelAccessRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{137}
+}
+
+func (m *CancelAccessRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelAccessRequestRequest.Unmarshal(m, b)
+}
+func (m *CancelAccessRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelAccessRequestRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelAccessRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelAccessRequestRequest.Merge(m, src)
+}
+func (m *CancelAccessRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelAccessRequestRequest.Size(m)
+}
+func (m *CancelAccessRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelAccessRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelAccessRequestRequest proto.InternalMessageInfo
+
+func (m *CancelAccessRequestRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *CancelAccessRequestRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+type CancelAccessRequestResponse struct {
+	AccessRequest        *AccessRequest `protobuf:"bytes,1,opt,name=access_request,json=accessRequest,proto3" json:"access_request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CancelAccessRequestResponse) Reset()         { *m = CancelAccessRequestResponse{} }
+func (m *CancelAccessRequestResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelAccessRequestResponse) ProtoMessage()    {}
+func (*CancelAccessRequestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{138}
+}
+
+func (m *CancelAccessRequestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelAccessRequestResponse.Unmarshal(m, b)
+}
+func (m *CancelAccessRequestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelAccessRequestResponse.Marshal(b, m, deterministic)
+}
+func (m *CancelAccessRequestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelAccessRequestResponse.Merge(m, src)
+}
+func (m *CancelAccessRequestResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelAccessRequestResponse.Size(m)
+}
+func (m *CancelAccessRequestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelAccessRequestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelAccessRequestResponse proto.InternalMessageInfo
+
+func (m *CancelAccessRequestResponse) GetAccessRequest() *AccessRequest {
+	if m != nil {
+		return m.AccessRequest
+	}
+	return nil
+}
+
+type AuditEvent struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Actor                string   `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	Action               string   `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Target               string   `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	Before               string   `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	After                string   `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	Source               string   `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	Address              string   `protobuf:"bytes,8,opt,name=address,proto3" json:"address,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{139}
+}
+
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
+}
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
+}
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
+}
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
+}
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
+
+func (m *AuditEvent) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+type ListAuditEventsRequest struct {
+	Actor                string   `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	Action               string   `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Target               string   `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Since                int64    `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`
+	Until                int64    `protobuf:"varint,6,opt,name=until,proto3" json:"until,omitempty"`
+	Skip                 int32    `protobuf:"varint,7,opt,name=skip,proto3" json:"skip,omitempty"`
+	Limit                int32    `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{140}
+}
+
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
+}
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
+}
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
+
+func (m *ListAuditEventsRequest) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetSkip() int32 {
+	if m != nil {
+		return m.Skip
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	AuditEvents          []*AuditEvent `protobuf:"bytes,1,rep,name=audit_events,json=auditEvents,proto3" json:"audit_events,omitempty"`
+	Total                int32         `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Skip                 int32         `protobuf:"varint,3,opt,name=skip,proto3" json:"skip,omitempty"`
+	Limit                int32         `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListAuditEventsResponse) Reset()         { *m = ListAuditEventsResponse{} }
+func (m *ListAuditEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsResponse) ProtoMessage()    {}
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{141}
+}
+
+func (m *ListAuditEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsResponse.Unmarshal(m, b)
+}
+func (m *ListAuditEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsResponse.Merge(m, src)
+}
+func (m *ListAuditEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsResponse.Size(m)
+}
+func (m *ListAuditEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsResponse proto.InternalMessageInfo
+
+func (m *ListAuditEventsResponse) GetAuditEvents() []*AuditEvent {
+	if m != nil {
+		return m.AuditEvents
+	}
+	return nil
+}
+
+func (m *ListAuditEventsResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *ListAuditEventsResponse) GetSkip() int32 {
+	if m != nil {
+		return m.Skip
+	}
+	return 0
+}
+
+func (m *ListAuditEventsResponse) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type BackupRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{142}
+}
+
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupRequest.Size(m)
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+type BackupChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupChunk) Reset()         { *m = BackupChunk{} }
+func (m *BackupChunk) String() string { return proto.CompactTextString(m) }
+func (*BackupChunk) ProtoMessage()    {}
+func (*BackupChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{143}
+}
+
+func (m *BackupChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupChunk.Unmarshal(m, b)
+}
+func (m *BackupChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupChunk.Marshal(b, m, deterministic)
+}
+func (m *BackupChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupChunk.Merge(m, src)
+}
+func (m *BackupChunk) XXX_Size() int {
+	return xxx_messageInfo_BackupChunk.Size(m)
+}
+func (m *BackupChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupChunk proto.InternalMessageInfo
+
+func (m *BackupChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ExportRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
+func (m *ExportRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()    {}
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{144}
+}
+
+func (m *ExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportRequest.Unmarshal(m, b)
+}
+func (m *ExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRequest.Merge(m, src)
+}
+func (m *ExportRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportRequest.Size(m)
+}
+func (m *ExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportRequest proto.InternalMessageInfo
+
+type ModelRecord struct {
+	Model                string   `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ModelRecord) Reset()         { *m = ModelRecord{} }
+func (m *ModelRecord) String() string { return proto.CompactTextString(m) }
+func (*ModelRecord) ProtoMessage()    {}
+func (*ModelRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{145}
+}
+
+func (m *ModelRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelRecord.Unmarshal(m, b)
+}
+func (m *ModelRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelRecord.Marshal(b, m, deterministic)
+}
+func (m *ModelRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelRecord.Merge(m, src)
+}
+func (m *ModelRecord) XXX_Size() int {
+	return xxx_messageInfo_ModelRecord.Size(m)
+}
+func (m *ModelRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelRecord proto.InternalMessageInfo
+
+func (m *ModelRecord) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+func (m *ModelRecord) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ImportResponse struct {
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportResponse) Reset()         { *m = ImportResponse{} }
+func (m *ImportResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportResponse) ProtoMessage()    {}
+func (*ImportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{146}
+}
+
+func (m *ImportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportResponse.Unmarshal(m, b)
+}
+func (m *ImportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportResponse.Merge(m, src)
+}
+func (m *ImportResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportResponse.Size(m)
+}
+func (m *ImportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportResponse proto.InternalMessageInfo
+
+func (m *ImportResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*User)(nil), "types.User")
+	proto.RegisterType((*ListUsersRequest)(nil), "types.ListUsersRequest")
+	proto.RegisterType((*ListUsersResponse)(nil), "types.ListUsersResponse")
+	proto.RegisterType((*CreateUserRequest)(nil), "types.CreateUserRequest")
+	proto.RegisterType((*CreateUserResponse)(nil), "types.CreateUserResponse")
+	proto.RegisterType((*TouchUserRequest)(nil), "types.TouchUserRequest")
+	proto.RegisterType((*TouchUserResponse)(nil), "types.TouchUserResponse")
+	proto.RegisterType((*UpdateUserRequest)(nil), "types.UpdateUserRequest")
+	proto.RegisterType((*UpdateUserResponse)(nil), "types.UpdateUserResponse")
+	proto.RegisterType((*AuthenticateUserRequest)(nil), "types.AuthenticateUserRequest")
+	proto.RegisterType((*AuthenticateUserResponse)(nil), "types.AuthenticateUserResponse")
+	proto.RegisterType((*GetUserRequest)(nil), "types.GetUserRequest")
+	proto.RegisterType((*GetUserResponse)(nil), "types.GetUserResponse")
+	proto.RegisterType((*Node)(nil), "types.Node")
+	proto.RegisterMapType((map[string]string)(nil), "types.Node.LabelsEntry")
+	proto.RegisterType((*ListNodesRequest)(nil), "types.ListNodesRequest")
+	proto.RegisterType((*ListNodesResponse)(nil), "types.ListNodesResponse")
+	proto.RegisterType((*PutNodeRequest)(nil), "types.PutNodeRequest")
+	proto.RegisterMapType((map[string]string)(nil), "types.PutNodeRequest.LabelsEntry")
+	proto.RegisterType((*PutNodeResponse)(nil), "types.PutNodeResponse")
+	proto.RegisterType((*DeleteNodeRequest)(nil), "types.DeleteNodeRequest")
+	proto.RegisterType((*DeleteNodeResponse)(nil), "types.DeleteNodeResponse")
+	proto.RegisterType((*GetNodeRequest)(nil), "types.GetNodeRequest")
+	proto.RegisterType((*GetNodeResponse)(nil), "types.GetNodeResponse")
+	proto.RegisterType((*TouchNodeRequest)(nil), "types.TouchNodeRequest")
+	proto.RegisterType((*TouchNodeResponse)(nil), "types.TouchNodeResponse")
+	proto.RegisterType((*UpdateNodeRequest)(nil), "types.UpdateNodeRequest")
+	proto.RegisterMapType((map[string]string)(nil), "types.UpdateNodeRequest.LabelsEntry")
+	proto.RegisterType((*UpdateNodeResponse)(nil), "types.UpdateNodeResponse")
+	proto.RegisterType((*Key)(nil), "types.Key")
+	proto.RegisterType((*ListKeysRequest)(nil), "types.ListKeysRequest")
+	proto.RegisterType((*ListKeysResponse)(nil), "types.ListKeysResponse")
+	proto.RegisterType((*CreateKeyRequest)(nil), "types.CreateKeyRequest")
+	proto.RegisterType((*CreateKeyResponse)(nil), "types.CreateKeyResponse")
+	proto.RegisterType((*DeleteKeyRequest)(nil), "types.DeleteKeyRequest")
+	proto.RegisterType((*DeleteKeyResponse)(nil), "types.DeleteKeyResponse")
+	proto.RegisterType((*GetKeyRequest)(nil), "types.GetKeyRequest")
+	proto.RegisterType((*GetKeyResponse)(nil), "types.GetKeyResponse")
+	proto.RegisterType((*TouchKeyRequest)(nil), "types.TouchKeyRequest")
+	proto.RegisterType((*TouchKeyResponse)(nil), "types.TouchKeyResponse")
+	proto.RegisterType((*MasterKey)(nil), "types.MasterKey")
+	proto.RegisterType((*ListMasterKeysRequest)(nil), "types.ListMasterKeysRequest")
+	proto.RegisterType((*ListMasterKeysResponse)(nil), "types.ListMasterKeysResponse")
+	proto.RegisterType((*UpdateAllMasterKeysRequest)(nil), "types.UpdateAllMasterKeysRequest")
+	proto.RegisterType((*UpdateAllMasterKeysResponse)(nil), "types.UpdateAllMasterKeysResponse")
+	proto.RegisterType((*Grant)(nil), "types.Grant")
+	proto.RegisterType((*GrantItem)(nil), "types.GrantItem")
+	proto.RegisterType((*PutGrantRequest)(nil), "types.PutGrantRequest")
+	proto.RegisterType((*PutGrantResponse)(nil), "types.PutGrantResponse")
+	proto.RegisterType((*ListGrantsRequest)(nil), "types.ListGrantsRequest")
+	proto.RegisterType((*ListGrantsResponse)(nil), "types.ListGrantsResponse")
+	proto.RegisterType((*ListGrantItemsRequest)(nil), "types.ListGrantItemsRequest")
+	proto.RegisterType((*ListGrantItemsResponse)(nil), "types.ListGrantItemsResponse")
+	proto.RegisterType((*DeleteGrantRequest)(nil), "types.DeleteGrantRequest")
+	proto.RegisterType((*DeleteGrantResponse)(nil), "types.DeleteGrantResponse")
+	proto.RegisterType((*CheckGrantRequest)(nil), "types.CheckGrantRequest")
+	proto.RegisterType((*CheckGrantResponse)(nil), "types.CheckGrantResponse")
+	proto.RegisterType((*WatchGrantsRequest)(nil), "types.WatchGrantsRequest")
+	proto.RegisterType((*WatchGrantsResponse)(nil), "types.WatchGrantsResponse")
+	proto.RegisterType((*Session)(nil), "types.Session")
+	proto.RegisterType((*CreateSessionRequest)(nil), "types.CreateSessionRequest")
+	proto.RegisterType((*CreateSessionResponse)(nil), "types.CreateSessionResponse")
+	proto.RegisterType((*FinishSessionRequest)(nil), "types.FinishSessionRequest")
+	proto.RegisterType((*FinishSessionResponse)(nil), "types.FinishSessionResponse")
+	proto.RegisterType((*ListSessionsRequest)(nil), "types.ListSessionsRequest")
+	proto.RegisterType((*ListSessionsResponse)(nil), "types.ListSessionsResponse")
+	proto.RegisterType((*GetSessionRequest)(nil), "types.GetSessionRequest")
+	proto.RegisterType((*GetSessionResponse)(nil), "types.GetSessionResponse")
+	proto.RegisterType((*UpdateSessionLegalHoldRequest)(nil), "types.UpdateSessionLegalHoldRequest")
+	proto.RegisterType((*UpdateSessionLegalHoldResponse)(nil), "types.UpdateSessionLegalHoldResponse")
+	proto.RegisterType((*Token)(nil), "types.Token")
+	proto.RegisterType((*CreateTokenRequest)(nil), "types.CreateTokenRequest")
+	proto.RegisterType((*CreateTokenResponse)(nil), "types.CreateTokenResponse")
+	proto.RegisterType((*GetTokenRequest)(nil), "types.GetTokenRequest")
+	proto.RegisterType((*GetTokenResponse)(nil), "types.GetTokenResponse")
+	proto.RegisterType((*TouchTokenRequest)(nil), "types.TouchTokenRequest")
+	proto.RegisterType((*TouchTokenResponse)(nil), "types.TouchTokenResponse")
+	proto.RegisterType((*ListTokensRequest)(nil), "types.ListTokensRequest")
+	proto.RegisterType((*ListTokensResponse)(nil), "types.ListTokensResponse")
+	proto.RegisterType((*DeleteTokenRequest)(nil), "types.DeleteTokenRequest")
+	proto.RegisterType((*DeleteTokenResponse)(nil), "types.DeleteTokenResponse")
+	proto.RegisterType((*ReplayFrame)(nil), "types.ReplayFrame")
+	proto.RegisterType((*ReplaySearchResult)(nil), "types.ReplaySearchResult")
+	proto.RegisterType((*TranscriptEntry)(nil), "types.TranscriptEntry")
+	proto.RegisterType((*WriteReplayResponse)(nil), "types.WriteReplayResponse")
+	proto.RegisterType((*ReadReplayRequest)(nil), "types.ReadReplayRequest")
+	proto.RegisterType((*SubmitReplayRequest)(nil), "types.SubmitReplayRequest")
+	proto.RegisterType((*SubmitReplayResponse)(nil), "types.SubmitReplayResponse")
+	proto.RegisterType((*SearchReplayRequest)(nil), "types.SearchReplayRequest")
+	proto.RegisterType((*SearchReplayResponse)(nil), "types.SearchReplayResponse")
+	proto.RegisterType((*GetTranscriptRequest)(nil), "types.GetTranscriptRequest")
+	proto.RegisterType((*GetTranscriptResponse)(nil), "types.GetTranscriptResponse")
+	proto.RegisterType((*VerifyReplayRequest)(nil), "types.VerifyReplayRequest")
+	proto.RegisterType((*VerifyReplayResponse)(nil), "types.VerifyReplayResponse")
+	proto.RegisterType((*Volume)(nil), "types.Volume")
+	proto.RegisterType((*VolumeMember)(nil), "types.VolumeMember")
+	proto.RegisterType((*VolumeMount)(nil), "types.VolumeMount")
+	proto.RegisterType((*ListVolumesRequest)(nil), "types.ListVolumesRequest")
+	proto.RegisterType((*ListVolumesResponse)(nil), "types.ListVolumesResponse")
+	proto.RegisterType((*PutVolumeRequest)(nil), "types.PutVolumeRequest")
+	proto.RegisterType((*PutVolumeResponse)(nil), "types.PutVolumeResponse")
+	proto.RegisterType((*DeleteVolumeRequest)(nil), "types.DeleteVolumeRequest")
+	proto.RegisterType((*DeleteVolumeResponse)(nil), "types.DeleteVolumeResponse")
+	proto.RegisterType((*ListVolumeMembersRequest)(nil), "types.ListVolumeMembersRequest")
+	proto.RegisterType((*ListVolumeMembersResponse)(nil), "types.ListVolumeMembersResponse")
+	proto.RegisterType((*PutVolumeMemberRequest)(nil), "types.PutVolumeMemberRequest")
+	proto.RegisterType((*PutVolumeMemberResponse)(nil), "types.PutVolumeMemberResponse")
+	proto.RegisterType((*DeleteVolumeMemberRequest)(nil), "types.DeleteVolumeMemberRequest")
+	proto.RegisterType((*DeleteVolumeMemberResponse)(nil), "types.DeleteVolumeMemberResponse")
+	proto.RegisterType((*ListVolumeMountsRequest)(nil), "types.ListVolumeMountsRequest")
+	proto.RegisterType((*ListVolumeMountsResponse)(nil), "types.ListVolumeMountsResponse")
+	proto.RegisterType((*Transfer)(nil), "types.Transfer")
+	proto.RegisterType((*CreateTransferRequest)(nil), "types.CreateTransferRequest")
+	proto.RegisterType((*CreateTransferResponse)(nil), "types.CreateTransferResponse")
+	proto.RegisterType((*ListTransfersRequest)(nil), "types.ListTransfersRequest")
+	proto.RegisterType((*ListTransfersResponse)(nil), "types.ListTransfersResponse")
+	proto.RegisterType((*Group)(nil), "types.Group")
+	proto.RegisterType((*GroupMember)(nil), "types.GroupMember")
+	proto.RegisterType((*ListGroupsRequest)(nil), "types.ListGroupsRequest")
+	proto.RegisterType((*ListGroupsResponse)(nil), "types.ListGroupsResponse")
+	proto.RegisterType((*PutGroupRequest)(nil), "types.PutGroupRequest")
+	proto.RegisterType((*PutGroupResponse)(nil), "types.PutGroupResponse")
+	proto.RegisterType((*DeleteGroupRequest)(nil), "types.DeleteGroupRequest")
+	proto.RegisterType((*DeleteGroupResponse)(nil), "types.DeleteGroupResponse")
+	proto.RegisterType((*ListGroupMembersRequest)(nil), "types.ListGroupMembersRequest")
+	proto.RegisterType((*ListGroupMembersResponse)(nil), "types.ListGroupMembersResponse")
+	proto.RegisterType((*PutGroupMemberRequest)(nil), "types.PutGroupMemberRequest")
+	proto.RegisterType((*PutGroupMemberResponse)(nil), "types.PutGroupMemberResponse")
+	proto.RegisterType((*DeleteGroupMemberRequest)(nil), "types.DeleteGroupMemberRequest")
+	proto.RegisterType((*DeleteGroupMemberResponse)(nil), "types.DeleteGroupMemberResponse")
+	proto.RegisterType((*AccessRequest)(nil), "types.AccessRequest")
+	proto.RegisterType((*AccessRequestEvent)(nil), "types.AccessRequestEvent")
+	proto.RegisterType((*CreateAccessRequestRequest)(nil), "types.CreateAccessRequestRequest")
+	proto.RegisterType((*CreateAccessRequestResponse)(nil), "types.CreateAccessRequestResponse")
+	proto.RegisterType((*ListAccessRequestsRequest)(nil), "types.ListAccessRequestsRequest")
+	proto.RegisterType((*ListAccessRequestsResponse)(nil), "types.ListAccessRequestsResponse")
+	proto.RegisterType((*GetAccessRequestRequest)(nil), "types.GetAccessRequestRequest")
+	proto.RegisterType((*GetAccessRequestResponse)(nil), "types.GetAccessRequestResponse")
+	proto.RegisterType((*ReviewAccessRequestRequest)(nil), "types.ReviewAccessRequestRequest")
+	proto.RegisterType((*ReviewAccessRequestResponse)(nil), "types.ReviewAccessRequestResponse")
+	proto.RegisterType((*CancelAccessRequestRequest)(nil), "types.CancelAccessRequestRequest")
+	proto.RegisterType((*CancelAccessRequestResponse)(nil), "types.CancelAccessRequestResponse")
+	proto.RegisterType((*AuditEvent)(nil), "types.AuditEvent")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "types.ListAuditEventsRequest")
+	proto.RegisterType((*ListAuditEventsResponse)(nil), "types.ListAuditEventsResponse")
+	proto.RegisterType((*BackupRequest)(nil), "types.BackupRequest")
+	proto.RegisterType((*BackupChunk)(nil), "types.BackupChunk")
+	proto.RegisterType((*ExportRequest)(nil), "types.ExportRequest")
+	proto.RegisterType((*ModelRecord)(nil), "types.ModelRecord")
+	proto.RegisterType((*ImportResponse)(nil), "types.ImportResponse")
+}
+
+func init() { proto.RegisterFile("daemon.proto", fileDescriptor_3ec90cbc4aa12fc6) }
+
+var fileDescriptor_3ec90cbc4aa12fc6 = []byte{
+	// 4932 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7c, 0xcd, 0x73, 0x1b, 0x47,
+	0x76, 0xf8, 0xe2, 0x1b, 0x78, 0xf8, 0x20, 0x39, 0x04, 0xc9, 0xe1, 0x90, 0x94, 0xa8, 0xb6, 0xec,
+	0xa5, 0xbd, 0xb6, 0x6c, 0xd1, 0xb6, 0xbc, 0xf6, 0xfe, 0xec, 0x5a, 0xae, 0xac, 0x2f, 0x4b, 0xb2,
+	0x5c, 0x23, 0xd9, 0xfe, 0xd5, 0xba, 0x76, 0x51, 0x43, 0xa0, 0x49, 0xce, 0x12, 0xc0, 0x20, 0x33,
+	0x03, 0xd9, 0xf0, 0x29, 0x87, 0x9c, 0x72, 0xc9, 0x21, 0xa7, 0xe4, 0x9a, 0xaf, 0x4a, 0x0e, 0x49,
+	0x4e, 0x49, 0x2a, 0x39, 0xa5, 0xb6, 0x6a, 0x73, 0x48, 0x4e, 0xa9, 0xdc, 0xb2, 0x97, 0xec, 0x25,
+	0xa9, 0xca, 0xff, 0x90, 0xa4, 0xfa, 0x73, 0xba, 0x67, 0x7a, 0x00, 0x70, 0x25, 0xed, 0x0d, 0xfd,
+	0x5e, 0xf7, 0xeb, 0xd7, 0xef, 0xab, 0x3f, 0xde, 0x1b, 0x40, 0x6b, 0xe0, 0xe1, 0x51, 0x30, 0xbe,
+	0x36, 0x09, 0x83, 0x38, 0xb0, 0x2a, 0xf1, 0x6c, 0x82, 0x23, 0xf4, 0xf3, 0x22, 0x94, 0x3f, 0x8f,
+	0x70, 0x68, 0xd9, 0x50, 0xf3, 0xfa, 0xfd, 0x60, 0x3a, 0x8e, 0xed, 0xe2, 0x7e, 0xe1, 0xa0, 0xe1,
+	0x8a, 0xa6, 0xe5, 0x40, 0x7d, 0xec, 0xf7, 0xcf, 0xc7, 0xde, 0x08, 0xdb, 0x25, 0x8a, 0x92, 0x6d,
+	0x6b, 0x1b, 0xea, 0x7e, 0xd4, 0xf3, 0x06, 0x23, 0x7f, 0x6c, 0x97, 0xf7, 0x0b, 0x07, 0x75, 0xb7,
+	0xe6, 0x47, 0x47, 0xa4, 0x69, 0xed, 0x01, 0xf8, 0x51, 0xef, 0x78, 0x18, 0xf4, 0xcf, 0xf1, 0xc0,
+	0xae, 0x50, 0x64, 0xc3, 0x8f, 0x7e, 0xc4, 0x00, 0x04, 0xdd, 0x0f, 0xb1, 0x17, 0xe3, 0x41, 0xcf,
+	0x8b, 0xed, 0xea, 0x7e, 0xe1, 0xa0, 0xe4, 0x36, 0x38, 0xe4, 0x28, 0x26, 0xe8, 0xe9, 0x64, 0x20,
+	0xd0, 0x35, 0x86, 0xe6, 0x90, 0xa3, 0xd8, 0xda, 0x81, 0xc6, 0x53, 0x1f, 0x7f, 0xcd, 0xb0, 0x75,
+	0x8a, 0xad, 0x33, 0xc0, 0x51, 0x6c, 0x75, 0xa1, 0x12, 0x06, 0x43, 0x1c, 0xd9, 0x8d, 0xfd, 0xd2,
+	0x41, 0xc3, 0x65, 0x0d, 0x6b, 0x13, 0xaa, 0x51, 0x30, 0x0d, 0xfb, 0xd8, 0x06, 0xba, 0x08, 0xde,
+	0xb2, 0x2e, 0x43, 0x33, 0xf0, 0x07, 0xfd, 0x9e, 0x1f, 0x45, 0x53, 0x1c, 0xda, 0x4d, 0x8a, 0x04,
+	0x02, 0xba, 0x47, 0x21, 0xd6, 0x15, 0x68, 0xd1, 0x0e, 0xd1, 0xf4, 0xf8, 0x67, 0xb8, 0x1f, 0xdb,
+	0x2d, 0xda, 0x83, 0x0e, 0x7a, 0xcc, 0x40, 0xc8, 0x82, 0xd5, 0x07, 0x7e, 0x14, 0x13, 0x41, 0x46,
+	0x2e, 0xfe, 0xad, 0x29, 0x8e, 0x62, 0x74, 0x03, 0xd6, 0x14, 0x58, 0x34, 0x09, 0xc6, 0x11, 0xb6,
+	0xae, 0x40, 0x65, 0x4a, 0x00, 0x76, 0x61, 0xbf, 0x74, 0xd0, 0x3c, 0x6c, 0x5e, 0xa3, 0x5a, 0xb8,
+	0x46, 0x3a, 0xb9, 0x0c, 0x83, 0x7e, 0x59, 0x80, 0xb5, 0x9b, 0x54, 0x0e, 0x14, 0xca, 0xa8, 0xa9,
+	0xea, 0x29, 0x64, 0xd4, 0x33, 0xf1, 0xa2, 0xe8, 0xeb, 0x20, 0x1c, 0x70, 0xcd, 0xc9, 0xf6, 0xaf,
+	0xab, 0xba, 0x44, 0x54, 0x95, 0x79, 0xa2, 0xaa, 0x2e, 0x14, 0x55, 0x2d, 0x2b, 0xaa, 0x77, 0xc1,
+	0x52, 0x57, 0xc7, 0xe5, 0x72, 0x19, 0xca, 0x64, 0xf5, 0x74, 0x6d, 0x29, 0xb1, 0x50, 0x04, 0x7a,
+	0x1d, 0x56, 0x9f, 0x04, 0xd3, 0xfe, 0xd9, 0x52, 0x32, 0x41, 0xef, 0xc0, 0x9a, 0xd2, 0x7b, 0xd9,
+	0x39, 0xfe, 0xa7, 0x08, 0x6b, 0x9f, 0x53, 0x13, 0x5b, 0x4e, 0xf2, 0xdf, 0x85, 0x15, 0x66, 0x91,
+	0x3d, 0x29, 0xe4, 0x22, 0x15, 0x64, 0x87, 0x81, 0x3f, 0x15, 0xa2, 0x9e, 0xa7, 0x86, 0x84, 0x88,
+	0xd4, 0x62, 0x59, 0x25, 0xf2, 0x99, 0xa2, 0x4b, 0xd9, 0xa3, 0x92, 0xd2, 0xf3, 0x2b, 0x92, 0x88,
+	0x54, 0x69, 0x95, 0x12, 0x69, 0x33, 0xf0, 0x3d, 0xae, 0x58, 0x55, 0xe7, 0x35, 0x5d, 0xe7, 0xaf,
+	0xc1, 0x5a, 0x42, 0x42, 0x78, 0x6d, 0x9d, 0xf6, 0x59, 0x11, 0x44, 0x14, 0xdf, 0x55, 0x3a, 0x35,
+	0xd2, 0xae, 0x7d, 0x05, 0x5a, 0x9c, 0x14, 0x73, 0x43, 0xa0, 0x1d, 0x9a, 0x0c, 0xe6, 0x12, 0x50,
+	0xe2, 0xa2, 0x4d, 0xc5, 0x45, 0x89, 0x6d, 0xa8, 0xf2, 0x5f, 0x56, 0x6f, 0x8f, 0x60, 0xeb, 0x68,
+	0x1a, 0x9f, 0xe1, 0x71, 0xec, 0xf7, 0x9f, 0x87, 0xdb, 0xa0, 0x1f, 0x80, 0x9d, 0x25, 0xb8, 0x2c,
+	0x37, 0xaf, 0x41, 0xe7, 0x0e, 0x8e, 0x97, 0xb3, 0xd3, 0xaf, 0x60, 0x45, 0xf6, 0x5d, 0x92, 0x3e,
+	0x31, 0x18, 0x7c, 0x72, 0x82, 0xfb, 0xb1, 0xff, 0x54, 0x08, 0xb8, 0x48, 0x85, 0xd8, 0x91, 0x60,
+	0x2a, 0x63, 0xf4, 0x37, 0x45, 0x28, 0x7f, 0x1a, 0x0c, 0xa8, 0xf9, 0x9d, 0x05, 0x51, 0x4c, 0xcd,
+	0x8f, 0x31, 0x20, 0xdb, 0x96, 0xc5, 0xa7, 0x63, 0x22, 0x60, 0x33, 0x10, 0x7e, 0x07, 0x83, 0x10,
+	0x47, 0x11, 0xb7, 0x56, 0xd1, 0x54, 0x02, 0x43, 0x59, 0x0b, 0x0c, 0x7a, 0x30, 0xaf, 0xa4, 0x83,
+	0xb9, 0x16, 0xad, 0xab, 0xa9, 0x68, 0x7d, 0x15, 0x3a, 0x7e, 0xd4, 0x3b, 0xc7, 0xb3, 0xde, 0xc8,
+	0x1b, 0x7b, 0xa7, 0x78, 0xc0, 0x2d, 0xb3, 0xe5, 0x47, 0xf7, 0xf1, 0xec, 0x21, 0x83, 0x59, 0x6f,
+	0x42, 0x75, 0xe8, 0x1d, 0xe3, 0x61, 0x64, 0xd7, 0x69, 0xe4, 0xdc, 0xe2, 0x82, 0x21, 0x0b, 0xbc,
+	0xf6, 0x80, 0x62, 0x6e, 0x8d, 0xe3, 0x70, 0xe6, 0xf2, 0x6e, 0xce, 0xfb, 0xd0, 0x54, 0xc0, 0xd6,
+	0x2a, 0x94, 0xce, 0xf1, 0x8c, 0x2f, 0x9f, 0xfc, 0x24, 0x26, 0xf8, 0xd4, 0x1b, 0x4e, 0x31, 0x5f,
+	0x3a, 0x6b, 0x7c, 0x50, 0xfc, 0x7e, 0x41, 0x44, 0x73, 0x42, 0x3a, 0x1d, 0xcd, 0x39, 0x2c, 0x89,
+	0xe6, 0x63, 0x02, 0x48, 0x45, 0x73, 0xd2, 0xc9, 0x65, 0x18, 0xf4, 0x5f, 0x05, 0xe8, 0x7c, 0x36,
+	0xa5, 0xe3, 0x84, 0x39, 0xbc, 0x78, 0x75, 0xbc, 0x2f, 0x85, 0x55, 0xa1, 0x8c, 0x5d, 0xe1, 0x8c,
+	0xe9, 0x8c, 0x3c, 0x6f, 0xb1, 0x1d, 0xc2, 0x8a, 0x9c, 0x20, 0x31, 0x66, 0x22, 0x86, 0x94, 0x31,
+	0xd3, 0x2e, 0x14, 0x81, 0xde, 0x84, 0xb5, 0x8f, 0xf1, 0x10, 0xc7, 0x78, 0x49, 0x01, 0xa1, 0x2e,
+	0x58, 0xea, 0x00, 0x36, 0x0f, 0x7a, 0x9d, 0xfa, 0xdc, 0xb2, 0x34, 0x0e, 0x61, 0x45, 0xf6, 0x5e,
+	0x96, 0xd1, 0x6b, 0x7c, 0xff, 0x59, 0x76, 0x0e, 0xb1, 0x03, 0x5d, 0x6c, 0x96, 0x3f, 0x95, 0x3b,
+	0xd0, 0xb2, 0x06, 0x73, 0x1d, 0x36, 0x92, 0xb0, 0xad, 0x3a, 0x11, 0xdb, 0x89, 0x2c, 0x11, 0xba,
+	0x15, 0x57, 0xca, 0x3a, 0x5c, 0xc9, 0xe0, 0x70, 0x2f, 0x01, 0xdf, 0x3b, 0x7a, 0xdc, 0x94, 0xd8,
+	0xae, 0xc4, 0x23, 0x3b, 0xb3, 0x11, 0xeb, 0xff, 0xa5, 0x0c, 0xed, 0xaa, 0x08, 0x57, 0xe9, 0x35,
+	0x3c, 0x6f, 0x5b, 0x93, 0x3b, 0xc5, 0xc5, 0xe4, 0xfb, 0xe7, 0x05, 0x28, 0xdd, 0xc7, 0x33, 0x6b,
+	0x1f, 0x9a, 0x27, 0xfe, 0xf8, 0x14, 0x87, 0x93, 0xd0, 0x97, 0x51, 0x59, 0x05, 0xcd, 0x39, 0x0e,
+	0x5b, 0x50, 0x56, 0x36, 0x72, 0xfa, 0xfb, 0x45, 0xc4, 0x45, 0xf4, 0x3d, 0x58, 0x21, 0x11, 0xe7,
+	0x3e, 0x9e, 0x45, 0x8b, 0x37, 0x92, 0x43, 0x58, 0x4d, 0x3a, 0x73, 0x69, 0x5c, 0x82, 0xf2, 0x39,
+	0x9e, 0x89, 0xe0, 0x04, 0x5c, 0x1a, 0xf7, 0xf1, 0xcc, 0xa5, 0x70, 0xf4, 0x2d, 0xac, 0xb2, 0x93,
+	0x18, 0x01, 0xf1, 0x19, 0x7e, 0x43, 0x82, 0x41, 0xd7, 0x61, 0x4d, 0x99, 0x9b, 0x33, 0xbc, 0x9b,
+	0x18, 0x80, 0xce, 0x2f, 0x01, 0xa3, 0x77, 0x60, 0x95, 0x79, 0xfe, 0x45, 0xd8, 0x45, 0xeb, 0xb0,
+	0xa6, 0x8c, 0xe2, 0xe1, 0xe2, 0x3a, 0xb4, 0xef, 0xe0, 0xf8, 0x42, 0x74, 0xae, 0x41, 0x47, 0x0c,
+	0x59, 0x8a, 0xdb, 0xb7, 0x61, 0x85, 0xfa, 0xff, 0x85, 0x26, 0x79, 0x0b, 0x56, 0x93, 0x41, 0x4b,
+	0x4d, 0xf3, 0x00, 0x1a, 0x0f, 0xbd, 0x28, 0xc6, 0xe1, 0x72, 0x56, 0xbd, 0x07, 0x30, 0x99, 0x1e,
+	0x0f, 0xfd, 0x3e, 0x71, 0x7f, 0xae, 0xbf, 0x06, 0x83, 0xdc, 0xc7, 0x33, 0xb4, 0x05, 0x1b, 0xc4,
+	0x8a, 0x24, 0x45, 0xb9, 0xfb, 0xdd, 0x87, 0xcd, 0x34, 0x82, 0xb3, 0x77, 0x1d, 0x9a, 0x23, 0x0a,
+	0xed, 0x29, 0xb6, 0xb6, 0xca, 0xd9, 0x94, 0xfd, 0x5d, 0x18, 0xc9, 0xa1, 0xe8, 0x11, 0x38, 0xcc,
+	0x77, 0x8f, 0x86, 0xc3, 0xcc, 0x54, 0xbf, 0x0e, 0xc1, 0x3d, 0xd8, 0x31, 0x12, 0xe4, 0xda, 0xfe,
+	0xf7, 0x02, 0x54, 0xee, 0x84, 0xde, 0x78, 0xde, 0x69, 0xf0, 0x55, 0x58, 0x15, 0x21, 0xb5, 0x37,
+	0xf1, 0xe2, 0x18, 0x87, 0x63, 0x2e, 0x9e, 0x15, 0x01, 0xff, 0x8c, 0x81, 0xe5, 0x16, 0x5d, 0x52,
+	0xb6, 0xe8, 0x3d, 0x00, 0xfc, 0xcd, 0xc4, 0x0f, 0x99, 0x27, 0x97, 0x99, 0x9f, 0x73, 0x08, 0xbb,
+	0xcc, 0xce, 0x0b, 0x03, 0x5d, 0xa8, 0x9c, 0x86, 0xc1, 0x74, 0xc2, 0x2f, 0x54, 0xac, 0x61, 0xbd,
+	0x0c, 0x1d, 0x1a, 0x27, 0x7b, 0x11, 0x1e, 0xe2, 0x7e, 0x1c, 0x84, 0xfc, 0x36, 0xd5, 0xa6, 0xd0,
+	0xc7, 0x1c, 0x88, 0x7e, 0x0c, 0x0d, 0xba, 0xb8, 0x7b, 0x31, 0x1e, 0x5d, 0xf8, 0x68, 0xa1, 0xf3,
+	0x5d, 0x4a, 0xf1, 0x8d, 0x7e, 0x51, 0xa0, 0x5b, 0x3a, 0xa5, 0xbf, 0xf8, 0x44, 0xfd, 0x62, 0x65,
+	0x28, 0x85, 0x54, 0x99, 0x2f, 0xa4, 0xaa, 0x49, 0x48, 0x37, 0x60, 0x35, 0x59, 0x07, 0xb7, 0x5c,
+	0x44, 0x08, 0x7a, 0x7c, 0x19, 0xcd, 0xc3, 0x16, 0x37, 0x31, 0xd6, 0x89, 0xa1, 0xd0, 0x4d, 0x76,
+	0xea, 0xa3, 0xb0, 0xc5, 0x51, 0x38, 0xe1, 0xb1, 0xa8, 0xf0, 0x88, 0x1e, 0x80, 0xa5, 0x12, 0xe1,
+	0xd3, 0x5f, 0x85, 0x2a, 0x9d, 0x43, 0x98, 0xb8, 0x3e, 0x3f, 0xc7, 0x91, 0x3d, 0x71, 0x1c, 0x7c,
+	0x4d, 0xe9, 0x95, 0x5c, 0xf2, 0x13, 0x5d, 0x67, 0x3e, 0x2a, 0x75, 0xbe, 0xc4, 0xe6, 0xc0, 0xbd,
+	0x57, 0x1d, 0x92, 0x78, 0x2f, 0x9d, 0xa8, 0xe7, 0x13, 0x70, 0xca, 0xd9, 0x64, 0x7f, 0x17, 0x4e,
+	0xe5, 0x50, 0xf4, 0x17, 0x05, 0x71, 0x02, 0xfb, 0xcd, 0x98, 0x85, 0x94, 0x69, 0x79, 0xbe, 0xde,
+	0x2b, 0x26, 0xbd, 0x6f, 0xc0, 0xba, 0xc6, 0x2b, 0x8f, 0x08, 0x3f, 0x81, 0xb5, 0x9b, 0x67, 0xb8,
+	0x7f, 0xbe, 0xe4, 0x0a, 0x54, 0xaf, 0x2a, 0xe6, 0x78, 0x95, 0xc2, 0x32, 0xba, 0x0a, 0x96, 0x4a,
+	0x9e, 0xcb, 0xba, 0x03, 0xc5, 0xe0, 0x9c, 0x92, 0xae, 0xbb, 0xc5, 0xe0, 0x9c, 0x9c, 0x64, 0xbf,
+	0xf4, 0xe2, 0xfe, 0x99, 0x66, 0x5c, 0xe8, 0x3a, 0xac, 0x6b, 0x50, 0x3e, 0xd8, 0x81, 0x3a, 0xe7,
+	0x86, 0x69, 0xa9, 0xe1, 0xca, 0x36, 0xfa, 0xb7, 0x0a, 0xd4, 0x1e, 0xe3, 0x28, 0xf2, 0x83, 0x31,
+	0x99, 0xc4, 0x1f, 0xd0, 0x49, 0x4a, 0x6e, 0xd1, 0x1f, 0xcc, 0xd9, 0xad, 0x6d, 0xa8, 0xf5, 0x83,
+	0xd1, 0xc8, 0x1b, 0x0f, 0xc4, 0xad, 0x82, 0x37, 0x53, 0xd1, 0xaa, 0x9c, 0x8e, 0x56, 0x97, 0xe9,
+	0x2e, 0xe3, 0x47, 0x67, 0x6a, 0x34, 0x03, 0x01, 0x62, 0x1d, 0xfc, 0xa8, 0x17, 0xe2, 0x7e, 0x10,
+	0x0e, 0xf0, 0x80, 0x3f, 0x44, 0x80, 0x1f, 0xb9, 0x1c, 0x42, 0x54, 0x1a, 0xc5, 0xde, 0x29, 0xe6,
+	0x01, 0x8d, 0x35, 0x34, 0x29, 0xd7, 0x73, 0xa4, 0xdc, 0x50, 0x0c, 0xe3, 0x32, 0x34, 0xfb, 0x43,
+	0x1f, 0x8f, 0xe3, 0x1e, 0xb9, 0x0e, 0xf1, 0x47, 0x3d, 0x60, 0xa0, 0xa3, 0xc1, 0x20, 0x24, 0x36,
+	0xc2, 0x3b, 0x3c, 0xc5, 0x21, 0x91, 0x0e, 0x7f, 0xdb, 0x6b, 0x33, 0xe8, 0x17, 0x0c, 0x48, 0xee,
+	0xd3, 0xe4, 0x2c, 0xac, 0xee, 0x9c, 0xec, 0x85, 0xaf, 0x73, 0x8e, 0x67, 0xb7, 0x13, 0x28, 0x79,
+	0x3c, 0x99, 0xc4, 0xb3, 0x5e, 0x8c, 0xc3, 0x91, 0xdd, 0x66, 0x22, 0x9b, 0xc4, 0xb3, 0x27, 0x38,
+	0x1c, 0x91, 0x83, 0x1c, 0x41, 0x7d, 0xed, 0x0f, 0xe2, 0x33, 0xbb, 0xb3, 0x5f, 0x38, 0xa8, 0xb8,
+	0xa4, 0xef, 0x97, 0xa4, 0x4d, 0x37, 0xdd, 0x78, 0xd6, 0x3b, 0xc3, 0xfe, 0xe9, 0x59, 0x6c, 0xaf,
+	0x50, 0x2c, 0xe9, 0x7e, 0x97, 0x02, 0xc8, 0x41, 0x3b, 0x8a, 0xce, 0x06, 0x3d, 0x7f, 0x1c, 0xc5,
+	0xde, 0xb8, 0x8f, 0xed, 0x55, 0x4a, 0xbb, 0x45, 0x80, 0xf7, 0x38, 0x8c, 0x4e, 0xe0, 0x85, 0x64,
+	0x2d, 0xfe, 0xc0, 0x5e, 0x63, 0x27, 0x45, 0x06, 0xb8, 0x47, 0x15, 0x36, 0xc4, 0xa7, 0xde, 0xb0,
+	0x77, 0x16, 0x0c, 0x07, 0xb6, 0xc5, 0x9e, 0x63, 0x28, 0xe4, 0x6e, 0x30, 0x1c, 0xd0, 0xb1, 0xd3,
+	0xf0, 0x94, 0xa9, 0x6b, 0x9d, 0x8f, 0xa5, 0x80, 0x23, 0x3a, 0x7b, 0x88, 0x27, 0x43, 0x6f, 0xd6,
+	0x1b, 0xf8, 0xa7, 0x38, 0x8a, 0xed, 0x2e, 0x9b, 0x9d, 0x01, 0x3f, 0xa6, 0x30, 0xa5, 0xd3, 0x49,
+	0xe8, 0x8d, 0x70, 0x64, 0x6f, 0x50, 0x2a, 0xbc, 0xd3, 0x6d, 0x0a, 0x23, 0x7e, 0xce, 0x3b, 0x45,
+	0xfe, 0xe9, 0xd8, 0x8b, 0xa7, 0x21, 0xb6, 0x37, 0x99, 0x9f, 0x33, 0xf8, 0x63, 0x01, 0x56, 0xe8,
+	0x91, 0xae, 0x38, 0xb4, 0xb7, 0xd4, 0x49, 0x1f, 0x53, 0x18, 0xfa, 0x87, 0x12, 0x74, 0xd9, 0x19,
+	0x91, 0x1b, 0xf7, 0x62, 0x47, 0x55, 0x6c, 0xba, 0xa8, 0xdb, 0x74, 0xca, 0x26, 0x4b, 0xf9, 0x36,
+	0x59, 0xce, 0xb3, 0xc9, 0x4a, 0x8e, 0x4d, 0x56, 0xf3, 0x6d, 0xb2, 0xb6, 0x84, 0x4d, 0xd6, 0x97,
+	0xb4, 0xc9, 0xc6, 0x42, 0x9b, 0x84, 0x39, 0x36, 0xd9, 0x9c, 0x6b, 0x93, 0xad, 0x85, 0x36, 0xd9,
+	0x5e, 0x64, 0x93, 0x1d, 0xdd, 0x26, 0xd1, 0x11, 0x6c, 0xa4, 0x94, 0xc7, 0x23, 0xd9, 0x01, 0xd4,
+	0x22, 0x06, 0xe2, 0x1b, 0x6f, 0x87, 0x6f, 0x37, 0xa2, 0xa3, 0x40, 0xa3, 0x57, 0xa0, 0x7b, 0x9b,
+	0x46, 0x95, 0x94, 0xfe, 0x53, 0x31, 0x8e, 0x4c, 0x95, 0xea, 0x77, 0xe1, 0xa9, 0xfe, 0xa0, 0x08,
+	0xeb, 0x64, 0x8b, 0xe4, 0x08, 0xb9, 0xa7, 0x5a, 0x50, 0x8e, 0xce, 0xfd, 0x09, 0x1d, 0x5e, 0x71,
+	0xe9, 0x6f, 0x62, 0x29, 0x43, 0x7f, 0xe4, 0xb3, 0x80, 0x5a, 0x71, 0x59, 0x43, 0x35, 0xca, 0x52,
+	0xfe, 0xee, 0x51, 0x4e, 0xd9, 0x10, 0xb1, 0x3a, 0x7f, 0xcc, 0xdf, 0xd9, 0x4b, 0x2e, 0x6b, 0x10,
+	0xe8, 0x74, 0x1c, 0xfb, 0x43, 0x7e, 0x25, 0x64, 0x0d, 0xd5, 0xb8, 0x6b, 0xba, 0x71, 0xbf, 0x04,
+	0xed, 0x60, 0x3c, 0x9c, 0x25, 0xe6, 0xcd, 0x9e, 0x6d, 0x5b, 0x04, 0x28, 0x0d, 0x9c, 0xbc, 0xdd,
+	0x93, 0x4e, 0x1e, 0x7d, 0x21, 0xe4, 0x8f, 0xb6, 0x40, 0x40, 0x47, 0x14, 0x42, 0xae, 0x6a, 0xfd,
+	0x69, 0x18, 0x05, 0x2c, 0x94, 0x96, 0x5c, 0xde, 0x42, 0x7f, 0x54, 0x80, 0xae, 0x2e, 0x1b, 0x2e,
+	0xde, 0xe5, 0x85, 0xd3, 0x85, 0x4a, 0x1c, 0xc4, 0xde, 0x90, 0x8a, 0xa6, 0xe2, 0xb2, 0x86, 0xf5,
+	0x1a, 0xd4, 0xb9, 0xfc, 0xc9, 0xe3, 0x42, 0xc9, 0xa0, 0x1f, 0x89, 0x27, 0xdc, 0x8f, 0xf1, 0x37,
+	0x71, 0x8f, 0x73, 0xc8, 0x37, 0x1d, 0x02, 0xba, 0xc9, 0xb8, 0x7c, 0x09, 0xd6, 0xee, 0xe0, 0x78,
+	0x81, 0xa5, 0x7c, 0x04, 0x96, 0xda, 0xe9, 0xc2, 0x66, 0xf2, 0x29, 0xec, 0xb1, 0x8b, 0x06, 0xc7,
+	0x3c, 0x10, 0x31, 0x36, 0x67, 0xc2, 0x54, 0x64, 0x2e, 0xa6, 0x22, 0x33, 0xfa, 0x04, 0x2e, 0xe5,
+	0xd1, 0xbb, 0x30, 0x6f, 0x7f, 0x56, 0x84, 0xca, 0x93, 0xe0, 0x1c, 0x5f, 0xe4, 0x0c, 0x40, 0xf5,
+	0x72, 0x8e, 0xc7, 0xdc, 0x64, 0x59, 0x83, 0x5c, 0x23, 0x07, 0x38, 0xea, 0x87, 0xfe, 0x24, 0x26,
+	0xf3, 0x32, 0x9b, 0x55, 0x41, 0xcf, 0xf4, 0xdc, 0x6b, 0x41, 0xf9, 0xdc, 0x97, 0x36, 0x4c, 0x7f,
+	0xcb, 0x97, 0x83, 0x7a, 0xea, 0xe5, 0xa0, 0x1f, 0x4c, 0x64, 0x16, 0x8f, 0xb7, 0x92, 0x6b, 0x42,
+	0x44, 0xa8, 0x83, 0x7a, 0x4d, 0x88, 0x8e, 0x62, 0x92, 0x7b, 0xf0, 0x07, 0x43, 0xdc, 0x8b, 0xfd,
+	0x11, 0x0e, 0xa6, 0x31, 0x0d, 0x7c, 0x25, 0xb7, 0x49, 0x60, 0x4f, 0x18, 0x08, 0xfd, 0x6b, 0x41,
+	0xa4, 0xa0, 0xa8, 0xbc, 0x16, 0x6f, 0x2b, 0x29, 0x81, 0x14, 0xb3, 0x02, 0x11, 0x8b, 0x2a, 0x19,
+	0x16, 0x55, 0x36, 0x2e, 0xaa, 0x32, 0x67, 0x51, 0xd5, 0x45, 0x8b, 0xaa, 0x65, 0x17, 0xf5, 0x3e,
+	0xac, 0x6b, 0x6b, 0x4a, 0x2e, 0x39, 0x4c, 0xc3, 0xfa, 0x25, 0x87, 0x75, 0x62, 0x28, 0xf4, 0x1e,
+	0x7d, 0x0e, 0xd5, 0x64, 0x91, 0x36, 0x21, 0x69, 0x28, 0x45, 0xc5, 0x50, 0xc8, 0xad, 0x2a, 0x19,
+	0x78, 0x81, 0x09, 0xdf, 0xe7, 0x6f, 0xa3, 0xda, 0x94, 0x5d, 0x75, 0xa0, 0xb4, 0x45, 0xc6, 0x48,
+	0x51, 0x7a, 0xf0, 0xf7, 0xc1, 0x52, 0x87, 0x5e, 0x60, 0xd2, 0x37, 0xd8, 0x55, 0x8e, 0xc2, 0x96,
+	0xb8, 0x33, 0x7d, 0x00, 0x96, 0xda, 0x3d, 0xb9, 0xb4, 0x51, 0x6a, 0xe9, 0x4b, 0x1b, 0x9b, 0x89,
+	0xe3, 0xc8, 0xf9, 0x9f, 0xdd, 0x3a, 0xe6, 0xc9, 0x34, 0xb9, 0x9b, 0x68, 0x6b, 0x41, 0xdf, 0x40,
+	0xd3, 0x4d, 0x8e, 0x55, 0xc4, 0x32, 0xb8, 0x87, 0xf7, 0xe4, 0xe8, 0x06, 0x87, 0xdc, 0x1b, 0x58,
+	0xbb, 0xd0, 0x20, 0x46, 0x11, 0xc5, 0xde, 0x88, 0xdd, 0x3a, 0xdb, 0x6e, 0x02, 0x20, 0x26, 0x48,
+	0xf8, 0xa3, 0x66, 0xd9, 0x76, 0xe9, 0x6f, 0xb2, 0xe4, 0x89, 0x37, 0x1b, 0x06, 0x1e, 0xcb, 0x33,
+	0xb6, 0x5c, 0xd1, 0x44, 0xbf, 0x2a, 0x80, 0xc5, 0xa6, 0x7e, 0x8c, 0xbd, 0xb0, 0x7f, 0xe6, 0xe2,
+	0x68, 0x3a, 0x8c, 0x9f, 0x8d, 0x83, 0xfc, 0x6d, 0x71, 0xc1, 0x2d, 0x43, 0xd9, 0xed, 0x2a, 0xfa,
+	0x6e, 0xb7, 0x0b, 0x0d, 0xb1, 0x7f, 0x46, 0x76, 0x95, 0xba, 0x51, 0x02, 0xb0, 0x2e, 0x01, 0x9c,
+	0xf9, 0xa7, 0x67, 0x43, 0x72, 0x8c, 0x89, 0xec, 0x1a, 0x45, 0x2b, 0x10, 0xf4, 0xc7, 0x05, 0x58,
+	0x79, 0x12, 0x7a, 0x63, 0xe6, 0xbb, 0xec, 0xe1, 0x59, 0x5b, 0x42, 0x21, 0xbd, 0x84, 0x4d, 0xa8,
+	0x4e, 0xc2, 0x60, 0x34, 0x11, 0xd1, 0x93, 0xb7, 0xe6, 0x5c, 0xa0, 0x36, 0xa1, 0x1a, 0x4c, 0xe3,
+	0xc9, 0x34, 0x16, 0x8f, 0x9e, 0xac, 0x45, 0x4e, 0xc8, 0xec, 0x57, 0x2f, 0x0e, 0xa7, 0xe3, 0x3e,
+	0x59, 0x29, 0xaf, 0x8b, 0x58, 0x61, 0xf0, 0x27, 0x02, 0x4c, 0x8c, 0xe3, 0xcb, 0xd0, 0x8f, 0x31,
+	0xd3, 0x87, 0x34, 0x8e, 0x43, 0x58, 0x73, 0xb1, 0x37, 0x10, 0x50, 0x66, 0x58, 0xf3, 0x15, 0x84,
+	0xde, 0x81, 0xf5, 0xc7, 0xd3, 0xe3, 0x91, 0x1f, 0x5f, 0x68, 0xd4, 0x26, 0x74, 0xf5, 0x51, 0x9c,
+	0x83, 0xff, 0x2e, 0xc0, 0xba, 0x30, 0x0f, 0x95, 0x9c, 0x0d, 0xb5, 0x73, 0x3c, 0xa3, 0xd9, 0x54,
+	0xee, 0x49, 0xbc, 0x49, 0xce, 0x3f, 0x5c, 0x30, 0x11, 0xdf, 0x01, 0x65, 0x9b, 0x38, 0xfd, 0x88,
+	0xdc, 0x76, 0xc5, 0x06, 0x44, 0x1b, 0xaa, 0xd1, 0x94, 0xf3, 0xcf, 0x52, 0x95, 0xbc, 0xb3, 0x54,
+	0xd5, 0x78, 0x96, 0xaa, 0xa9, 0x67, 0x29, 0x0b, 0xca, 0x27, 0x61, 0x30, 0xe2, 0x95, 0x23, 0xf4,
+	0x37, 0x81, 0x45, 0xfe, 0xb7, 0xec, 0x64, 0x54, 0x72, 0xe9, 0x6f, 0xe4, 0x41, 0x57, 0x5f, 0x2c,
+	0x8f, 0x03, 0x6f, 0x43, 0x2d, 0xa4, 0xde, 0x21, 0x02, 0xc1, 0x36, 0x0f, 0x04, 0x59, 0xff, 0x71,
+	0x45, 0xcf, 0xe4, 0x14, 0xc4, 0xc2, 0x19, 0x6b, 0xa0, 0x77, 0xa1, 0x4b, 0x82, 0xa8, 0x34, 0xc9,
+	0x25, 0xf5, 0x73, 0x0f, 0x36, 0x52, 0xc3, 0x38, 0x6b, 0x6f, 0x41, 0x0d, 0x8f, 0xe3, 0xd0, 0x97,
+	0x59, 0xc9, 0x4d, 0x11, 0xa3, 0x74, 0xab, 0x77, 0x45, 0x37, 0x62, 0x20, 0x5f, 0xe0, 0xd0, 0x3f,
+	0x99, 0x5d, 0xc8, 0x40, 0xfe, 0xaa, 0x00, 0x5d, 0x7d, 0x18, 0x67, 0x60, 0xfe, 0x38, 0xe2, 0x1c,
+	0x4c, 0x20, 0xc2, 0x9d, 0x58, 0x8b, 0x28, 0x7d, 0x84, 0xa3, 0x88, 0x5c, 0xc1, 0xb8, 0x3b, 0xf1,
+	0x26, 0x19, 0xc1, 0xef, 0xa6, 0xdc, 0x9d, 0x58, 0x8b, 0xc0, 0xf9, 0x75, 0x94, 0x9d, 0x40, 0x78,
+	0x8b, 0xc0, 0xf9, 0xb5, 0x92, 0x5d, 0xcd, 0x78, 0x0b, 0xfd, 0x04, 0xaa, 0x5f, 0x04, 0xc3, 0x29,
+	0xbb, 0xba, 0x29, 0x4f, 0xa4, 0xf4, 0xf7, 0x12, 0x9b, 0xbc, 0x1e, 0xb1, 0x4a, 0xa9, 0x88, 0x85,
+	0x7e, 0xaf, 0x00, 0x2d, 0x46, 0xff, 0x21, 0x1e, 0x1d, 0xe3, 0x90, 0xf0, 0xf1, 0x94, 0xb6, 0xf9,
+	0x3c, 0xd5, 0xa7, 0x72, 0x76, 0x7a, 0x58, 0x28, 0x1a, 0x0e, 0x0b, 0x25, 0x8d, 0xa3, 0x16, 0xbd,
+	0xb3, 0x7a, 0x83, 0x1e, 0x39, 0xa6, 0xdb, 0xe5, 0xe4, 0xd2, 0xea, 0x0d, 0x1e, 0x8d, 0x87, 0xb3,
+	0x05, 0xe7, 0x30, 0x74, 0x07, 0x9a, 0x9c, 0x21, 0xea, 0x3c, 0x79, 0xfc, 0xa4, 0xe7, 0x29, 0xa6,
+	0xe7, 0x41, 0x5d, 0xb6, 0x19, 0x32, 0x62, 0xf2, 0xa9, 0xea, 0x23, 0x58, 0xd7, 0xa0, 0x5c, 0xff,
+	0xdf, 0x85, 0x1a, 0x23, 0x2c, 0x0c, 0xb0, 0xcd, 0x0d, 0x90, 0x75, 0x74, 0x05, 0x16, 0xdd, 0xa5,
+	0x8f, 0xb2, 0x1c, 0x9a, 0x5c, 0xb8, 0x2e, 0xae, 0x19, 0xf4, 0x01, 0xac, 0x29, 0x94, 0x38, 0x1f,
+	0x2f, 0x6b, 0xcb, 0xcd, 0xb0, 0xc1, 0x91, 0xe8, 0x55, 0xb1, 0x0d, 0x2f, 0x64, 0x84, 0xc4, 0x44,
+	0xbd, 0xab, 0x8c, 0xca, 0x76, 0x22, 0x08, 0xa6, 0x7c, 0x79, 0xc2, 0xc8, 0x11, 0x3a, 0xfa, 0x04,
+	0xb6, 0x0d, 0x63, 0x38, 0xeb, 0x6f, 0x10, 0x5f, 0xa0, 0x20, 0x2e, 0xc2, 0x75, 0x8d, 0x77, 0xd6,
+	0xdd, 0x15, 0x7d, 0xd0, 0xb7, 0xb0, 0x29, 0x97, 0xcf, 0x71, 0xf3, 0x67, 0x7f, 0x7e, 0x26, 0x88,
+	0x6e, 0xc3, 0x56, 0x66, 0x6e, 0xbe, 0x8a, 0xef, 0x41, 0x95, 0x71, 0xc8, 0x15, 0x60, 0x5c, 0x04,
+	0xef, 0x82, 0xbe, 0x82, 0x6d, 0x55, 0xb6, 0xcf, 0x75, 0x19, 0x68, 0x17, 0x1c, 0x13, 0x71, 0xae,
+	0xbe, 0xb7, 0x61, 0x4b, 0x51, 0x05, 0x7d, 0x53, 0x5d, 0x7c, 0x3e, 0xbc, 0xad, 0xe9, 0x9c, 0x0f,
+	0xe2, 0x0b, 0x7f, 0x0d, 0xaa, 0xa3, 0xe4, 0xa9, 0xb6, 0x79, 0x68, 0xe9, 0x0b, 0x27, 0x28, 0x97,
+	0xf7, 0x40, 0xbf, 0x53, 0x84, 0x3a, 0x8d, 0xcc, 0x27, 0x38, 0xbc, 0xc0, 0xcd, 0x4d, 0x0f, 0xb2,
+	0x25, 0x43, 0x90, 0x35, 0xe6, 0xa3, 0x77, 0xa1, 0x31, 0xf0, 0x43, 0xdc, 0xa7, 0x8e, 0xc4, 0x36,
+	0xd0, 0x04, 0x40, 0x76, 0xd7, 0x13, 0x7f, 0x88, 0xa9, 0xf8, 0x58, 0xe8, 0x94, 0x6d, 0x22, 0xd6,
+	0x11, 0x49, 0xad, 0xd7, 0xd8, 0x51, 0x92, 0xfc, 0x96, 0x3b, 0x66, 0x3d, 0xd9, 0x31, 0xe9, 0xcc,
+	0x67, 0xde, 0xe1, 0xbb, 0x37, 0xf8, 0x83, 0x15, 0x6f, 0xa5, 0x42, 0x15, 0xa4, 0x43, 0xd5, 0xaf,
+	0x0a, 0xe2, 0xbd, 0x48, 0x08, 0x63, 0xf1, 0xb5, 0x4c, 0x97, 0x41, 0x31, 0x5f, 0x06, 0xa5, 0x7c,
+	0x19, 0x94, 0xe7, 0xc9, 0xa0, 0x92, 0x23, 0x83, 0xaa, 0x41, 0x06, 0x35, 0xa3, 0x0c, 0xea, 0xaa,
+	0x0c, 0xd0, 0x2d, 0xd8, 0x4c, 0xaf, 0x51, 0xba, 0x4a, 0x3d, 0xe6, 0x30, 0xee, 0x2c, 0x2b, 0xea,
+	0xae, 0x4d, 0xba, 0xca, 0x0e, 0xe8, 0x11, 0x7b, 0x8f, 0x11, 0x98, 0xe8, 0x59, 0x25, 0x85, 0x6e,
+	0xc3, 0x46, 0x8a, 0xa0, 0x8c, 0x43, 0x0d, 0x31, 0xab, 0xb0, 0xe5, 0x0c, 0x5f, 0x49, 0x0f, 0x14,
+	0x92, 0x3c, 0x2b, 0xc9, 0xce, 0xbc, 0x88, 0xfd, 0x35, 0x29, 0x19, 0x2c, 0xab, 0x25, 0x83, 0x31,
+	0x34, 0xe9, 0x9c, 0x7c, 0xcf, 0x95, 0xd9, 0xa2, 0x82, 0x9a, 0x2d, 0x9a, 0xeb, 0x47, 0xf3, 0xe6,
+	0xcc, 0x2b, 0x5f, 0x78, 0x43, 0xe4, 0x05, 0x83, 0xe9, 0x64, 0xf9, 0xcb, 0xa4, 0xe8, 0xae, 0x66,
+	0x00, 0x09, 0x24, 0x93, 0x01, 0x0c, 0xa6, 0x13, 0x97, 0xe3, 0xd0, 0x6f, 0x8b, 0x1c, 0x2c, 0x01,
+	0x3e, 0xcb, 0x2e, 0x99, 0x29, 0xcb, 0x2c, 0xcd, 0x29, 0xcb, 0xd4, 0x64, 0x2c, 0xb2, 0xa7, 0x94,
+	0x03, 0x35, 0x7b, 0x2a, 0x04, 0x9d, 0xe6, 0x9d, 0xa1, 0xd0, 0x41, 0x92, 0x29, 0x9c, 0xcf, 0xbc,
+	0x9a, 0xa7, 0x53, 0x26, 0x41, 0x6f, 0xb2, 0xc8, 0xac, 0x28, 0x38, 0x52, 0x9e, 0x0b, 0xb2, 0x8a,
+	0x46, 0x77, 0xc1, 0xce, 0x0e, 0xe0, 0x1c, 0xbf, 0x9e, 0xde, 0x54, 0x2d, 0x95, 0xe7, 0xf4, 0x9e,
+	0xda, 0x83, 0x0d, 0xb1, 0x66, 0x7d, 0x2f, 0xba, 0xa8, 0x85, 0xe5, 0x84, 0x21, 0xf4, 0x31, 0x6c,
+	0xa6, 0x27, 0x50, 0xb6, 0x0f, 0x75, 0xdf, 0x34, 0xf1, 0x29, 0xb6, 0xcd, 0x4f, 0xc0, 0x56, 0x04,
+	0xf7, 0x4c, 0x9c, 0xa2, 0x1d, 0xd8, 0x36, 0xd0, 0xe2, 0xaa, 0xf8, 0xdf, 0x22, 0xb4, 0x8f, 0xfa,
+	0x7d, 0x1c, 0x45, 0x79, 0x6f, 0x44, 0xf9, 0x22, 0x30, 0x65, 0x80, 0x4b, 0xf3, 0x33, 0xc0, 0x65,
+	0x25, 0xa9, 0xe2, 0x40, 0x7d, 0x30, 0x0d, 0x3d, 0xb9, 0x67, 0x95, 0x5c, 0xd9, 0xb6, 0xae, 0x42,
+	0xfb, 0x67, 0xd3, 0x28, 0xf6, 0x4f, 0xfc, 0x3e, 0xeb, 0xc0, 0xd3, 0xff, 0x1a, 0x90, 0xea, 0x20,
+	0xf6, 0xe2, 0x69, 0xc4, 0x5f, 0x1d, 0x79, 0x8b, 0x50, 0x0e, 0x31, 0x7d, 0x99, 0x0c, 0x45, 0xca,
+	0x51, 0xb4, 0x49, 0xa6, 0x86, 0xfd, 0xee, 0x91, 0xdb, 0x2a, 0x96, 0x19, 0x98, 0x36, 0x83, 0xde,
+	0x64, 0xc0, 0x05, 0xfb, 0x1a, 0x79, 0xb7, 0xe6, 0x14, 0x29, 0x9e, 0xbd, 0x46, 0x82, 0x00, 0x1d,
+	0xc5, 0xd6, 0x01, 0xac, 0xb2, 0x0c, 0xbc, 0x52, 0xfb, 0xd0, 0xa2, 0xbd, 0x3a, 0x14, 0x7e, 0x4b,
+	0x16, 0x63, 0xfc, 0x61, 0x01, 0x2c, 0x4d, 0x03, 0xb7, 0x9e, 0x72, 0x06, 0x42, 0xd6, 0x56, 0xae,
+	0x5b, 0x1c, 0xc2, 0x76, 0x41, 0xaf, 0xaf, 0x44, 0x04, 0xde, 0x22, 0xc6, 0xe1, 0xd1, 0xbc, 0x39,
+	0xbf, 0x79, 0xd3, 0x86, 0x78, 0xd3, 0xc0, 0xc9, 0xcd, 0xbb, 0x6f, 0x5c, 0x67, 0xe6, 0xaa, 0xf1,
+	0xb7, 0x05, 0x70, 0xd8, 0xde, 0xa6, 0xb1, 0xf8, 0xc2, 0xab, 0x03, 0x54, 0xdb, 0x28, 0x2f, 0xb2,
+	0x8d, 0x8a, 0xc1, 0x36, 0xd0, 0x8f, 0x61, 0xc7, 0xc8, 0x38, 0x77, 0xc6, 0x1f, 0x40, 0xc7, 0xa3,
+	0x88, 0x1e, 0x97, 0x29, 0x77, 0xca, 0x2e, 0x77, 0x4a, 0x7d, 0x54, 0xdb, 0x53, 0x9b, 0xe8, 0x21,
+	0x3b, 0xe4, 0x6b, 0x7d, 0x96, 0xd8, 0xae, 0x13, 0x73, 0x2d, 0xaa, 0xe6, 0x8a, 0xbe, 0x02, 0xc7,
+	0x44, 0x8e, 0x73, 0xfa, 0x21, 0xac, 0xe8, 0x9c, 0x8a, 0x38, 0x67, 0x66, 0xb5, 0xa3, 0xb1, 0x1a,
+	0xa1, 0x57, 0x61, 0xeb, 0x0e, 0x8e, 0x8d, 0xda, 0x4b, 0xbf, 0x5c, 0xfe, 0x6e, 0x01, 0xec, 0x6c,
+	0xdf, 0xe7, 0x20, 0x30, 0xeb, 0x3a, 0x54, 0x31, 0xb1, 0x6a, 0x56, 0xd2, 0x9e, 0x3c, 0xab, 0x64,
+	0xed, 0xde, 0xe5, 0x1d, 0xd1, 0x31, 0x38, 0x2e, 0x75, 0xa7, 0x65, 0x58, 0xd7, 0x3c, 0xbe, 0x98,
+	0xf2, 0x78, 0xc5, 0xf8, 0x4b, 0x9a, 0xf1, 0x13, 0x1b, 0x31, 0xce, 0xf1, 0x3c, 0x6c, 0xe4, 0x36,
+	0x38, 0x37, 0xbd, 0x71, 0x1f, 0x0f, 0x97, 0xe2, 0x3f, 0x3f, 0x7a, 0x13, 0x3b, 0x36, 0xd1, 0x79,
+	0x1e, 0x3c, 0xfe, 0x47, 0x01, 0xe0, 0x68, 0x3a, 0xf0, 0x79, 0xc8, 0x31, 0x64, 0x07, 0x58, 0x2c,
+	0x29, 0xaa, 0xb1, 0x24, 0x89, 0x3c, 0x25, 0x2d, 0xf2, 0x6c, 0x42, 0x35, 0xf6, 0xc2, 0x53, 0x2c,
+	0x9f, 0x73, 0x58, 0x8b, 0xc0, 0x8f, 0xf1, 0x49, 0x10, 0xca, 0x8f, 0x8e, 0x58, 0x8b, 0x52, 0x3f,
+	0x89, 0xe5, 0x6b, 0x0e, 0x6b, 0x28, 0xdb, 0x6a, 0x4d, 0x3b, 0xdd, 0x2b, 0xc5, 0xf2, 0x75, 0xbd,
+	0x58, 0x5e, 0x8f, 0x60, 0x8d, 0x74, 0x04, 0xfb, 0x97, 0x02, 0xab, 0x92, 0x4a, 0xd6, 0xa9, 0x9e,
+	0x35, 0xd8, 0xfa, 0x0a, 0xe6, 0xf5, 0x15, 0x73, 0xd6, 0x57, 0x4a, 0xaf, 0xcf, 0x78, 0x27, 0xbb,
+	0x48, 0x0e, 0x58, 0xa4, 0x5c, 0x6b, 0xa6, 0x94, 0x6b, 0x5d, 0x49, 0xb9, 0x92, 0xd7, 0xa8, 0xad,
+	0xcc, 0x72, 0xb8, 0x2d, 0xbc, 0x03, 0x2d, 0x8f, 0x80, 0x7b, 0xdc, 0xd7, 0x58, 0x98, 0x58, 0x13,
+	0x96, 0x20, 0x47, 0xb8, 0x4d, 0x2f, 0x19, 0xad, 0x3f, 0x5f, 0xca, 0x24, 0xae, 0xe0, 0xa8, 0x64,
+	0xe2, 0xa8, 0xac, 0x72, 0xb4, 0x02, 0xed, 0x1f, 0x79, 0xfd, 0x73, 0x79, 0x10, 0x44, 0x57, 0xa0,
+	0xc9, 0x00, 0x37, 0xcf, 0xa6, 0xe3, 0x73, 0x42, 0x69, 0xe0, 0xc5, 0x1e, 0x15, 0x72, 0xcb, 0xa5,
+	0xbf, 0xc9, 0x98, 0x5b, 0xdf, 0x4c, 0x82, 0x50, 0xf8, 0x03, 0x7a, 0x0f, 0x9a, 0x0f, 0x83, 0x01,
+	0x1e, 0xb2, 0xb4, 0x36, 0x99, 0x89, 0xdc, 0xc2, 0x86, 0x42, 0x33, 0xb4, 0x21, 0x29, 0x15, 0x15,
+	0x4a, 0xaf, 0x40, 0xe7, 0xde, 0x88, 0x51, 0xe2, 0x52, 0xe8, 0x42, 0x25, 0x89, 0xbe, 0x25, 0x97,
+	0x35, 0x0e, 0xff, 0xb2, 0x04, 0x4d, 0xf2, 0xb1, 0xcd, 0x63, 0x1c, 0x3e, 0xf5, 0xfb, 0xd8, 0xfa,
+	0x21, 0x34, 0xe4, 0x57, 0x7c, 0x96, 0xf8, 0xe8, 0x24, 0xfd, 0xad, 0x9f, 0x63, 0x67, 0x11, 0xfc,
+	0xdc, 0xf4, 0x1d, 0xeb, 0x26, 0x40, 0xf2, 0xc1, 0x9b, 0x25, 0x7a, 0x66, 0xbe, 0xf0, 0x73, 0xb6,
+	0x0d, 0x18, 0x49, 0xe4, 0x87, 0xd0, 0x90, 0x1f, 0xb4, 0x49, 0x36, 0xd2, 0x1f, 0xc4, 0x39, 0x76,
+	0x16, 0xa1, 0xb2, 0x91, 0x7c, 0x5b, 0x25, 0xd9, 0xc8, 0x7c, 0xee, 0xe6, 0x6c, 0x1b, 0x30, 0x92,
+	0xc8, 0xe7, 0xb0, 0x9a, 0xfe, 0x30, 0xca, 0xba, 0x24, 0xed, 0xc6, 0xf8, 0x09, 0x96, 0x73, 0x39,
+	0x17, 0x2f, 0xc9, 0x7e, 0x00, 0x35, 0xfe, 0x19, 0x94, 0xb5, 0x21, 0x0e, 0xbb, 0xda, 0x27, 0x54,
+	0xce, 0x66, 0x1a, 0x2c, 0xc6, 0x1e, 0xfe, 0x7e, 0x09, 0x9a, 0xa4, 0xc2, 0x3f, 0xa5, 0x30, 0x02,
+	0xd2, 0x15, 0xa6, 0x7e, 0xce, 0xe3, 0xd8, 0x59, 0x84, 0xca, 0x0d, 0xff, 0x8e, 0x45, 0x72, 0xa3,
+	0x7f, 0x38, 0xe3, 0x6c, 0xa6, 0xc1, 0xaa, 0x94, 0x93, 0xcf, 0x53, 0xa4, 0x94, 0x33, 0x9f, 0xb8,
+	0x38, 0xdb, 0x06, 0x4c, 0x4a, 0x1c, 0x1a, 0x03, 0x77, 0xb0, 0x91, 0x81, 0xd4, 0x67, 0x2c, 0x8a,
+	0xa1, 0xd0, 0xd1, 0x9a, 0xa1, 0xa8, 0xe3, 0xed, 0x2c, 0x22, 0x6b, 0x28, 0xda, 0x12, 0x32, 0x5f,
+	0x74, 0x38, 0xdb, 0x06, 0x8c, 0xd4, 0xca, 0x3f, 0x15, 0x01, 0xee, 0xe3, 0x99, 0x50, 0xca, 0x87,
+	0x50, 0x17, 0x9f, 0x27, 0x58, 0x9b, 0x8a, 0xe8, 0x95, 0xc2, 0x6f, 0x67, 0x2b, 0x03, 0x57, 0x17,
+	0x25, 0xbf, 0x16, 0x90, 0x8b, 0x4a, 0x7f, 0xbb, 0xe0, 0xd8, 0x59, 0x84, 0x4a, 0x41, 0x7e, 0x06,
+	0x20, 0x29, 0xa4, 0x3f, 0x27, 0x70, 0xec, 0x2c, 0x42, 0x52, 0x78, 0x0f, 0xaa, 0xec, 0x03, 0x00,
+	0xab, 0x9b, 0x08, 0x5f, 0x19, 0xbb, 0x91, 0x82, 0xca, 0x81, 0x1f, 0x42, 0x5d, 0x14, 0xf5, 0xcb,
+	0xb5, 0xa7, 0x3e, 0x0d, 0x70, 0xb6, 0x32, 0x70, 0x29, 0xc9, 0x9f, 0x17, 0x60, 0x55, 0x16, 0xb5,
+	0x0b, 0x79, 0x3e, 0x82, 0x8e, 0x5e, 0x8f, 0x6f, 0xed, 0x2a, 0xd2, 0xcb, 0x14, 0xd5, 0x3b, 0x7b,
+	0x39, 0x58, 0xc9, 0xe4, 0x4f, 0x61, 0xdd, 0x50, 0x42, 0x6f, 0x5d, 0xd1, 0x74, 0x6c, 0xaa, 0xd7,
+	0x77, 0xd0, 0xbc, 0x2e, 0x72, 0x15, 0x7f, 0x5f, 0x82, 0x16, 0x2d, 0x69, 0x55, 0x2c, 0x42, 0x54,
+	0x64, 0x5b, 0x8a, 0x3b, 0xa9, 0x15, 0xb9, 0xce, 0x56, 0x06, 0xae, 0x1a, 0x69, 0x52, 0x53, 0x6d,
+	0xa9, 0xde, 0xac, 0x95, 0xd3, 0x3a, 0xdb, 0x06, 0x8c, 0x24, 0x72, 0x1b, 0x9a, 0x4a, 0x75, 0xb0,
+	0xa5, 0xfb, 0xa4, 0xc6, 0x89, 0x63, 0x42, 0x69, 0x11, 0x5e, 0xd6, 0xfb, 0x26, 0x11, 0x3e, 0x5d,
+	0x61, 0xec, 0x6c, 0x1b, 0x30, 0x92, 0x08, 0x57, 0x69, 0x52, 0xa4, 0xad, 0xa9, 0x34, 0x53, 0xee,
+	0xed, 0xec, 0xe5, 0x60, 0x25, 0xc1, 0xbb, 0xd0, 0x54, 0x2a, 0x89, 0xe5, 0xea, 0xb2, 0x35, 0xc7,
+	0x8e, 0x63, 0x42, 0x09, 0x3a, 0x6f, 0x15, 0x0e, 0xff, 0xa4, 0x04, 0x1d, 0x5e, 0x6f, 0x24, 0xd4,
+	0xf7, 0x00, 0xda, 0x5a, 0x79, 0x9f, 0xb5, 0xa3, 0x39, 0x9f, 0x5e, 0x87, 0xe5, 0xec, 0x9a, 0x91,
+	0x92, 0xd5, 0x07, 0xd0, 0xd6, 0x2a, 0xf8, 0x24, 0x35, 0x53, 0xfd, 0x9f, 0xb3, 0x6b, 0x46, 0x4a,
+	0x6a, 0xf7, 0xa0, 0xa5, 0xd6, 0xab, 0x59, 0x8e, 0x22, 0xa9, 0x54, 0x81, 0x9f, 0xb3, 0x63, 0xc4,
+	0xa9, 0x9a, 0x4d, 0x0a, 0xc6, 0xa4, 0x66, 0x33, 0x85, 0x66, 0xce, 0xb6, 0x01, 0x23, 0x89, 0x9c,
+	0xc2, 0xa6, 0xb9, 0xca, 0xcb, 0xd2, 0x3f, 0x97, 0xcb, 0x29, 0x2a, 0x73, 0x5e, 0x5e, 0xd0, 0x4b,
+	0x3a, 0xd9, 0x7f, 0x16, 0xa1, 0x45, 0x8b, 0x49, 0x84, 0x96, 0x6e, 0x43, 0x53, 0x29, 0x0a, 0xb2,
+	0xf4, 0x13, 0x86, 0x5a, 0x9c, 0xe2, 0x38, 0x26, 0x94, 0x1a, 0xc2, 0x44, 0xa1, 0x8f, 0xa5, 0x6c,
+	0x3d, 0x1a, 0x85, 0xad, 0x0c, 0x5c, 0x95, 0x62, 0x52, 0xb4, 0x63, 0x69, 0x7b, 0x8f, 0x46, 0x62,
+	0xdb, 0x80, 0x49, 0x7b, 0x3c, 0x05, 0xeb, 0x1e, 0xaf, 0x95, 0xf4, 0x38, 0xdb, 0x06, 0x4c, 0xd6,
+	0xe3, 0x75, 0x81, 0x64, 0xab, 0x75, 0x1c, 0xc7, 0x84, 0x92, 0x92, 0xfe, 0xc7, 0x12, 0xb4, 0x45,
+	0xaa, 0x9f, 0x89, 0xfa, 0x08, 0x9a, 0x4a, 0xc1, 0x86, 0x65, 0x69, 0xf5, 0x00, 0xb4, 0x94, 0x27,
+	0x71, 0x33, 0x43, 0x61, 0xc7, 0x77, 0x0e, 0x0a, 0xd6, 0x47, 0x00, 0x49, 0x71, 0x87, 0x5c, 0x61,
+	0xa6, 0xde, 0xc3, 0x31, 0xd0, 0x26, 0x6e, 0x4a, 0xec, 0x5e, 0x2d, 0xd9, 0x90, 0x76, 0x6f, 0xa8,
+	0xfe, 0x70, 0x76, 0x8c, 0x38, 0xd5, 0x85, 0xd4, 0xba, 0x87, 0x84, 0x54, 0xb6, 0xf2, 0xc3, 0xd9,
+	0x31, 0xe2, 0x54, 0xdf, 0xd6, 0x0a, 0x15, 0xa4, 0x6f, 0x9b, 0xaa, 0x1e, 0x9c, 0x5d, 0x33, 0x52,
+	0x65, 0x4c, 0x2d, 0x3a, 0x90, 0x8c, 0x19, 0x0a, 0x18, 0x9c, 0x1d, 0x23, 0x4e, 0xea, 0xf0, 0x17,
+	0x65, 0x68, 0xb3, 0x8c, 0x9c, 0xe2, 0x2e, 0x4a, 0x42, 0xdb, 0x52, 0x2d, 0x49, 0x4f, 0x7d, 0x3b,
+	0x8e, 0x09, 0xa5, 0x1e, 0x36, 0x64, 0x4e, 0xd4, 0x52, 0x36, 0x31, 0x2d, 0xc3, 0xec, 0xd8, 0x59,
+	0x84, 0xba, 0x4c, 0x35, 0x61, 0x69, 0xe9, 0xd6, 0xa8, 0xd3, 0xd9, 0x31, 0xe2, 0x24, 0xa9, 0xff,
+	0xcf, 0x52, 0x15, 0x5a, 0xa2, 0xd9, 0xba, 0x9c, 0xe1, 0x5f, 0x7f, 0x5e, 0x77, 0xf6, 0xf3, 0x3b,
+	0x48, 0xca, 0x2e, 0x4d, 0x4c, 0xa8, 0x58, 0x6b, 0x2f, 0xbd, 0x26, 0xed, 0x45, 0xda, 0xb9, 0x94,
+	0x87, 0x96, 0x34, 0xbf, 0x12, 0x29, 0x03, 0x8d, 0xec, 0xbe, 0x61, 0x89, 0x3a, 0xe5, 0x2b, 0x73,
+	0x7a, 0xa8, 0xb7, 0x97, 0x74, 0xce, 0x56, 0xde, 0x5e, 0x72, 0x32, 0xc0, 0xce, 0xe5, 0x5c, 0xbc,
+	0x34, 0xa4, 0xbf, 0x16, 0x25, 0x65, 0x27, 0xc9, 0xb5, 0xf1, 0x11, 0x74, 0xf4, 0x54, 0x9f, 0xa5,
+	0xef, 0x81, 0xa9, 0x2c, 0xa7, 0xb3, 0x97, 0x83, 0x55, 0xdd, 0x48, 0xcb, 0xd1, 0x59, 0xea, 0xce,
+	0x95, 0x4e, 0x05, 0x3a, 0xbb, 0x66, 0xa4, 0x64, 0xf9, 0x97, 0xf4, 0x38, 0x16, 0x4c, 0x27, 0x82,
+	0x5f, 0x79, 0x9e, 0x22, 0x39, 0xa7, 0xd4, 0x79, 0x4a, 0xc9, 0x71, 0x39, 0xdb, 0x06, 0x8c, 0xba,
+	0x4d, 0x88, 0x94, 0x86, 0x7e, 0xa6, 0x4b, 0xb2, 0x3f, 0xce, 0x56, 0x06, 0x6e, 0x3a, 0x8e, 0x11,
+	0x0a, 0xe9, 0xe3, 0x98, 0x42, 0xc4, 0x31, 0xa1, 0xd2, 0x6a, 0x56, 0x93, 0x40, 0x9a, 0x9a, 0x0d,
+	0xe9, 0x24, 0xe7, 0x72, 0x2e, 0x5e, 0x3d, 0xa0, 0xe9, 0x09, 0x1b, 0xa9, 0x52, 0x63, 0xa2, 0xc8,
+	0xd9, 0xcb, 0xc1, 0xaa, 0x9e, 0x99, 0xc9, 0xb7, 0x48, 0xcf, 0xcc, 0xcb, 0xea, 0x38, 0xfb, 0xf9,
+	0x1d, 0xa4, 0x7a, 0xff, 0xb9, 0x0c, 0x5d, 0xed, 0x41, 0x4f, 0xa8, 0xf9, 0xa7, 0xa2, 0x4a, 0x58,
+	0xc3, 0xca, 0x63, 0x7e, 0xfe, 0x0b, 0xbe, 0x83, 0xe6, 0x75, 0x51, 0xdd, 0x37, 0xfb, 0x42, 0x6d,
+	0xa9, 0xc1, 0xc4, 0xf8, 0x16, 0xee, 0x5c, 0x99, 0xd3, 0x43, 0xd5, 0x6b, 0xfa, 0xd5, 0x59, 0xea,
+	0x35, 0xe7, 0xe9, 0xda, 0xb9, 0x9c, 0x8b, 0x97, 0x64, 0x7b, 0xd0, 0x3d, 0x9a, 0x4c, 0xc2, 0xe0,
+	0x69, 0x8e, 0x50, 0xf2, 0x5f, 0x97, 0x1d, 0x34, 0xaf, 0x8b, 0x7a, 0xb7, 0x72, 0x31, 0xf9, 0xef,
+	0xa3, 0x17, 0x47, 0xdf, 0xf0, 0xf2, 0x9b, 0x28, 0x35, 0xf7, 0x75, 0xd9, 0x41, 0xf3, 0xba, 0x48,
+	0x6b, 0x3a, 0x86, 0x16, 0x7d, 0x13, 0x14, 0x46, 0xe4, 0xb2, 0x3f, 0x26, 0x50, 0x5e, 0x16, 0x2d,
+	0xf5, 0x32, 0x92, 0x7d, 0x40, 0x75, 0x2e, 0xe5, 0xa1, 0xe5, 0x1c, 0x7f, 0x57, 0x10, 0xaf, 0x83,
+	0x62, 0x96, 0x1b, 0x50, 0x65, 0x00, 0x79, 0xdf, 0xd6, 0x5e, 0x0f, 0x1d, 0x4b, 0x83, 0xd2, 0x27,
+	0x44, 0x7a, 0x0a, 0xba, 0x01, 0x55, 0xf6, 0x64, 0x28, 0xc7, 0x69, 0x2f, 0x88, 0x72, 0x9c, 0xf2,
+	0x8c, 0x48, 0xc7, 0xbd, 0x07, 0x55, 0xf6, 0x40, 0x68, 0x19, 0x7a, 0xc8, 0xdb, 0xbd, 0xfe, 0x86,
+	0x48, 0x8e, 0x6d, 0xc7, 0x55, 0xfa, 0x7f, 0x6a, 0x6f, 0xff, 0xdf, 0x00, 0xf2, 0x14, 0x53, 0x21,
+	0x5f, 0x4d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UserServiceClient interface {
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
+	TouchUser(ctx context.Context, in *TouchUserRequest, opts ...grpc.CallOption) (*TouchUserResponse, error)
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
+	AuthenticateUser(ctx context.Context, in *AuthenticateUserRequest, opts ...grpc.CallOption) (*AuthenticateUserResponse, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+}
+
+type userServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUserServiceClient(cc *grpc.ClientConn) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, "/types.UserService/ListUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
+	out := new(CreateUserResponse)
+	err := c.cc.Invoke(ctx, "/types.UserService/CreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (c *userServiceClient) TouchUser(ctx context.Context, in *TouchUserRequest, opts ...grpc.CallOption) (*TouchUserResponse, error) {
@@ -4043,1323 +8151,2586 @@ func (c *userServiceClient) TouchUser(ctx context.Context, in *TouchUserRequest,
 	return out, nil
 }
 
-func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error) {
-	out := new(UpdateUserResponse)
-	err := c.cc.Invoke(ctx, "/types.UserService/UpdateUser", in, out, opts...)
+func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error) {
+	out := new(UpdateUserResponse)
+	err := c.cc.Invoke(ctx, "/types.UserService/UpdateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AuthenticateUser(ctx context.Context, in *AuthenticateUserRequest, opts ...grpc.CallOption) (*AuthenticateUserResponse, error) {
+	out := new(AuthenticateUserResponse)
+	err := c.cc.Invoke(ctx, "/types.UserService/AuthenticateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
+	out := new(GetUserResponse)
+	err := c.cc.Invoke(ctx, "/types.UserService/GetUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+type UserServiceServer interface {
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	TouchUser(context.Context, *TouchUserRequest) (*TouchUserResponse, error)
+	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
+	AuthenticateUser(context.Context, *AuthenticateUserRequest) (*AuthenticateUserResponse, error)
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+}
+
+func RegisterUserServiceServer(s *grpc.Server, srv UserServiceServer) {
+	s.RegisterService(&_UserService_serviceDesc, srv)
+}
+
+func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.UserService/ListUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.UserService/CreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_TouchUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).TouchUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.UserService/TouchUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).TouchUser(ctx, req.(*TouchUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.UserService/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AuthenticateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AuthenticateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.UserService/AuthenticateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AuthenticateUser(ctx, req.(*AuthenticateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.UserService/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _UserService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUsers",
+			Handler:    _UserService_ListUsers_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _UserService_CreateUser_Handler,
+		},
+		{
+			MethodName: "TouchUser",
+			Handler:    _UserService_TouchUser_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _UserService_UpdateUser_Handler,
+		},
+		{
+			MethodName: "AuthenticateUser",
+			Handler:    _UserService_AuthenticateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "daemon.proto",
+}
+
+// NodeServiceClient is the client API for NodeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NodeServiceClient interface {
+	ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error)
+	PutNode(ctx context.Context, in *PutNodeRequest, opts ...grpc.CallOption) (*PutNodeResponse, error)
+	DeleteNode(ctx context.Context, in *DeleteNodeRequest, opts ...grpc.CallOption) (*DeleteNodeResponse, error)
+	GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error)
+	TouchNode(ctx context.Context, in *TouchNodeRequest, opts ...grpc.CallOption) (*TouchNodeResponse, error)
+	UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error)
+}
+
+type nodeServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNodeServiceClient(cc *grpc.ClientConn) NodeServiceClient {
+	return &nodeServiceClient{cc}
+}
+
+func (c *nodeServiceClient) ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error) {
+	out := new(ListNodesResponse)
+	err := c.cc.Invoke(ctx, "/types.NodeService/ListNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) PutNode(ctx context.Context, in *PutNodeRequest, opts ...grpc.CallOption) (*PutNodeResponse, error) {
+	out := new(PutNodeResponse)
+	err := c.cc.Invoke(ctx, "/types.NodeService/PutNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) DeleteNode(ctx context.Context, in *DeleteNodeRequest, opts ...grpc.CallOption) (*DeleteNodeResponse, error) {
+	out := new(DeleteNodeResponse)
+	err := c.cc.Invoke(ctx, "/types.NodeService/DeleteNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error) {
+	out := new(GetNodeResponse)
+	err := c.cc.Invoke(ctx, "/types.NodeService/GetNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) TouchNode(ctx context.Context, in *TouchNodeRequest, opts ...grpc.CallOption) (*TouchNodeResponse, error) {
+	out := new(TouchNodeResponse)
+	err := c.cc.Invoke(ctx, "/types.NodeService/TouchNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error) {
+	out := new(UpdateNodeResponse)
+	err := c.cc.Invoke(ctx, "/types.NodeService/UpdateNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NodeServiceServer is the server API for NodeService service.
+type NodeServiceServer interface {
+	ListNodes(context.Context, *ListNodesRequest) (*ListNodesResponse, error)
+	PutNode(context.Context, *PutNodeRequest) (*PutNodeResponse, error)
+	DeleteNode(context.Context, *DeleteNodeRequest) (*DeleteNodeResponse, error)
+	GetNode(context.Context, *GetNodeRequest) (*GetNodeResponse, error)
+	TouchNode(context.Context, *TouchNodeRequest) (*TouchNodeResponse, error)
+	UpdateNode(context.Context, *UpdateNodeRequest) (*UpdateNodeResponse, error)
+}
+
+func RegisterNodeServiceServer(s *grpc.Server, srv NodeServiceServer) {
+	s.RegisterService(&_NodeService_serviceDesc, srv)
+}
+
+func _NodeService_ListNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ListNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.NodeService/ListNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ListNodes(ctx, req.(*ListNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_PutNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).PutNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.NodeService/PutNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).PutNode(ctx, req.(*PutNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_DeleteNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).DeleteNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.NodeService/DeleteNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).DeleteNode(ctx, req.(*DeleteNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.NodeService/GetNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetNode(ctx, req.(*GetNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_TouchNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).TouchNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.NodeService/TouchNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).TouchNode(ctx, req.(*TouchNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_UpdateNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).UpdateNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.NodeService/UpdateNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).UpdateNode(ctx, req.(*UpdateNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _NodeService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.NodeService",
+	HandlerType: (*NodeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListNodes",
+			Handler:    _NodeService_ListNodes_Handler,
+		},
+		{
+			MethodName: "PutNode",
+			Handler:    _NodeService_PutNode_Handler,
+		},
+		{
+			MethodName: "DeleteNode",
+			Handler:    _NodeService_DeleteNode_Handler,
+		},
+		{
+			MethodName: "GetNode",
+			Handler:    _NodeService_GetNode_Handler,
+		},
+		{
+			MethodName: "TouchNode",
+			Handler:    _NodeService_TouchNode_Handler,
+		},
+		{
+			MethodName: "UpdateNode",
+			Handler:    _NodeService_UpdateNode_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "daemon.proto",
+}
+
+// KeyServiceClient is the client API for KeyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type KeyServiceClient interface {
+	ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error)
+	CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*CreateKeyResponse, error)
+	DeleteKey(ctx context.Context, in *DeleteKeyRequest, opts ...grpc.CallOption) (*DeleteKeyResponse, error)
+	GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error)
+	TouchKey(ctx context.Context, in *TouchKeyRequest, opts ...grpc.CallOption) (*TouchKeyResponse, error)
+}
+
+type keyServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewKeyServiceClient(cc *grpc.ClientConn) KeyServiceClient {
+	return &keyServiceClient{cc}
+}
+
+func (c *keyServiceClient) ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error) {
+	out := new(ListKeysResponse)
+	err := c.cc.Invoke(ctx, "/types.KeyService/ListKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*CreateKeyResponse, error) {
+	out := new(CreateKeyResponse)
+	err := c.cc.Invoke(ctx, "/types.KeyService/CreateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) DeleteKey(ctx context.Context, in *DeleteKeyRequest, opts ...grpc.CallOption) (*DeleteKeyResponse, error) {
+	out := new(DeleteKeyResponse)
+	err := c.cc.Invoke(ctx, "/types.KeyService/DeleteKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error) {
+	out := new(GetKeyResponse)
+	err := c.cc.Invoke(ctx, "/types.KeyService/GetKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) TouchKey(ctx context.Context, in *TouchKeyRequest, opts ...grpc.CallOption) (*TouchKeyResponse, error) {
+	out := new(TouchKeyResponse)
+	err := c.cc.Invoke(ctx, "/types.KeyService/TouchKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KeyServiceServer is the server API for KeyService service.
+type KeyServiceServer interface {
+	ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error)
+	CreateKey(context.Context, *CreateKeyRequest) (*CreateKeyResponse, error)
+	DeleteKey(context.Context, *DeleteKeyRequest) (*DeleteKeyResponse, error)
+	GetKey(context.Context, *GetKeyRequest) (*GetKeyResponse, error)
+	TouchKey(context.Context, *TouchKeyRequest) (*TouchKeyResponse, error)
+}
+
+func RegisterKeyServiceServer(s *grpc.Server, srv KeyServiceServer) {
+	s.RegisterService(&_KeyService_serviceDesc, srv)
+}
+
+func _KeyService_ListKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).ListKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.KeyService/ListKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).ListKeys(ctx, req.(*ListKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_CreateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).CreateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.KeyService/CreateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).CreateKey(ctx, req.(*CreateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_DeleteKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).DeleteKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.KeyService/DeleteKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).DeleteKey(ctx, req.(*DeleteKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.KeyService/GetKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetKey(ctx, req.(*GetKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_TouchKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).TouchKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.KeyService/TouchKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).TouchKey(ctx, req.(*TouchKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _KeyService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.KeyService",
+	HandlerType: (*KeyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListKeys",
+			Handler:    _KeyService_ListKeys_Handler,
+		},
+		{
+			MethodName: "CreateKey",
+			Handler:    _KeyService_CreateKey_Handler,
+		},
+		{
+			MethodName: "DeleteKey",
+			Handler:    _KeyService_DeleteKey_Handler,
+		},
+		{
+			MethodName: "GetKey",
+			Handler:    _KeyService_GetKey_Handler,
+		},
+		{
+			MethodName: "TouchKey",
+			Handler:    _KeyService_TouchKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "daemon.proto",
+}
+
+// MasterKeyServiceClient is the client API for MasterKeyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MasterKeyServiceClient interface {
+	ListMasterKeys(ctx context.Context, in *ListMasterKeysRequest, opts ...grpc.CallOption) (*ListMasterKeysResponse, error)
+	UpdateAllMasterKeys(ctx context.Context, in *UpdateAllMasterKeysRequest, opts ...grpc.CallOption) (*UpdateAllMasterKeysResponse, error)
+}
+
+type masterKeyServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMasterKeyServiceClient(cc *grpc.ClientConn) MasterKeyServiceClient {
+	return &masterKeyServiceClient{cc}
+}
+
+func (c *masterKeyServiceClient) ListMasterKeys(ctx context.Context, in *ListMasterKeysRequest, opts ...grpc.CallOption) (*ListMasterKeysResponse, error) {
+	out := new(ListMasterKeysResponse)
+	err := c.cc.Invoke(ctx, "/types.MasterKeyService/ListMasterKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterKeyServiceClient) UpdateAllMasterKeys(ctx context.Context, in *UpdateAllMasterKeysRequest, opts ...grpc.CallOption) (*UpdateAllMasterKeysResponse, error) {
+	out := new(UpdateAllMasterKeysResponse)
+	err := c.cc.Invoke(ctx, "/types.MasterKeyService/UpdateAllMasterKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MasterKeyServiceServer is the server API for MasterKeyService service.
+type MasterKeyServiceServer interface {
+	ListMasterKeys(context.Context, *ListMasterKeysRequest) (*ListMasterKeysResponse, error)
+	UpdateAllMasterKeys(context.Context, *UpdateAllMasterKeysRequest) (*UpdateAllMasterKeysResponse, error)
+}
+
+func RegisterMasterKeyServiceServer(s *grpc.Server, srv MasterKeyServiceServer) {
+	s.RegisterService(&_MasterKeyService_serviceDesc, srv)
+}
+
+func _MasterKeyService_ListMasterKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMasterKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterKeyServiceServer).ListMasterKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.MasterKeyService/ListMasterKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterKeyServiceServer).ListMasterKeys(ctx, req.(*ListMasterKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterKeyService_UpdateAllMasterKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAllMasterKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterKeyServiceServer).UpdateAllMasterKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.MasterKeyService/UpdateAllMasterKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterKeyServiceServer).UpdateAllMasterKeys(ctx, req.(*UpdateAllMasterKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MasterKeyService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.MasterKeyService",
+	HandlerType: (*MasterKeyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListMasterKeys",
+			Handler:    _MasterKeyService_ListMasterKeys_Handler,
+		},
+		{
+			MethodName: "UpdateAllMasterKeys",
+			Handler:    _MasterKeyService_UpdateAllMasterKeys_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "daemon.proto",
+}
+
+// GrantServiceClient is the client API for GrantService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GrantServiceClient interface {
+	PutGrant(ctx context.Context, in *PutGrantRequest, opts ...grpc.CallOption) (*PutGrantResponse, error)
+	ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error)
+	DeleteGrant(ctx context.Context, in *DeleteGrantRequest, opts ...grpc.CallOption) (*DeleteGrantResponse, error)
+	CheckGrant(ctx context.Context, in *CheckGrantRequest, opts ...grpc.CallOption) (*CheckGrantResponse, error)
+	ListGrantItems(ctx context.Context, in *ListGrantItemsRequest, opts ...grpc.CallOption) (*ListGrantItemsResponse, error)
+	WatchGrants(ctx context.Context, in *WatchGrantsRequest, opts ...grpc.CallOption) (GrantService_WatchGrantsClient, error)
+}
+
+type grantServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGrantServiceClient(cc *grpc.ClientConn) GrantServiceClient {
+	return &grantServiceClient{cc}
+}
+
+func (c *grantServiceClient) PutGrant(ctx context.Context, in *PutGrantRequest, opts ...grpc.CallOption) (*PutGrantResponse, error) {
+	out := new(PutGrantResponse)
+	err := c.cc.Invoke(ctx, "/types.GrantService/PutGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *grantServiceClient) ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error) {
+	out := new(ListGrantsResponse)
+	err := c.cc.Invoke(ctx, "/types.GrantService/ListGrants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *grantServiceClient) DeleteGrant(ctx context.Context, in *DeleteGrantRequest, opts ...grpc.CallOption) (*DeleteGrantResponse, error) {
+	out := new(DeleteGrantResponse)
+	err := c.cc.Invoke(ctx, "/types.GrantService/DeleteGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *grantServiceClient) CheckGrant(ctx context.Context, in *CheckGrantRequest, opts ...grpc.CallOption) (*CheckGrantResponse, error) {
+	out := new(CheckGrantResponse)
+	err := c.cc.Invoke(ctx, "/types.GrantService/CheckGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *grantServiceClient) ListGrantItems(ctx context.Context, in *ListGrantItemsRequest, opts ...grpc.CallOption) (*ListGrantItemsResponse, error) {
+	out := new(ListGrantItemsResponse)
+	err := c.cc.Invoke(ctx, "/types.GrantService/ListGrantItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *grantServiceClient) WatchGrants(ctx context.Context, in *WatchGrantsRequest, opts ...grpc.CallOption) (GrantService_WatchGrantsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_GrantService_serviceDesc.Streams[0], "/types.GrantService/WatchGrants", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grantServiceWatchGrantsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GrantService_WatchGrantsClient interface {
+	Recv() (*WatchGrantsResponse, error)
+	grpc.ClientStream
+}
+
+type grantServiceWatchGrantsClient struct {
+	grpc.ClientStream
+}
+
+func (x *grantServiceWatchGrantsClient) Recv() (*WatchGrantsResponse, error) {
+	m := new(WatchGrantsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GrantServiceServer is the server API for GrantService service.
+type GrantServiceServer interface {
+	PutGrant(context.Context, *PutGrantRequest) (*PutGrantResponse, error)
+	ListGrants(context.Context, *ListGrantsRequest) (*ListGrantsResponse, error)
+	DeleteGrant(context.Context, *DeleteGrantRequest) (*DeleteGrantResponse, error)
+	CheckGrant(context.Context, *CheckGrantRequest) (*CheckGrantResponse, error)
+	ListGrantItems(context.Context, *ListGrantItemsRequest) (*ListGrantItemsResponse, error)
+	WatchGrants(*WatchGrantsRequest, GrantService_WatchGrantsServer) error
+}
+
+func RegisterGrantServiceServer(s *grpc.Server, srv GrantServiceServer) {
+	s.RegisterService(&_GrantService_serviceDesc, srv)
+}
+
+func _GrantService_PutGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutGrantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GrantServiceServer).PutGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.GrantService/PutGrant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GrantServiceServer).PutGrant(ctx, req.(*PutGrantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GrantService_ListGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GrantServiceServer).ListGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.GrantService/ListGrants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GrantServiceServer).ListGrants(ctx, req.(*ListGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GrantService_DeleteGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGrantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GrantServiceServer).DeleteGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.GrantService/DeleteGrant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GrantServiceServer).DeleteGrant(ctx, req.(*DeleteGrantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GrantService_CheckGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckGrantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GrantServiceServer).CheckGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.GrantService/CheckGrant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GrantServiceServer).CheckGrant(ctx, req.(*CheckGrantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GrantService_ListGrantItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGrantItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GrantServiceServer).ListGrantItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.GrantService/ListGrantItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GrantServiceServer).ListGrantItems(ctx, req.(*ListGrantItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GrantService_WatchGrants_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchGrantsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GrantServiceServer).WatchGrants(m, &grantServiceWatchGrantsServer{stream})
+}
+
+type GrantService_WatchGrantsServer interface {
+	Send(*WatchGrantsResponse) error
+	grpc.ServerStream
+}
+
+type grantServiceWatchGrantsServer struct {
+	grpc.ServerStream
+}
+
+func (x *grantServiceWatchGrantsServer) Send(m *WatchGrantsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _GrantService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.GrantService",
+	HandlerType: (*GrantServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PutGrant",
+			Handler:    _GrantService_PutGrant_Handler,
+		},
+		{
+			MethodName: "ListGrants",
+			Handler:    _GrantService_ListGrants_Handler,
+		},
+		{
+			MethodName: "DeleteGrant",
+			Handler:    _GrantService_DeleteGrant_Handler,
+		},
+		{
+			MethodName: "CheckGrant",
+			Handler:    _GrantService_CheckGrant_Handler,
+		},
+		{
+			MethodName: "ListGrantItems",
+			Handler:    _GrantService_ListGrantItems_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchGrants",
+			Handler:       _GrantService_WatchGrants_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "daemon.proto",
+}
+
+// SessionServiceClient is the client API for SessionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SessionServiceClient interface {
+	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*CreateSessionResponse, error)
+	FinishSession(ctx context.Context, in *FinishSessionRequest, opts ...grpc.CallOption) (*FinishSessionResponse, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error)
+	UpdateSessionLegalHold(ctx context.Context, in *UpdateSessionLegalHoldRequest, opts ...grpc.CallOption) (*UpdateSessionLegalHoldResponse, error)
+}
+
+type sessionServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSessionServiceClient(cc *grpc.ClientConn) SessionServiceClient {
+	return &sessionServiceClient{cc}
+}
+
+func (c *sessionServiceClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*CreateSessionResponse, error) {
+	out := new(CreateSessionResponse)
+	err := c.cc.Invoke(ctx, "/types.SessionService/CreateSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) FinishSession(ctx context.Context, in *FinishSessionRequest, opts ...grpc.CallOption) (*FinishSessionResponse, error) {
+	out := new(FinishSessionResponse)
+	err := c.cc.Invoke(ctx, "/types.SessionService/FinishSession", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) AuthenticateUser(ctx context.Context, in *AuthenticateUserRequest, opts ...grpc.CallOption) (*AuthenticateUserResponse, error) {
-	out := new(AuthenticateUserResponse)
-	err := c.cc.Invoke(ctx, "/types.UserService/AuthenticateUser", in, out, opts...)
+func (c *sessionServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, "/types.SessionService/ListSessions", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
-	out := new(GetUserResponse)
-	err := c.cc.Invoke(ctx, "/types.UserService/GetUser", in, out, opts...)
+func (c *sessionServiceClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error) {
+	out := new(GetSessionResponse)
+	err := c.cc.Invoke(ctx, "/types.SessionService/GetSession", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// UserServiceServer is the server API for UserService service.
-type UserServiceServer interface {
-	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
-	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
-	TouchUser(context.Context, *TouchUserRequest) (*TouchUserResponse, error)
-	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
-	AuthenticateUser(context.Context, *AuthenticateUserRequest) (*AuthenticateUserResponse, error)
-	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+func (c *sessionServiceClient) UpdateSessionLegalHold(ctx context.Context, in *UpdateSessionLegalHoldRequest, opts ...grpc.CallOption) (*UpdateSessionLegalHoldResponse, error) {
+	out := new(UpdateSessionLegalHoldResponse)
+	err := c.cc.Invoke(ctx, "/types.SessionService/UpdateSessionLegalHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterUserServiceServer(s *grpc.Server, srv UserServiceServer) {
-	s.RegisterService(&_UserService_serviceDesc, srv)
+// SessionServiceServer is the server API for SessionService service.
+type SessionServiceServer interface {
+	CreateSession(context.Context, *CreateSessionRequest) (*CreateSessionResponse, error)
+	FinishSession(context.Context, *FinishSessionRequest) (*FinishSessionResponse, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	GetSession(context.Context, *GetSessionRequest) (*GetSessionResponse, error)
+	UpdateSessionLegalHold(context.Context, *UpdateSessionLegalHoldRequest) (*UpdateSessionLegalHoldResponse, error)
 }
 
-func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListUsersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UserServiceServer).ListUsers(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.UserService/ListUsers",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func RegisterSessionServiceServer(s *grpc.Server, srv SessionServiceServer) {
+	s.RegisterService(&_SessionService_serviceDesc, srv)
 }
 
-func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateUserRequest)
+func _SessionService_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).CreateUser(ctx, in)
+		return srv.(SessionServiceServer).CreateSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.UserService/CreateUser",
+		FullMethod: "/types.SessionService/CreateSession",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+		return srv.(SessionServiceServer).CreateSession(ctx, req.(*CreateSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_TouchUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TouchUserRequest)
+func _SessionService_FinishSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).TouchUser(ctx, in)
+		return srv.(SessionServiceServer).FinishSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.UserService/TouchUser",
+		FullMethod: "/types.SessionService/FinishSession",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).TouchUser(ctx, req.(*TouchUserRequest))
+		return srv.(SessionServiceServer).FinishSession(ctx, req.(*FinishSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
+func _SessionService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UpdateUser(ctx, in)
+		return srv.(SessionServiceServer).ListSessions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.UserService/UpdateUser",
+		FullMethod: "/types.SessionService/ListSessions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+		return srv.(SessionServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_AuthenticateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthenticateUserRequest)
+func _SessionService_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).AuthenticateUser(ctx, in)
+		return srv.(SessionServiceServer).GetSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.UserService/AuthenticateUser",
+		FullMethod: "/types.SessionService/GetSession",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).AuthenticateUser(ctx, req.(*AuthenticateUserRequest))
+		return srv.(SessionServiceServer).GetSession(ctx, req.(*GetSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserRequest)
+func _SessionService_UpdateSessionLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSessionLegalHoldRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetUser(ctx, in)
+		return srv.(SessionServiceServer).UpdateSessionLegalHold(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.UserService/GetUser",
+		FullMethod: "/types.SessionService/UpdateSessionLegalHold",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+		return srv.(SessionServiceServer).UpdateSessionLegalHold(ctx, req.(*UpdateSessionLegalHoldRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _UserService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.UserService",
-	HandlerType: (*UserServiceServer)(nil),
+var _SessionService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.SessionService",
+	HandlerType: (*SessionServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ListUsers",
-			Handler:    _UserService_ListUsers_Handler,
-		},
-		{
-			MethodName: "CreateUser",
-			Handler:    _UserService_CreateUser_Handler,
+			MethodName: "CreateSession",
+			Handler:    _SessionService_CreateSession_Handler,
 		},
 		{
-			MethodName: "TouchUser",
-			Handler:    _UserService_TouchUser_Handler,
+			MethodName: "FinishSession",
+			Handler:    _SessionService_FinishSession_Handler,
 		},
 		{
-			MethodName: "UpdateUser",
-			Handler:    _UserService_UpdateUser_Handler,
+			MethodName: "ListSessions",
+			Handler:    _SessionService_ListSessions_Handler,
 		},
 		{
-			MethodName: "AuthenticateUser",
-			Handler:    _UserService_AuthenticateUser_Handler,
+			MethodName: "GetSession",
+			Handler:    _SessionService_GetSession_Handler,
 		},
 		{
-			MethodName: "GetUser",
-			Handler:    _UserService_GetUser_Handler,
+			MethodName: "UpdateSessionLegalHold",
+			Handler:    _SessionService_UpdateSessionLegalHold_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "daemon.proto",
 }
 
-// NodeServiceClient is the client API for NodeService service.
+// TokenServiceClient is the client API for TokenService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NodeServiceClient interface {
-	ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error)
-	PutNode(ctx context.Context, in *PutNodeRequest, opts ...grpc.CallOption) (*PutNodeResponse, error)
-	DeleteNode(ctx context.Context, in *DeleteNodeRequest, opts ...grpc.CallOption) (*DeleteNodeResponse, error)
-	GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error)
-	TouchNode(ctx context.Context, in *TouchNodeRequest, opts ...grpc.CallOption) (*TouchNodeResponse, error)
-	UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error)
+type TokenServiceClient interface {
+	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error)
+	TouchToken(ctx context.Context, in *TouchTokenRequest, opts ...grpc.CallOption) (*TouchTokenResponse, error)
+	ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error)
+	DeleteToken(ctx context.Context, in *DeleteTokenRequest, opts ...grpc.CallOption) (*DeleteTokenResponse, error)
 }
 
-type nodeServiceClient struct {
+type tokenServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewNodeServiceClient(cc *grpc.ClientConn) NodeServiceClient {
-	return &nodeServiceClient{cc}
-}
-
-func (c *nodeServiceClient) ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error) {
-	out := new(ListNodesResponse)
-	err := c.cc.Invoke(ctx, "/types.NodeService/ListNodes", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func NewTokenServiceClient(cc *grpc.ClientConn) TokenServiceClient {
+	return &tokenServiceClient{cc}
 }
 
-func (c *nodeServiceClient) PutNode(ctx context.Context, in *PutNodeRequest, opts ...grpc.CallOption) (*PutNodeResponse, error) {
-	out := new(PutNodeResponse)
-	err := c.cc.Invoke(ctx, "/types.NodeService/PutNode", in, out, opts...)
+func (c *tokenServiceClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, "/types.TokenService/CreateToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) DeleteNode(ctx context.Context, in *DeleteNodeRequest, opts ...grpc.CallOption) (*DeleteNodeResponse, error) {
-	out := new(DeleteNodeResponse)
-	err := c.cc.Invoke(ctx, "/types.NodeService/DeleteNode", in, out, opts...)
+func (c *tokenServiceClient) GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error) {
+	out := new(GetTokenResponse)
+	err := c.cc.Invoke(ctx, "/types.TokenService/GetToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error) {
-	out := new(GetNodeResponse)
-	err := c.cc.Invoke(ctx, "/types.NodeService/GetNode", in, out, opts...)
+func (c *tokenServiceClient) TouchToken(ctx context.Context, in *TouchTokenRequest, opts ...grpc.CallOption) (*TouchTokenResponse, error) {
+	out := new(TouchTokenResponse)
+	err := c.cc.Invoke(ctx, "/types.TokenService/TouchToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) TouchNode(ctx context.Context, in *TouchNodeRequest, opts ...grpc.CallOption) (*TouchNodeResponse, error) {
-	out := new(TouchNodeResponse)
-	err := c.cc.Invoke(ctx, "/types.NodeService/TouchNode", in, out, opts...)
+func (c *tokenServiceClient) ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error) {
+	out := new(ListTokensResponse)
+	err := c.cc.Invoke(ctx, "/types.TokenService/ListTokens", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error) {
-	out := new(UpdateNodeResponse)
-	err := c.cc.Invoke(ctx, "/types.NodeService/UpdateNode", in, out, opts...)
+func (c *tokenServiceClient) DeleteToken(ctx context.Context, in *DeleteTokenRequest, opts ...grpc.CallOption) (*DeleteTokenResponse, error) {
+	out := new(DeleteTokenResponse)
+	err := c.cc.Invoke(ctx, "/types.TokenService/DeleteToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// NodeServiceServer is the server API for NodeService service.
-type NodeServiceServer interface {
-	ListNodes(context.Context, *ListNodesRequest) (*ListNodesResponse, error)
-	PutNode(context.Context, *PutNodeRequest) (*PutNodeResponse, error)
-	DeleteNode(context.Context, *DeleteNodeRequest) (*DeleteNodeResponse, error)
-	GetNode(context.Context, *GetNodeRequest) (*GetNodeResponse, error)
-	TouchNode(context.Context, *TouchNodeRequest) (*TouchNodeResponse, error)
-	UpdateNode(context.Context, *UpdateNodeRequest) (*UpdateNodeResponse, error)
-}
-
-func RegisterNodeServiceServer(s *grpc.Server, srv NodeServiceServer) {
-	s.RegisterService(&_NodeService_serviceDesc, srv)
+// TokenServiceServer is the server API for TokenService service.
+type TokenServiceServer interface {
+	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
+	GetToken(context.Context, *GetTokenRequest) (*GetTokenResponse, error)
+	TouchToken(context.Context, *TouchTokenRequest) (*TouchTokenResponse, error)
+	ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
+	DeleteToken(context.Context, *DeleteTokenRequest) (*DeleteTokenResponse, error)
 }
 
-func _NodeService_ListNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListNodesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(NodeServiceServer).ListNodes(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.NodeService/ListNodes",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServiceServer).ListNodes(ctx, req.(*ListNodesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func RegisterTokenServiceServer(s *grpc.Server, srv TokenServiceServer) {
+	s.RegisterService(&_TokenService_serviceDesc, srv)
 }
 
-func _NodeService_PutNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PutNodeRequest)
+func _TokenService_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServiceServer).PutNode(ctx, in)
+		return srv.(TokenServiceServer).CreateToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.NodeService/PutNode",
+		FullMethod: "/types.TokenService/CreateToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServiceServer).PutNode(ctx, req.(*PutNodeRequest))
+		return srv.(TokenServiceServer).CreateToken(ctx, req.(*CreateTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NodeService_DeleteNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteNodeRequest)
+func _TokenService_GetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServiceServer).DeleteNode(ctx, in)
+		return srv.(TokenServiceServer).GetToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.NodeService/DeleteNode",
+		FullMethod: "/types.TokenService/GetToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServiceServer).DeleteNode(ctx, req.(*DeleteNodeRequest))
+		return srv.(TokenServiceServer).GetToken(ctx, req.(*GetTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NodeService_GetNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNodeRequest)
+func _TokenService_TouchToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServiceServer).GetNode(ctx, in)
+		return srv.(TokenServiceServer).TouchToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.NodeService/GetNode",
+		FullMethod: "/types.TokenService/TouchToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServiceServer).GetNode(ctx, req.(*GetNodeRequest))
+		return srv.(TokenServiceServer).TouchToken(ctx, req.(*TouchTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NodeService_TouchNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TouchNodeRequest)
+func _TokenService_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTokensRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServiceServer).TouchNode(ctx, in)
+		return srv.(TokenServiceServer).ListTokens(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.NodeService/TouchNode",
+		FullMethod: "/types.TokenService/ListTokens",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServiceServer).TouchNode(ctx, req.(*TouchNodeRequest))
+		return srv.(TokenServiceServer).ListTokens(ctx, req.(*ListTokensRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NodeService_UpdateNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateNodeRequest)
+func _TokenService_DeleteToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServiceServer).UpdateNode(ctx, in)
+		return srv.(TokenServiceServer).DeleteToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.NodeService/UpdateNode",
+		FullMethod: "/types.TokenService/DeleteToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServiceServer).UpdateNode(ctx, req.(*UpdateNodeRequest))
+		return srv.(TokenServiceServer).DeleteToken(ctx, req.(*DeleteTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _NodeService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.NodeService",
-	HandlerType: (*NodeServiceServer)(nil),
+var _TokenService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.TokenService",
+	HandlerType: (*TokenServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ListNodes",
-			Handler:    _NodeService_ListNodes_Handler,
-		},
-		{
-			MethodName: "PutNode",
-			Handler:    _NodeService_PutNode_Handler,
+			MethodName: "CreateToken",
+			Handler:    _TokenService_CreateToken_Handler,
 		},
 		{
-			MethodName: "DeleteNode",
-			Handler:    _NodeService_DeleteNode_Handler,
+			MethodName: "GetToken",
+			Handler:    _TokenService_GetToken_Handler,
 		},
 		{
-			MethodName: "GetNode",
-			Handler:    _NodeService_GetNode_Handler,
+			MethodName: "TouchToken",
+			Handler:    _TokenService_TouchToken_Handler,
 		},
 		{
-			MethodName: "TouchNode",
-			Handler:    _NodeService_TouchNode_Handler,
+			MethodName: "ListTokens",
+			Handler:    _TokenService_ListTokens_Handler,
 		},
 		{
-			MethodName: "UpdateNode",
-			Handler:    _NodeService_UpdateNode_Handler,
+			MethodName: "DeleteToken",
+			Handler:    _TokenService_DeleteToken_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "daemon.proto",
 }
 
-// KeyServiceClient is the client API for KeyService service.
+// ReplayServiceClient is the client API for ReplayService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type KeyServiceClient interface {
-	ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error)
-	CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*CreateKeyResponse, error)
-	DeleteKey(ctx context.Context, in *DeleteKeyRequest, opts ...grpc.CallOption) (*DeleteKeyResponse, error)
-	GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error)
-	TouchKey(ctx context.Context, in *TouchKeyRequest, opts ...grpc.CallOption) (*TouchKeyResponse, error)
+type ReplayServiceClient interface {
+	WriteReplay(ctx context.Context, opts ...grpc.CallOption) (ReplayService_WriteReplayClient, error)
+	ReadReplay(ctx context.Context, in *ReadReplayRequest, opts ...grpc.CallOption) (ReplayService_ReadReplayClient, error)
+	SubmitReplay(ctx context.Context, in *SubmitReplayRequest, opts ...grpc.CallOption) (*SubmitReplayResponse, error)
+	SearchReplay(ctx context.Context, in *SearchReplayRequest, opts ...grpc.CallOption) (*SearchReplayResponse, error)
+	GetTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (*GetTranscriptResponse, error)
+	VerifyReplay(ctx context.Context, in *VerifyReplayRequest, opts ...grpc.CallOption) (*VerifyReplayResponse, error)
 }
 
-type keyServiceClient struct {
+type replayServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewKeyServiceClient(cc *grpc.ClientConn) KeyServiceClient {
-	return &keyServiceClient{cc}
+func NewReplayServiceClient(cc *grpc.ClientConn) ReplayServiceClient {
+	return &replayServiceClient{cc}
 }
 
-func (c *keyServiceClient) ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error) {
-	out := new(ListKeysResponse)
-	err := c.cc.Invoke(ctx, "/types.KeyService/ListKeys", in, out, opts...)
+func (c *replayServiceClient) WriteReplay(ctx context.Context, opts ...grpc.CallOption) (ReplayService_WriteReplayClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ReplayService_serviceDesc.Streams[0], "/types.ReplayService/WriteReplay", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replayServiceWriteReplayClient{stream}
+	return x, nil
+}
+
+type ReplayService_WriteReplayClient interface {
+	Send(*ReplayFrame) error
+	CloseAndRecv() (*WriteReplayResponse, error)
+	grpc.ClientStream
+}
+
+type replayServiceWriteReplayClient struct {
+	grpc.ClientStream
+}
+
+func (x *replayServiceWriteReplayClient) Send(m *ReplayFrame) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *replayServiceWriteReplayClient) CloseAndRecv() (*WriteReplayResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteReplayResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *replayServiceClient) ReadReplay(ctx context.Context, in *ReadReplayRequest, opts ...grpc.CallOption) (ReplayService_ReadReplayClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ReplayService_serviceDesc.Streams[1], "/types.ReplayService/ReadReplay", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replayServiceReadReplayClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReplayService_ReadReplayClient interface {
+	Recv() (*ReplayFrame, error)
+	grpc.ClientStream
+}
+
+type replayServiceReadReplayClient struct {
+	grpc.ClientStream
+}
+
+func (x *replayServiceReadReplayClient) Recv() (*ReplayFrame, error) {
+	m := new(ReplayFrame)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *replayServiceClient) SubmitReplay(ctx context.Context, in *SubmitReplayRequest, opts ...grpc.CallOption) (*SubmitReplayResponse, error) {
+	out := new(SubmitReplayResponse)
+	err := c.cc.Invoke(ctx, "/types.ReplayService/SubmitReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replayServiceClient) SearchReplay(ctx context.Context, in *SearchReplayRequest, opts ...grpc.CallOption) (*SearchReplayResponse, error) {
+	out := new(SearchReplayResponse)
+	err := c.cc.Invoke(ctx, "/types.ReplayService/SearchReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replayServiceClient) GetTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (*GetTranscriptResponse, error) {
+	out := new(GetTranscriptResponse)
+	err := c.cc.Invoke(ctx, "/types.ReplayService/GetTranscript", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replayServiceClient) VerifyReplay(ctx context.Context, in *VerifyReplayRequest, opts ...grpc.CallOption) (*VerifyReplayResponse, error) {
+	out := new(VerifyReplayResponse)
+	err := c.cc.Invoke(ctx, "/types.ReplayService/VerifyReplay", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *keyServiceClient) CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*CreateKeyResponse, error) {
-	out := new(CreateKeyResponse)
-	err := c.cc.Invoke(ctx, "/types.KeyService/CreateKey", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// ReplayServiceServer is the server API for ReplayService service.
+type ReplayServiceServer interface {
+	WriteReplay(ReplayService_WriteReplayServer) error
+	ReadReplay(*ReadReplayRequest, ReplayService_ReadReplayServer) error
+	SubmitReplay(context.Context, *SubmitReplayRequest) (*SubmitReplayResponse, error)
+	SearchReplay(context.Context, *SearchReplayRequest) (*SearchReplayResponse, error)
+	GetTranscript(context.Context, *GetTranscriptRequest) (*GetTranscriptResponse, error)
+	VerifyReplay(context.Context, *VerifyReplayRequest) (*VerifyReplayResponse, error)
+}
+
+func RegisterReplayServiceServer(s *grpc.Server, srv ReplayServiceServer) {
+	s.RegisterService(&_ReplayService_serviceDesc, srv)
+}
+
+func _ReplayService_WriteReplay_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReplayServiceServer).WriteReplay(&replayServiceWriteReplayServer{stream})
+}
+
+type ReplayService_WriteReplayServer interface {
+	SendAndClose(*WriteReplayResponse) error
+	Recv() (*ReplayFrame, error)
+	grpc.ServerStream
+}
+
+type replayServiceWriteReplayServer struct {
+	grpc.ServerStream
 }
 
-func (c *keyServiceClient) DeleteKey(ctx context.Context, in *DeleteKeyRequest, opts ...grpc.CallOption) (*DeleteKeyResponse, error) {
-	out := new(DeleteKeyResponse)
-	err := c.cc.Invoke(ctx, "/types.KeyService/DeleteKey", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (x *replayServiceWriteReplayServer) SendAndClose(m *WriteReplayResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *keyServiceClient) GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error) {
-	out := new(GetKeyResponse)
-	err := c.cc.Invoke(ctx, "/types.KeyService/GetKey", in, out, opts...)
-	if err != nil {
+func (x *replayServiceWriteReplayServer) Recv() (*ReplayFrame, error) {
+	m := new(ReplayFrame)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *keyServiceClient) TouchKey(ctx context.Context, in *TouchKeyRequest, opts ...grpc.CallOption) (*TouchKeyResponse, error) {
-	out := new(TouchKeyResponse)
-	err := c.cc.Invoke(ctx, "/types.KeyService/TouchKey", in, out, opts...)
-	if err != nil {
-		return nil, err
+func _ReplayService_ReadReplay_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadReplayRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(ReplayServiceServer).ReadReplay(m, &replayServiceReadReplayServer{stream})
 }
 
-// KeyServiceServer is the server API for KeyService service.
-type KeyServiceServer interface {
-	ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error)
-	CreateKey(context.Context, *CreateKeyRequest) (*CreateKeyResponse, error)
-	DeleteKey(context.Context, *DeleteKeyRequest) (*DeleteKeyResponse, error)
-	GetKey(context.Context, *GetKeyRequest) (*GetKeyResponse, error)
-	TouchKey(context.Context, *TouchKeyRequest) (*TouchKeyResponse, error)
+type ReplayService_ReadReplayServer interface {
+	Send(*ReplayFrame) error
+	grpc.ServerStream
 }
 
-func RegisterKeyServiceServer(s *grpc.Server, srv KeyServiceServer) {
-	s.RegisterService(&_KeyService_serviceDesc, srv)
+type replayServiceReadReplayServer struct {
+	grpc.ServerStream
 }
 
-func _KeyService_ListKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListKeysRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(KeyServiceServer).ListKeys(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.KeyService/ListKeys",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeyServiceServer).ListKeys(ctx, req.(*ListKeysRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (x *replayServiceReadReplayServer) Send(m *ReplayFrame) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _KeyService_CreateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateKeyRequest)
+func _ReplayService_SubmitReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitReplayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeyServiceServer).CreateKey(ctx, in)
+		return srv.(ReplayServiceServer).SubmitReplay(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.KeyService/CreateKey",
+		FullMethod: "/types.ReplayService/SubmitReplay",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeyServiceServer).CreateKey(ctx, req.(*CreateKeyRequest))
+		return srv.(ReplayServiceServer).SubmitReplay(ctx, req.(*SubmitReplayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeyService_DeleteKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteKeyRequest)
+func _ReplayService_SearchReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchReplayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeyServiceServer).DeleteKey(ctx, in)
+		return srv.(ReplayServiceServer).SearchReplay(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.KeyService/DeleteKey",
+		FullMethod: "/types.ReplayService/SearchReplay",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeyServiceServer).DeleteKey(ctx, req.(*DeleteKeyRequest))
+		return srv.(ReplayServiceServer).SearchReplay(ctx, req.(*SearchReplayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeyService_GetKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetKeyRequest)
+func _ReplayService_GetTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTranscriptRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeyServiceServer).GetKey(ctx, in)
+		return srv.(ReplayServiceServer).GetTranscript(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.KeyService/GetKey",
+		FullMethod: "/types.ReplayService/GetTranscript",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeyServiceServer).GetKey(ctx, req.(*GetKeyRequest))
+		return srv.(ReplayServiceServer).GetTranscript(ctx, req.(*GetTranscriptRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeyService_TouchKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TouchKeyRequest)
+func _ReplayService_VerifyReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyReplayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeyServiceServer).TouchKey(ctx, in)
+		return srv.(ReplayServiceServer).VerifyReplay(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.KeyService/TouchKey",
+		FullMethod: "/types.ReplayService/VerifyReplay",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeyServiceServer).TouchKey(ctx, req.(*TouchKeyRequest))
+		return srv.(ReplayServiceServer).VerifyReplay(ctx, req.(*VerifyReplayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _KeyService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.KeyService",
-	HandlerType: (*KeyServiceServer)(nil),
+var _ReplayService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.ReplayService",
+	HandlerType: (*ReplayServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ListKeys",
-			Handler:    _KeyService_ListKeys_Handler,
+			MethodName: "SubmitReplay",
+			Handler:    _ReplayService_SubmitReplay_Handler,
 		},
 		{
-			MethodName: "CreateKey",
-			Handler:    _KeyService_CreateKey_Handler,
+			MethodName: "SearchReplay",
+			Handler:    _ReplayService_SearchReplay_Handler,
 		},
 		{
-			MethodName: "DeleteKey",
-			Handler:    _KeyService_DeleteKey_Handler,
+			MethodName: "GetTranscript",
+			Handler:    _ReplayService_GetTranscript_Handler,
 		},
 		{
-			MethodName: "GetKey",
-			Handler:    _KeyService_GetKey_Handler,
+			MethodName: "VerifyReplay",
+			Handler:    _ReplayService_VerifyReplay_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WriteReplay",
+			Handler:       _ReplayService_WriteReplay_Handler,
+			ClientStreams: true,
 		},
 		{
-			MethodName: "TouchKey",
-			Handler:    _KeyService_TouchKey_Handler,
+			StreamName:    "ReadReplay",
+			Handler:       _ReplayService_ReadReplay_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "daemon.proto",
 }
 
-// MasterKeyServiceClient is the client API for MasterKeyService service.
+// VolumeServiceClient is the client API for VolumeService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type MasterKeyServiceClient interface {
-	ListMasterKeys(ctx context.Context, in *ListMasterKeysRequest, opts ...grpc.CallOption) (*ListMasterKeysResponse, error)
-	UpdateAllMasterKeys(ctx context.Context, in *UpdateAllMasterKeysRequest, opts ...grpc.CallOption) (*UpdateAllMasterKeysResponse, error)
+type VolumeServiceClient interface {
+	ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error)
+	PutVolume(ctx context.Context, in *PutVolumeRequest, opts ...grpc.CallOption) (*PutVolumeResponse, error)
+	DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*DeleteVolumeResponse, error)
+	ListVolumeMembers(ctx context.Context, in *ListVolumeMembersRequest, opts ...grpc.CallOption) (*ListVolumeMembersResponse, error)
+	PutVolumeMember(ctx context.Context, in *PutVolumeMemberRequest, opts ...grpc.CallOption) (*PutVolumeMemberResponse, error)
+	DeleteVolumeMember(ctx context.Context, in *DeleteVolumeMemberRequest, opts ...grpc.CallOption) (*DeleteVolumeMemberResponse, error)
+	ListVolumeMounts(ctx context.Context, in *ListVolumeMountsRequest, opts ...grpc.CallOption) (*ListVolumeMountsResponse, error)
 }
 
-type masterKeyServiceClient struct {
+type volumeServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewMasterKeyServiceClient(cc *grpc.ClientConn) MasterKeyServiceClient {
-	return &masterKeyServiceClient{cc}
+func NewVolumeServiceClient(cc *grpc.ClientConn) VolumeServiceClient {
+	return &volumeServiceClient{cc}
 }
 
-func (c *masterKeyServiceClient) ListMasterKeys(ctx context.Context, in *ListMasterKeysRequest, opts ...grpc.CallOption) (*ListMasterKeysResponse, error) {
-	out := new(ListMasterKeysResponse)
-	err := c.cc.Invoke(ctx, "/types.MasterKeyService/ListMasterKeys", in, out, opts...)
+func (c *volumeServiceClient) ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error) {
+	out := new(ListVolumesResponse)
+	err := c.cc.Invoke(ctx, "/types.VolumeService/ListVolumes", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *masterKeyServiceClient) UpdateAllMasterKeys(ctx context.Context, in *UpdateAllMasterKeysRequest, opts ...grpc.CallOption) (*UpdateAllMasterKeysResponse, error) {
-	out := new(UpdateAllMasterKeysResponse)
-	err := c.cc.Invoke(ctx, "/types.MasterKeyService/UpdateAllMasterKeys", in, out, opts...)
+func (c *volumeServiceClient) PutVolume(ctx context.Context, in *PutVolumeRequest, opts ...grpc.CallOption) (*PutVolumeResponse, error) {
+	out := new(PutVolumeResponse)
+	err := c.cc.Invoke(ctx, "/types.VolumeService/PutVolume", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// MasterKeyServiceServer is the server API for MasterKeyService service.
-type MasterKeyServiceServer interface {
-	ListMasterKeys(context.Context, *ListMasterKeysRequest) (*ListMasterKeysResponse, error)
-	UpdateAllMasterKeys(context.Context, *UpdateAllMasterKeysRequest) (*UpdateAllMasterKeysResponse, error)
-}
-
-func RegisterMasterKeyServiceServer(s *grpc.Server, srv MasterKeyServiceServer) {
-	s.RegisterService(&_MasterKeyService_serviceDesc, srv)
-}
-
-func _MasterKeyService_ListMasterKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListMasterKeysRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MasterKeyServiceServer).ListMasterKeys(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.MasterKeyService/ListMasterKeys",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterKeyServiceServer).ListMasterKeys(ctx, req.(*ListMasterKeysRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _MasterKeyService_UpdateAllMasterKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateAllMasterKeysRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MasterKeyServiceServer).UpdateAllMasterKeys(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.MasterKeyService/UpdateAllMasterKeys",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MasterKeyServiceServer).UpdateAllMasterKeys(ctx, req.(*UpdateAllMasterKeysRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-var _MasterKeyService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.MasterKeyService",
-	HandlerType: (*MasterKeyServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ListMasterKeys",
-			Handler:    _MasterKeyService_ListMasterKeys_Handler,
-		},
-		{
-			MethodName: "UpdateAllMasterKeys",
-			Handler:    _MasterKeyService_UpdateAllMasterKeys_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "daemon.proto",
-}
-
-// GrantServiceClient is the client API for GrantService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type GrantServiceClient interface {
-	PutGrant(ctx context.Context, in *PutGrantRequest, opts ...grpc.CallOption) (*PutGrantResponse, error)
-	ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error)
-	DeleteGrant(ctx context.Context, in *DeleteGrantRequest, opts ...grpc.CallOption) (*DeleteGrantResponse, error)
-	CheckGrant(ctx context.Context, in *CheckGrantRequest, opts ...grpc.CallOption) (*CheckGrantResponse, error)
-	ListGrantItems(ctx context.Context, in *ListGrantItemsRequest, opts ...grpc.CallOption) (*ListGrantItemsResponse, error)
-}
-
-type grantServiceClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewGrantServiceClient(cc *grpc.ClientConn) GrantServiceClient {
-	return &grantServiceClient{cc}
-}
-
-func (c *grantServiceClient) PutGrant(ctx context.Context, in *PutGrantRequest, opts ...grpc.CallOption) (*PutGrantResponse, error) {
-	out := new(PutGrantResponse)
-	err := c.cc.Invoke(ctx, "/types.GrantService/PutGrant", in, out, opts...)
+func (c *volumeServiceClient) DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*DeleteVolumeResponse, error) {
+	out := new(DeleteVolumeResponse)
+	err := c.cc.Invoke(ctx, "/types.VolumeService/DeleteVolume", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *grantServiceClient) ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error) {
-	out := new(ListGrantsResponse)
-	err := c.cc.Invoke(ctx, "/types.GrantService/ListGrants", in, out, opts...)
+func (c *volumeServiceClient) ListVolumeMembers(ctx context.Context, in *ListVolumeMembersRequest, opts ...grpc.CallOption) (*ListVolumeMembersResponse, error) {
+	out := new(ListVolumeMembersResponse)
+	err := c.cc.Invoke(ctx, "/types.VolumeService/ListVolumeMembers", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *grantServiceClient) DeleteGrant(ctx context.Context, in *DeleteGrantRequest, opts ...grpc.CallOption) (*DeleteGrantResponse, error) {
-	out := new(DeleteGrantResponse)
-	err := c.cc.Invoke(ctx, "/types.GrantService/DeleteGrant", in, out, opts...)
+func (c *volumeServiceClient) PutVolumeMember(ctx context.Context, in *PutVolumeMemberRequest, opts ...grpc.CallOption) (*PutVolumeMemberResponse, error) {
+	out := new(PutVolumeMemberResponse)
+	err := c.cc.Invoke(ctx, "/types.VolumeService/PutVolumeMember", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *grantServiceClient) CheckGrant(ctx context.Context, in *CheckGrantRequest, opts ...grpc.CallOption) (*CheckGrantResponse, error) {
-	out := new(CheckGrantResponse)
-	err := c.cc.Invoke(ctx, "/types.GrantService/CheckGrant", in, out, opts...)
+func (c *volumeServiceClient) DeleteVolumeMember(ctx context.Context, in *DeleteVolumeMemberRequest, opts ...grpc.CallOption) (*DeleteVolumeMemberResponse, error) {
+	out := new(DeleteVolumeMemberResponse)
+	err := c.cc.Invoke(ctx, "/types.VolumeService/DeleteVolumeMember", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *grantServiceClient) ListGrantItems(ctx context.Context, in *ListGrantItemsRequest, opts ...grpc.CallOption) (*ListGrantItemsResponse, error) {
-	out := new(ListGrantItemsResponse)
-	err := c.cc.Invoke(ctx, "/types.GrantService/ListGrantItems", in, out, opts...)
+func (c *volumeServiceClient) ListVolumeMounts(ctx context.Context, in *ListVolumeMountsRequest, opts ...grpc.CallOption) (*ListVolumeMountsResponse, error) {
+	out := new(ListVolumeMountsResponse)
+	err := c.cc.Invoke(ctx, "/types.VolumeService/ListVolumeMounts", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// GrantServiceServer is the server API for GrantService service.
-type GrantServiceServer interface {
-	PutGrant(context.Context, *PutGrantRequest) (*PutGrantResponse, error)
-	ListGrants(context.Context, *ListGrantsRequest) (*ListGrantsResponse, error)
-	DeleteGrant(context.Context, *DeleteGrantRequest) (*DeleteGrantResponse, error)
-	CheckGrant(context.Context, *CheckGrantRequest) (*CheckGrantResponse, error)
-	ListGrantItems(context.Context, *ListGrantItemsRequest) (*ListGrantItemsResponse, error)
+// VolumeServiceServer is the server API for VolumeService service.
+type VolumeServiceServer interface {
+	ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error)
+	PutVolume(context.Context, *PutVolumeRequest) (*PutVolumeResponse, error)
+	DeleteVolume(context.Context, *DeleteVolumeRequest) (*DeleteVolumeResponse, error)
+	ListVolumeMembers(context.Context, *ListVolumeMembersRequest) (*ListVolumeMembersResponse, error)
+	PutVolumeMember(context.Context, *PutVolumeMemberRequest) (*PutVolumeMemberResponse, error)
+	DeleteVolumeMember(context.Context, *DeleteVolumeMemberRequest) (*DeleteVolumeMemberResponse, error)
+	ListVolumeMounts(context.Context, *ListVolumeMountsRequest) (*ListVolumeMountsResponse, error)
+}
+
+func RegisterVolumeServiceServer(s *grpc.Server, srv VolumeServiceServer) {
+	s.RegisterService(&_VolumeService_serviceDesc, srv)
+}
+
+func _VolumeService_ListVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).ListVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.VolumeService/ListVolumes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).ListVolumes(ctx, req.(*ListVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterGrantServiceServer(s *grpc.Server, srv GrantServiceServer) {
-	s.RegisterService(&_GrantService_serviceDesc, srv)
+func _VolumeService_PutVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).PutVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.VolumeService/PutVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).PutVolume(ctx, req.(*PutVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _GrantService_PutGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PutGrantRequest)
+func _VolumeService_DeleteVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVolumeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GrantServiceServer).PutGrant(ctx, in)
+		return srv.(VolumeServiceServer).DeleteVolume(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.GrantService/PutGrant",
+		FullMethod: "/types.VolumeService/DeleteVolume",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GrantServiceServer).PutGrant(ctx, req.(*PutGrantRequest))
+		return srv.(VolumeServiceServer).DeleteVolume(ctx, req.(*DeleteVolumeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GrantService_ListGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListGrantsRequest)
+func _VolumeService_ListVolumeMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumeMembersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GrantServiceServer).ListGrants(ctx, in)
+		return srv.(VolumeServiceServer).ListVolumeMembers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.GrantService/ListGrants",
+		FullMethod: "/types.VolumeService/ListVolumeMembers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GrantServiceServer).ListGrants(ctx, req.(*ListGrantsRequest))
+		return srv.(VolumeServiceServer).ListVolumeMembers(ctx, req.(*ListVolumeMembersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GrantService_DeleteGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteGrantRequest)
+func _VolumeService_PutVolumeMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutVolumeMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GrantServiceServer).DeleteGrant(ctx, in)
+		return srv.(VolumeServiceServer).PutVolumeMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.GrantService/DeleteGrant",
+		FullMethod: "/types.VolumeService/PutVolumeMember",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GrantServiceServer).DeleteGrant(ctx, req.(*DeleteGrantRequest))
+		return srv.(VolumeServiceServer).PutVolumeMember(ctx, req.(*PutVolumeMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GrantService_CheckGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckGrantRequest)
+func _VolumeService_DeleteVolumeMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVolumeMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GrantServiceServer).CheckGrant(ctx, in)
+		return srv.(VolumeServiceServer).DeleteVolumeMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.GrantService/CheckGrant",
+		FullMethod: "/types.VolumeService/DeleteVolumeMember",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GrantServiceServer).CheckGrant(ctx, req.(*CheckGrantRequest))
+		return srv.(VolumeServiceServer).DeleteVolumeMember(ctx, req.(*DeleteVolumeMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GrantService_ListGrantItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListGrantItemsRequest)
+func _VolumeService_ListVolumeMounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumeMountsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GrantServiceServer).ListGrantItems(ctx, in)
+		return srv.(VolumeServiceServer).ListVolumeMounts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.GrantService/ListGrantItems",
+		FullMethod: "/types.VolumeService/ListVolumeMounts",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GrantServiceServer).ListGrantItems(ctx, req.(*ListGrantItemsRequest))
+		return srv.(VolumeServiceServer).ListVolumeMounts(ctx, req.(*ListVolumeMountsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _GrantService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.GrantService",
-	HandlerType: (*GrantServiceServer)(nil),
+var _VolumeService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.VolumeService",
+	HandlerType: (*VolumeServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "PutGrant",
-			Handler:    _GrantService_PutGrant_Handler,
+			MethodName: "ListVolumes",
+			Handler:    _VolumeService_ListVolumes_Handler,
 		},
 		{
-			MethodName: "ListGrants",
-			Handler:    _GrantService_ListGrants_Handler,
+			MethodName: "PutVolume",
+			Handler:    _VolumeService_PutVolume_Handler,
 		},
 		{
-			MethodName: "DeleteGrant",
-			Handler:    _GrantService_DeleteGrant_Handler,
+			MethodName: "DeleteVolume",
+			Handler:    _VolumeService_DeleteVolume_Handler,
 		},
 		{
-			MethodName: "CheckGrant",
-			Handler:    _GrantService_CheckGrant_Handler,
+			MethodName: "ListVolumeMembers",
+			Handler:    _VolumeService_ListVolumeMembers_Handler,
 		},
 		{
-			MethodName: "ListGrantItems",
-			Handler:    _GrantService_ListGrantItems_Handler,
+			MethodName: "PutVolumeMember",
+			Handler:    _VolumeService_PutVolumeMember_Handler,
+		},
+		{
+			MethodName: "DeleteVolumeMember",
+			Handler:    _VolumeService_DeleteVolumeMember_Handler,
+		},
+		{
+			MethodName: "ListVolumeMounts",
+			Handler:    _VolumeService_ListVolumeMounts_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "daemon.proto",
 }
 
-// SessionServiceClient is the client API for SessionService service.
+// TransferServiceClient is the client API for TransferService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SessionServiceClient interface {
-	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*CreateSessionResponse, error)
-	FinishSession(ctx context.Context, in *FinishSessionRequest, opts ...grpc.CallOption) (*FinishSessionResponse, error)
-	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
-	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error)
+type TransferServiceClient interface {
+	CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error)
+	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
 }
 
-type sessionServiceClient struct {
+type transferServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewSessionServiceClient(cc *grpc.ClientConn) SessionServiceClient {
-	return &sessionServiceClient{cc}
+func NewTransferServiceClient(cc *grpc.ClientConn) TransferServiceClient {
+	return &transferServiceClient{cc}
 }
 
-func (c *sessionServiceClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*CreateSessionResponse, error) {
-	out := new(CreateSessionResponse)
-	err := c.cc.Invoke(ctx, "/types.SessionService/CreateSession", in, out, opts...)
+func (c *transferServiceClient) CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error) {
+	out := new(CreateTransferResponse)
+	err := c.cc.Invoke(ctx, "/types.TransferService/CreateTransfer", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *sessionServiceClient) FinishSession(ctx context.Context, in *FinishSessionRequest, opts ...grpc.CallOption) (*FinishSessionResponse, error) {
-	out := new(FinishSessionResponse)
-	err := c.cc.Invoke(ctx, "/types.SessionService/FinishSession", in, out, opts...)
+func (c *transferServiceClient) ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error) {
+	out := new(ListTransfersResponse)
+	err := c.cc.Invoke(ctx, "/types.TransferService/ListTransfers", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *sessionServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
-	out := new(ListSessionsResponse)
-	err := c.cc.Invoke(ctx, "/types.SessionService/ListSessions", in, out, opts...)
+// TransferServiceServer is the server API for TransferService service.
+type TransferServiceServer interface {
+	CreateTransfer(context.Context, *CreateTransferRequest) (*CreateTransferResponse, error)
+	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
+}
+
+func RegisterTransferServiceServer(s *grpc.Server, srv TransferServiceServer) {
+	s.RegisterService(&_TransferService_serviceDesc, srv)
+}
+
+func _TransferService_CreateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).CreateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.TransferService/CreateTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).CreateTransfer(ctx, req.(*CreateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransferService_ListTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).ListTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.TransferService/ListTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).ListTransfers(ctx, req.(*ListTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TransferService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.TransferService",
+	HandlerType: (*TransferServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTransfer",
+			Handler:    _TransferService_CreateTransfer_Handler,
+		},
+		{
+			MethodName: "ListTransfers",
+			Handler:    _TransferService_ListTransfers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "daemon.proto",
+}
+
+// GroupServiceClient is the client API for GroupService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GroupServiceClient interface {
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	PutGroup(ctx context.Context, in *PutGroupRequest, opts ...grpc.CallOption) (*PutGroupResponse, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	ListGroupMembers(ctx context.Context, in *ListGroupMembersRequest, opts ...grpc.CallOption) (*ListGroupMembersResponse, error)
+	PutGroupMember(ctx context.Context, in *PutGroupMemberRequest, opts ...grpc.CallOption) (*PutGroupMemberResponse, error)
+	DeleteGroupMember(ctx context.Context, in *DeleteGroupMemberRequest, opts ...grpc.CallOption) (*DeleteGroupMemberResponse, error)
+}
+
+type groupServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGroupServiceClient(cc *grpc.ClientConn) GroupServiceClient {
+	return &groupServiceClient{cc}
+}
+
+func (c *groupServiceClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, "/types.GroupService/ListGroups", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *sessionServiceClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error) {
-	out := new(GetSessionResponse)
-	err := c.cc.Invoke(ctx, "/types.SessionService/GetSession", in, out, opts...)
+func (c *groupServiceClient) PutGroup(ctx context.Context, in *PutGroupRequest, opts ...grpc.CallOption) (*PutGroupResponse, error) {
+	out := new(PutGroupResponse)
+	err := c.cc.Invoke(ctx, "/types.GroupService/PutGroup", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// SessionServiceServer is the server API for SessionService service.
-type SessionServiceServer interface {
-	CreateSession(context.Context, *CreateSessionRequest) (*CreateSessionResponse, error)
-	FinishSession(context.Context, *FinishSessionRequest) (*FinishSessionResponse, error)
-	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
-	GetSession(context.Context, *GetSessionRequest) (*GetSessionResponse, error)
+func (c *groupServiceClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	out := new(DeleteGroupResponse)
+	err := c.cc.Invoke(ctx, "/types.GroupService/DeleteGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterSessionServiceServer(s *grpc.Server, srv SessionServiceServer) {
-	s.RegisterService(&_SessionService_serviceDesc, srv)
+func (c *groupServiceClient) ListGroupMembers(ctx context.Context, in *ListGroupMembersRequest, opts ...grpc.CallOption) (*ListGroupMembersResponse, error) {
+	out := new(ListGroupMembersResponse)
+	err := c.cc.Invoke(ctx, "/types.GroupService/ListGroupMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func _SessionService_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateSessionRequest)
+func (c *groupServiceClient) PutGroupMember(ctx context.Context, in *PutGroupMemberRequest, opts ...grpc.CallOption) (*PutGroupMemberResponse, error) {
+	out := new(PutGroupMemberResponse)
+	err := c.cc.Invoke(ctx, "/types.GroupService/PutGroupMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupServiceClient) DeleteGroupMember(ctx context.Context, in *DeleteGroupMemberRequest, opts ...grpc.CallOption) (*DeleteGroupMemberResponse, error) {
+	out := new(DeleteGroupMemberResponse)
+	err := c.cc.Invoke(ctx, "/types.GroupService/DeleteGroupMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupServiceServer is the server API for GroupService service.
+type GroupServiceServer interface {
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	PutGroup(context.Context, *PutGroupRequest) (*PutGroupResponse, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	ListGroupMembers(context.Context, *ListGroupMembersRequest) (*ListGroupMembersResponse, error)
+	PutGroupMember(context.Context, *PutGroupMemberRequest) (*PutGroupMemberResponse, error)
+	DeleteGroupMember(context.Context, *DeleteGroupMemberRequest) (*DeleteGroupMemberResponse, error)
+}
+
+func RegisterGroupServiceServer(s *grpc.Server, srv GroupServiceServer) {
+	s.RegisterService(&_GroupService_serviceDesc, srv)
+}
+
+func _GroupService_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SessionServiceServer).CreateSession(ctx, in)
+		return srv.(GroupServiceServer).ListGroups(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.SessionService/CreateSession",
+		FullMethod: "/types.GroupService/ListGroups",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SessionServiceServer).CreateSession(ctx, req.(*CreateSessionRequest))
+		return srv.(GroupServiceServer).ListGroups(ctx, req.(*ListGroupsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SessionService_FinishSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FinishSessionRequest)
+func _GroupService_PutGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SessionServiceServer).FinishSession(ctx, in)
+		return srv.(GroupServiceServer).PutGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.SessionService/FinishSession",
+		FullMethod: "/types.GroupService/PutGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SessionServiceServer).FinishSession(ctx, req.(*FinishSessionRequest))
+		return srv.(GroupServiceServer).PutGroup(ctx, req.(*PutGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SessionService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSessionsRequest)
+func _GroupService_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SessionServiceServer).ListSessions(ctx, in)
+		return srv.(GroupServiceServer).DeleteGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.SessionService/ListSessions",
+		FullMethod: "/types.GroupService/DeleteGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SessionServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
+		return srv.(GroupServiceServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SessionService_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetSessionRequest)
+func _GroupService_ListGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupMembersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SessionServiceServer).GetSession(ctx, in)
+		return srv.(GroupServiceServer).ListGroupMembers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.SessionService/GetSession",
+		FullMethod: "/types.GroupService/ListGroupMembers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SessionServiceServer).GetSession(ctx, req.(*GetSessionRequest))
+		return srv.(GroupServiceServer).ListGroupMembers(ctx, req.(*ListGroupMembersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _SessionService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.SessionService",
-	HandlerType: (*SessionServiceServer)(nil),
+func _GroupService_PutGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutGroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).PutGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.GroupService/PutGroupMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).PutGroupMember(ctx, req.(*PutGroupMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupService_DeleteGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).DeleteGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.GroupService/DeleteGroupMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).DeleteGroupMember(ctx, req.(*DeleteGroupMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _GroupService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.GroupService",
+	HandlerType: (*GroupServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateSession",
-			Handler:    _SessionService_CreateSession_Handler,
+			MethodName: "ListGroups",
+			Handler:    _GroupService_ListGroups_Handler,
 		},
 		{
-			MethodName: "FinishSession",
-			Handler:    _SessionService_FinishSession_Handler,
+			MethodName: "PutGroup",
+			Handler:    _GroupService_PutGroup_Handler,
 		},
 		{
-			MethodName: "ListSessions",
-			Handler:    _SessionService_ListSessions_Handler,
+			MethodName: "DeleteGroup",
+			Handler:    _GroupService_DeleteGroup_Handler,
 		},
 		{
-			MethodName: "GetSession",
-			Handler:    _SessionService_GetSession_Handler,
+			MethodName: "ListGroupMembers",
+			Handler:    _GroupService_ListGroupMembers_Handler,
+		},
+		{
+			MethodName: "PutGroupMember",
+			Handler:    _GroupService_PutGroupMember_Handler,
+		},
+		{
+			MethodName: "DeleteGroupMember",
+			Handler:    _GroupService_DeleteGroupMember_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "daemon.proto",
 }
 
-// TokenServiceClient is the client API for TokenService service.
+// AccessRequestServiceClient is the client API for AccessRequestService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type TokenServiceClient interface {
-	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
-	GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error)
-	TouchToken(ctx context.Context, in *TouchTokenRequest, opts ...grpc.CallOption) (*TouchTokenResponse, error)
-	ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error)
-	DeleteToken(ctx context.Context, in *DeleteTokenRequest, opts ...grpc.CallOption) (*DeleteTokenResponse, error)
+type AccessRequestServiceClient interface {
+	CreateAccessRequest(ctx context.Context, in *CreateAccessRequestRequest, opts ...grpc.CallOption) (*CreateAccessRequestResponse, error)
+	ListAccessRequests(ctx context.Context, in *ListAccessRequestsRequest, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error)
+	GetAccessRequest(ctx context.Context, in *GetAccessRequestRequest, opts ...grpc.CallOption) (*GetAccessRequestResponse, error)
+	ApproveAccessRequest(ctx context.Context, in *ReviewAccessRequestRequest, opts ...grpc.CallOption) (*ReviewAccessRequestResponse, error)
+	RejectAccessRequest(ctx context.Context, in *ReviewAccessRequestRequest, opts ...grpc.CallOption) (*ReviewAccessRequestResponse, error)
+	CancelAccessRequest(ctx context.Context, in *CancelAccessRequestRequest, opts ...grpc.CallOption) (*CancelAccessRequestResponse, error)
 }
 
-type tokenServiceClient struct {
+type accessRequestServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewTokenServiceClient(cc *grpc.ClientConn) TokenServiceClient {
-	return &tokenServiceClient{cc}
+func NewAccessRequestServiceClient(cc *grpc.ClientConn) AccessRequestServiceClient {
+	return &accessRequestServiceClient{cc}
 }
 
-func (c *tokenServiceClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
-	out := new(CreateTokenResponse)
-	err := c.cc.Invoke(ctx, "/types.TokenService/CreateToken", in, out, opts...)
+func (c *accessRequestServiceClient) CreateAccessRequest(ctx context.Context, in *CreateAccessRequestRequest, opts ...grpc.CallOption) (*CreateAccessRequestResponse, error) {
+	out := new(CreateAccessRequestResponse)
+	err := c.cc.Invoke(ctx, "/types.AccessRequestService/CreateAccessRequest", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *tokenServiceClient) GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error) {
-	out := new(GetTokenResponse)
-	err := c.cc.Invoke(ctx, "/types.TokenService/GetToken", in, out, opts...)
+func (c *accessRequestServiceClient) ListAccessRequests(ctx context.Context, in *ListAccessRequestsRequest, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error) {
+	out := new(ListAccessRequestsResponse)
+	err := c.cc.Invoke(ctx, "/types.AccessRequestService/ListAccessRequests", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *tokenServiceClient) TouchToken(ctx context.Context, in *TouchTokenRequest, opts ...grpc.CallOption) (*TouchTokenResponse, error) {
-	out := new(TouchTokenResponse)
-	err := c.cc.Invoke(ctx, "/types.TokenService/TouchToken", in, out, opts...)
+func (c *accessRequestServiceClient) GetAccessRequest(ctx context.Context, in *GetAccessRequestRequest, opts ...grpc.CallOption) (*GetAccessRequestResponse, error) {
+	out := new(GetAccessRequestResponse)
+	err := c.cc.Invoke(ctx, "/types.AccessRequestService/GetAccessRequest", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *tokenServiceClient) ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error) {
-	out := new(ListTokensResponse)
-	err := c.cc.Invoke(ctx, "/types.TokenService/ListTokens", in, out, opts...)
+func (c *accessRequestServiceClient) ApproveAccessRequest(ctx context.Context, in *ReviewAccessRequestRequest, opts ...grpc.CallOption) (*ReviewAccessRequestResponse, error) {
+	out := new(ReviewAccessRequestResponse)
+	err := c.cc.Invoke(ctx, "/types.AccessRequestService/ApproveAccessRequest", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *tokenServiceClient) DeleteToken(ctx context.Context, in *DeleteTokenRequest, opts ...grpc.CallOption) (*DeleteTokenResponse, error) {
-	out := new(DeleteTokenResponse)
-	err := c.cc.Invoke(ctx, "/types.TokenService/DeleteToken", in, out, opts...)
+func (c *accessRequestServiceClient) RejectAccessRequest(ctx context.Context, in *ReviewAccessRequestRequest, opts ...grpc.CallOption) (*ReviewAccessRequestResponse, error) {
+	out := new(ReviewAccessRequestResponse)
+	err := c.cc.Invoke(ctx, "/types.AccessRequestService/RejectAccessRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessRequestServiceClient) CancelAccessRequest(ctx context.Context, in *CancelAccessRequestRequest, opts ...grpc.CallOption) (*CancelAccessRequestResponse, error) {
+	out := new(CancelAccessRequestResponse)
+	err := c.cc.Invoke(ctx, "/types.AccessRequestService/CancelAccessRequest", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// TokenServiceServer is the server API for TokenService service.
-type TokenServiceServer interface {
-	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
-	GetToken(context.Context, *GetTokenRequest) (*GetTokenResponse, error)
-	TouchToken(context.Context, *TouchTokenRequest) (*TouchTokenResponse, error)
-	ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
-	DeleteToken(context.Context, *DeleteTokenRequest) (*DeleteTokenResponse, error)
+// AccessRequestServiceServer is the server API for AccessRequestService service.
+type AccessRequestServiceServer interface {
+	CreateAccessRequest(context.Context, *CreateAccessRequestRequest) (*CreateAccessRequestResponse, error)
+	ListAccessRequests(context.Context, *ListAccessRequestsRequest) (*ListAccessRequestsResponse, error)
+	GetAccessRequest(context.Context, *GetAccessRequestRequest) (*GetAccessRequestResponse, error)
+	ApproveAccessRequest(context.Context, *ReviewAccessRequestRequest) (*ReviewAccessRequestResponse, error)
+	RejectAccessRequest(context.Context, *ReviewAccessRequestRequest) (*ReviewAccessRequestResponse, error)
+	CancelAccessRequest(context.Context, *CancelAccessRequestRequest) (*CancelAccessRequestResponse, error)
 }
 
-func RegisterTokenServiceServer(s *grpc.Server, srv TokenServiceServer) {
-	s.RegisterService(&_TokenService_serviceDesc, srv)
+func RegisterAccessRequestServiceServer(s *grpc.Server, srv AccessRequestServiceServer) {
+	s.RegisterService(&_AccessRequestService_serviceDesc, srv)
 }
 
-func _TokenService_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTokenRequest)
+func _AccessRequestService_CreateAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessRequestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TokenServiceServer).CreateToken(ctx, in)
+		return srv.(AccessRequestServiceServer).CreateAccessRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.TokenService/CreateToken",
+		FullMethod: "/types.AccessRequestService/CreateAccessRequest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TokenServiceServer).CreateToken(ctx, req.(*CreateTokenRequest))
+		return srv.(AccessRequestServiceServer).CreateAccessRequest(ctx, req.(*CreateAccessRequestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TokenService_GetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTokenRequest)
+func _AccessRequestService_ListAccessRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessRequestsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TokenServiceServer).GetToken(ctx, in)
+		return srv.(AccessRequestServiceServer).ListAccessRequests(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.TokenService/GetToken",
+		FullMethod: "/types.AccessRequestService/ListAccessRequests",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TokenServiceServer).GetToken(ctx, req.(*GetTokenRequest))
+		return srv.(AccessRequestServiceServer).ListAccessRequests(ctx, req.(*ListAccessRequestsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TokenService_TouchToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TouchTokenRequest)
+func _AccessRequestService_GetAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessRequestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TokenServiceServer).TouchToken(ctx, in)
+		return srv.(AccessRequestServiceServer).GetAccessRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.TokenService/TouchToken",
+		FullMethod: "/types.AccessRequestService/GetAccessRequest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TokenServiceServer).TouchToken(ctx, req.(*TouchTokenRequest))
+		return srv.(AccessRequestServiceServer).GetAccessRequest(ctx, req.(*GetAccessRequestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TokenService_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTokensRequest)
+func _AccessRequestService_ApproveAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewAccessRequestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TokenServiceServer).ListTokens(ctx, in)
+		return srv.(AccessRequestServiceServer).ApproveAccessRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.TokenService/ListTokens",
+		FullMethod: "/types.AccessRequestService/ApproveAccessRequest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TokenServiceServer).ListTokens(ctx, req.(*ListTokensRequest))
+		return srv.(AccessRequestServiceServer).ApproveAccessRequest(ctx, req.(*ReviewAccessRequestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TokenService_DeleteToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteTokenRequest)
+func _AccessRequestService_RejectAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewAccessRequestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TokenServiceServer).DeleteToken(ctx, in)
+		return srv.(AccessRequestServiceServer).RejectAccessRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.TokenService/DeleteToken",
+		FullMethod: "/types.AccessRequestService/RejectAccessRequest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TokenServiceServer).DeleteToken(ctx, req.(*DeleteTokenRequest))
+		return srv.(AccessRequestServiceServer).RejectAccessRequest(ctx, req.(*ReviewAccessRequestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _TokenService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.TokenService",
-	HandlerType: (*TokenServiceServer)(nil),
+func _AccessRequestService_CancelAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).CancelAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AccessRequestService/CancelAccessRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).CancelAccessRequest(ctx, req.(*CancelAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AccessRequestService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.AccessRequestService",
+	HandlerType: (*AccessRequestServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateToken",
-			Handler:    _TokenService_CreateToken_Handler,
+			MethodName: "CreateAccessRequest",
+			Handler:    _AccessRequestService_CreateAccessRequest_Handler,
 		},
 		{
-			MethodName: "GetToken",
-			Handler:    _TokenService_GetToken_Handler,
+			MethodName: "ListAccessRequests",
+			Handler:    _AccessRequestService_ListAccessRequests_Handler,
 		},
 		{
-			MethodName: "TouchToken",
-			Handler:    _TokenService_TouchToken_Handler,
+			MethodName: "GetAccessRequest",
+			Handler:    _AccessRequestService_GetAccessRequest_Handler,
 		},
 		{
-			MethodName: "ListTokens",
-			Handler:    _TokenService_ListTokens_Handler,
+			MethodName: "ApproveAccessRequest",
+			Handler:    _AccessRequestService_ApproveAccessRequest_Handler,
 		},
 		{
-			MethodName: "DeleteToken",
-			Handler:    _TokenService_DeleteToken_Handler,
+			MethodName: "RejectAccessRequest",
+			Handler:    _AccessRequestService_RejectAccessRequest_Handler,
+		},
+		{
+			MethodName: "CancelAccessRequest",
+			Handler:    _AccessRequestService_CancelAccessRequest_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "daemon.proto",
 }
 
-// ReplayServiceClient is the client API for ReplayService service.
+// AuditServiceClient is the client API for AuditService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ReplayServiceClient interface {
-	WriteReplay(ctx context.Context, opts ...grpc.CallOption) (ReplayService_WriteReplayClient, error)
-	ReadReplay(ctx context.Context, in *ReadReplayRequest, opts ...grpc.CallOption) (ReplayService_ReadReplayClient, error)
-	SubmitReplay(ctx context.Context, in *SubmitReplayRequest, opts ...grpc.CallOption) (*SubmitReplayResponse, error)
-	SearchReplay(ctx context.Context, in *SearchReplayRequest, opts ...grpc.CallOption) (*SearchReplayResponse, error)
+type AuditServiceClient interface {
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 }
 
-type replayServiceClient struct {
+type auditServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewReplayServiceClient(cc *grpc.ClientConn) ReplayServiceClient {
-	return &replayServiceClient{cc}
+func NewAuditServiceClient(cc *grpc.ClientConn) AuditServiceClient {
+	return &auditServiceClient{cc}
 }
 
-func (c *replayServiceClient) WriteReplay(ctx context.Context, opts ...grpc.CallOption) (ReplayService_WriteReplayClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ReplayService_serviceDesc.Streams[0], "/types.ReplayService/WriteReplay", opts...)
+func (c *auditServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/types.AuditService/ListAuditEvents", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &replayServiceWriteReplayClient{stream}
-	return x, nil
+	return out, nil
 }
 
-type ReplayService_WriteReplayClient interface {
-	Send(*ReplayFrame) error
-	CloseAndRecv() (*WriteReplayResponse, error)
-	grpc.ClientStream
+// AuditServiceServer is the server API for AuditService service.
+type AuditServiceServer interface {
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
 }
 
-type replayServiceWriteReplayClient struct {
-	grpc.ClientStream
+func RegisterAuditServiceServer(s *grpc.Server, srv AuditServiceServer) {
+	s.RegisterService(&_AuditService_serviceDesc, srv)
 }
 
-func (x *replayServiceWriteReplayClient) Send(m *ReplayFrame) error {
-	return x.ClientStream.SendMsg(m)
+func _AuditService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AuditService/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *replayServiceWriteReplayClient) CloseAndRecv() (*WriteReplayResponse, error) {
+var _AuditService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.AuditService",
+	HandlerType: (*AuditServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _AuditService_ListAuditEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "daemon.proto",
+}
+
+// BackupServiceClient is the client API for BackupService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BackupServiceClient interface {
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (BackupService_BackupClient, error)
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (BackupService_ExportClient, error)
+	Import(ctx context.Context, opts ...grpc.CallOption) (BackupService_ImportClient, error)
+}
+
+type backupServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBackupServiceClient(cc *grpc.ClientConn) BackupServiceClient {
+	return &backupServiceClient{cc}
+}
+
+func (c *backupServiceClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (BackupService_BackupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackupService_serviceDesc.Streams[0], "/types.BackupService/Backup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backupServiceBackupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
 	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	m := new(WriteReplayResponse)
+	return x, nil
+}
+
+type BackupService_BackupClient interface {
+	Recv() (*BackupChunk, error)
+	grpc.ClientStream
+}
+
+type backupServiceBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *backupServiceBackupClient) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *replayServiceClient) ReadReplay(ctx context.Context, in *ReadReplayRequest, opts ...grpc.CallOption) (ReplayService_ReadReplayClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ReplayService_serviceDesc.Streams[1], "/types.ReplayService/ReadReplay", opts...)
+func (c *backupServiceClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (BackupService_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackupService_serviceDesc.Streams[1], "/types.BackupService/Export", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &replayServiceReadReplayClient{stream}
+	x := &backupServiceExportClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -5369,160 +10740,156 @@ func (c *replayServiceClient) ReadReplay(ctx context.Context, in *ReadReplayRequ
 	return x, nil
 }
 
-type ReplayService_ReadReplayClient interface {
-	Recv() (*ReplayFrame, error)
+type BackupService_ExportClient interface {
+	Recv() (*ModelRecord, error)
 	grpc.ClientStream
 }
 
-type replayServiceReadReplayClient struct {
+type backupServiceExportClient struct {
 	grpc.ClientStream
 }
 
-func (x *replayServiceReadReplayClient) Recv() (*ReplayFrame, error) {
-	m := new(ReplayFrame)
+func (x *backupServiceExportClient) Recv() (*ModelRecord, error) {
+	m := new(ModelRecord)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *replayServiceClient) SubmitReplay(ctx context.Context, in *SubmitReplayRequest, opts ...grpc.CallOption) (*SubmitReplayResponse, error) {
-	out := new(SubmitReplayResponse)
-	err := c.cc.Invoke(ctx, "/types.ReplayService/SubmitReplay", in, out, opts...)
+func (c *backupServiceClient) Import(ctx context.Context, opts ...grpc.CallOption) (BackupService_ImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackupService_serviceDesc.Streams[2], "/types.BackupService/Import", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &backupServiceImportClient{stream}
+	return x, nil
 }
 
-func (c *replayServiceClient) SearchReplay(ctx context.Context, in *SearchReplayRequest, opts ...grpc.CallOption) (*SearchReplayResponse, error) {
-	out := new(SearchReplayResponse)
-	err := c.cc.Invoke(ctx, "/types.ReplayService/SearchReplay", in, out, opts...)
-	if err != nil {
+type BackupService_ImportClient interface {
+	Send(*ModelRecord) error
+	CloseAndRecv() (*ImportResponse, error)
+	grpc.ClientStream
+}
+
+type backupServiceImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *backupServiceImportClient) Send(m *ModelRecord) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *backupServiceImportClient) CloseAndRecv() (*ImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	m := new(ImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-// ReplayServiceServer is the server API for ReplayService service.
-type ReplayServiceServer interface {
-	WriteReplay(ReplayService_WriteReplayServer) error
-	ReadReplay(*ReadReplayRequest, ReplayService_ReadReplayServer) error
-	SubmitReplay(context.Context, *SubmitReplayRequest) (*SubmitReplayResponse, error)
-	SearchReplay(context.Context, *SearchReplayRequest) (*SearchReplayResponse, error)
+// BackupServiceServer is the server API for BackupService service.
+type BackupServiceServer interface {
+	Backup(*BackupRequest, BackupService_BackupServer) error
+	Export(*ExportRequest, BackupService_ExportServer) error
+	Import(BackupService_ImportServer) error
 }
 
-func RegisterReplayServiceServer(s *grpc.Server, srv ReplayServiceServer) {
-	s.RegisterService(&_ReplayService_serviceDesc, srv)
+func RegisterBackupServiceServer(s *grpc.Server, srv BackupServiceServer) {
+	s.RegisterService(&_BackupService_serviceDesc, srv)
 }
 
-func _ReplayService_WriteReplay_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ReplayServiceServer).WriteReplay(&replayServiceWriteReplayServer{stream})
+func _BackupService_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackupServiceServer).Backup(m, &backupServiceBackupServer{stream})
 }
 
-type ReplayService_WriteReplayServer interface {
-	SendAndClose(*WriteReplayResponse) error
-	Recv() (*ReplayFrame, error)
+type BackupService_BackupServer interface {
+	Send(*BackupChunk) error
 	grpc.ServerStream
 }
 
-type replayServiceWriteReplayServer struct {
+type backupServiceBackupServer struct {
 	grpc.ServerStream
 }
 
-func (x *replayServiceWriteReplayServer) SendAndClose(m *WriteReplayResponse) error {
+func (x *backupServiceBackupServer) Send(m *BackupChunk) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *replayServiceWriteReplayServer) Recv() (*ReplayFrame, error) {
-	m := new(ReplayFrame)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
-
-func _ReplayService_ReadReplay_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ReadReplayRequest)
+func _BackupService_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ReplayServiceServer).ReadReplay(m, &replayServiceReadReplayServer{stream})
+	return srv.(BackupServiceServer).Export(m, &backupServiceExportServer{stream})
 }
 
-type ReplayService_ReadReplayServer interface {
-	Send(*ReplayFrame) error
+type BackupService_ExportServer interface {
+	Send(*ModelRecord) error
 	grpc.ServerStream
 }
 
-type replayServiceReadReplayServer struct {
+type backupServiceExportServer struct {
 	grpc.ServerStream
 }
 
-func (x *replayServiceReadReplayServer) Send(m *ReplayFrame) error {
+func (x *backupServiceExportServer) Send(m *ModelRecord) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ReplayService_SubmitReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SubmitReplayRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ReplayServiceServer).SubmitReplay(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ReplayService/SubmitReplay",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReplayServiceServer).SubmitReplay(ctx, req.(*SubmitReplayRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func _BackupService_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BackupServiceServer).Import(&backupServiceImportServer{stream})
 }
 
-func _ReplayService_SearchReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchReplayRequest)
-	if err := dec(in); err != nil {
+type BackupService_ImportServer interface {
+	SendAndClose(*ImportResponse) error
+	Recv() (*ModelRecord, error)
+	grpc.ServerStream
+}
+
+type backupServiceImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *backupServiceImportServer) SendAndClose(m *ImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *backupServiceImportServer) Recv() (*ModelRecord, error) {
+	m := new(ModelRecord)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ReplayServiceServer).SearchReplay(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ReplayService/SearchReplay",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReplayServiceServer).SearchReplay(ctx, req.(*SearchReplayRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-var _ReplayService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.ReplayService",
-	HandlerType: (*ReplayServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
+var _BackupService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.BackupService",
+	HandlerType: (*BackupServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
 		{
-			MethodName: "SubmitReplay",
-			Handler:    _ReplayService_SubmitReplay_Handler,
+			StreamName:    "Backup",
+			Handler:       _BackupService_Backup_Handler,
+			ServerStreams: true,
 		},
 		{
-			MethodName: "SearchReplay",
-			Handler:    _ReplayService_SearchReplay_Handler,
+			StreamName:    "Export",
+			Handler:       _BackupService_Export_Handler,
+			ServerStreams: true,
 		},
-	},
-	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "WriteReplay",
-			Handler:       _ReplayService_WriteReplay_Handler,
+			StreamName:    "Import",
+			Handler:       _BackupService_Import_Handler,
 			ClientStreams: true,
 		},
-		{
-			StreamName:    "ReadReplay",
-			Handler:       _ReplayService_ReadReplay_Handler,
-			ServerStreams: true,
-		},
 	},
 	Metadata: "daemon.proto",
 }
@@ -11,17 +11,100 @@ import (
 
 const (
 	ReplayElasticsearchIndexPrefix = "bastion-replays-"
+	// CommandElasticsearchIndexPrefix index of commands extracted from transcripts of replays
+	CommandElasticsearchIndexPrefix = "bastion-commands-"
 
 	NodeSourceManual = "manual"
 	NodeSourceConsul = "consul"
 
 	KeySourceManual  = "manual"
 	KeySourceSandbox = "sandbox"
+	KeySourceLDAP    = "ldap"
+
+	UserSourceManual = "manual"
+	UserSourceLDAP   = "ldap"
+	UserSourceOIDC   = "oidc"
+
+	GroupMemberSourceManual = "manual"
+	GroupMemberSourceLDAP   = "ldap"
+	GroupMemberSourceOIDC   = "oidc"
 
 	NodeUserRoot = "root"
 
 	GrantUserTunnel = "__tunnel__" // special linux user for TCP tunnel permission
 
+	VolumeMemberKindUser  = "user"
+	VolumeMemberKindGroup = "group"
+
+	SessionStageLv1 = "lv1" // session in sandbox
+	SessionStageLv2 = "lv2" // session on target node, initiated from sandbox
+
+	// SessionEnvParentID environment variable carrying lv1 session id from sandbox to lv2 sessions
+	SessionEnvParentID = "BASTION_SESSION_ID"
+
+	TransferSourceWeb = "web"
+	TransferSourceSCP = "scp"
+
+	TransferDirectionUpload   = "upload"
+	TransferDirectionDownload = "download"
+
+	AccessRequestStatusPending   = "pending"
+	AccessRequestStatusApproved  = "approved"
+	AccessRequestStatusRejected  = "rejected"
+	AccessRequestStatusCancelled = "cancelled"
+
+	AccessRequestActionCreate  = "create"
+	AccessRequestActionApprove = "approve"
+	AccessRequestActionReject  = "reject"
+	AccessRequestActionCancel  = "cancel"
+
+	AuditActionUserCreate           = "user.create"
+	AuditActionUserUpdate           = "user.update"
+	AuditActionUserUpdatePassword   = "user.update_password"
+	AuditActionNodePut              = "node.put"
+	AuditActionNodeUpdate           = "node.update"
+	AuditActionNodeDelete           = "node.delete"
+	AuditActionGrantPut             = "grant.put"
+	AuditActionGrantDelete          = "grant.delete"
+	AuditActionKeyCreate            = "key.create"
+	AuditActionKeyDelete            = "key.delete"
+	AuditActionGroupPut             = "group.put"
+	AuditActionGroupDelete          = "group.delete"
+	AuditActionGroupMemberPut       = "group_member.put"
+	AuditActionGroupMemberDelete    = "group_member.delete"
+	AuditActionVolumePut            = "volume.put"
+	AuditActionVolumeDelete         = "volume.delete"
+	AuditActionVolumeMemberPut      = "volume_member.put"
+	AuditActionVolumeMemberDelete   = "volume_member.delete"
+	AuditActionAccessRequestCreate  = "access_request.create"
+	AuditActionAccessRequestApprove = "access_request.approve"
+	AuditActionAccessRequestReject  = "access_request.reject"
+	AuditActionAccessRequestCancel  = "access_request.cancel"
+	AuditActionSessionLegalHold     = "session.legal_hold"
+	AuditActionSessionPurge         = "session.purge"
+	AuditActionDatabaseBackup       = "database.backup"
+	AuditActionDatabaseExport       = "database.export"
+	AuditActionDatabaseImport       = "database.import"
+	AuditActionTokenCreate          = "token.create"
+	AuditActionTokenDelete          = "token.delete"
+	AuditActionMasterKeyUpdateAll   = "master_key.update_all"
+
+	SearchMatchTerms    = "terms"    // all words in any order
+	SearchMatchPhrase   = "phrase"   // words in exact order
+	SearchMatchWildcard = "wildcard" // a word with '*' and '?'
+
+	SearchDefaultSize = 20
+	SearchMaxSize     = 100
+	// SearchMaxWindow max from + size of search, same as default index.max_result_window of elasticsearch
+	SearchMaxWindow = 10000
+
+	ReplayVerifyValid     = "valid"     // digest and signature match
+	ReplayVerifyUnsigned  = "unsigned"  // no digest recorded, replay is recorded before signing or still being written
+	ReplayVerifyNoDigest  = "no_digest" // no digest recorded, but the replay is finished after signing is enabled
+	ReplayVerifyMissing   = "missing"   // replay file not found
+	ReplayVerifyUntrusted = "untrusted" // signed by a key not trusted
+	ReplayVerifyTampered  = "tampered"  // replay or its digest is altered
+
 	ReplayFrameTypeStdout     = uint32(1)
 	ReplayFrameTypeStderr     = uint32(2)
 	ReplayFrameTypeWindowSize = uint32(3)
@@ -32,6 +115,8 @@ var (
 	UserNicknameMaxLength = 16
 	UserPasswordMinLength = 6
 
+	TokenNameMaxLength = 64
+
 	NodeHostnamePattern = regexp.MustCompile(`[0-9a-zA-Z_.-]{4,64}`)
 	NodeUserPattern     = UserAccountPattern
 	NodeAddressPattern  = regexp.MustCompile(`^[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}(:\d+)?$`)
@@ -41,6 +126,24 @@ var (
 	GrantHostnamePatternPattern = regexp.MustCompile(`[0-9a-zA-Z_.*-]{4,64}`)
 	GrantUserPattern            = UserAccountPattern
 
+	VolumeNamePattern          = regexp.MustCompile(`^[a-zA-Z0-9][0-9a-zA-Z_.-]{1,31}$`)
+	VolumeDescriptionMaxLength = 64
+
+	TransferSHA256Pattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+	GroupNamePattern          = regexp.MustCompile(`^[a-zA-Z0-9][0-9a-zA-Z_.-]{1,31}$`)
+	GroupDescriptionMaxLength = 64
+
+	AccessRequestMaxDuration            = int64(7 * 24 * 3600)
+	AccessRequestJustificationMaxLength = 256
+	AccessRequestCommentMaxLength       = 256
+
+	AuditEventsDefaultLimit = int32(50)
+	AuditEventsMaxLimit     = int32(1000)
+
+	LabelKeyPattern   = regexp.MustCompile(`^[a-zA-Z0-9][0-9a-zA-Z_./-]{0,62}$`)
+	LabelValuePattern = regexp.MustCompile(`^[0-9a-zA-Z_./-]{0,63}$`)
+
 	errInvalidFingerprint = errInvalidField("fingerprint", "a valid ssh sha256 fingerprint of public key")
 )
 
@@ -79,6 +182,29 @@ func (m *CreateUserRequest) Validate() (err error) {
 	} else if len(m.Nickname) == 0 {
 		m.Nickname = m.Account
 	}
+	trimSpace(&m.Source)
+	if len(m.Source) == 0 {
+		m.Source = UserSourceManual
+	} else if m.Source != UserSourceManual && m.Source != UserSourceLDAP && m.Source != UserSourceOIDC {
+		err = errInvalidField("source", "one of 'manual', 'ldap' or 'oidc'")
+		return
+	}
+	// users created by OIDC are bound to the issuer and subject
+	trimSpace(&m.OidcIssuer)
+	trimSpace(&m.OidcSubject)
+	if m.Source == UserSourceOIDC {
+		if len(m.OidcIssuer) == 0 {
+			err = errMissingField("oidc_issuer")
+			return
+		}
+		if len(m.OidcSubject) == 0 {
+			err = errMissingField("oidc_subject")
+			return
+		}
+	} else if len(m.OidcIssuer) > 0 || len(m.OidcSubject) > 0 {
+		err = errInvalidField("oidc_issuer", "empty for users not created by OIDC")
+		return
+	}
 	return
 }
 
@@ -99,6 +225,11 @@ func (m *UpdateUserRequest) Validate() (err error) {
 			return
 		}
 	}
+	if m.UpdateRoles {
+		if err = validateRoles(m.Roles); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -147,6 +278,9 @@ func (m *PutNodeRequest) Validate() (err error) {
 		err = errInvalidField("source", "one of 'manual' or 'consul'")
 		return
 	}
+	if err = validateLabels(m.Labels); err != nil {
+		return
+	}
 	return
 }
 
@@ -174,6 +308,25 @@ func (m *UpdateNodeRequest) Validate() (err error) {
 		err = errMissingField("hostname")
 		return
 	}
+	if m.UpdateLabels {
+		if err = validateLabels(m.Labels); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func validateLabels(labels map[string]string) (err error) {
+	for k, v := range labels {
+		if !LabelKeyPattern.MatchString(k) {
+			err = errInvalidField("labels", "keys of letters, digits, '_', '.', '/' and '-'")
+			return
+		}
+		if !LabelValuePattern.MatchString(v) {
+			err = errInvalidField("labels", "values of letters, digits, '_', '.', '/' and '-'")
+			return
+		}
+	}
 	return
 }
 
@@ -196,8 +349,8 @@ func (m *CreateKeyRequest) Validate() (err error) {
 	if len(m.Source) == 0 {
 		m.Source = KeySourceManual
 	}
-	if m.Source != KeySourceManual && m.Source != KeySourceSandbox {
-		err = errInvalidField("source", "one of 'manual' or 'sandbox'")
+	if m.Source != KeySourceManual && m.Source != KeySourceSandbox && m.Source != KeySourceLDAP {
+		err = errInvalidField("source", "one of 'manual', 'sandbox' or 'ldap'")
 		return
 	}
 	return
@@ -239,14 +392,29 @@ func (m *TouchKeyRequest) Validate() (err error) {
 	return
 }
 
-func (m *PutGrantRequest) Validate() (err error) {
-	trimSpace(&m.Account)
-	if len(m.Account) == 0 {
+// validateGrantTarget grant targets either an account or a group
+func validateGrantTarget(account *string, group *string) (err error) {
+	trimSpace(account)
+	trimSpace(group)
+	if len(*account) == 0 && len(*group) == 0 {
 		err = errMissingField("account")
 		return
 	}
+	if len(*account) > 0 && len(*group) > 0 {
+		err = errInvalidField("group", "empty if account is specified")
+		return
+	}
+	return
+}
+
+func (m *PutGrantRequest) Validate() (err error) {
+	if err = validateGrantTarget(&m.Account, &m.Group); err != nil {
+		return
+	}
 	trimSpace(&m.HostnamePattern)
-	if !GrantHostnamePatternPattern.MatchString(m.HostnamePattern) {
+	trimSpace(&m.LabelSelector)
+	// hostname pattern is optional if label selector is specified
+	if (len(m.HostnamePattern) > 0 || len(m.LabelSelector) == 0) && !GrantHostnamePatternPattern.MatchString(m.HostnamePattern) {
 		err = errInvalidField("hostname_pattern", "valid hostname pattern with options wildcards")
 		return
 	}
@@ -261,22 +429,16 @@ func (m *PutGrantRequest) Validate() (err error) {
 }
 
 func (m *ListGrantsRequest) Validate() (err error) {
-	trimSpace(&m.Account)
-	if len(m.Account) == 0 {
-		err = errMissingField("account")
-		return
-	}
-	return
+	return validateGrantTarget(&m.Account, &m.Group)
 }
 
 func (m *DeleteGrantRequest) Validate() (err error) {
-	trimSpace(&m.Account)
-	if len(m.Account) == 0 {
-		err = errMissingField("account")
+	if err = validateGrantTarget(&m.Account, &m.Group); err != nil {
 		return
 	}
 	trimSpace(&m.HostnamePattern)
-	if !GrantHostnamePatternPattern.MatchString(m.HostnamePattern) {
+	trimSpace(&m.LabelSelector)
+	if (len(m.HostnamePattern) > 0 || len(m.LabelSelector) == 0) && !GrantHostnamePatternPattern.MatchString(m.HostnamePattern) {
 		err = errInvalidField("hostname_pattern", "valid hostname pattern with wildcard support")
 		return
 	}
@@ -323,6 +485,32 @@ func (m *CreateSessionRequest) Validate() (err error) {
 		return
 	}
 	trimSpace(&m.Command)
+	trimSpace(&m.Stage)
+	if len(m.Stage) == 0 {
+		m.Stage = SessionStageLv1
+	} else if m.Stage != SessionStageLv1 && m.Stage != SessionStageLv2 {
+		err = errInvalidField("stage", "one of 'lv1' or 'lv2'")
+		return
+	}
+	trimSpace(&m.Hostname)
+	trimSpace(&m.User)
+	if m.Stage == SessionStageLv2 {
+		if len(m.Hostname) == 0 {
+			err = errMissingField("hostname")
+			return
+		}
+		if len(m.User) == 0 {
+			err = errMissingField("user")
+			return
+		}
+	} else if m.ParentId != 0 {
+		err = errInvalidField("parent_id", "zero for lv1 session")
+		return
+	}
+	if m.PtyWidth < 0 || m.PtyHeight < 0 {
+		err = errInvalidField("pty_width/pty_height", "positive or zero")
+		return
+	}
 	return
 }
 
@@ -334,6 +522,14 @@ func (m *FinishSessionRequest) Validate() (err error) {
 	return
 }
 
+func (m *UpdateSessionLegalHoldRequest) Validate() (err error) {
+	if m.Id == 0 {
+		err = errMissingField("id")
+		return
+	}
+	return
+}
+
 func (m *ListSessionsRequest) Validate() (err error) {
 	if m.Skip < 0 {
 		err = errInvalidField("skip", "positive or zero")
@@ -343,6 +539,17 @@ func (m *ListSessionsRequest) Validate() (err error) {
 		err = errInvalidField("limit", "positive")
 		return
 	}
+	if m.Cursor < 0 {
+		err = errInvalidField("cursor", "positive or zero")
+		return
+	}
+	if m.Since < 0 || m.Until < 0 {
+		err = errInvalidField("since/until", "positive or zero")
+		return
+	}
+	trimSpace(&m.Account)
+	trimSpace(&m.Hostname)
+	trimSpace(&m.Command)
 	return
 }
 
@@ -353,6 +560,42 @@ func (m *CreateTokenRequest) Validate() (err error) {
 		return
 	}
 	trimSpace(&m.Description)
+	trimSpace(&m.Kind)
+	if len(m.Kind) == 0 {
+		m.Kind = TokenKindSession
+	} else if m.Kind != TokenKindSession && m.Kind != TokenKindPersonal {
+		err = errInvalidField("kind", "one of 'session' or 'personal'")
+		return
+	}
+	trimSpace(&m.Name)
+	if len(m.Name) > TokenNameMaxLength {
+		err = errInvalidField("name", fmt.Sprintf("shorter than %d characterstics", TokenNameMaxLength))
+		return
+	}
+	if err = validateTokenScopes(m.Scopes); err != nil {
+		return
+	}
+	if m.Kind == TokenKindPersonal {
+		// personal access tokens are used for automation, name and scopes must be explicit
+		if len(m.Name) == 0 {
+			err = errMissingField("name")
+			return
+		}
+		if len(m.Scopes) == 0 {
+			err = errMissingField("scopes")
+			return
+		}
+	} else if len(m.Scopes) == 0 {
+		m.Scopes = []string{TokenScopeAdmin}
+	}
+	if m.ExpiresAt < 0 {
+		err = errInvalidField("expires_at", "non-negative")
+		return
+	}
+	if m.IdleTimeout < 0 {
+		err = errInvalidField("idle_timeout", "non-negative")
+		return
+	}
 	return
 }
 
@@ -382,12 +625,64 @@ func (m *SubmitReplayRequest) Validate() (err error) {
 	return
 }
 
+func (m *GetTranscriptRequest) Validate() (err error) {
+	if m.SessionId == 0 {
+		err = errMissingField("session_id")
+		return
+	}
+	return
+}
+
+func (m *VerifyReplayRequest) Validate() (err error) {
+	if m.SessionId == 0 {
+		err = errMissingField("session_id")
+		return
+	}
+	return
+}
+
 func (m *SearchReplayRequest) Validate() (err error) {
 	trimSpace(&m.Keyword)
 	if len(m.Keyword) < 3 {
 		err = errInvalidField("keyword", "longer than 3")
 		return
 	}
+	trimSpace(&m.Match)
+	if len(m.Match) == 0 {
+		m.Match = SearchMatchTerms
+	} else if m.Match != SearchMatchTerms && m.Match != SearchMatchPhrase && m.Match != SearchMatchWildcard {
+		err = errInvalidField("match", "one of 'terms', 'phrase' or 'wildcard'")
+		return
+	}
+	if m.Match == SearchMatchWildcard && strings.ContainsAny(m.Keyword, " \t") {
+		err = errInvalidField("keyword", "a single word for wildcard")
+		return
+	}
+	if m.Match == SearchMatchWildcard && strings.IndexAny(m.Keyword, "*?") == 0 {
+		err = errInvalidField("keyword", "not starting with '*' or '?' for wildcard")
+		return
+	}
+	trimSpace(&m.Account)
+	trimSpace(&m.Hostname)
+	if m.Since < 0 || m.Until < 0 {
+		err = errInvalidField("since/until", "positive or zero")
+		return
+	}
+	if m.From < 0 {
+		err = errInvalidField("from", "positive or zero")
+		return
+	}
+	if m.Size < 0 || m.Size > SearchMaxSize {
+		err = errInvalidField("size", fmt.Sprintf("between 0 and %d", SearchMaxSize))
+		return
+	}
+	if m.Size == 0 {
+		m.Size = SearchDefaultSize
+	}
+	if m.From+m.Size > SearchMaxWindow {
+		err = errInvalidField("from", fmt.Sprintf("from + size not greater than %d", SearchMaxWindow))
+		return
+	}
 	return
 }
 
@@ -399,3 +694,293 @@ func (m *TouchTokenRequest) Validate() (err error) {
 func (m *DeleteTokenRequest) Validate() (err error) {
 	return
 }
+
+func (m *PutVolumeRequest) Validate() (err error) {
+	trimSpace(&m.Name)
+	if !VolumeNamePattern.MatchString(m.Name) {
+		err = errInvalidField("name", "valid volume name")
+		return
+	}
+	trimSpace(&m.Description)
+	if len(m.Description) > VolumeDescriptionMaxLength {
+		err = errInvalidField("description", fmt.Sprintf("shorter than %d characterstics", VolumeDescriptionMaxLength))
+		return
+	}
+	return
+}
+
+func (m *DeleteVolumeRequest) Validate() (err error) {
+	trimSpace(&m.Name)
+	if len(m.Name) == 0 {
+		err = errMissingField("name")
+		return
+	}
+	return
+}
+
+func (m *ListVolumeMembersRequest) Validate() (err error) {
+	trimSpace(&m.Volume)
+	if len(m.Volume) == 0 {
+		err = errMissingField("volume")
+		return
+	}
+	return
+}
+
+func (m *PutVolumeMemberRequest) Validate() (err error) {
+	trimSpace(&m.Volume)
+	if len(m.Volume) == 0 {
+		err = errMissingField("volume")
+		return
+	}
+	trimSpace(&m.Kind)
+	if len(m.Kind) == 0 {
+		m.Kind = VolumeMemberKindUser
+	} else if m.Kind != VolumeMemberKindUser && m.Kind != VolumeMemberKindGroup {
+		err = errInvalidField("kind", "one of 'user' or 'group'")
+		return
+	}
+	trimSpace(&m.Name)
+	if len(m.Name) == 0 {
+		err = errMissingField("name")
+		return
+	}
+	return
+}
+
+func (m *DeleteVolumeMemberRequest) Validate() (err error) {
+	trimSpace(&m.Volume)
+	if len(m.Volume) == 0 {
+		err = errMissingField("volume")
+		return
+	}
+	trimSpace(&m.Kind)
+	if len(m.Kind) == 0 {
+		m.Kind = VolumeMemberKindUser
+	}
+	trimSpace(&m.Name)
+	if len(m.Name) == 0 {
+		err = errMissingField("name")
+		return
+	}
+	return
+}
+
+func (m *ListVolumeMountsRequest) Validate() (err error) {
+	trimSpace(&m.Account)
+	if len(m.Account) == 0 {
+		err = errMissingField("account")
+		return
+	}
+	return
+}
+
+func (m *CreateTransferRequest) Validate() (err error) {
+	trimSpace(&m.Account)
+	if len(m.Account) == 0 {
+		err = errMissingField("account")
+		return
+	}
+	trimSpace(&m.Source)
+	if m.Source != TransferSourceWeb && m.Source != TransferSourceSCP {
+		err = errInvalidField("source", "one of 'web' or 'scp'")
+		return
+	}
+	trimSpace(&m.Direction)
+	if m.Direction != TransferDirectionUpload && m.Direction != TransferDirectionDownload {
+		err = errInvalidField("direction", "one of 'upload' or 'download'")
+		return
+	}
+	trimSpace(&m.Filename)
+	if len(m.Filename) == 0 {
+		err = errMissingField("filename")
+		return
+	}
+	if m.Size < 0 {
+		err = errInvalidField("size", "positive or zero")
+		return
+	}
+	trimSpace(&m.Sha256)
+	if !TransferSHA256Pattern.MatchString(m.Sha256) {
+		err = errInvalidField("sha256", "hex encoded sha256 checksum")
+		return
+	}
+	return
+}
+
+func (m *ListTransfersRequest) Validate() (err error) {
+	trimSpace(&m.Account)
+	return
+}
+
+func (m *ListGroupsRequest) Validate() (err error) {
+	trimSpace(&m.Account)
+	return
+}
+
+func (m *PutGroupRequest) Validate() (err error) {
+	trimSpace(&m.Name)
+	if !GroupNamePattern.MatchString(m.Name) {
+		err = errInvalidField("name", "valid group name")
+		return
+	}
+	trimSpace(&m.Description)
+	if len(m.Description) > GroupDescriptionMaxLength {
+		err = errInvalidField("description", fmt.Sprintf("shorter than %d characterstics", GroupDescriptionMaxLength))
+		return
+	}
+	if m.UpdateRoles {
+		if err = validateRoles(m.Roles); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (m *DeleteGroupRequest) Validate() (err error) {
+	trimSpace(&m.Name)
+	if len(m.Name) == 0 {
+		err = errMissingField("name")
+		return
+	}
+	return
+}
+
+func (m *ListGroupMembersRequest) Validate() (err error) {
+	trimSpace(&m.Group)
+	if len(m.Group) == 0 {
+		err = errMissingField("group")
+		return
+	}
+	return
+}
+
+func (m *PutGroupMemberRequest) Validate() (err error) {
+	trimSpace(&m.Group)
+	if len(m.Group) == 0 {
+		err = errMissingField("group")
+		return
+	}
+	trimSpace(&m.Account)
+	if len(m.Account) == 0 {
+		err = errMissingField("account")
+		return
+	}
+	trimSpace(&m.Source)
+	if len(m.Source) == 0 {
+		m.Source = GroupMemberSourceManual
+	} else if m.Source != GroupMemberSourceManual && m.Source != GroupMemberSourceLDAP && m.Source != GroupMemberSourceOIDC {
+		err = errInvalidField("source", "one of 'manual', 'ldap' or 'oidc'")
+		return
+	}
+	return
+}
+
+func (m *DeleteGroupMemberRequest) Validate() (err error) {
+	trimSpace(&m.Group)
+	if len(m.Group) == 0 {
+		err = errMissingField("group")
+		return
+	}
+	trimSpace(&m.Account)
+	if len(m.Account) == 0 {
+		err = errMissingField("account")
+		return
+	}
+	return
+}
+
+func (m *CreateAccessRequestRequest) Validate() (err error) {
+	trimSpace(&m.Account)
+	if len(m.Account) == 0 {
+		err = errMissingField("account")
+		return
+	}
+	trimSpace(&m.HostnamePattern)
+	if !GrantHostnamePatternPattern.MatchString(m.HostnamePattern) {
+		err = errInvalidField("hostname_pattern", "valid hostname pattern with options wildcards")
+		return
+	}
+	trimSpace(&m.User)
+	if len(m.User) == 0 {
+		m.User = NodeUserRoot
+	} else if !GrantUserPattern.MatchString(m.User) {
+		err = errInvalidField("user", "a valid linux user")
+		return
+	}
+	if m.Duration <= 0 || m.Duration > AccessRequestMaxDuration {
+		err = errInvalidField("duration", fmt.Sprintf("positive seconds no more than %d", AccessRequestMaxDuration))
+		return
+	}
+	trimSpace(&m.Justification)
+	if len(m.Justification) == 0 {
+		err = errMissingField("justification")
+		return
+	}
+	if len(m.Justification) > AccessRequestJustificationMaxLength {
+		err = errInvalidField("justification", fmt.Sprintf("shorter than %d characterstics", AccessRequestJustificationMaxLength))
+		return
+	}
+	return
+}
+
+func (m *ListAccessRequestsRequest) Validate() (err error) {
+	trimSpace(&m.Account)
+	trimSpace(&m.Status)
+	switch m.Status {
+	case "", AccessRequestStatusPending, AccessRequestStatusApproved, AccessRequestStatusRejected, AccessRequestStatusCancelled:
+	default:
+		err = errInvalidField("status", "one of pending, approved, rejected or cancelled")
+		return
+	}
+	return
+}
+
+func (m *GetAccessRequestRequest) Validate() (err error) {
+	if m.Id == 0 {
+		err = errMissingField("id")
+		return
+	}
+	return
+}
+
+func (m *ReviewAccessRequestRequest) Validate() (err error) {
+	if m.Id == 0 {
+		err = errMissingField("id")
+		return
+	}
+	trimSpace(&m.Reviewer)
+	trimSpace(&m.Comment)
+	if len(m.Comment) > AccessRequestCommentMaxLength {
+		err = errInvalidField("comment", fmt.Sprintf("shorter than %d characterstics", AccessRequestCommentMaxLength))
+		return
+	}
+	return
+}
+
+func (m *CancelAccessRequestRequest) Validate() (err error) {
+	if m.Id == 0 {
+		err = errMissingField("id")
+		return
+	}
+	trimSpace(&m.Account)
+	return
+}
+
+func (m *ListAuditEventsRequest) Validate() (err error) {
+	trimSpace(&m.Actor)
+	trimSpace(&m.Action)
+	trimSpace(&m.Target)
+	trimSpace(&m.Source)
+	if m.Skip < 0 {
+		err = errInvalidField("skip", "positive or zero")
+		return
+	}
+	if m.Limit < 0 || m.Limit > AuditEventsMaxLimit {
+		err = errInvalidField("limit", fmt.Sprintf("positive and no more than %d", AuditEventsMaxLimit))
+		return
+	} else if m.Limit == 0 {
+		m.Limit = AuditEventsDefaultLimit
+	}
+	return
+}
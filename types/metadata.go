@@ -0,0 +1,208 @@
+package types
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// MetadataKeyActor grpc metadata key of the account on whose behalf a request is made
+	MetadataKeyActor = "x-bastion-actor"
+	// MetadataKeySource grpc metadata key of the component making the request
+	MetadataKeySource = "x-bastion-source"
+	// MetadataKeyClientAddr grpc metadata key of the end user address, forwarded by web
+	MetadataKeyClientAddr = "x-bastion-client-addr"
+
+	SourceWeb          = "web"
+	SourceSSHD         = "sshd"
+	SourceBastionAdmin = "bastionadmin"
+	SourceConsul       = "consul"
+	SourceLDAP         = "ldap"
+)
+
+// sshdMethods rpc methods called by sshd, all of them are system calls without actor,
+// master keys are set by operator with bastionadmin, sshd only lists them
+var sshdMethods = []string{
+	"/types.UserService/GetUser",
+	"/types.UserService/TouchUser",
+	"/types.KeyService/GetKey",
+	"/types.KeyService/TouchKey",
+	"/types.KeyService/CreateKey",
+	"/types.NodeService/ListNodes",
+	"/types.NodeService/GetNode",
+	"/types.MasterKeyService/ListMasterKeys",
+	"/types.GrantService/CheckGrant",
+	"/types.GrantService/ListGrantItems",
+	"/types.GrantService/WatchGrants",
+	"/types.SessionService/CreateSession",
+	"/types.SessionService/FinishSession",
+	"/types.ReplayService/WriteReplay",
+	"/types.TransferService/CreateTransfer",
+	"/types.VolumeService/ListVolumeMounts",
+}
+
+// bastionAdminMethods rpc methods called by bastionadmin, bastionadmin is operated on the daemon host and trusted
+// to make system calls, calls with --actor are checked by roles of the actor
+var bastionAdminMethods = []string{
+	"/types.UserService/ListUsers",
+	"/types.UserService/CreateUser",
+	"/types.UserService/UpdateUser",
+	"/types.NodeService/ListNodes",
+	"/types.NodeService/PutNode",
+	"/types.NodeService/UpdateNode",
+	"/types.KeyService/ListKeys",
+	"/types.KeyService/CreateKey",
+	"/types.KeyService/DeleteKey",
+	"/types.MasterKeyService/ListMasterKeys",
+	"/types.MasterKeyService/UpdateAllMasterKeys",
+	"/types.GrantService/PutGrant",
+	"/types.GrantService/ListGrants",
+	"/types.GrantService/DeleteGrant",
+	"/types.SessionService/CreateSession",
+	"/types.SessionService/FinishSession",
+	"/types.SessionService/ListSessions",
+	"/types.SessionService/GetSession",
+	"/types.SessionService/UpdateSessionLegalHold",
+	"/types.ReplayService/ReadReplay",
+	"/types.ReplayService/SubmitReplay",
+	"/types.ReplayService/GetTranscript",
+	"/types.ReplayService/VerifyReplay",
+	"/types.VolumeService/ListVolumes",
+	"/types.VolumeService/PutVolume",
+	"/types.VolumeService/DeleteVolume",
+	"/types.VolumeService/ListVolumeMembers",
+	"/types.VolumeService/PutVolumeMember",
+	"/types.VolumeService/DeleteVolumeMember",
+	"/types.GroupService/*",
+	"/types.AccessRequestService/*",
+	"/types.BackupService/*",
+}
+
+// DefaultCallerMethods rpc methods allowed for every caller if TLS is enabled, callers are identified by common name of client certificates,
+// web and bastionadmin act on behalf of users and are further checked by roles of the actor
+var DefaultCallerMethods = map[string][]string{
+	SourceWeb: {
+		"/types.UserService/ListUsers",
+		"/types.UserService/CreateUser",
+		"/types.UserService/TouchUser",
+		"/types.UserService/UpdateUser",
+		"/types.UserService/AuthenticateUser",
+		"/types.UserService/GetUser",
+		"/types.NodeService/ListNodes",
+		"/types.NodeService/PutNode",
+		"/types.NodeService/DeleteNode",
+		"/types.NodeService/GetNode",
+		"/types.NodeService/UpdateNode",
+		"/types.KeyService/ListKeys",
+		"/types.KeyService/CreateKey",
+		"/types.KeyService/DeleteKey",
+		"/types.KeyService/GetKey",
+		"/types.MasterKeyService/ListMasterKeys",
+		"/types.GrantService/PutGrant",
+		"/types.GrantService/ListGrants",
+		"/types.GrantService/DeleteGrant",
+		"/types.GrantService/CheckGrant",
+		"/types.GrantService/ListGrantItems",
+		"/types.SessionService/CreateSession",
+		"/types.SessionService/FinishSession",
+		"/types.SessionService/ListSessions",
+		"/types.SessionService/GetSession",
+		"/types.SessionService/UpdateSessionLegalHold",
+		"/types.TokenService/CreateToken",
+		"/types.TokenService/GetToken",
+		"/types.TokenService/TouchToken",
+		"/types.TokenService/ListTokens",
+		"/types.TokenService/DeleteToken",
+		"/types.ReplayService/WriteReplay",
+		"/types.ReplayService/ReadReplay",
+		"/types.ReplayService/SearchReplay",
+		"/types.ReplayService/GetTranscript",
+		"/types.TransferService/CreateTransfer",
+		"/types.TransferService/ListTransfers",
+		"/types.VolumeService/ListVolumeMounts",
+		"/types.GroupService/ListGroups",
+		"/types.GroupService/PutGroup",
+		"/types.GroupService/DeleteGroup",
+		"/types.GroupService/ListGroupMembers",
+		"/types.GroupService/PutGroupMember",
+		"/types.GroupService/DeleteGroupMember",
+		"/types.AccessRequestService/*",
+		"/types.AuditService/ListAuditEvents",
+	},
+	SourceBastionAdmin: bastionAdminMethods,
+	SourceSSHD:         sshdMethods,
+	SourceConsul: {
+		"/types.NodeService/*",
+	},
+	SourceLDAP: {
+		"/types.UserService/*",
+		"/types.KeyService/*",
+		"/types.GroupService/*",
+	},
+}
+
+// DefaultSystemMethods rpc methods every caller may call without actor if TLS is enabled, calls without actor skip role checks,
+// web only makes system calls to authenticate and provision users and to prepare sandboxes of terminals
+var DefaultSystemMethods = map[string][]string{
+	SourceWeb: {
+		"/types.UserService/GetUser",
+		"/types.UserService/TouchUser",
+		"/types.UserService/AuthenticateUser",
+		"/types.UserService/CreateUser",
+		"/types.UserService/UpdateUser",
+		"/types.TokenService/GetToken",
+		"/types.TokenService/TouchToken",
+		"/types.TokenService/CreateToken",
+		"/types.GroupService/ListGroups",
+		"/types.GroupService/ListGroupMembers",
+		"/types.GroupService/PutGroupMember",
+		"/types.GroupService/DeleteGroupMember",
+		"/types.KeyService/CreateKey",
+		"/types.GrantService/ListGrantItems",
+		"/types.VolumeService/ListVolumeMounts",
+		"/types.ReplayService/WriteReplay",
+	},
+	SourceBastionAdmin: bastionAdminMethods,
+	SourceSSHD:         sshdMethods,
+	SourceConsul: {
+		"/types.NodeService/*",
+	},
+	SourceLDAP: {
+		"/types.UserService/*",
+		"/types.KeyService/*",
+		"/types.GroupService/*",
+	},
+}
+
+func appendClientMetadata(ctx context.Context, source string, actor string) context.Context {
+	kv := []string{MetadataKeySource, source}
+	if len(actor) > 0 {
+		kv = append(kv, MetadataKeyActor, actor)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// DialOptions dial options for daemon clients, source and optional actor are attached to every rpc call,
+// connection is secured with client certificate if TLS is enabled
+func DialOptions(source string, actor string, tlsOpts TLSOptions) (opts []grpc.DialOption, err error) {
+	if tlsOpts.Enabled() {
+		var c credentials.TransportCredentials
+		if c, err = tlsOpts.ClientCredentials(); err != nil {
+			return
+		}
+		opts = append(opts, grpc.WithTransportCredentials(c))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	opts = append(opts,
+		grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(appendClientMetadata(ctx, source, actor), method, req, reply, cc, opts...)
+		}),
+		grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(appendClientMetadata(ctx, source, actor), desc, cc, method, opts...)
+		}),
+	)
+	return
+}
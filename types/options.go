@@ -5,10 +5,27 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
 
+const (
+	DBDriverBolt     = "bolt"
+	DBDriverSQLite   = "sqlite3"
+	DBDriverPostgres = "postgres"
+)
+
+const (
+	ReplayStorageLocal = "local"
+	ReplayStorageS3    = "s3"
+)
+
+const (
+	SearchBackendElasticsearch = "elasticsearch"
+	SearchBackendEmbedded      = "embedded"
+)
+
 // Options options for bastion
 type Options struct {
 	// Daemon daemon options
@@ -31,20 +48,70 @@ type DaemonOptions struct {
 	// Dev dev mode
 	Dev bool `yaml:"dev"`
 
-	// DB database file path, using bolt, default to "/var/lib/bastion/database"
+	// DBDriver database driver, "bolt", "sqlite3" or "postgres", default to "bolt",
+	// daemons can share a postgres database, grant changes made by other daemons reach WatchGrants streams within 10 seconds
+	DBDriver string `yaml:"db_driver"`
+
+	// DB database file path for bolt, data source name for sqlite3 and postgres,
+	// default to "/var/lib/bastion/database.bolt" for bolt and "/var/lib/bastion/database.sqlite3" for sqlite3
 	DB string `yaml:"db"`
 
-	// elasticsearch urls, default to "http://127.0.0.1:9200"
+	// SearchBackend search backend of replays, "elasticsearch" or "embedded", default to "elasticsearch" for existing
+	// deployments, installations without elasticsearch must set "embedded", which keeps a full-text index in local file,
+	// searches of "embedded" verify at most 10000 newest candidates, suits a single daemon with moderate replays
+	SearchBackend string `yaml:"search_backend"`
+
+	// elasticsearch urls, default to "http://127.0.0.1:9200", only for "elasticsearch" search backend
 	Elasticsearch []string `yaml:"elasticsearch"`
 
+	// SearchIndex file of embedded search index, default to "/var/lib/bastion/search.bolt", only for "embedded" search backend
+	SearchIndex string `yaml:"search_index"`
+
 	// Host host to bind for bastion rpc, default to "127.0.0.1"
 	Host string `yaml:"host"`
 
 	// Port port to bind for bastion rpc, default to 9777
 	Port int `yaml:"port"`
 
-	// ReplayDir directory of replay files
+	// ReplayStorage storage backend of replays, "local" or "s3", default to "local",
+	// daemons can share a S3-compatible object storage
+	ReplayStorage string `yaml:"replay_storage"`
+
+	// ReplayDir directory of replay files, only for "local" replay storage
 	ReplayDir string `yaml:"replay_dir"`
+
+	// S3 S3-compatible object storage of replays, only for "s3" replay storage
+	S3 S3Options `yaml:"s3"`
+
+	// Retention retention of replays, replays are kept forever if not configured
+	Retention RetentionOptions `yaml:"retention"`
+
+	// SigningKey ed25519 key file signing digests of replays, default to "/var/lib/bastion/signing.key",
+	// created if not exists, an ephemeral key is used if empty, the creation time is recorded in the file,
+	// anyone able to read the key can re-sign altered replays, keep it apart from the database and replays,
+	// i.e. on a separate volume or a mounted secret
+	SigningKey string `yaml:"signing_key"`
+
+	// SigningSince unix time since which replays are signed, default to the time recorded in the signing key file,
+	// finished replays of sessions created since then without a digest fail verification,
+	// set the same value on every daemon sharing the database
+	SigningSince int64 `yaml:"signing_since"`
+
+	// TrustedSigningKeys base64 public keys of other daemons sharing the database and replay storage,
+	// replays signed by these keys are also verified
+	TrustedSigningKeys []string `yaml:"trusted_signing_keys"`
+
+	// TLS serve rpc with TLS, client certificates signed by the CA are required
+	TLS TLSOptions `yaml:"tls"`
+
+	// Callers rpc methods allowed for every caller, keyed by common name of client certificate,
+	// full method names like "/types.SessionService/CreateSession", asterisk (*) supported,
+	// default to DefaultCallerMethods, only applies if TLS is enabled
+	Callers map[string][]string `yaml:"callers"`
+
+	// SystemMethods rpc methods every caller may call without actor, keyed by common name of client certificate,
+	// same format as Callers, default to DefaultSystemMethods, only applies if TLS is enabled
+	SystemMethods map[string][]string `yaml:"system_methods"`
 }
 
 func (o DaemonOptions) String() string {
@@ -52,6 +119,78 @@ func (o DaemonOptions) String() string {
 	return "DaemonOptions" + string(buf)
 }
 
+// RetentionOptions retention policies of replays, expired replay files and their elasticsearch documents are purged,
+// sessions on legal hold are never purged
+type RetentionOptions struct {
+	// Replays default retention of replays, zero keeps replays forever
+	Replays time.Duration `yaml:"replays"`
+
+	// Rules override the default retention, the first matched rule applies
+	Rules []RetentionRule `yaml:"rules"`
+}
+
+// RetentionRule retention of replays of matched sessions, all conditions set must be satisfied
+type RetentionRule struct {
+	// Account account of the session
+	Account string `yaml:"account"`
+
+	// LabelSelector matches labels of nodes the session accessed, including nodes of lv2 sessions initiated from the sandbox,
+	// for example "env=prod,!deprecated"
+	LabelSelector string `yaml:"label_selector"`
+
+	// Replays retention of replays, zero keeps replays forever
+	Replays time.Duration `yaml:"replays"`
+}
+
+// S3Options S3-compatible object storage options, path-style addressing is used,
+// multipart uploads of a crashed daemon are left incomplete and billed, configure a lifecycle rule of the bucket
+// with AbortIncompleteMultipartUpload, i.e. DaysAfterInitiation 1, to clean them up
+type S3Options struct {
+	// Endpoint url of the object storage service, i.e. "https://s3.us-east-1.amazonaws.com" or "http://127.0.0.1:9000"
+	Endpoint string `yaml:"endpoint"`
+
+	// Region region for request signing, default to "us-east-1"
+	Region string `yaml:"region"`
+
+	// Bucket bucket of replays, must exist
+	Bucket string `yaml:"bucket"`
+
+	// Prefix prefix of object keys, i.e. "replays/"
+	Prefix string `yaml:"prefix"`
+
+	// AccessKey access key id
+	AccessKey string `yaml:"access_key"`
+
+	// SecretKey secret access key
+	SecretKey string `yaml:"secret_key" json:"-"`
+
+	// PartSize part size of multipart uploads in bytes, default to and at least 5MiB
+	PartSize int64 `yaml:"part_size"`
+
+	// Timeout timeout of each request, default to "1m", replays being read are streamed without timeout once responded
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// TLSOptions mutual TLS options of bastion daemon rpc service, disabled if cert is empty
+type TLSOptions struct {
+	// CA certificate file of CA, verifies certificates of the other side
+	CA string `yaml:"ca"`
+
+	// Cert certificate file, common name of client certificate is the caller identity
+	Cert string `yaml:"cert"`
+
+	// Key private key file of certificate
+	Key string `yaml:"key"`
+
+	// ServerName overrides server name verified by clients, default to host of daemon endpoint
+	ServerName string `yaml:"server_name"`
+}
+
+// Enabled returns whether TLS is configured
+func (o TLSOptions) Enabled() bool {
+	return len(o.Cert) > 0
+}
+
 // WebOptions web options for bastion
 type WebOptions struct {
 	// SSHDomain ssh target for display
@@ -68,6 +207,18 @@ type WebOptions struct {
 
 	// DaemonEndpoint address of bastion daemon rpc service, default to "127.0.0.1:9777"
 	DaemonEndpoint string `yaml:"daemon_endpoint"`
+
+	// TLS client certificate for bastion daemon rpc service
+	TLS TLSOptions `yaml:"tls"`
+
+	// OIDC OpenID Connect login, disabled if issuer is empty
+	OIDC OIDCOptions `yaml:"oidc"`
+
+	// TokenTTL lifetime of tokens created by login, default to "168h", personal access tokens are not affected
+	TokenTTL time.Duration `yaml:"token_ttl"`
+
+	// TokenIdleTimeout tokens created by login expire if not used within, default to "24h"
+	TokenIdleTimeout time.Duration `yaml:"token_idle_timeout"`
 }
 
 func (o WebOptions) String() string {
@@ -75,6 +226,48 @@ func (o WebOptions) String() string {
 	return "WebOptions" + string(buf)
 }
 
+// OIDCOptions OpenID Connect options for bastion web
+type OIDCOptions struct {
+	// Issuer url of issuer, discovery document is fetched from "/.well-known/openid-configuration"
+	Issuer string `yaml:"issuer"`
+
+	// ClientID client id registered in issuer
+	ClientID string `yaml:"client_id"`
+
+	// ClientSecret client secret registered in issuer
+	ClientSecret string `yaml:"client_secret" json:"-"`
+
+	// RedirectURL url of callback, i.e. "https://bastion.example.com/api/oidc/callback"
+	RedirectURL string `yaml:"redirect_url"`
+
+	// Scopes extra scopes besides "openid", default to "profile" and "email"
+	Scopes []string `yaml:"scopes"`
+
+	// AccountClaim claim mapped to account, local part is used for email addresses, default to "preferred_username"
+	AccountClaim string `yaml:"account_claim"`
+
+	// AllowedDomains domains of email addresses accepted as account claim, email addresses are refused if empty
+	AllowedDomains []string `yaml:"allowed_domains"`
+
+	// NicknameClaim claim mapped to nickname, default to "name"
+	NicknameClaim string `yaml:"nickname_claim"`
+
+	// AutoProvision create user on first login
+	AutoProvision bool `yaml:"auto_provision"`
+
+	// GroupsClaim claim of group names, group memberships are synchronized if not empty,
+	// only existing groups are joined
+	GroupsClaim string `yaml:"groups_claim"`
+
+	// AdminGroups members of these groups are admins, only applies to users created by OIDC
+	AdminGroups []string `yaml:"admin_groups"`
+}
+
+// Enabled returns whether OpenID Connect login is configured
+func (o OIDCOptions) Enabled() bool {
+	return len(o.Issuer) > 0
+}
+
 // SSHDOptions sshd options
 type SSHDOptions struct {
 	// Dev development mode, will output colorful logs
@@ -89,6 +282,12 @@ type SSHDOptions struct {
 	// DaemonEndpoint address of bastion daemon rpc service, default to "127.0.0.1:9777"
 	DaemonEndpoint string `yaml:"daemon_endpoint"`
 
+	// TLS client certificate for bastion daemon rpc service
+	TLS TLSOptions `yaml:"tls"`
+
+	// Instance name of this bastion sshd instance, recorded in sessions, default to hostname
+	Instance string `yaml:"instance"`
+
 	// ClientKeys client key file path for bastion ssh proxy
 	// should presents on all target hosts' /root/.ssh/authorized_keys
 	// default to "/etc/bastion/client_rsa"
@@ -126,9 +325,15 @@ func defaultStr(s *string, d string) {
 	}
 }
 
-func defaultSts(s *[]string, d string) {
+func defaultSts(s *[]string, d ...string) {
 	if len(*s) == 0 {
-		*s = []string{d}
+		*s = d
+	}
+}
+
+func defaultDur(i *time.Duration, d time.Duration) {
+	if *i == 0 {
+		*i = d
 	}
 }
 
@@ -158,16 +363,35 @@ func LoadOptions(f string) (opt Options, err error) {
 		return
 	}
 	// default values
-	defaultStr(&opt.Daemon.DB, "/var/lib/bastion/database.bolt")
+	defaultStr(&opt.Daemon.DBDriver, DBDriverBolt)
+	if opt.Daemon.DBDriver == DBDriverBolt {
+		defaultStr(&opt.Daemon.DB, "/var/lib/bastion/database.bolt")
+	} else if opt.Daemon.DBDriver == DBDriverSQLite {
+		defaultStr(&opt.Daemon.DB, "/var/lib/bastion/database.sqlite3")
+	}
+	defaultStr(&opt.Daemon.SearchBackend, SearchBackendElasticsearch)
+	defaultStr(&opt.Daemon.SearchIndex, "/var/lib/bastion/search.bolt")
 	defaultStr(&opt.Daemon.Host, "127.0.0.1")
 	defaultInt(&opt.Daemon.Port, 9777)
+	defaultStr(&opt.Daemon.ReplayStorage, ReplayStorageLocal)
 	defaultStr(&opt.Daemon.ReplayDir, "/var/lib/bastion/replays")
+	defaultStr(&opt.Daemon.S3.Region, "us-east-1")
+	defaultDur(&opt.Daemon.S3.Timeout, time.Minute)
+	defaultStr(&opt.Daemon.SigningKey, "/var/lib/bastion/signing.key")
 	defaultStr(&opt.Web.Host, "127.0.0.1")
 	defaultInt(&opt.Web.Port, 9778)
 	defaultStr(&opt.Web.DaemonEndpoint, "127.0.0.1:9777")
+	defaultDur(&opt.Web.TokenTTL, time.Hour*24*7)
+	defaultDur(&opt.Web.TokenIdleTimeout, time.Hour*24)
+	defaultSts(&opt.Web.OIDC.Scopes, "profile", "email")
+	defaultStr(&opt.Web.OIDC.AccountClaim, "preferred_username")
+	defaultStr(&opt.Web.OIDC.NicknameClaim, "name")
 	defaultStr(&opt.SSHD.Host, "0.0.0.0")
 	defaultInt(&opt.SSHD.Port, 22)
 	defaultStr(&opt.SSHD.DaemonEndpoint, "127.0.0.1:9777")
+	if hostname, err := os.Hostname(); err == nil {
+		defaultStr(&opt.SSHD.Instance, hostname)
+	}
 	defaultSts(&opt.SSHD.ClientKeys, "/etc/bastion/client_rsa")
 	defaultStr(&opt.SSHD.HostKey, "/etc/bastion/host_rsa")
 	defaultStr(&opt.SSHD.SandboxImage, "bastion-sandbox")
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"strings"
+)
+
+const (
+	RoleAuditor    = "auditor"
+	RoleNodeAdmin  = "node-admin"
+	RoleUserAdmin  = "user-admin"
+	RoleSuperAdmin = "super-admin"
+
+	PermissionSessionsRead   = "sessions:read"
+	PermissionSessionsWrite  = "sessions:write"
+	PermissionUsersRead      = "users:read"
+	PermissionUsersWrite     = "users:write"
+	PermissionNodesRead      = "nodes:read"
+	PermissionNodesWrite     = "nodes:write"
+	PermissionGrantsRead     = "grants:read"
+	PermissionGrantsWrite    = "grants:write"
+	PermissionRolesWrite     = "roles:write"
+	PermissionAuditRead      = "audit:read"
+	PermissionDatabaseBackup = "database:backup"
+)
+
+// RolePermissions permissions of every role
+var RolePermissions = map[string][]string{
+	RoleAuditor: {
+		PermissionAuditRead,
+		PermissionSessionsRead,
+		PermissionSessionsWrite,
+		PermissionUsersRead,
+		PermissionNodesRead,
+		PermissionGrantsRead,
+	},
+	RoleNodeAdmin: {
+		PermissionUsersRead,
+		PermissionNodesRead,
+		PermissionNodesWrite,
+		PermissionGrantsRead,
+		PermissionGrantsWrite,
+	},
+	RoleUserAdmin: {
+		PermissionUsersRead,
+		PermissionUsersWrite,
+		PermissionNodesRead,
+		PermissionGrantsRead,
+	},
+	RoleSuperAdmin: {
+		PermissionAuditRead,
+		PermissionSessionsRead,
+		PermissionSessionsWrite,
+		PermissionUsersRead,
+		PermissionUsersWrite,
+		PermissionNodesRead,
+		PermissionNodesWrite,
+		PermissionGrantsRead,
+		PermissionGrantsWrite,
+		PermissionRolesWrite,
+		PermissionDatabaseBackup,
+	},
+}
+
+// ParseRole parse a role binding, "name" or "name:scope", scope is a hostname pattern limits nodes and grants the role can write
+func ParseRole(role string) (name string, scope string) {
+	ss := strings.SplitN(strings.TrimSpace(role), ":", 2)
+	name = ss[0]
+	if len(ss) > 1 {
+		scope = ss[1]
+	}
+	return
+}
+
+func validateRoles(roles []string) (err error) {
+	for i, r := range roles {
+		roles[i] = strings.TrimSpace(r)
+		name, scope := ParseRole(roles[i])
+		if RolePermissions[name] == nil {
+			err = errInvalidField("roles", "one of auditor, node-admin, user-admin, super-admin")
+			return
+		}
+		if len(scope) > 0 && (name != RoleNodeAdmin || !GrantHostnamePatternPattern.MatchString(scope)) {
+			err = errInvalidField("roles", "scoped with a valid hostname pattern, node-admin only")
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"strings"
+)
+
+const (
+	TokenKindSession  = "session"
+	TokenKindPersonal = "personal"
+
+	TokenScopeReadOnly = "read-only"
+	TokenScopeReplays  = "replays"
+	TokenScopeAdmin    = "admin"
+)
+
+// TokenScopePermissions permissions a token scope is limited to, "admin" is not limited
+var TokenScopePermissions = map[string][]string{
+	TokenScopeReadOnly: {
+		PermissionAuditRead,
+		PermissionSessionsRead,
+		PermissionUsersRead,
+		PermissionNodesRead,
+		PermissionGrantsRead,
+	},
+	TokenScopeReplays: {
+		PermissionSessionsRead,
+	},
+	TokenScopeAdmin: nil,
+}
+
+// HasScope check the token has the scope, tokens created without scopes are considered "admin"
+func (m *Token) HasScope(scope string) bool {
+	if len(m.Scopes) == 0 {
+		return scope == TokenScopeAdmin
+	}
+	for _, s := range m.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired check the token is expired at given unix time, by expires_at or by idle timeout since last use
+func (m *Token) IsExpired(now int64) bool {
+	if m.ExpiresAt > 0 && now >= m.ExpiresAt {
+		return true
+	}
+	if m.IdleTimeout > 0 {
+		last := m.ViewedAt
+		if last < m.CreatedAt {
+			last = m.CreatedAt
+		}
+		if now >= last+m.IdleTimeout {
+			return true
+		}
+	}
+	return false
+}
+
+func validateTokenScopes(scopes []string) (err error) {
+	for i, s := range scopes {
+		scopes[i] = strings.TrimSpace(s)
+		if _, ok := TokenScopePermissions[scopes[i]]; !ok {
+			err = errInvalidField("scopes", "one of read-only, replays, admin")
+			return
+		}
+	}
+	return
+}
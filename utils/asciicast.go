@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+const (
+	AsciicastVersion       = 2
+	AsciicastDefaultWidth  = 80
+	AsciicastDefaultHeight = 24
+)
+
+// AsciicastHeader header line of asciicast v2 file
+type AsciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     uint32            `json:"width"`
+	Height    uint32            `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// AsciicastHeaderForSession header with pty info and creation time of session
+func AsciicastHeaderForSession(s *types.Session) AsciicastHeader {
+	h := AsciicastHeader{
+		Version:   AsciicastVersion,
+		Width:     uint32(s.PtyWidth),
+		Height:    uint32(s.PtyHeight),
+		Timestamp: s.CreatedAt,
+		Title:     fmt.Sprintf("session %d of %s", s.Id, s.Account),
+	}
+	if len(s.PtyTerm) > 0 {
+		h.Env = map[string]string{"TERM": s.PtyTerm}
+	}
+	return h
+}
+
+// AsciicastWriter converts replay frames to asciicast v2, stdout and stderr frames become output events,
+// window size frames become resize events, header is written with the last window size before the first output
+type AsciicastWriter struct {
+	w       io.Writer
+	header  AsciicastHeader
+	started bool
+	// pending incomplete utf-8 sequence at end of last output
+	pending   []byte
+	timestamp uint32
+}
+
+func NewAsciicastWriter(w io.Writer, h AsciicastHeader) *AsciicastWriter {
+	h.Version = AsciicastVersion
+	return &AsciicastWriter{w: w, header: h}
+}
+
+func (a *AsciicastWriter) start() (err error) {
+	if a.started {
+		return
+	}
+	a.started = true
+	if a.header.Width == 0 || a.header.Height == 0 {
+		a.header.Width, a.header.Height = AsciicastDefaultWidth, AsciicastDefaultHeight
+	}
+	return a.writeLine(a.header)
+}
+
+func (a *AsciicastWriter) writeLine(v interface{}) (err error) {
+	var buf []byte
+	if buf, err = json.Marshal(v); err != nil {
+		return
+	}
+	_, err = a.w.Write(append(buf, '\n'))
+	return
+}
+
+func (a *AsciicastWriter) writeEvent(timestamp uint32, code string, data string) error {
+	return a.writeLine([]interface{}{float64(timestamp) / 1000, code, data})
+}
+
+// WriteFrame convert and write a replay frame, timestamp of frame is in milliseconds
+func (a *AsciicastWriter) WriteFrame(f *types.ReplayFrame) (err error) {
+	switch f.Type {
+	case types.ReplayFrameTypeWindowSize:
+		width, height, ok := UnmarshalReplayFrameWindowSizePayload(f.Payload)
+		if !ok || width == 0 || height == 0 {
+			return
+		}
+		if !a.started {
+			a.header.Width, a.header.Height = width, height
+			return
+		}
+		return a.writeEvent(f.Timestamp, "r", fmt.Sprintf("%dx%d", width, height))
+	case types.ReplayFrameTypeStdout, types.ReplayFrameTypeStderr:
+		if err = a.start(); err != nil {
+			return
+		}
+		a.timestamp = f.Timestamp
+		buf := append(a.pending, f.Payload...)
+		// keep incomplete utf-8 sequence for next output
+		n := len(buf) - incompleteUTF8Suffix(buf)
+		a.pending = append([]byte(nil), buf[n:]...)
+		if n == 0 {
+			return
+		}
+		return a.writeEvent(f.Timestamp, "o", string(buf[:n]))
+	}
+	return
+}
+
+// Close write header if nothing written, and flush pending bytes, underlying writer is not closed
+func (a *AsciicastWriter) Close() (err error) {
+	if err = a.start(); err != nil {
+		return
+	}
+	if len(a.pending) > 0 {
+		err = a.writeEvent(a.timestamp, "o", string(a.pending))
+		a.pending = nil
+	}
+	return
+}
+
+// incompleteUTF8Suffix length of trailing bytes which may start a valid utf-8 sequence
+func incompleteUTF8Suffix(buf []byte) int {
+	for i := 1; i < utf8.UTFMax && i <= len(buf); i++ {
+		c := buf[len(buf)-i]
+		if utf8.RuneStart(c) {
+			if !utf8.FullRune(buf[len(buf)-i:]) {
+				return i
+			}
+			return 0
+		}
+	}
+	return 0
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+func TestAsciicastWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	a := NewAsciicastWriter(buf, AsciicastHeader{Timestamp: 1500000000, Env: map[string]string{"TERM": "xterm"}})
+	hello := []byte("hello 你好")
+	for _, f := range []*types.ReplayFrame{
+		{Timestamp: 0, Type: types.ReplayFrameTypeWindowSize, Payload: MarshalReplayFrameWindowSizePayload(80, 24)},
+		{Timestamp: 10, Type: types.ReplayFrameTypeWindowSize, Payload: MarshalReplayFrameWindowSizePayload(120, 40)},
+		// multi-byte character split across frames
+		{Timestamp: 1500, Type: types.ReplayFrameTypeStdout, Payload: hello[:8]},
+		{Timestamp: 2000, Type: types.ReplayFrameTypeStderr, Payload: hello[8:]},
+		{Timestamp: 3250, Type: types.ReplayFrameTypeWindowSize, Payload: MarshalReplayFrameWindowSizePayload(100, 30)},
+		{Timestamp: 4000, Type: 99, Payload: []byte("unknown")},
+	} {
+		if err := a.WriteFrame(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"version":2,"width":120,"height":40,"timestamp":1500000000,"env":{"TERM":"xterm"}}
+[1.5,"o","hello "]
+[2,"o","你好"]
+[3.25,"r","100x30"]
+`
+	if buf.String() != expected {
+		t.Fatal("bad asciicast", buf.String())
+	}
+
+	buf.Reset()
+	a = NewAsciicastWriter(buf, AsciicastHeader{})
+	a.WriteFrame(&types.ReplayFrame{Timestamp: 1, Type: types.ReplayFrameTypeStdout, Payload: hello[:7]})
+	a.Close()
+	expected = `{"version":2,"width":80,"height":24}
+[0.001,"o","hello "]
+[0.001,"o","�"]
+`
+	if buf.String() != expected {
+		t.Fatal("bad asciicast", buf.String())
+	}
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+const (
+	LabelOperatorEquals    = "="
+	LabelOperatorNotEquals = "!="
+	LabelOperatorExists    = "exists"
+	LabelOperatorNotExists = "!exists"
+)
+
+// LabelRequirement a single requirement of a label selector
+type LabelRequirement struct {
+	Key      string
+	Operator string
+	Value    string
+}
+
+func (r LabelRequirement) String() string {
+	switch r.Operator {
+	case LabelOperatorExists:
+		return r.Key
+	case LabelOperatorNotExists:
+		return "!" + r.Key
+	}
+	return r.Key + r.Operator + r.Value
+}
+
+// Matches check the labels satisfy the requirement
+func (r LabelRequirement) Matches(labels map[string]string) bool {
+	v, ok := labels[r.Key]
+	switch r.Operator {
+	case LabelOperatorEquals:
+		return ok && v == r.Value
+	case LabelOperatorNotEquals:
+		return !ok || v != r.Value
+	case LabelOperatorExists:
+		return ok
+	case LabelOperatorNotExists:
+		return !ok
+	}
+	return false
+}
+
+// LabelSelector requirements all of which must be satisfied
+type LabelSelector []LabelRequirement
+
+// ParseLabelSelector parse a comma separated label selector, supports "key=value", "key!=value", "key" and "!key",
+// for example "env=prod,role=db,!deprecated"
+func ParseLabelSelector(s string) (ls LabelSelector, err error) {
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		r := LabelRequirement{}
+		if i := strings.Index(item, "!="); i >= 0 {
+			r.Key, r.Operator, r.Value = item[:i], LabelOperatorNotEquals, item[i+2:]
+		} else if i = strings.Index(item, "="); i >= 0 {
+			r.Key, r.Operator, r.Value = item[:i], LabelOperatorEquals, item[i+1:]
+		} else if strings.HasPrefix(item, "!") {
+			r.Key, r.Operator = item[1:], LabelOperatorNotExists
+		} else {
+			r.Key, r.Operator = item, LabelOperatorExists
+		}
+		r.Key, r.Value = strings.TrimSpace(r.Key), strings.TrimSpace(r.Value)
+		if !types.LabelKeyPattern.MatchString(r.Key) || !types.LabelValuePattern.MatchString(r.Value) {
+			err = fmt.Errorf("invalid label selector requirement '%s'", item)
+			return
+		}
+		ls = append(ls, r)
+	}
+	if len(ls) == 0 {
+		err = errors.New("empty label selector")
+		return
+	}
+	// sort requirements, makes String() canonical
+	sort.Slice(ls, func(i, j int) bool {
+		return ls[i].String() < ls[j].String()
+	})
+	return
+}
+
+// Matches check the labels satisfy all requirements
+func (ls LabelSelector) Matches(labels map[string]string) bool {
+	for _, r := range ls {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// String canonical form of the label selector
+func (ls LabelSelector) String() string {
+	ss := make([]string, 0, len(ls))
+	for _, r := range ls {
+		ss = append(ss, r.String())
+	}
+	return strings.Join(ss, ",")
+}
+
+// ParseLabels parse comma separated labels, for example "env=prod,role=db"
+func ParseLabels(s string) (labels map[string]string, err error) {
+	labels = map[string]string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			err = fmt.Errorf("invalid label '%s', should be key=value", item)
+			return
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return
+}
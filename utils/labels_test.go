@@ -0,0 +1,44 @@
+package utils
+
+import "testing"
+
+func TestParseLabelSelector(t *testing.T) {
+	ls, err := ParseLabelSelector(" role=db, env=prod ,!deprecated,zone!=b,gpu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ls.String() != "!deprecated,env=prod,gpu,role=db,zone!=b" {
+		t.Fatal("bad canonical form", ls.String())
+	}
+	if !ls.Matches(map[string]string{"env": "prod", "role": "db", "gpu": "", "zone": "a"}) {
+		t.Fatal("should match")
+	}
+	if ls.Matches(map[string]string{"env": "prod", "role": "db", "gpu": "", "zone": "b"}) {
+		t.Fatal("should not match zone")
+	}
+	if ls.Matches(map[string]string{"env": "prod", "role": "db", "gpu": "", "deprecated": "true"}) {
+		t.Fatal("should not match deprecated")
+	}
+	if ls.Matches(map[string]string{"env": "prod", "role": "db"}) {
+		t.Fatal("should not match without gpu")
+	}
+	if _, err = ParseLabelSelector(" , "); err == nil {
+		t.Fatal("should fail with empty selector")
+	}
+	if _, err = ParseLabelSelector("env=p r o d"); err == nil {
+		t.Fatal("should fail with invalid value")
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	labels, err := ParseLabels("env=prod, role = db,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 2 || labels["env"] != "prod" || labels["role"] != "db" {
+		t.Fatal("bad labels", labels)
+	}
+	if _, err = ParseLabels("env"); err == nil {
+		t.Fatal("should fail without value")
+	}
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"sort"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+// Permissions resolved permissions, permission to hostname patterns it applies to, "*" for all
+type Permissions map[string][]string
+
+// ResolvePermissions resolve permissions of role bindings, unknown roles are ignored
+func ResolvePermissions(roles []string) Permissions {
+	p := Permissions{}
+	for _, role := range roles {
+		name, scope := types.ParseRole(role)
+		if len(scope) == 0 {
+			scope = "*"
+		}
+		for _, perm := range types.RolePermissions[name] {
+			p[perm] = append(p[perm], scope)
+		}
+	}
+	return p
+}
+
+// Limit limit permissions to token scopes, permissions are kept if any scope is "admin" or no scope is given
+func (p Permissions) Limit(scopes []string) Permissions {
+	if len(scopes) == 0 {
+		return p
+	}
+	allowed := map[string]bool{}
+	for _, s := range scopes {
+		if s == types.TokenScopeAdmin {
+			return p
+		}
+		for _, perm := range types.TokenScopePermissions[s] {
+			allowed[perm] = true
+		}
+	}
+	ret := Permissions{}
+	for perm, scopes := range p {
+		if allowed[perm] {
+			ret[perm] = scopes
+		}
+	}
+	return ret
+}
+
+// Has check the permission is granted, regardless of scope
+func (p Permissions) Has(perm string) bool {
+	return len(p[perm]) > 0
+}
+
+// HasForHostname check the permission is granted for the hostname, or hostname pattern of a grant
+func (p Permissions) HasForHostname(perm string, hostname string) bool {
+	for _, scope := range p[perm] {
+		if MatchAsterisk(scope, hostname) {
+			return true
+		}
+	}
+	return false
+}
+
+// Names sorted names of granted permissions
+func (p Permissions) Names() []string {
+	ret := make([]string, 0, len(p))
+	for perm := range p {
+		ret = append(ret, perm)
+	}
+	sort.Strings(ret)
+	return ret
+}
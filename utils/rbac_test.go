@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+func TestResolvePermissions(t *testing.T) {
+	p := ResolvePermissions([]string{types.RoleAuditor, "node-admin:web-*", "unknown"})
+	if !p.Has(types.PermissionSessionsRead) || !p.Has(types.PermissionNodesWrite) {
+		t.Fatal("missing permissions")
+	}
+	if p.Has(types.PermissionUsersWrite) || p.Has(types.PermissionRolesWrite) {
+		t.Fatal("unexpected permissions")
+	}
+	if !p.HasForHostname(types.PermissionGrantsWrite, "web-1") || !p.HasForHostname(types.PermissionGrantsWrite, "web-*") {
+		t.Fatal("scoped permission not granted")
+	}
+	if p.HasForHostname(types.PermissionGrantsWrite, "db-1") {
+		t.Fatal("scoped permission granted out of scope")
+	}
+	if !p.HasForHostname(types.PermissionNodesRead, "db-1") {
+		t.Fatal("unscoped permission not granted")
+	}
+	p = ResolvePermissions([]string{types.RoleSuperAdmin})
+	if !p.HasForHostname(types.PermissionGrantsWrite, "db-1") || !p.Has(types.PermissionRolesWrite) {
+		t.Fatal("super-admin missing permissions")
+	}
+}
+
+func TestPermissions_Limit(t *testing.T) {
+	p := ResolvePermissions([]string{types.RoleSuperAdmin, "node-admin:web-*"})
+	if l := p.Limit(nil); !l.Has(types.PermissionRolesWrite) {
+		t.Fatal("permissions should not be limited without scopes")
+	}
+	if l := p.Limit([]string{types.TokenScopeReplays, types.TokenScopeAdmin}); !l.Has(types.PermissionRolesWrite) {
+		t.Fatal("permissions should not be limited with admin scope")
+	}
+	l := p.Limit([]string{types.TokenScopeReadOnly})
+	if !l.Has(types.PermissionNodesRead) || !l.Has(types.PermissionAuditRead) {
+		t.Fatal("missing read permissions")
+	}
+	if l.Has(types.PermissionNodesWrite) || l.Has(types.PermissionGrantsWrite) || l.Has(types.PermissionDatabaseBackup) {
+		t.Fatal("unexpected write permissions")
+	}
+	l = p.Limit([]string{types.TokenScopeReplays})
+	if !l.Has(types.PermissionSessionsRead) || l.Has(types.PermissionUsersRead) {
+		t.Fatal("replays scope should only read sessions")
+	}
+	if l = ResolvePermissions([]string{types.RoleUserAdmin}).Limit([]string{types.TokenScopeReplays}); l.Has(types.PermissionSessionsRead) {
+		t.Fatal("scope should not grant permissions")
+	}
+}
@@ -2,10 +2,35 @@ package utils
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"github.com/yankeguo/bastion/types"
 	"io"
 )
 
+// ReplayFrameMaxPayload max payload length of a replay frame, a larger length read from a replay means corruption
+const ReplayFrameMaxPayload = 1024 * 1024
+
+// ErrReplayFrameTooLarge payload of replay frame exceeds ReplayFrameMaxPayload
+var ErrReplayFrameTooLarge = errors.New("replay frame payload too large")
+
+// SplitReplayFrame split payload of a frame into frames no larger than ReplayFrameMaxPayload
+func SplitReplayFrame(f *types.ReplayFrame) []*types.ReplayFrame {
+	if len(f.Payload) <= ReplayFrameMaxPayload {
+		return []*types.ReplayFrame{f}
+	}
+	var fs []*types.ReplayFrame
+	for p := f.Payload; len(p) > 0; {
+		l := len(p)
+		if l > ReplayFrameMaxPayload {
+			l = ReplayFrameMaxPayload
+		}
+		fs = append(fs, &types.ReplayFrame{SessionId: f.SessionId, Timestamp: f.Timestamp, Type: f.Type, Payload: p[:l]})
+		p = p[l:]
+	}
+	return fs
+}
+
 func MarshalReplayFrameWindowSizePayload(width, height uint32) []byte {
 	buf := make([]byte, 8, 8)
 	binary.BigEndian.PutUint32(buf, width)
@@ -14,6 +39,9 @@ func MarshalReplayFrameWindowSizePayload(width, height uint32) []byte {
 }
 
 func WriteReplayFrame(f *types.ReplayFrame, w io.Writer) (err error) {
+	if len(f.Payload) > ReplayFrameMaxPayload {
+		return ErrReplayFrameTooLarge
+	}
 	// TIMESTAMP (4 bytes) + TYPE (1 byte) + PAYLOAD_LEN (4 bytes) + PAYLOAD
 	l := 4 + 1 + 4 + len(f.Payload)
 	buf := make([]byte, l, l)
@@ -28,23 +56,70 @@ func WriteReplayFrame(f *types.ReplayFrame, w io.Writer) (err error) {
 func ReadReplayFrame(f *types.ReplayFrame, r io.Reader) (err error) {
 	// TIMESTAMP (4 bytes) + TYPE (1 byte) + PAYLOAD_LEN (4 bytes)
 	h := make([]byte, 4+1+4, 4+1+4)
-	if _, err = r.Read(h); err != nil {
+	if _, err = io.ReadFull(r, h); err != nil {
 		return
 	}
 	f.SessionId = 0
 	f.Timestamp = binary.BigEndian.Uint32(h)
 	f.Type = uint32(h[4])
 	l := binary.BigEndian.Uint32(h[5:])
+	// length is not trusted, a tampered replay must not allocate up to 4 GiB
+	if l > ReplayFrameMaxPayload {
+		return ErrReplayFrameTooLarge
+	}
 	if l > 0 {
 		f.Payload = make([]byte, l, l)
-		var i int
-		if i, err = r.Read(f.Payload); err != nil {
-			if i == int(l) && err == io.EOF {
-				err = nil
-			}
+		// decompressing readers may return less bytes than available
+		if _, err = io.ReadFull(r, f.Payload); err == io.EOF {
+			err = io.ErrUnexpectedEOF
 		}
 	} else {
 		f.Payload = make([]byte, 0, 0)
 	}
 	return
 }
+
+func UnmarshalReplayFrameWindowSizePayload(buf []byte) (width, height uint32, ok bool) {
+	if len(buf) < 8 {
+		return
+	}
+	return binary.BigEndian.Uint32(buf), binary.BigEndian.Uint32(buf[4:]), true
+}
+
+const (
+	ReplayFormatRaw  = "raw"
+	ReplayFormatCast = "cast"
+)
+
+// ReplayExporter writes replay frames in an export format
+type ReplayExporter interface {
+	WriteFrame(f *types.ReplayFrame) error
+	// Close finishes the export, underlying writer is not closed
+	Close() error
+}
+
+type rawReplayExporter struct {
+	w io.Writer
+}
+
+func (r rawReplayExporter) WriteFrame(f *types.ReplayFrame) error {
+	return WriteReplayFrame(f, r.w)
+}
+
+func (r rawReplayExporter) Close() error {
+	return nil
+}
+
+// NewReplayExporter create exporter of replay of session, format is "raw" for frame format of bastion,
+// or "cast" for asciicast v2
+func NewReplayExporter(w io.Writer, format string, s *types.Session) (e ReplayExporter, err error) {
+	switch format {
+	case ReplayFormatRaw:
+		e = rawReplayExporter{w: w}
+	case ReplayFormatCast:
+		e = NewAsciicastWriter(w, AsciicastHeaderForSession(s))
+	default:
+		err = fmt.Errorf("unknown replay format '%s'", format)
+	}
+	return
+}
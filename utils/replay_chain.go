@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+// ReplayHashChain running hash over frames of a replay, each link is SHA-256 of the previous link and the encoded frame,
+// altering, inserting, removing or reordering any frame changes the final digest
+type ReplayHashChain struct {
+	sum    []byte
+	frames int64
+}
+
+func NewReplayHashChain() *ReplayHashChain {
+	return &ReplayHashChain{sum: make([]byte, sha256.Size)}
+}
+
+// Write chain a frame, frames are encoded same as in replay files
+func (c *ReplayHashChain) Write(f *types.ReplayFrame) {
+	h := sha256.New()
+	h.Write(c.sum)
+	WriteReplayFrame(f, h)
+	c.sum = h.Sum(nil)
+	c.frames++
+}
+
+// Digest hex encoded last link of the chain
+func (c *ReplayHashChain) Digest() string {
+	return hex.EncodeToString(c.sum)
+}
+
+// Frames number of frames chained
+func (c *ReplayHashChain) Frames() int64 {
+	return c.frames
+}
+
+// ReplayDigestMessage message to sign for digest of a replay, digest is bound to the session
+func ReplayDigestMessage(sessionID int64, frames int64, digest string) []byte {
+	return []byte(fmt.Sprintf("bastion-replay-v1:%d:%d:%s", sessionID, frames, digest))
+}
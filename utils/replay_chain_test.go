@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+func TestReplayHashChain(t *testing.T) {
+	frames := []*types.ReplayFrame{
+		{Timestamp: 0, Type: types.ReplayFrameTypeStdout, Payload: []byte("hello")},
+		{Timestamp: 10, Type: types.ReplayFrameTypeStdout, Payload: []byte("world")},
+	}
+	digest := func(frames ...*types.ReplayFrame) string {
+		c := NewReplayHashChain()
+		for _, f := range frames {
+			c.Write(f)
+		}
+		if c.Frames() != int64(len(frames)) {
+			t.Fatal("bad frames", c.Frames())
+		}
+		return c.Digest()
+	}
+	d := digest(frames...)
+	if len(d) != 64 || d != digest(frames...) {
+		t.Fatal("bad digest", d)
+	}
+	if d == digest(frames[1], frames[0]) || d == digest(frames[0]) || d == digest(frames[0], frames[1], frames[1]) {
+		t.Fatal("digest should change")
+	}
+	if d == digest(frames[0], &types.ReplayFrame{Timestamp: 11, Type: types.ReplayFrameTypeStdout, Payload: []byte("world")}) {
+		t.Fatal("digest should change")
+	}
+
+	// frames read back byte by byte chain to the same digest
+	buf := &bytes.Buffer{}
+	for _, f := range frames {
+		WriteReplayFrame(f, buf)
+	}
+	c := NewReplayHashChain()
+	r := iotest.OneByteReader(buf)
+	for {
+		var f types.ReplayFrame
+		if err := ReadReplayFrame(&f, r); err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+		c.Write(&f)
+	}
+	if c.Digest() != d {
+		t.Fatal("bad digest", c.Digest())
+	}
+}
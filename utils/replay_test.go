@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+func TestReadReplayFrame(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteReplayFrame(&types.ReplayFrame{Timestamp: 1, Type: types.ReplayFrameTypeStdout, Payload: []byte("hello")}, buf); err != nil {
+		t.Fatal(err)
+	}
+	var f types.ReplayFrame
+	if err := ReadReplayFrame(&f, bytes.NewReader(buf.Bytes())); err != nil || string(f.Payload) != "hello" {
+		t.Fatal("bad frame", f, err)
+	}
+	// truncated payload
+	if err := ReadReplayFrame(&f, bytes.NewReader(buf.Bytes()[:buf.Len()-1])); err != io.ErrUnexpectedEOF {
+		t.Fatal("should fail on truncated payload", err)
+	}
+	// huge payload length of a tampered replay is refused before allocation
+	h := make([]byte, 9)
+	binary.BigEndian.PutUint32(h[5:], 0xffffffff)
+	if err := ReadReplayFrame(&f, bytes.NewReader(h)); err != ErrReplayFrameTooLarge {
+		t.Fatal("should refuse huge payload", err)
+	}
+	if err := WriteReplayFrame(&types.ReplayFrame{Payload: make([]byte, ReplayFrameMaxPayload+1)}, buf); err != ErrReplayFrameTooLarge {
+		t.Fatal("should refuse to write huge payload", err)
+	}
+}
+
+func TestSplitReplayFrame(t *testing.T) {
+	f := &types.ReplayFrame{SessionId: 1, Timestamp: 2, Type: types.ReplayFrameTypeStdout, Payload: make([]byte, ReplayFrameMaxPayload*2+1)}
+	fs := SplitReplayFrame(f)
+	if len(fs) != 3 || len(fs[0].Payload) != ReplayFrameMaxPayload || len(fs[2].Payload) != 1 || fs[2].Timestamp != 2 || fs[2].SessionId != 1 {
+		t.Fatal("bad split", len(fs))
+	}
+	if fs = SplitReplayFrame(&types.ReplayFrame{Payload: []byte("x")}); len(fs) != 1 {
+		t.Fatal("should not split small frame")
+	}
+}
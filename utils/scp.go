@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// scp protocol, see https://web.archive.org/web/20170215184048/https://blogs.oracle.com/janp/entry/how_the_scp_protocol_works
+
+const scpMaxLineLength = 4096
+
+const (
+	scpStateHeader = iota
+	scpStateData
+	scpStateDataEnd
+	scpStateBroken
+)
+
+// SCPCommand a parsed remote side scp command, "scp -t" or "scp -f"
+type SCPCommand struct {
+	// IsSink "-t", remote side receives files
+	IsSink bool
+	// IsRecursive "-r"
+	IsRecursive bool
+	// IsTargetDir "-d", target must be a directory
+	IsTargetDir bool
+	// Paths target path for sink, or source paths
+	Paths []string
+}
+
+// ParseSCPCommand parse a splitted remote side scp command, returns false if not a "scp -t" or "scp -f" command
+func ParseSCPCommand(cmds []string) (c SCPCommand, ok bool) {
+	if len(cmds) == 0 || path.Base(strings.TrimSpace(cmds[0])) != "scp" {
+		return
+	}
+	var isSink, isSource, noFlags bool
+	for _, arg := range cmds[1:] {
+		if !noFlags && arg == "--" {
+			noFlags = true
+			continue
+		}
+		if !noFlags && len(arg) > 1 && arg[0] == '-' {
+			for _, f := range arg[1:] {
+				switch f {
+				case 't':
+					isSink = true
+				case 'f':
+					isSource = true
+				case 'r':
+					c.IsRecursive = true
+				case 'd':
+					c.IsTargetDir = true
+				}
+			}
+			continue
+		}
+		c.Paths = append(c.Paths, arg)
+	}
+	if isSink == isSource {
+		return
+	}
+	c.IsSink = isSink
+	ok = true
+	return
+}
+
+// ResolvePath resolve the path of a transferred file, name is the path reported by scp protocol, relative to the transfer root
+//
+// for sink, name is joined with the target unless the target is a single file, for source, the first component of name is replaced with the matching source path
+func (c SCPCommand) ResolvePath(name string) string {
+	if c.IsSink {
+		target := "."
+		if len(c.Paths) > 0 {
+			target = c.Paths[0]
+		}
+		if c.IsTargetDir || c.IsRecursive || target == "." || target == "~" || strings.HasSuffix(target, "/") {
+			return path.Join(target, name)
+		}
+		return target
+	}
+	ns := strings.SplitN(name, "/", 2)
+	for _, p := range c.Paths {
+		if path.Base(p) == ns[0] {
+			ns[0] = p
+			return path.Join(ns...)
+		}
+	}
+	return name
+}
+
+// SCPFile a file transferred with scp protocol
+type SCPFile struct {
+	// Name path relative to the transfer root
+	Name   string
+	Mode   uint32
+	Size   int64
+	SHA256 string
+}
+
+// SCPParser parses the stream sent by the scp source side, and reports every completely transferred file
+type SCPParser struct {
+	// OnFile invoked when a file is completely transferred
+	OnFile func(f SCPFile)
+
+	state     int
+	line      []byte
+	dirs      []string
+	file      SCPFile
+	remaining int64
+	hash      hash.Hash
+}
+
+// NewSCPParser create a new scp parser
+func NewSCPParser(onFile func(f SCPFile)) *SCPParser {
+	return &SCPParser{OnFile: onFile, state: scpStateHeader}
+}
+
+// Write feed the parser, never fails, malformed stream stops the parsing silently
+func (p *SCPParser) Write(buf []byte) (int, error) {
+	l := len(buf)
+	for len(buf) > 0 {
+		switch p.state {
+		case scpStateHeader:
+			i := 0
+			for ; i < len(buf) && buf[i] != '\n'; i++ {
+			}
+			p.line = append(p.line, buf[:i]...)
+			if len(p.line) > scpMaxLineLength {
+				p.state = scpStateBroken
+				continue
+			}
+			if i == len(buf) {
+				buf = nil
+				continue
+			}
+			buf = buf[i+1:]
+			line := string(p.line)
+			p.line = p.line[:0]
+			p.handleHeader(line)
+		case scpStateData:
+			n := int64(len(buf))
+			if n > p.remaining {
+				n = p.remaining
+			}
+			p.hash.Write(buf[:n])
+			p.remaining -= n
+			buf = buf[n:]
+			if p.remaining == 0 {
+				p.finishFile()
+			}
+		case scpStateDataEnd:
+			// a single '\0' follows the file data
+			if buf[0] != 0 {
+				p.state = scpStateBroken
+				continue
+			}
+			buf = buf[1:]
+			p.state = scpStateHeader
+		default:
+			buf = nil
+		}
+	}
+	return l, nil
+}
+
+func (p *SCPParser) handleHeader(line string) {
+	if len(line) == 0 {
+		p.state = scpStateBroken
+		return
+	}
+	switch line[0] {
+	case 'C', 'D':
+		// Cmmmm <length> <filename> or Dmmmm 0 <dirname>
+		ss := strings.SplitN(line[1:], " ", 3)
+		if len(ss) != 3 || len(ss[2]) == 0 || strings.Contains(ss[2], "/") {
+			p.state = scpStateBroken
+			return
+		}
+		mode, err1 := strconv.ParseUint(ss[0], 8, 32)
+		size, err2 := strconv.ParseInt(ss[1], 10, 64)
+		if err1 != nil || err2 != nil || size < 0 {
+			p.state = scpStateBroken
+			return
+		}
+		if line[0] == 'D' {
+			p.dirs = append(p.dirs, ss[2])
+			return
+		}
+		p.file = SCPFile{
+			Name: path.Join(append(append([]string{}, p.dirs...), ss[2])...),
+			Mode: uint32(mode),
+			Size: size,
+		}
+		p.remaining = size
+		p.hash = sha256.New()
+		if size == 0 {
+			p.finishFile()
+		} else {
+			p.state = scpStateData
+		}
+	case 'E':
+		if len(p.dirs) > 0 {
+			p.dirs = p.dirs[:len(p.dirs)-1]
+		}
+	case 'T', '\x01', '\x02':
+		// times, warnings and errors are ignored
+	default:
+		p.state = scpStateBroken
+	}
+}
+
+func (p *SCPParser) finishFile() {
+	p.file.SHA256 = hex.EncodeToString(p.hash.Sum(nil))
+	if p.OnFile != nil {
+		p.OnFile(p.file)
+	}
+	p.state = scpStateDataEnd
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestParseSCPCommand(t *testing.T) {
+	c, ok := ParseSCPCommand([]string{"scp", "-v", "-r", "-t", "--", "/tmp"})
+	if !ok || !c.IsSink || !c.IsRecursive || c.IsTargetDir || len(c.Paths) != 1 || c.Paths[0] != "/tmp" {
+		t.Fatal("failed to parse sink", c, ok)
+	}
+	c, ok = ParseSCPCommand([]string{"/usr/bin/scp", "-pf", "a.txt", "dir/b.txt"})
+	if !ok || c.IsSink || len(c.Paths) != 2 {
+		t.Fatal("failed to parse source", c, ok)
+	}
+	if _, ok = ParseSCPCommand([]string{"scp", "a.txt", "b.txt"}); ok {
+		t.Fatal("should not parse scp without -t or -f")
+	}
+	if _, ok = ParseSCPCommand([]string{"bash"}); ok {
+		t.Fatal("should not parse non-scp command")
+	}
+}
+
+func TestSCPCommand_ResolvePath(t *testing.T) {
+	c := SCPCommand{IsSink: true, Paths: []string{"/tmp/x.txt"}}
+	if c.ResolvePath("a.txt") != "/tmp/x.txt" {
+		t.Fatal("failed 1")
+	}
+	c = SCPCommand{IsSink: true, IsTargetDir: true, Paths: []string{"/tmp"}}
+	if c.ResolvePath("a.txt") != "/tmp/a.txt" {
+		t.Fatal("failed 2")
+	}
+	c = SCPCommand{IsSink: true}
+	if c.ResolvePath("a.txt") != "a.txt" {
+		t.Fatal("failed 3")
+	}
+	c = SCPCommand{IsRecursive: true, Paths: []string{"/var/log", "a.txt"}}
+	if c.ResolvePath("log/syslog") != "/var/log/syslog" {
+		t.Fatal("failed 4")
+	}
+	if c.ResolvePath("b.txt") != "b.txt" {
+		t.Fatal("failed 5")
+	}
+}
+
+func TestSCPParser(t *testing.T) {
+	files := []SCPFile{}
+	p := NewSCPParser(func(f SCPFile) {
+		files = append(files, f)
+	})
+	stream := "T1234 0 1234 0\nD0755 0 dir\nC0644 5 hello.txt\nhello\x00C0600 0 empty\n\x00E\nC0644 3 b.txt\nabc\x00"
+	// feed byte by byte to test buffering
+	for i := 0; i < len(stream); i++ {
+		p.Write([]byte{stream[i]})
+	}
+	if len(files) != 3 {
+		t.Fatal("invalid files count", files)
+	}
+	sum := sha256.Sum256([]byte("hello"))
+	if files[0].Name != "dir/hello.txt" || files[0].Mode != 0644 || files[0].Size != 5 || files[0].SHA256 != hex.EncodeToString(sum[:]) {
+		t.Fatal("invalid file 0", files[0])
+	}
+	if files[1].Name != "dir/empty" || files[1].Mode != 0600 || files[1].Size != 0 {
+		t.Fatal("invalid file 1", files[1])
+	}
+	if files[2].Name != "b.txt" || files[2].Size != 3 {
+		t.Fatal("invalid file 2", files[2])
+	}
+	// broken stream
+	files = files[:0]
+	p = NewSCPParser(func(f SCPFile) {
+		files = append(files, f)
+	})
+	p.Write([]byte("C0644 5 ../hello.txt\nhello\x00C0644 3 b.txt\nabc\x00"))
+	if len(files) != 0 {
+		t.Fatal("should stop on malformed stream", files)
+	}
+}
@@ -0,0 +1,260 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+const (
+	// TranscriptOutputLimit bytes of output kept for each command
+	TranscriptOutputLimit = 4096
+	// TranscriptLineLimit columns of a line, cursor never moves beyond the last column, like a terminal without auto wrap
+	TranscriptLineLimit = 4096
+)
+
+var (
+	// transcriptPromptPattern common shell prompts like "user@host:~$ ", "[root@host ~]# ", "bash-4.2$ " and "user@host ~ % "
+	transcriptPromptPattern = regexp.MustCompile(`^(\[[^\]]{1,80}\]|[^\s$#%>]{1,80}( [^\s$#%>]{1,80})?) ?[$#%>]( |$)`)
+	// transcriptPromptHint prompt prefix contains at least one of these, avoids lines like "100% done"
+	transcriptPromptHint = regexp.MustCompile(`[@:~/\]=]|-\d`)
+)
+
+const (
+	transcriptStateNormal = iota
+	transcriptStateEsc
+	transcriptStateCSI
+	transcriptStateOSC
+	transcriptStateOSCEsc
+	transcriptStateCharset
+)
+
+// Transcriber reconstructs terminal lines from output frames, and extracts commands typed after shell prompts,
+// output of full screen applications using alternate screen is skipped
+type Transcriber struct {
+	entries   []*types.TranscriptEntry
+	current   *types.TranscriptEntry
+	timestamp uint32
+
+	line    []rune
+	cursor  int
+	pending []byte
+	state   int
+	params  []byte
+	alt     bool
+}
+
+func NewTranscriber() *Transcriber {
+	return &Transcriber{}
+}
+
+// WriteFrame feed a replay frame, only stdout and stderr frames are used
+func (t *Transcriber) WriteFrame(f *types.ReplayFrame) {
+	if f.Type != types.ReplayFrameTypeStdout && f.Type != types.ReplayFrameTypeStderr {
+		return
+	}
+	t.timestamp = f.Timestamp
+	for _, b := range f.Payload {
+		t.feed(b)
+	}
+}
+
+// Entries finish the transcript and returns entries, a prompt line without enter pressed is ignored
+func (t *Transcriber) Entries() []*types.TranscriptEntry {
+	t.finishEntry()
+	if t.entries == nil {
+		return []*types.TranscriptEntry{}
+	}
+	return t.entries
+}
+
+func (t *Transcriber) feed(b byte) {
+	switch t.state {
+	case transcriptStateEsc:
+		switch b {
+		case '[':
+			t.state, t.params = transcriptStateCSI, t.params[:0]
+		case ']':
+			t.state = transcriptStateOSC
+		case '(', ')', '*', '+':
+			t.state = transcriptStateCharset
+		default:
+			t.state = transcriptStateNormal
+		}
+		return
+	case transcriptStateCSI:
+		if b >= 0x20 && b <= 0x3f {
+			t.params = append(t.params, b)
+			return
+		}
+		t.state = transcriptStateNormal
+		t.csi(b)
+		return
+	case transcriptStateOSC:
+		if b == '\a' {
+			t.state = transcriptStateNormal
+		} else if b == 0x1b {
+			t.state = transcriptStateOSCEsc
+		}
+		return
+	case transcriptStateOSCEsc, transcriptStateCharset:
+		t.state = transcriptStateNormal
+		return
+	}
+	switch {
+	case b == 0x1b:
+		t.state = transcriptStateEsc
+	case b == '\r':
+		t.cursor = 0
+	case b == '\n':
+		t.commitLine()
+	case b == '\b':
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case b == '\t':
+		t.moveTo((t.cursor/8 + 1) * 8)
+	case b < 0x20 || b == 0x7f:
+	default:
+		t.pending = append(t.pending, b)
+		if utf8.FullRune(t.pending) {
+			r, _ := utf8.DecodeRune(t.pending)
+			t.pending = t.pending[:0]
+			t.put(r)
+		}
+	}
+}
+
+// csi handles control sequences moving cursor or erasing, and switching of alternate screen
+func (t *Transcriber) csi(final byte) {
+	params := string(t.params)
+	if strings.HasPrefix(params, "?") {
+		if final == 'h' || final == 'l' {
+			for _, p := range strings.Split(params[1:], ";") {
+				if p == "47" || p == "1047" || p == "1049" {
+					t.alt = final == 'h'
+				}
+			}
+		}
+		return
+	}
+	n, err := strconv.Atoi(strings.Split(params, ";")[0])
+	if err != nil {
+		n = -1
+	}
+	count := n
+	if count < 1 {
+		count = 1
+	}
+	switch final {
+	case 'K':
+		switch n {
+		case -1, 0:
+			if t.cursor < len(t.line) {
+				t.line = t.line[:t.cursor]
+			}
+		case 1:
+			for i := 0; i < t.cursor && i < len(t.line); i++ {
+				t.line[i] = ' '
+			}
+		case 2:
+			t.line = t.line[:0]
+		}
+	case 'C':
+		t.moveTo(t.cursor + count)
+	case 'D':
+		t.moveTo(t.cursor - count)
+	case 'G':
+		t.moveTo(count - 1)
+	case 'P':
+		if t.cursor < len(t.line) {
+			end := t.cursor + count
+			if end > len(t.line) {
+				end = len(t.line)
+			}
+			t.line = append(t.line[:t.cursor], t.line[end:]...)
+		}
+	case '@':
+		if t.cursor < len(t.line) {
+			if count > TranscriptLineLimit-t.cursor {
+				count = TranscriptLineLimit - t.cursor
+			}
+			t.line = append(t.line[:t.cursor], append([]rune(strings.Repeat(" ", count)), t.line[t.cursor:]...)...)
+			if len(t.line) > TranscriptLineLimit {
+				t.line = t.line[:TranscriptLineLimit]
+			}
+		}
+	}
+}
+
+// moveTo move cursor to the column, bounded by the line limit
+func (t *Transcriber) moveTo(column int) {
+	if column < 0 {
+		column = 0
+	} else if column > TranscriptLineLimit-1 {
+		column = TranscriptLineLimit - 1
+	}
+	t.cursor = column
+}
+
+func (t *Transcriber) put(r rune) {
+	if t.alt {
+		return
+	}
+	for len(t.line) < t.cursor {
+		t.line = append(t.line, ' ')
+	}
+	if t.cursor == len(t.line) {
+		t.line = append(t.line, r)
+	} else {
+		t.line[t.cursor] = r
+	}
+	t.moveTo(t.cursor + 1)
+}
+
+func (t *Transcriber) commitLine() {
+	line := strings.TrimRight(string(t.line), " ")
+	t.line, t.cursor = t.line[:0], 0
+	if t.alt {
+		return
+	}
+	if loc := transcriptPromptPattern.FindStringSubmatchIndex(line); loc != nil && transcriptPromptHint.MatchString(line[loc[2]:loc[3]]) {
+		t.finishEntry()
+		command := strings.TrimSpace(line[loc[1]:])
+		// empty enter, or cancelled with Ctrl-C
+		if len(command) == 0 || strings.HasSuffix(command, "^C") {
+			return
+		}
+		t.current = &types.TranscriptEntry{
+			Timestamp: t.timestamp,
+			Prompt:    strings.TrimSpace(line[:loc[1]]),
+			Command:   command,
+		}
+		return
+	}
+	if t.current == nil || t.current.OutputTruncated {
+		return
+	}
+	if len(t.current.Output)+len(line)+1 > TranscriptOutputLimit {
+		line = line[:TranscriptOutputLimit-len(t.current.Output)]
+		// cut at rune boundary
+		for len(line) > 0 && !utf8.ValidString(line) {
+			line = line[:len(line)-1]
+		}
+		t.current.Output += line
+		t.current.OutputTruncated = true
+		return
+	}
+	t.current.Output += line + "\n"
+}
+
+func (t *Transcriber) finishEntry() {
+	if t.current == nil {
+		return
+	}
+	t.entries = append(t.entries, t.current)
+	t.current = nil
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yankeguo/bastion/types"
+)
+
+func TestTranscriber(t *testing.T) {
+	tr := NewTranscriber()
+	ls := "\x1b[01;34mbin\x1b[0m  etc\r\n"
+	for i, out := range []string{
+		// title, bracketed paste mode and colored prompt
+		"\x1b]0;root@web-1: ~\x07\x1b[?2004h\x1b[01;32mroot@web-1\x1b[00m:\x1b[01;34m~\x1b[00m# ",
+		// typo corrected with backspace
+		"lx\b\x1b[K", "s -l", "\r\n",
+		ls,
+		"[root@web-1 ~]# ",
+		// empty enter, and cancelled command
+		"\r\n[root@web-1 ~]# rm -rf^C\r\n",
+		// full screen application
+		"[root@web-1 ~]# vim\r\n\x1b[?1049hgarbage\r\nmore\r\n\x1b[?1049l",
+		// cursor moved back to insert characters, with bash deleting characters after cursor
+		"[root@web-1 ~]# echo 你好\x1b[D\x1b[D\x1b[@x\x1b[C\x1b[Pz\r\n你z\r\n",
+		"100% done\r\n",
+		"bash-4.2$ exit",
+	} {
+		tr.WriteFrame(&types.ReplayFrame{Timestamp: uint32(i * 1000), Type: types.ReplayFrameTypeStdout, Payload: []byte(out)})
+	}
+	tr.WriteFrame(&types.ReplayFrame{Timestamp: 10000, Type: types.ReplayFrameTypeWindowSize, Payload: []byte("\r\n")})
+	entries := tr.Entries()
+	if len(entries) != 3 {
+		t.Fatal("bad entries", entries)
+	}
+	if entries[0].Timestamp != 3000 || entries[0].Prompt != "root@web-1:~#" || entries[0].Command != "ls -l" || entries[0].Output != "bin  etc\n" {
+		t.Fatal("bad entry", entries[0])
+	}
+	if entries[1].Command != "vim" || entries[1].Output != "" {
+		t.Fatal("bad entry", entries[1])
+	}
+	if entries[2].Command != "echo x你z" || entries[2].Output != "你z\n100% done\n" {
+		t.Fatal("bad entry", entries[2])
+	}
+
+	// output is truncated
+	tr = NewTranscriber()
+	tr.WriteFrame(&types.ReplayFrame{Type: types.ReplayFrameTypeStderr, Payload: []byte("user@host:~$ cat big\r\n" + strings.Repeat("你好\r\n", 1000) + "user@host:~$ ")})
+	entries = tr.Entries()
+	if len(entries) != 1 || !entries[0].OutputTruncated || len(entries[0].Output) > TranscriptOutputLimit {
+		t.Fatal("output should be truncated", entries)
+	}
+
+	// cursor moves are bounded, huge parameters never grow the line
+	tr = NewTranscriber()
+	tr.WriteFrame(&types.ReplayFrame{Type: types.ReplayFrameTypeStdout, Payload: []byte("\x1b[2000000000Cx\x1b[1;2000000000Gy\t\x1b[D\x1b[2000000000@z")})
+	if len(tr.line) > TranscriptLineLimit || tr.cursor >= TranscriptLineLimit {
+		t.Fatal("line should be bounded", len(tr.line), tr.cursor)
+	}
+}
@@ -1,27 +1,45 @@
 package web
 
 import (
+	"github.com/gorilla/websocket"
 	"github.com/novakit/nova"
 	"github.com/pkg/errors"
+	"github.com/yankeguo/bastion/sshd/sandbox"
 	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"net"
+	"net/http"
+	"strings"
+	"time"
 )
 
 const (
-	contextKeyGRPCConn   = "_rpc_conn"
-	contextKeyAuth       = "_auth"
-	contextKeyWebOptions = "_web_options"
+	contextKeyGRPCConn    = "_rpc_conn"
+	contextKeyAuth        = "_auth"
+	contextKeyWebOptions  = "_web_options"
+	contextKeySSHDOptions = "_sshd_options"
+	contextKeySandbox     = "_sandbox"
+	contextKeySandboxMgr  = "_sandbox_manager"
+	contextKeyOIDC        = "_oidc"
 
 	headerKeyToken        = "X-Bastion-Token"
 	headerKeyAction       = "X-Bastion-Action"
 	headerValueClearToken = "clear-token"
+
+	// browsers can not set headers for websocket, token is passed as subprotocol "bastion.token.<token>" along with "bastion",
+	// query string is not used since it ends up in access logs
+	subprotocolBastion     = "bastion"
+	subprotocolTokenPrefix = "bastion.token."
 )
 
-func optsModule(opts types.WebOptions) nova.HandlerFunc {
+func optsModule(opts types.WebOptions, sshdOpts types.SSHDOptions) nova.HandlerFunc {
 	return func(c *nova.Context) error {
 		c.Values[contextKeyWebOptions] = opts
+		c.Values[contextKeySSHDOptions] = sshdOpts
 		c.Next()
 		return nil
 	}
@@ -32,10 +50,49 @@ func webOptions(c *nova.Context) (opts types.WebOptions) {
 	return
 }
 
+func sshdOptions(c *nova.Context) (opts types.SSHDOptions) {
+	opts, _ = c.Values[contextKeySSHDOptions].(types.SSHDOptions)
+	return
+}
+
+// oidcModule provides the OpenID Connect client if configured, client is shared across requests to cache discovery
+func oidcModule(opts types.OIDCOptions) nova.HandlerFunc {
+	var o *oidcClient
+	if opts.Enabled() {
+		o = newOIDCClient(opts)
+	}
+	return func(c *nova.Context) error {
+		c.Values[contextKeyOIDC] = o
+		c.Next()
+		return nil
+	}
+}
+
+// requiresOIDC requires OpenID Connect login configured
+func requiresOIDC() nova.HandlerFunc {
+	return func(c *nova.Context) (err error) {
+		if oidcClientOf(c) == nil {
+			err = errors.New("oidc login not enabled")
+			return
+		}
+		c.Next()
+		return
+	}
+}
+
+func oidcClientOf(c *nova.Context) *oidcClient {
+	o, _ := c.Values[contextKeyOIDC].(*oidcClient)
+	return o
+}
+
 func rpcModule(opts types.WebOptions) nova.HandlerFunc {
 	return func(c *nova.Context) (err error) {
+		var dos []grpc.DialOption
+		if dos, err = types.DialOptions(types.SourceWeb, "", opts.TLS); err != nil {
+			return
+		}
 		var conn *grpc.ClientConn
-		if conn, err = grpc.Dial(opts.DaemonEndpoint, grpc.WithInsecure()); err != nil {
+		if conn, err = grpc.Dial(opts.DaemonEndpoint, dos...); err != nil {
 			return
 		}
 		defer conn.Close()
@@ -45,6 +102,40 @@ func rpcModule(opts types.WebOptions) nova.HandlerFunc {
 	}
 }
 
+// sandboxManagerModule provides the sandbox manager, manager is shared across requests to serialize sandbox creation
+func sandboxManagerModule(m sandbox.Manager) nova.HandlerFunc {
+	return func(c *nova.Context) error {
+		c.Values[contextKeySandboxMgr] = m
+		c.Next()
+		return nil
+	}
+}
+
+func sandboxManager(c *nova.Context) sandbox.Manager {
+	return c.Values[contextKeySandboxMgr].(sandbox.Manager)
+}
+
+// sandboxModule find the sandbox of current user, must be mounted after requiresLoggedIn
+func sandboxModule() nova.HandlerFunc {
+	return func(c *nova.Context) (err error) {
+		var sb sandbox.Sandbox
+		if sb, err = sandboxManager(c).Find(authResult(c).User.Account); err != nil {
+			return
+		}
+		c.Values[contextKeySandbox] = sb
+		c.Next()
+		return
+	}
+}
+
+func currentSandbox(c *nova.Context) sandbox.Sandbox {
+	return c.Values[contextKeySandbox].(sandbox.Sandbox)
+}
+
+func rpcConn(c *nova.Context) *grpc.ClientConn {
+	return c.Values[contextKeyGRPCConn].(*grpc.ClientConn)
+}
+
 func userService(c *nova.Context) types.UserServiceClient {
 	return types.NewUserServiceClient(c.Values[contextKeyGRPCConn].(*grpc.ClientConn))
 }
@@ -77,10 +168,28 @@ func masterKeyService(c *nova.Context) types.MasterKeyServiceClient {
 	return types.NewMasterKeyServiceClient(c.Values[contextKeyGRPCConn].(*grpc.ClientConn))
 }
 
+func transferService(c *nova.Context) types.TransferServiceClient {
+	return types.NewTransferServiceClient(c.Values[contextKeyGRPCConn].(*grpc.ClientConn))
+}
+
+func groupService(c *nova.Context) types.GroupServiceClient {
+	return types.NewGroupServiceClient(c.Values[contextKeyGRPCConn].(*grpc.ClientConn))
+}
+
+func accessRequestService(c *nova.Context) types.AccessRequestServiceClient {
+	return types.NewAccessRequestServiceClient(c.Values[contextKeyGRPCConn].(*grpc.ClientConn))
+}
+
+func auditService(c *nova.Context) types.AuditServiceClient {
+	return types.NewAuditServiceClient(c.Values[contextKeyGRPCConn].(*grpc.ClientConn))
+}
+
 // Auth result
 type Auth struct {
-	Token *types.Token
-	User  *types.User
+	Token       *types.Token
+	User        *types.User
+	Roles       []string
+	Permissions utils.Permissions
 }
 
 func (a Auth) IsLoggedIn() bool {
@@ -91,8 +200,12 @@ func (a Auth) IsBlocked() bool {
 	return a.IsLoggedIn() && a.User.IsBlocked
 }
 
-func (a Auth) IsLoggedInAsAdmin() bool {
-	return a.IsLoggedIn() && a.User.IsAdmin
+func (a Auth) HasPermission(perm string) bool {
+	return a.IsLoggedIn() && a.Permissions.Has(perm)
+}
+
+func (a Auth) HasScope(scope string) bool {
+	return a.IsLoggedIn() && a.Token.HasScope(scope)
 }
 
 func markClearTokenIfNeeded(c *nova.Context, err error) {
@@ -107,11 +220,28 @@ func markClearTokenIfNeeded(c *nova.Context, err error) {
 	}
 }
 
+// websocketToken extract token from the websocket subprotocols
+func websocketToken(req *http.Request) string {
+	for _, p := range websocket.Subprotocols(req) {
+		if strings.HasPrefix(p, subprotocolTokenPrefix) {
+			return strings.TrimPrefix(p, subprotocolTokenPrefix)
+		}
+	}
+	return ""
+}
+
 func authModule() nova.HandlerFunc {
 	return func(c *nova.Context) (err error) {
+		// forward address of end user, recorded in audit events
+		if host, _, err := net.SplitHostPort(c.Req.RemoteAddr); err == nil {
+			c.Req = c.Req.WithContext(metadata.AppendToOutgoingContext(c.Req.Context(), types.MetadataKeyClientAddr, host))
+		}
 		ts, us := tokenService(c), userService(c)
 		a := Auth{}
 		token := c.Req.Header.Get(headerKeyToken)
+		if len(token) == 0 && websocket.IsWebSocketUpgrade(c.Req) {
+			token = websocketToken(c.Req)
+		}
 		if len(token) != 0 {
 			// get token by token value
 			var res1 *types.GetTokenResponse
@@ -120,6 +250,11 @@ func authModule() nova.HandlerFunc {
 				return
 			}
 			a.Token = res1.Token
+			if a.Token.IsExpired(time.Now().Unix()) {
+				c.Res.Header().Set(headerKeyAction, headerValueClearToken)
+				err = errors.New("token expired")
+				return
+			}
 			// get user
 			var res2 *types.GetUserResponse
 			if res2, err = us.GetUser(c.Req.Context(), &types.GetUserRequest{Account: a.Token.Account}); err != nil {
@@ -127,9 +262,18 @@ func authModule() nova.HandlerFunc {
 				return
 			}
 			a.User = res2.User
+			a.Roles = res2.EffectiveRoles
+			a.Permissions = utils.ResolvePermissions(res2.EffectiveRoles).Limit(a.Token.Scopes)
 			// touch token by token id, touch user by user account
 			ts.TouchToken(c.Req.Context(), &types.TouchTokenRequest{Id: res1.Token.Id})
 			us.TouchUser(c.Req.Context(), &types.TouchUserRequest{Account: res2.User.Account})
+			// following rpc calls are made on behalf of the user, daemon checks permissions of the user
+			c.Req = c.Req.WithContext(metadata.AppendToOutgoingContext(c.Req.Context(), types.MetadataKeyActor, a.User.Account))
+			// only tokens with admin scope can make changes
+			if c.Req.Method != http.MethodGet && c.Req.Method != http.MethodHead && !a.HasScope(types.TokenScopeAdmin) {
+				err = errors.New("token scope does not allow changes")
+				return
+			}
 		}
 		c.Values[contextKeyAuth] = a
 		c.Next()
@@ -137,18 +281,46 @@ func authModule() nova.HandlerFunc {
 	}
 }
 
-func requiresLoggedIn(admin bool) nova.HandlerFunc {
+// requiresLoggedIn requires a token with "admin" or "read-only" scope, "replays" scope only accesses sessions and replays
+func requiresLoggedIn() nova.HandlerFunc {
 	return func(c *nova.Context) (err error) {
 		a := authResult(c)
 		if !a.IsLoggedIn() {
 			err = errors.New("not logged in")
 			return
 		}
-		if admin {
-			if !a.IsLoggedInAsAdmin() {
-				err = errors.New("not admin")
-				return
-			}
+		if !a.HasScope(types.TokenScopeAdmin) && !a.HasScope(types.TokenScopeReadOnly) {
+			err = errors.New("token scope not allowed")
+			return
+		}
+		c.Next()
+		return
+	}
+}
+
+// requiresScope requires the token scope, must be mounted after requiresLoggedIn
+func requiresScope(scope string) nova.HandlerFunc {
+	return func(c *nova.Context) (err error) {
+		if !authResult(c).HasScope(scope) {
+			err = errors.New("token scope not allowed")
+			return
+		}
+		c.Next()
+		return
+	}
+}
+
+// requiresPermission requires the permission in any scope, scoped permissions are further checked by daemon
+func requiresPermission(perm string) nova.HandlerFunc {
+	return func(c *nova.Context) (err error) {
+		a := authResult(c)
+		if !a.IsLoggedIn() {
+			err = errors.New("not logged in")
+			return
+		}
+		if !a.HasPermission(perm) {
+			err = errors.New("no permission")
+			return
 		}
 		c.Next()
 		return
@@ -0,0 +1,252 @@
+package web
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/coreos/go-oidc"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"sync"
+)
+
+// oidcIdentity identity extracted from claims of id token
+type oidcIdentity struct {
+	Issuer   string
+	Subject  string
+	Account  string
+	Nickname string
+	Groups   []string
+}
+
+// oidcClient OpenID Connect relying party, provider is discovered on first use and cached
+type oidcClient struct {
+	opts types.OIDCOptions
+
+	lock     sync.Mutex
+	provider *oidc.Provider
+}
+
+func newOIDCClient(opts types.OIDCOptions) *oidcClient {
+	return &oidcClient{opts: opts}
+}
+
+func (o *oidcClient) setup(ctx context.Context) (cfg oauth2.Config, verifier *oidc.IDTokenVerifier, err error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	// discovery failure is not cached, issuer may be temporarily unavailable
+	if o.provider == nil {
+		if o.provider, err = oidc.NewProvider(ctx, o.opts.Issuer); err != nil {
+			return
+		}
+	}
+	cfg = oauth2.Config{
+		ClientID:     o.opts.ClientID,
+		ClientSecret: o.opts.ClientSecret,
+		Endpoint:     o.provider.Endpoint(),
+		RedirectURL:  o.opts.RedirectURL,
+		Scopes:       append([]string{oidc.ScopeOpenID}, o.opts.Scopes...),
+	}
+	verifier = o.provider.Verifier(&oidc.Config{ClientID: o.opts.ClientID})
+	return
+}
+
+// AuthCodeURL returns the url of issuer to redirect to
+func (o *oidcClient) AuthCodeURL(ctx context.Context, state, nonce string) (u string, err error) {
+	var cfg oauth2.Config
+	if cfg, _, err = o.setup(ctx); err != nil {
+		return
+	}
+	u = cfg.AuthCodeURL(state, oidc.Nonce(nonce))
+	return
+}
+
+// Exchange exchanges the authorization code for id token, verifies it and extracts the identity
+func (o *oidcClient) Exchange(ctx context.Context, code, nonce string) (id oidcIdentity, err error) {
+	var cfg oauth2.Config
+	var verifier *oidc.IDTokenVerifier
+	if cfg, verifier, err = o.setup(ctx); err != nil {
+		return
+	}
+	var t *oauth2.Token
+	if t, err = cfg.Exchange(ctx, code); err != nil {
+		return
+	}
+	raw, ok := t.Extra("id_token").(string)
+	if !ok {
+		err = errors.New("missing id_token in token response")
+		return
+	}
+	var it *oidc.IDToken
+	if it, err = verifier.Verify(ctx, raw); err != nil {
+		return
+	}
+	if it.Nonce != nonce {
+		err = errors.New("nonce of id_token mismatched")
+		return
+	}
+	claims := map[string]interface{}{}
+	if err = it.Claims(&claims); err != nil {
+		return
+	}
+	if id, err = o.identity(claims); err != nil {
+		return
+	}
+	id.Issuer, id.Subject = it.Issuer, it.Subject
+	return
+}
+
+func (o *oidcClient) identity(claims map[string]interface{}) (id oidcIdentity, err error) {
+	id.Account, _ = claims[o.opts.AccountClaim].(string)
+	id.Account = strings.ToLower(strings.TrimSpace(id.Account))
+	// use local part if account claim is an email address of allowed domains
+	if i := strings.Index(id.Account, "@"); i >= 0 {
+		domain := id.Account[i+1:]
+		allowed := false
+		for _, d := range o.opts.AllowedDomains {
+			if strings.ToLower(strings.TrimSpace(d)) == domain {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			err = fmt.Errorf("email domain '%s' not allowed", domain)
+			return
+		}
+		id.Account = id.Account[:i]
+	}
+	if len(id.Account) == 0 {
+		err = fmt.Errorf("missing claim '%s' in id_token", o.opts.AccountClaim)
+		return
+	}
+	id.Nickname, _ = claims[o.opts.NicknameClaim].(string)
+	if len(o.opts.GroupsClaim) > 0 {
+		switch v := claims[o.opts.GroupsClaim].(type) {
+		case []interface{}:
+			for _, g := range v {
+				if s, ok := g.(string); ok {
+					id.Groups = append(id.Groups, s)
+				}
+			}
+		case string:
+			id.Groups = append(id.Groups, v)
+		}
+	}
+	return
+}
+
+// Provision resolves the user of identity, creates it if auto provisioning is enabled,
+// and synchronizes is_admin and group memberships of users created by OIDC,
+// existing users are only resolved if they are created by OIDC with the same issuer and subject
+func (o *oidcClient) Provision(ctx context.Context, conn *grpc.ClientConn, id oidcIdentity) (u *types.User, err error) {
+	us := types.NewUserServiceClient(conn)
+	var gur *types.GetUserResponse
+	if gur, err = us.GetUser(ctx, &types.GetUserRequest{Account: id.Account}); err == nil {
+		u = gur.User
+		if u.Source != types.UserSourceOIDC || u.OidcIssuer != id.Issuer || u.OidcSubject != id.Subject {
+			log.Warn().Str("account", u.Account).Str("issuer", id.Issuer).Str("subject", id.Subject).Msg("oidc identity not bound to existing user")
+			err = fmt.Errorf("user '%s' is not bound to the oidc identity", id.Account)
+			return
+		}
+	} else if s, ok := status.FromError(err); ok && s.Code() == codes.InvalidArgument {
+		if !o.opts.AutoProvision {
+			err = fmt.Errorf("user '%s' not exists", id.Account)
+			return
+		}
+		log.Info().Str("account", id.Account).Msg("create user from oidc")
+		var cur *types.CreateUserResponse
+		if cur, err = us.CreateUser(ctx, &types.CreateUserRequest{
+			Account:     id.Account,
+			Password:    randomHex(24),
+			Nickname:    id.Nickname,
+			Source:      types.UserSourceOIDC,
+			OidcIssuer:  id.Issuer,
+			OidcSubject: id.Subject,
+		}); err != nil {
+			return
+		}
+		u = cur.User
+	} else {
+		return
+	}
+	if u.IsBlocked {
+		err = errors.New("user is blocked")
+		return
+	}
+	if len(o.opts.GroupsClaim) == 0 {
+		return
+	}
+	if len(o.opts.AdminGroups) > 0 {
+		isAdmin := false
+		for _, g := range id.Groups {
+			for _, ag := range o.opts.AdminGroups {
+				if g == ag {
+					isAdmin = true
+				}
+			}
+		}
+		if isAdmin != u.IsAdmin {
+			log.Info().Str("account", u.Account).Bool("is_admin", isAdmin).Msg("update user is_admin from oidc")
+			var uur *types.UpdateUserResponse
+			if uur, err = us.UpdateUser(ctx, &types.UpdateUserRequest{
+				Account:       u.Account,
+				UpdateIsAdmin: true,
+				IsAdmin:       isAdmin,
+			}); err != nil {
+				return
+			}
+			u = uur.User
+		}
+	}
+	err = o.syncGroups(ctx, types.NewGroupServiceClient(conn), u.Account, id.Groups)
+	return
+}
+
+// syncGroups joins existing groups in claim, and leaves groups joined by OIDC but missing in claim
+func (o *oidcClient) syncGroups(ctx context.Context, gs types.GroupServiceClient, account string, groups []string) (err error) {
+	var lgr *types.ListGroupsResponse
+	if lgr, err = gs.ListGroups(ctx, &types.ListGroupsRequest{}); err != nil {
+		return
+	}
+	wanted := map[string]bool{}
+	for _, g := range groups {
+		wanted[g] = true
+	}
+	for _, g := range lgr.Groups {
+		var lmr *types.ListGroupMembersResponse
+		if lmr, err = gs.ListGroupMembers(ctx, &types.ListGroupMembersRequest{Group: g.Name}); err != nil {
+			return
+		}
+		var m *types.GroupMember
+		for _, gm := range lmr.Members {
+			if gm.Account == account {
+				m = gm
+			}
+		}
+		if wanted[g.Name] && m == nil {
+			log.Info().Str("group", g.Name).Str("account", account).Msg("add group member from oidc")
+			if _, err = gs.PutGroupMember(ctx, &types.PutGroupMemberRequest{Group: g.Name, Account: account, Source: types.GroupMemberSourceOIDC}); err != nil {
+				return
+			}
+		} else if !wanted[g.Name] && m != nil && m.Source == types.GroupMemberSourceOIDC {
+			log.Info().Str("group", g.Name).Str("account", account).Msg("remove group member from oidc")
+			if _, err = gs.DeleteGroupMember(ctx, &types.DeleteGroupMemberRequest{Group: g.Name, Account: account}); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
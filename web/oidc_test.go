@@ -0,0 +1,251 @@
+package web
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/yankeguo/bastion/daemon"
+	"github.com/yankeguo/bastion/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"gopkg.in/square/go-jose.v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testIssuer minimal OpenID Connect issuer, id tokens are issued with claims registered by code
+type testIssuer struct {
+	s      *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]map[string]interface{}
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := &testIssuer{key: key, claims: map[string]map[string]interface{}{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                i.s.URL,
+			"authorization_endpoint":                i.s.URL + "/auth",
+			"token_endpoint":                        i.s.URL + "/token",
+			"jwks_uri":                              i.s.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &i.key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := i.claims[r.FormValue("code")]
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     i.sign(t, claims),
+		})
+	})
+	i.s = httptest.NewServer(mux)
+	return i
+}
+
+func (i *testIssuer) sign(t *testing.T, claims map[string]interface{}) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: i.key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	full := map[string]interface{}{
+		"iss": i.s.URL,
+		"sub": "subject",
+		"aud": "bastion",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+	}
+	for k, v := range claims {
+		full[k] = v
+	}
+	buf, _ := json.Marshal(full)
+	var jws *jose.JSONWebSignature
+	if jws, err = signer.Sign(buf); err != nil {
+		t.Fatal(err)
+	}
+	var raw string
+	if raw, err = jws.CompactSerialize(); err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func (i *testIssuer) Close() {
+	i.s.Close()
+}
+
+func withDaemon(t *testing.T, cb func(*testing.T, *grpc.ClientConn)) {
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	d := daemon.New(types.DaemonOptions{
+		DB:        filepath.Join(os.TempDir(), "bnktestdb"+hex.EncodeToString(buf)+".bolt"),
+		Host:      "127.0.0.1",
+		Port:      2995,
+		ReplayDir: os.TempDir(),
+	})
+	go d.Run()
+	defer d.Stop()
+	time.Sleep(time.Second / 2)
+	c, err := grpc.Dial("127.0.0.1:2995", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	cb(t, c)
+}
+
+func TestOIDCClient_Identity(t *testing.T) {
+	o := newOIDCClient(types.OIDCOptions{AccountClaim: "email", NicknameClaim: "name", GroupsClaim: "groups", AllowedDomains: []string{"Example.com"}})
+	id, err := o.identity(map[string]interface{}{
+		"email":  " Test@example.com",
+		"name":   "Test User",
+		"groups": []interface{}{"ops", 1, "dev"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Account != "test" || id.Nickname != "Test User" || strings.Join(id.Groups, ",") != "ops,dev" {
+		t.Fatal("bad identity", id)
+	}
+	if _, err = o.identity(map[string]interface{}{"name": "Test User"}); err == nil {
+		t.Fatal("should fail without account claim")
+	}
+	if _, err = o.identity(map[string]interface{}{"email": "test@evil.com"}); err == nil {
+		t.Fatal("should fail with email of other domain")
+	}
+	if _, err = o.identity(map[string]interface{}{"email": "test@evil.com@example.com"}); err == nil {
+		t.Fatal("should fail with invalid account")
+	}
+}
+
+func TestOIDCClient_Exchange(t *testing.T) {
+	i := newTestIssuer(t)
+	defer i.Close()
+	o := newOIDCClient(types.OIDCOptions{
+		Issuer:        i.s.URL,
+		ClientID:      "bastion",
+		ClientSecret:  "secret",
+		RedirectURL:   "http://127.0.0.1:9778/api/oidc/callback",
+		AccountClaim:  "preferred_username",
+		NicknameClaim: "name",
+	})
+	ctx := context.Background()
+	u, err := o.AuthCodeURL(ctx, "state1", "nonce1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pu, _ := url.Parse(u)
+	if !strings.HasPrefix(u, i.s.URL+"/auth?") || pu.Query().Get("state") != "state1" || pu.Query().Get("nonce") != "nonce1" || pu.Query().Get("client_id") != "bastion" {
+		t.Fatal("bad auth code url", u)
+	}
+	i.claims["code1"] = map[string]interface{}{"preferred_username": "test", "name": "Test User", "nonce": "nonce1"}
+	id, err := o.Exchange(ctx, "code1", "nonce1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Account != "test" || id.Nickname != "Test User" || id.Issuer != i.s.URL || id.Subject != "subject" {
+		t.Fatal("bad identity", id)
+	}
+	if _, err = o.Exchange(ctx, "code1", "nonce2"); err == nil {
+		t.Fatal("should fail with mismatched nonce")
+	}
+	if _, err = o.Exchange(ctx, "code2", "nonce1"); err == nil {
+		t.Fatal("should fail with unknown code")
+	}
+	i.claims["code3"] = map[string]interface{}{"preferred_username": "test", "aud": "other", "nonce": "nonce1"}
+	if _, err = o.Exchange(ctx, "code3", "nonce1"); err == nil {
+		t.Fatal("should fail with other audience")
+	}
+}
+
+func TestOIDCClient_Provision(t *testing.T) {
+	withDaemon(t, func(t *testing.T, conn *grpc.ClientConn) {
+		ctx := context.Background()
+		us, gs := types.NewUserServiceClient(conn), types.NewGroupServiceClient(conn)
+		opts := types.OIDCOptions{GroupsClaim: "groups", AdminGroups: []string{"admins"}}
+		o := newOIDCClient(opts)
+		if _, err := o.Provision(ctx, conn, oidcIdentity{Issuer: "https://issuer", Subject: "test", Account: "test"}); err == nil {
+			t.Fatal("should fail without auto provision")
+		}
+		opts.AutoProvision = true
+		o = newOIDCClient(opts)
+		for _, name := range []string{"ops", "admins", "dev"} {
+			if _, err := gs.PutGroup(ctx, &types.PutGroupRequest{Name: name}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		u, err := o.Provision(ctx, conn, oidcIdentity{Issuer: "https://issuer", Subject: "test", Account: "test", Nickname: "Test User", Groups: []string{"ops", "admins", "missing"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Source != types.UserSourceOIDC || u.Nickname != "Test User" || !u.IsAdmin {
+			t.Fatal("bad user", u)
+		}
+		if _, err = gs.PutGroupMember(ctx, &types.PutGroupMemberRequest{Group: "dev", Account: "test"}); err != nil {
+			t.Fatal(err)
+		}
+		// second login removes memberships and admin granted by oidc, manual memberships are kept
+		if u, err = o.Provision(ctx, conn, oidcIdentity{Issuer: "https://issuer", Subject: "test", Account: "test", Groups: []string{"ops"}}); err != nil {
+			t.Fatal(err)
+		}
+		if u.IsAdmin {
+			t.Fatal("should not be admin")
+		}
+		lgr, err := gs.ListGroups(ctx, &types.ListGroupsRequest{Account: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := []string{}
+		for _, g := range lgr.Groups {
+			names = append(names, g.Name)
+		}
+		if strings.Join(names, ",") != "dev,ops" {
+			t.Fatal("bad groups", names)
+		}
+		// other identities of the same account are refused
+		if _, err = o.Provision(ctx, conn, oidcIdentity{Issuer: "https://issuer", Subject: "other", Account: "test"}); err == nil {
+			t.Fatal("should fail for other subject")
+		}
+		if _, err = o.Provision(ctx, conn, oidcIdentity{Issuer: "https://other", Subject: "test", Account: "test"}); err == nil {
+			t.Fatal("should fail for other issuer")
+		}
+		// users created manually are never bound
+		if _, err = us.CreateUser(ctx, &types.CreateUserRequest{Account: "manual", Password: "qwerty123", IsAdmin: true}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = o.Provision(ctx, conn, oidcIdentity{Issuer: "https://issuer", Subject: "manual", Account: "manual"}); err == nil {
+			t.Fatal("should fail for manual user")
+		}
+		if _, err = us.UpdateUser(ctx, &types.UpdateUserRequest{Account: "test", UpdateIsBlocked: true, IsBlocked: true}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = o.Provision(ctx, conn, oidcIdentity{Issuer: "https://issuer", Subject: "test", Account: "test"}); err == nil {
+			t.Fatal("should fail for blocked user")
+		}
+	})
+}
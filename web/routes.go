@@ -12,102 +12,239 @@ func mountRoutes(n *nova.Nova) {
 	router.Route(n).Get("/api/check").Use(routeCheck)
 	router.Route(n).Get("/api/authorized_keys").Use(routeAuthorizedKeys)
 	router.Route(n).Post("/api/tokens/create").Use(routeCreateToken)
+	router.Route(n).Get("/api/tokens/current").Use(
+		requiresLoggedIn(),
+		routeGetCurrentToken,
+	)
+	router.Route(n).Get("/api/oidc").Use(routeGetOIDC)
+	router.Route(n).Get("/api/oidc/login").Use(
+		requiresOIDC(),
+		routeOIDCLogin,
+	)
+	router.Route(n).Get("/api/oidc/callback").Use(
+		requiresOIDC(),
+		routeOIDCCallback,
+	)
+	router.Route(n).Post("/api/tokens/create_personal").Use(
+		requiresLoggedIn(),
+		routeCreatePersonalToken,
+	)
 	router.Route(n).Get("/api/tokens").Use(
-		requiresLoggedIn(false),
+		requiresLoggedIn(),
 		routeListTokens,
 	)
 	router.Route(n).Post("/api/tokens/destroy").Use(
-		requiresLoggedIn(false),
+		requiresLoggedIn(),
 		routeDestroyToken,
 	)
 	router.Route(n).Get("/api/users/current").Use(
-		requiresLoggedIn(false),
+		requiresLoggedIn(),
 		routeGetCurrentUser,
 	)
 	router.Route(n).Post("/api/users/current/update_password").Use(
-		requiresLoggedIn(false),
+		requiresLoggedIn(),
 		routeUpdateCurrentUserPassword,
 	)
 	router.Route(n).Get("/api/users/current/grant_items").Use(
-		requiresLoggedIn(false),
+		requiresLoggedIn(),
 		routeGetCurrentUserGrantItems,
 	)
+	router.Route(n).Get("/api/users/current/groups").Use(
+		requiresLoggedIn(),
+		routeGetCurrentUserGroups,
+	)
 	router.Route(n).Get("/api/users/current/keys").Use(
-		requiresLoggedIn(false),
+		requiresLoggedIn(),
 		routeListKeys,
 	)
 	router.Route(n).Post("/api/users/current/keys/create").Use(
-		requiresLoggedIn(false),
+		requiresLoggedIn(),
 		routeCreateKey,
 	)
+	router.Route(n).Post("/api/users/current/sandbox/upload").Use(
+		requiresLoggedIn(),
+		sandboxModule(),
+		routeUploadSandboxFile,
+	)
+	router.Route(n).Get("/api/users/current/sandbox/download").Use(
+		requiresLoggedIn(),
+		requiresScope(types.TokenScopeAdmin),
+		sandboxModule(),
+		routeDownloadSandboxFile,
+	)
+	router.Route(n).Get("/api/users/current/terminal").Use(
+		requiresLoggedIn(),
+		requiresScope(types.TokenScopeAdmin),
+		routeTerminal,
+	)
+	router.Route(n).Get("/api/users/current/access_requests").Use(
+		requiresLoggedIn(),
+		routeListCurrentUserAccessRequests,
+	)
+	router.Route(n).Post("/api/users/current/access_requests/create").Use(
+		requiresLoggedIn(),
+		routeCreateCurrentUserAccessRequest,
+	)
+	router.Route(n).Post("/api/users/current/access_requests/cancel").Use(
+		requiresLoggedIn(),
+		routeCancelCurrentUserAccessRequest,
+	)
 	router.Route(n).Post("/api/keys/destroy").Use(
-		requiresLoggedIn(false),
+		requiresLoggedIn(),
 		routeDestroyKey,
 	)
 	router.Route(n).Get("/api/nodes").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionNodesRead),
 		routeListNodes,
 	)
 	router.Route(n).Post("/api/nodes/create").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionNodesWrite),
 		routeCreateNode,
 	)
 	router.Route(n).Post("/api/nodes/destroy").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionNodesWrite),
 		routeDestroyNode,
 	)
 	router.Route(n).Post("/api/nodes/update_is_key_managed").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionNodesWrite),
 		routeUpdateNodeIsKeyManaged,
 	)
+	router.Route(n).Post("/api/nodes/update_labels").Use(
+		requiresPermission(types.PermissionNodesWrite),
+		routeUpdateNodeLabels,
+	)
 	router.Route(n).Get("/api/users").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionUsersRead),
 		routeListUsers,
 	)
 	router.Route(n).Post("/api/users/create").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionUsersWrite),
 		routeCreateUser,
 	)
 	router.Route(n).Post("/api/users/update_is_admin").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionRolesWrite),
 		routeUpdateUserIsAdmin,
 	)
+	router.Route(n).Post("/api/users/update_roles").Use(
+		requiresPermission(types.PermissionRolesWrite),
+		routeUpdateUserRoles,
+	)
 	router.Route(n).Post("/api/users/update_is_blocked").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionUsersWrite),
 		routeUpdateUserIsBlocked,
 	)
 	router.Route(n).Post("/api/users/update_nickname").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionUsersWrite),
 		routeUpdateUserNickname,
 	)
 	router.Route(n).Get("/api/users/:account").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionUsersRead),
 		routeGetUser,
 	)
 	router.Route(n).Get("/api/users/:account/grants").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionGrantsRead),
 		routeGetGrants,
 	)
 	router.Route(n).Post("/api/users/:account/grants/create").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionGrantsWrite),
 		routeCreateGrant,
 	)
 	router.Route(n).Post("/api/users/:account/grants/destroy").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionGrantsWrite),
 		routeDestroyGrant,
 	)
+	router.Route(n).Get("/api/groups").Use(
+		requiresPermission(types.PermissionUsersRead),
+		routeListGroups,
+	)
+	router.Route(n).Post("/api/groups/create").Use(
+		requiresPermission(types.PermissionUsersWrite),
+		routeCreateGroup,
+	)
+	router.Route(n).Post("/api/groups/destroy").Use(
+		requiresPermission(types.PermissionUsersWrite),
+		routeDestroyGroup,
+	)
+	router.Route(n).Post("/api/groups/update_roles").Use(
+		requiresPermission(types.PermissionRolesWrite),
+		routeUpdateGroupRoles,
+	)
+	router.Route(n).Get("/api/groups/:name/members").Use(
+		requiresPermission(types.PermissionUsersRead),
+		routeGetGroupMembers,
+	)
+	router.Route(n).Post("/api/groups/:name/members/create").Use(
+		requiresPermission(types.PermissionUsersWrite),
+		routeCreateGroupMember,
+	)
+	router.Route(n).Post("/api/groups/:name/members/destroy").Use(
+		requiresPermission(types.PermissionUsersWrite),
+		routeDestroyGroupMember,
+	)
+	router.Route(n).Get("/api/groups/:name/grants").Use(
+		requiresPermission(types.PermissionGrantsRead),
+		routeGetGroupGrants,
+	)
+	router.Route(n).Post("/api/groups/:name/grants/create").Use(
+		requiresPermission(types.PermissionGrantsWrite),
+		routeCreateGroupGrant,
+	)
+	router.Route(n).Post("/api/groups/:name/grants/destroy").Use(
+		requiresPermission(types.PermissionGrantsWrite),
+		routeDestroyGroupGrant,
+	)
+	router.Route(n).Get("/api/access_requests").Use(
+		requiresPermission(types.PermissionGrantsRead),
+		routeListAccessRequests,
+	)
+	router.Route(n).Get("/api/access_requests/:id").Use(
+		requiresLoggedIn(),
+		routeGetAccessRequest,
+	)
+	router.Route(n).Post("/api/access_requests/approve").Use(
+		requiresPermission(types.PermissionGrantsWrite),
+		routeApproveAccessRequest,
+	)
+	router.Route(n).Post("/api/access_requests/reject").Use(
+		requiresPermission(types.PermissionGrantsWrite),
+		routeRejectAccessRequest,
+	)
 	router.Route(n).Get("/api/sessions").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionSessionsRead),
 		routeListSessions,
 	)
+	router.Route(n).Post("/api/sessions/update_legal_hold").Use(
+		requiresPermission(types.PermissionSessionsWrite),
+		routeUpdateSessionLegalHold,
+	)
 	router.Route(n).Get("/api/sessions/:id").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionSessionsRead),
 		routeGetSession,
 	)
+	router.Route(n).Get("/api/replays/search").Use(
+		requiresPermission(types.PermissionSessionsRead),
+		routeSearchReplays,
+	)
 	router.Route(n).Get("/api/replays/:id/download").Use(
-		requiresLoggedIn(true),
+		requiresPermission(types.PermissionSessionsRead),
 		routeDownloadReplay,
 	)
+	router.Route(n).Get("/api/replays/:id/export").Use(
+		requiresPermission(types.PermissionSessionsRead),
+		routeExportReplay,
+	)
+	router.Route(n).Get("/api/replays/:id/transcript").Use(
+		requiresPermission(types.PermissionSessionsRead),
+		routeGetTranscript,
+	)
+	router.Route(n).Get("/api/transfers").Use(
+		requiresPermission(types.PermissionSessionsRead),
+		routeListTransfers,
+	)
+	router.Route(n).Get("/api/audit_events").Use(
+		requiresPermission(types.PermissionAuditRead),
+		routeListAuditEvents,
+	)
 	router.Route(n).Get("/replays/:id").Use(routePageReplay)
 }
 
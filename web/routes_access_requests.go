@@ -0,0 +1,117 @@
+package web
+
+import (
+	"github.com/novakit/nova"
+	"github.com/novakit/router"
+	"github.com/novakit/view"
+	"github.com/yankeguo/bastion/types"
+	"strconv"
+)
+
+func routeListCurrentUserAccessRequests(c *nova.Context) (err error) {
+	a, ars, v := authResult(c), accessRequestService(c), view.Extract(c)
+	var res1 *types.ListAccessRequestsResponse
+	if res1, err = ars.ListAccessRequests(c.Req.Context(), &types.ListAccessRequestsRequest{
+		Account: a.User.Account,
+		Status:  c.Req.FormValue("status"),
+	}); err != nil {
+		return
+	}
+	v.Data["access_requests"] = res1.AccessRequests
+	v.DataAsJSON()
+	return
+}
+
+func routeCreateCurrentUserAccessRequest(c *nova.Context) (err error) {
+	a, ars, v := authResult(c), accessRequestService(c), view.Extract(c)
+	duration, _ := strconv.ParseInt(c.Req.FormValue("duration"), 10, 64)
+	var res1 *types.CreateAccessRequestResponse
+	if res1, err = ars.CreateAccessRequest(c.Req.Context(), &types.CreateAccessRequestRequest{
+		Account:         a.User.Account,
+		HostnamePattern: c.Req.FormValue("hostname_pattern"),
+		User:            c.Req.FormValue("user"),
+		Duration:        duration,
+		Justification:   c.Req.FormValue("justification"),
+	}); err != nil {
+		return
+	}
+	v.Data["access_request"] = res1.AccessRequest
+	v.DataAsJSON()
+	return
+}
+
+func routeCancelCurrentUserAccessRequest(c *nova.Context) (err error) {
+	a, ars, v := authResult(c), accessRequestService(c), view.Extract(c)
+	id, _ := strconv.ParseInt(c.Req.FormValue("id"), 10, 64)
+	var res1 *types.CancelAccessRequestResponse
+	if res1, err = ars.CancelAccessRequest(c.Req.Context(), &types.CancelAccessRequestRequest{
+		Id:      id,
+		Account: a.User.Account,
+	}); err != nil {
+		return
+	}
+	v.Data["access_request"] = res1.AccessRequest
+	v.DataAsJSON()
+	return
+}
+
+func routeListAccessRequests(c *nova.Context) (err error) {
+	ars, v := accessRequestService(c), view.Extract(c)
+	var res1 *types.ListAccessRequestsResponse
+	if res1, err = ars.ListAccessRequests(c.Req.Context(), &types.ListAccessRequestsRequest{
+		Account: c.Req.FormValue("account"),
+		Status:  c.Req.FormValue("status"),
+	}); err != nil {
+		return
+	}
+	v.Data["access_requests"] = res1.AccessRequests
+	v.DataAsJSON()
+	return
+}
+
+func routeGetAccessRequest(c *nova.Context) (err error) {
+	ars, v, rp := accessRequestService(c), view.Extract(c), router.PathParams(c)
+	id, _ := strconv.ParseInt(rp.Get("id"), 10, 64)
+	var res1 *types.GetAccessRequestResponse
+	if res1, err = ars.GetAccessRequest(c.Req.Context(), &types.GetAccessRequestRequest{
+		Id: id,
+	}); err != nil {
+		return
+	}
+	v.Data["access_request"] = res1.AccessRequest
+	v.Data["events"] = res1.Events
+	v.DataAsJSON()
+	return
+}
+
+func routeApproveAccessRequest(c *nova.Context) (err error) {
+	a, ars, v := authResult(c), accessRequestService(c), view.Extract(c)
+	id, _ := strconv.ParseInt(c.Req.FormValue("id"), 10, 64)
+	var res1 *types.ReviewAccessRequestResponse
+	if res1, err = ars.ApproveAccessRequest(c.Req.Context(), &types.ReviewAccessRequestRequest{
+		Id:       id,
+		Reviewer: a.User.Account,
+		Comment:  c.Req.FormValue("comment"),
+	}); err != nil {
+		return
+	}
+	v.Data["access_request"] = res1.AccessRequest
+	v.DataAsJSON()
+	return
+}
+
+func routeRejectAccessRequest(c *nova.Context) (err error) {
+	a, ars, v := authResult(c), accessRequestService(c), view.Extract(c)
+	id, _ := strconv.ParseInt(c.Req.FormValue("id"), 10, 64)
+	var res1 *types.ReviewAccessRequestResponse
+	if res1, err = ars.RejectAccessRequest(c.Req.Context(), &types.ReviewAccessRequestRequest{
+		Id:       id,
+		Reviewer: a.User.Account,
+		Comment:  c.Req.FormValue("comment"),
+	}); err != nil {
+		return
+	}
+	v.Data["access_request"] = res1.AccessRequest
+	v.DataAsJSON()
+	return
+}
@@ -0,0 +1,35 @@
+package web
+
+import (
+	"github.com/novakit/nova"
+	"github.com/novakit/view"
+	"github.com/yankeguo/bastion/types"
+	"strconv"
+)
+
+func routeListAuditEvents(c *nova.Context) (err error) {
+	skip, _ := strconv.ParseInt(c.Req.FormValue("skip"), 10, 64)
+	limit, _ := strconv.ParseInt(c.Req.FormValue("limit"), 10, 64)
+	since, _ := strconv.ParseInt(c.Req.FormValue("since"), 10, 64)
+	until, _ := strconv.ParseInt(c.Req.FormValue("until"), 10, 64)
+	v, as := view.Extract(c), auditService(c)
+	var res *types.ListAuditEventsResponse
+	if res, err = as.ListAuditEvents(c.Req.Context(), &types.ListAuditEventsRequest{
+		Actor:  c.Req.FormValue("actor"),
+		Action: c.Req.FormValue("action"),
+		Target: c.Req.FormValue("target"),
+		Source: c.Req.FormValue("source"),
+		Since:  since,
+		Until:  until,
+		Skip:   int32(skip),
+		Limit:  int32(limit),
+	}); err != nil {
+		return
+	}
+	v.Data["audit_events"] = res.AuditEvents
+	v.Data["skip"] = res.Skip
+	v.Data["limit"] = res.Limit
+	v.Data["total"] = res.Total
+	v.DataAsJSON()
+	return
+}
@@ -36,6 +36,7 @@ func routeCreateGrant(c *nova.Context) (err error) {
 		Account:         rp.Get("account"),
 		User:            c.Req.FormValue("user"),
 		HostnamePattern: c.Req.FormValue("hostname_pattern"),
+		LabelSelector:   c.Req.FormValue("label_selector"),
 		ExpiredAt:       expiresAt,
 	}); err != nil {
 		return
@@ -51,6 +52,7 @@ func routeDestroyGrant(c *nova.Context) (err error) {
 		Account:         rp.Get("account"),
 		User:            c.Req.FormValue("user"),
 		HostnamePattern: c.Req.FormValue("hostname_pattern"),
+		LabelSelector:   c.Req.FormValue("label_selector"),
 	}); err != nil {
 		return
 	}
@@ -0,0 +1,182 @@
+package web
+
+import (
+	"github.com/novakit/nova"
+	"github.com/novakit/router"
+	"github.com/novakit/view"
+	"github.com/pkg/errors"
+	"github.com/yankeguo/bastion/types"
+	"strconv"
+	"time"
+)
+
+func routeListGroups(c *nova.Context) (err error) {
+	gs, v := groupService(c), view.Extract(c)
+	var res1 *types.ListGroupsResponse
+	if res1, err = gs.ListGroups(c.Req.Context(), &types.ListGroupsRequest{
+		Account: c.Req.FormValue("account"),
+	}); err != nil {
+		return
+	}
+	v.Data["groups"] = res1.Groups
+	v.DataAsJSON()
+	return
+}
+
+func routeGetCurrentUserGroups(c *nova.Context) (err error) {
+	a, gs, v := authResult(c), groupService(c), view.Extract(c)
+	var res1 *types.ListGroupsResponse
+	if res1, err = gs.ListGroups(c.Req.Context(), &types.ListGroupsRequest{
+		Account: a.User.Account,
+	}); err != nil {
+		return
+	}
+	v.Data["groups"] = res1.Groups
+	v.DataAsJSON()
+	return
+}
+
+func routeCreateGroup(c *nova.Context) (err error) {
+	gs, v := groupService(c), view.Extract(c)
+	var res1 *types.PutGroupResponse
+	if res1, err = gs.PutGroup(c.Req.Context(), &types.PutGroupRequest{
+		Name:        c.Req.FormValue("name"),
+		Description: c.Req.FormValue("description"),
+	}); err != nil {
+		return
+	}
+	v.Data["group"] = res1.Group
+	v.DataAsJSON()
+	return
+}
+
+func routeUpdateGroupRoles(c *nova.Context) (err error) {
+	gs, v := groupService(c), view.Extract(c)
+	name := c.Req.FormValue("name")
+	// PutGroup overwrites description, find the existing one
+	var res1 *types.ListGroupsResponse
+	if res1, err = gs.ListGroups(c.Req.Context(), &types.ListGroupsRequest{}); err != nil {
+		return
+	}
+	var g *types.Group
+	for _, e := range res1.Groups {
+		if e.Name == name {
+			g = e
+		}
+	}
+	if g == nil {
+		err = errors.New("group not found")
+		return
+	}
+	var res2 *types.PutGroupResponse
+	if res2, err = gs.PutGroup(c.Req.Context(), &types.PutGroupRequest{
+		Name:        g.Name,
+		Description: g.Description,
+		UpdateRoles: true,
+		Roles:       SplitFormValue(c.Req.FormValue("roles")),
+	}); err != nil {
+		return
+	}
+	v.Data["group"] = res2.Group
+	v.DataAsJSON()
+	return
+}
+
+func routeDestroyGroup(c *nova.Context) (err error) {
+	gs, v := groupService(c), view.Extract(c)
+	if _, err = gs.DeleteGroup(c.Req.Context(), &types.DeleteGroupRequest{
+		Name: c.Req.FormValue("name"),
+	}); err != nil {
+		return
+	}
+	v.DataAsJSON()
+	return
+}
+
+func routeGetGroupMembers(c *nova.Context) (err error) {
+	gs, v, rp := groupService(c), view.Extract(c), router.PathParams(c)
+	var res1 *types.ListGroupMembersResponse
+	if res1, err = gs.ListGroupMembers(c.Req.Context(), &types.ListGroupMembersRequest{
+		Group: rp.Get("name"),
+	}); err != nil {
+		return
+	}
+	v.Data["members"] = res1.Members
+	v.DataAsJSON()
+	return
+}
+
+func routeCreateGroupMember(c *nova.Context) (err error) {
+	gs, v, rp := groupService(c), view.Extract(c), router.PathParams(c)
+	var res1 *types.PutGroupMemberResponse
+	if res1, err = gs.PutGroupMember(c.Req.Context(), &types.PutGroupMemberRequest{
+		Group:   rp.Get("name"),
+		Account: c.Req.FormValue("account"),
+	}); err != nil {
+		return
+	}
+	v.Data["member"] = res1.Member
+	v.DataAsJSON()
+	return
+}
+
+func routeDestroyGroupMember(c *nova.Context) (err error) {
+	gs, v, rp := groupService(c), view.Extract(c), router.PathParams(c)
+	if _, err = gs.DeleteGroupMember(c.Req.Context(), &types.DeleteGroupMemberRequest{
+		Group:   rp.Get("name"),
+		Account: c.Req.FormValue("account"),
+	}); err != nil {
+		return
+	}
+	v.DataAsJSON()
+	return
+}
+
+func routeGetGroupGrants(c *nova.Context) (err error) {
+	gs, v, rp := grantService(c), view.Extract(c), router.PathParams(c)
+	var res1 *types.ListGrantsResponse
+	if res1, err = gs.ListGrants(c.Req.Context(), &types.ListGrantsRequest{
+		Group: rp.Get("name"),
+	}); err != nil {
+		return
+	}
+	v.Data["grants"] = res1.Grants
+	v.DataAsJSON()
+	return
+}
+
+func routeCreateGroupGrant(c *nova.Context) (err error) {
+	gs, v, rp := grantService(c), view.Extract(c), router.PathParams(c)
+	expiresIn, _ := strconv.ParseInt(c.Req.FormValue("expires_in"), 10, 64)
+	var expiresAt int64
+	if expiresIn != 0 {
+		expiresAt = time.Now().Unix() + expiresIn
+	}
+	var res1 *types.PutGrantResponse
+	if res1, err = gs.PutGrant(c.Req.Context(), &types.PutGrantRequest{
+		Group:           rp.Get("name"),
+		User:            c.Req.FormValue("user"),
+		HostnamePattern: c.Req.FormValue("hostname_pattern"),
+		LabelSelector:   c.Req.FormValue("label_selector"),
+		ExpiredAt:       expiresAt,
+	}); err != nil {
+		return
+	}
+	v.Data["grant"] = res1.Grant
+	v.DataAsJSON()
+	return
+}
+
+func routeDestroyGroupGrant(c *nova.Context) (err error) {
+	gs, v, rp := grantService(c), view.Extract(c), router.PathParams(c)
+	if _, err = gs.DeleteGrant(c.Req.Context(), &types.DeleteGrantRequest{
+		Group:           rp.Get("name"),
+		User:            c.Req.FormValue("user"),
+		HostnamePattern: c.Req.FormValue("hostname_pattern"),
+		LabelSelector:   c.Req.FormValue("label_selector"),
+	}); err != nil {
+		return
+	}
+	v.DataAsJSON()
+	return
+}
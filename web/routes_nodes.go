@@ -5,6 +5,7 @@ import (
 	"github.com/novakit/view"
 	"github.com/pkg/errors"
 	"github.com/yankeguo/bastion/types"
+	"github.com/yankeguo/bastion/utils"
 )
 
 func routeListNodes(c *nova.Context) (err error) {
@@ -20,6 +21,10 @@ func routeListNodes(c *nova.Context) (err error) {
 
 func routeCreateNode(c *nova.Context) (err error) {
 	ns, v := nodeService(c), view.Extract(c)
+	var labels map[string]string
+	if labels, err = utils.ParseLabels(c.Req.FormValue("labels")); err != nil {
+		return
+	}
 	var res1 *types.PutNodeResponse
 	if res1, err = ns.PutNode(
 		c.Req.Context(),
@@ -27,6 +32,7 @@ func routeCreateNode(c *nova.Context) (err error) {
 			Hostname: c.Req.FormValue("hostname"),
 			Address:  c.Req.FormValue("address"),
 			Source:   types.NodeSourceManual,
+			Labels:   labels,
 		}); err != nil {
 		return
 	}
@@ -70,3 +76,22 @@ func routeUpdateNodeIsKeyManaged(c *nova.Context) (err error) {
 	v.DataAsJSON()
 	return
 }
+
+func routeUpdateNodeLabels(c *nova.Context) (err error) {
+	ns, v := nodeService(c), view.Extract(c)
+	var labels map[string]string
+	if labels, err = utils.ParseLabels(c.Req.FormValue("labels")); err != nil {
+		return
+	}
+	var res1 *types.UpdateNodeResponse
+	if res1, err = ns.UpdateNode(c.Req.Context(), &types.UpdateNodeRequest{
+		Hostname:     c.Req.FormValue("hostname"),
+		UpdateLabels: true,
+		Labels:       labels,
+	}); err != nil {
+		return
+	}
+	v.Data["node"] = res1.Node
+	v.DataAsJSON()
+	return
+}
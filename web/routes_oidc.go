@@ -0,0 +1,81 @@
+package web
+
+import (
+	"github.com/novakit/nova"
+	"github.com/novakit/view"
+	"github.com/pkg/errors"
+	"github.com/yankeguo/bastion/types"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	cookieKeyOIDC    = "bastion_oidc"
+	cookiePathOIDC   = "/api/oidc"
+	cookieMaxAgeOIDC = 600
+)
+
+func routeGetOIDC(c *nova.Context) (err error) {
+	v := view.Extract(c)
+	v.Data["enabled"] = oidcClientOf(c) != nil
+	v.DataAsJSON()
+	return
+}
+
+// routeOIDCLogin redirects to issuer, state and nonce are kept in a short-lived cookie
+func routeOIDCLogin(c *nova.Context) (err error) {
+	o := oidcClientOf(c)
+	state, nonce := randomHex(16), randomHex(16)
+	var u string
+	if u, err = o.AuthCodeURL(c.Req.Context(), state, nonce); err != nil {
+		return
+	}
+	http.SetCookie(c.Res, &http.Cookie{
+		Name:     cookieKeyOIDC,
+		Value:    state + "." + nonce,
+		Path:     cookiePathOIDC,
+		MaxAge:   cookieMaxAgeOIDC,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(o.opts.RedirectURL, "https://"),
+	})
+	http.Redirect(c.Res, c.Req, u, http.StatusFound)
+	return
+}
+
+// routeOIDCCallback verifies the id token, provisions the user and issues a token,
+// the token value is handed to ui in the fragment of redirection
+func routeOIDCCallback(c *nova.Context) (err error) {
+	o, ts := oidcClientOf(c), tokenService(c)
+	// state and nonce are single use
+	var ck *http.Cookie
+	if ck, err = c.Req.Cookie(cookieKeyOIDC); err != nil {
+		err = errors.New("oidc login expired, please try again")
+		return
+	}
+	http.SetCookie(c.Res, &http.Cookie{Name: cookieKeyOIDC, Path: cookiePathOIDC, MaxAge: -1})
+	q := c.Req.URL.Query()
+	if e := q.Get("error"); len(e) > 0 {
+		err = errors.New("oidc login failed: " + e + " " + q.Get("error_description"))
+		return
+	}
+	splits := strings.SplitN(ck.Value, ".", 2)
+	if len(splits) != 2 || splits[0] != q.Get("state") {
+		err = errors.New("oidc state mismatched")
+		return
+	}
+	var id oidcIdentity
+	if id, err = o.Exchange(c.Req.Context(), q.Get("code"), splits[1]); err != nil {
+		return
+	}
+	var u *types.User
+	if u, err = o.Provision(c.Req.Context(), rpcConn(c), id); err != nil {
+		return
+	}
+	var res *types.CreateTokenResponse
+	if res, err = ts.CreateToken(c.Req.Context(), sessionTokenRequest(c, u.Account, "OIDC "+c.Req.Header.Get("User-Agent"))); err != nil {
+		return
+	}
+	http.Redirect(c.Res, c.Req, "/#/login?"+url.Values{"token": []string{res.Token.Token}}.Encode(), http.StatusFound)
+	return
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"fmt"
 	"github.com/novakit/nova"
 	"github.com/novakit/router"
 	"github.com/novakit/view"
@@ -35,10 +36,97 @@ func routeDownloadReplay(c *nova.Context) (err error) {
 	return
 }
 
+// routeExportReplay export replay as attachment, format is "cast" (default) or "raw"
+func routeExportReplay(c *nova.Context) (err error) {
+	rs, ss, qp := replayService(c), sessionService(c), router.PathParams(c)
+	id, _ := strconv.ParseInt(qp.Get("id"), 10, 64)
+	format := c.Req.FormValue("format")
+	if len(format) == 0 {
+		format = utils.ReplayFormatCast
+	}
+	var res *types.GetSessionResponse
+	if res, err = ss.GetSession(c.Req.Context(), &types.GetSessionRequest{Id: id}); err != nil {
+		return
+	}
+	var e utils.ReplayExporter
+	if e, err = utils.NewReplayExporter(c.Res, format, res.Session); err != nil {
+		return
+	}
+	var sess types.ReplayService_ReadReplayClient
+	if sess, err = rs.ReadReplay(c.Req.Context(), &types.ReadReplayRequest{SessionId: id}); err != nil {
+		return
+	}
+	// errors like purged replay come with the first frame, respond headers after it
+	var f *types.ReplayFrame
+	if f, err = sess.Recv(); err != nil && err != io.EOF {
+		return
+	}
+	if format == utils.ReplayFormatCast {
+		c.Res.Header().Set("Content-Type", "application/x-asciicast")
+	} else {
+		c.Res.Header().Set("Content-Type", "application/octet-stream")
+	}
+	c.Res.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="session-%d.%s"`, id, format))
+	c.Res.WriteHeader(http.StatusOK)
+	for err == nil {
+		if err = e.WriteFrame(f); err != nil {
+			return
+		}
+		f, err = sess.Recv()
+	}
+	if err != io.EOF {
+		return
+	}
+	err = e.Close()
+	return
+}
+
+func routeGetTranscript(c *nova.Context) (err error) {
+	v, rs, qp := view.Extract(c), replayService(c), router.PathParams(c)
+	id, _ := strconv.ParseInt(qp.Get("id"), 10, 64)
+	var res *types.GetTranscriptResponse
+	if res, err = rs.GetTranscript(c.Req.Context(), &types.GetTranscriptRequest{SessionId: id}); err != nil {
+		return
+	}
+	v.Data["entries"] = res.Entries
+	v.DataAsJSON()
+	return
+}
+
+// routeSearchReplays search contents or commands of replays, highlights are html snippets with matches wrapped by <em>
+func routeSearchReplays(c *nova.Context) (err error) {
+	since, _ := strconv.ParseInt(c.Req.FormValue("since"), 10, 64)
+	until, _ := strconv.ParseInt(c.Req.FormValue("until"), 10, 64)
+	from, _ := strconv.ParseInt(c.Req.FormValue("from"), 10, 64)
+	size, _ := strconv.ParseInt(c.Req.FormValue("size"), 10, 64)
+	v, rs := view.Extract(c), replayService(c)
+	var res *types.SearchReplayResponse
+	if res, err = rs.SearchReplay(c.Req.Context(), &types.SearchReplayRequest{
+		Keyword:  c.Req.FormValue("keyword"),
+		Commands: IsFormValueTrue(c.Req.FormValue("commands")),
+		Match:    c.Req.FormValue("match"),
+		Account:  c.Req.FormValue("account"),
+		Hostname: c.Req.FormValue("hostname"),
+		Since:    since,
+		Until:    until,
+		From:     from,
+		Size:     size,
+	}); err != nil {
+		return
+	}
+	v.Data["results"] = res.Results
+	v.Data["total"] = res.Total
+	v.DataAsJSON()
+	return
+}
+
 func routePageReplay(c *nova.Context) (err error) {
 	v, ar := view.Extract(c), router.PathParams(c)
+	// t milliseconds since start of session to jump to, from search results
+	t, _ := strconv.ParseInt(c.Req.FormValue("t"), 10, 64)
 	v.Data["SessionId"] = ar.Get("id")
 	v.Data["ViewKey"] = c.Req.FormValue("viewKey")
+	v.Data["JumpTo"] = t
 	v.HTML("replay")
 	return
 }
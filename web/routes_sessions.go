@@ -9,7 +9,7 @@ import (
 )
 
 func routeGetSession(c *nova.Context) (err error) {
-	v, ss, us, pr := view.Extract(c), sessionService(c), userService(c), router.PathParams(c)
+	v, ss, us, ts, pr := view.Extract(c), sessionService(c), userService(c), transferService(c), router.PathParams(c)
 	id, _ := strconv.ParseInt(pr.Get("id"), 10, 64)
 	var res1 *types.GetSessionResponse
 	if res1, err = ss.GetSession(c.Req.Context(), &types.GetSessionRequest{Id: id}); err != nil {
@@ -19,8 +19,13 @@ func routeGetSession(c *nova.Context) (err error) {
 	if res2, err = us.GetUser(c.Req.Context(), &types.GetUserRequest{Account: res1.Session.Account}); err != nil {
 		return
 	}
+	var res3 *types.ListTransfersResponse
+	if res3, err = ts.ListTransfers(c.Req.Context(), &types.ListTransfersRequest{SessionId: res1.Session.Id}); err != nil {
+		return
+	}
 	v.Data["session"] = res1.Session
 	v.Data["user"] = res2.User
+	v.Data["transfers"] = res3.Transfers
 	v.DataAsJSON()
 	return
 }
@@ -28,11 +33,22 @@ func routeGetSession(c *nova.Context) (err error) {
 func routeListSessions(c *nova.Context) (err error) {
 	skip, _ := strconv.ParseInt(c.Req.FormValue("skip"), 10, 64)
 	limit, _ := strconv.ParseInt(c.Req.FormValue("limit"), 10, 64)
+	since, _ := strconv.ParseInt(c.Req.FormValue("since"), 10, 64)
+	until, _ := strconv.ParseInt(c.Req.FormValue("until"), 10, 64)
+	cursor, _ := strconv.ParseInt(c.Req.FormValue("cursor"), 10, 64)
 	v, ss := view.Extract(c), sessionService(c)
 	var res *types.ListSessionsResponse
 	if res, err = ss.ListSessions(c.Req.Context(), &types.ListSessionsRequest{
-		Skip:  int32(skip),
-		Limit: int32(limit),
+		Skip:         int32(skip),
+		Limit:        int32(limit),
+		Account:      c.Req.FormValue("account"),
+		Hostname:     c.Req.FormValue("hostname"),
+		Command:      c.Req.FormValue("command"),
+		Since:        since,
+		Until:        until,
+		OnlyRecorded: IsFormValueTrue(c.Req.FormValue("only_recorded")),
+		OnlyActive:   IsFormValueTrue(c.Req.FormValue("only_active")),
+		Cursor:       cursor,
 	}); err != nil {
 		return
 	}
@@ -40,6 +56,22 @@ func routeListSessions(c *nova.Context) (err error) {
 	v.Data["skip"] = res.Skip
 	v.Data["limit"] = res.Limit
 	v.Data["total"] = res.Total
+	v.Data["next_cursor"] = res.NextCursor
+	v.DataAsJSON()
+	return
+}
+
+func routeUpdateSessionLegalHold(c *nova.Context) (err error) {
+	id, _ := strconv.ParseInt(c.Req.FormValue("id"), 10, 64)
+	v, ss := view.Extract(c), sessionService(c)
+	var res *types.UpdateSessionLegalHoldResponse
+	if res, err = ss.UpdateSessionLegalHold(c.Req.Context(), &types.UpdateSessionLegalHoldRequest{
+		Id:        id,
+		LegalHold: IsFormValueTrue(c.Req.FormValue("legal_hold")),
+	}); err != nil {
+		return
+	}
+	v.Data["session"] = res.Session
 	v.DataAsJSON()
 	return
 }
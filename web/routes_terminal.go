@@ -0,0 +1,195 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"github.com/kballard/go-shellquote"
+	"github.com/novakit/nova"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/sshd"
+	"github.com/yankeguo/bastion/sshd/recorder"
+	"github.com/yankeguo/bastion/sshd/sandbox"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc/metadata"
+	"io"
+	"strconv"
+	"sync"
+)
+
+const (
+	terminalMessageTypeStdin        = "stdin"
+	terminalMessageTypeWindowChange = "window-change"
+
+	terminalDefaultTerm = "xterm"
+)
+
+var terminalUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	Subprotocols:    []string{subprotocolBastion},
+}
+
+// terminalMessage control message sent by browser as websocket text message
+type terminalMessage struct {
+	Type string `json:"type"`
+	Data string `json:"data"`
+	Cols uint   `json:"cols"`
+	Rows uint   `json:"rows"`
+}
+
+// terminalWriter writes stdout/stderr as websocket binary messages, websocket.Conn does not support concurrent writers
+type terminalWriter struct {
+	conn  *websocket.Conn
+	mutex *sync.Mutex
+}
+
+func (w *terminalWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if err := w.conn.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *terminalWriter) Close(msg string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, msg))
+}
+
+// pipeTerminalInput read messages from browser, binary messages and "stdin" messages are piped to stdin, "window-change" messages to wch
+func pipeTerminalInput(conn *websocket.Conn, stdin *io.PipeWriter, wch chan sandbox.Window) {
+	defer close(wch)
+	defer stdin.Close()
+	for {
+		mt, buf, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		switch mt {
+		case websocket.BinaryMessage:
+			if _, err = stdin.Write(buf); err != nil {
+				return
+			}
+		case websocket.TextMessage:
+			var msg terminalMessage
+			if err = json.Unmarshal(buf, &msg); err != nil {
+				log.Debug().Err(err).Msg("invalid terminal message")
+				continue
+			}
+			switch msg.Type {
+			case terminalMessageTypeStdin:
+				if _, err = stdin.Write([]byte(msg.Data)); err != nil {
+					return
+				}
+			case terminalMessageTypeWindowChange:
+				wch <- sandbox.Window{Width: msg.Cols, Height: msg.Rows}
+			}
+		}
+	}
+}
+
+// routeTerminal websocket terminal into the sandbox, or into a granted host if "hostname" and "user" are specified
+//
+// the terminal is recorded as a normal session, browser sends stdin as binary messages or {"type":"stdin","data":"..."},
+// and window size as {"type":"window-change","cols":80,"rows":24}, stdout is sent back as binary messages,
+// browser authenticates with subprotocols ["bastion", "bastion.token.<token>"]
+func routeTerminal(c *nova.Context) (err error) {
+	a, gs, ss, rs := authResult(c), grantService(c), sessionService(c), replayService(c)
+	// determine the command, connect to granted host with sandbox ssh config, same as lv2 stage in ssh
+	var cmds []string
+	hostname, user := c.Req.FormValue("hostname"), c.Req.FormValue("user")
+	if len(hostname) > 0 || len(user) > 0 {
+		if len(hostname) == 0 || len(user) == 0 || user == types.GrantUserTunnel {
+			err = errors.New("invalid hostname or user")
+			return
+		}
+		var res1 *types.CheckGrantResponse
+		if res1, err = gs.CheckGrant(c.Req.Context(), &types.CheckGrantRequest{
+			Account:  a.User.Account,
+			User:     user,
+			Hostname: hostname,
+		}); err != nil {
+			return
+		}
+		if !res1.Ok {
+			err = errors.New("no permission")
+			return
+		}
+		cmds = []string{"ssh", sshd.SSHEntryName(hostname, user)}
+	}
+	// prepare the sandbox, same as lv1 stage in ssh
+	var sb sandbox.Sandbox
+	if sb, err = sshd.PrepareSandbox(sshdOptions(c), sandboxManager(c), rpcConn(c), a.User.Account); err != nil {
+		return
+	}
+	// upgrade to websocket, upgrader responses the error itself
+	var conn *websocket.Conn
+	if conn, err = terminalUpgrader.Upgrade(c.Res, c.Req, nil); err != nil {
+		log.Error().Err(err).Str("account", a.User.Account).Msg("failed to upgrade terminal websocket")
+		err = nil
+		return
+	}
+	defer conn.Close()
+	w := &terminalWriter{conn: conn, mutex: &sync.Mutex{}}
+	// errors after upgrade can only be reported by close message
+	// same as the session request of ssh, browser is the client
+	sreq := &types.CreateSessionRequest{
+		Account:       a.User.Account,
+		Stage:         types.SessionStageLv1,
+		Command:       shellquote.Join(cmds...),
+		ClientAddr:    c.Req.RemoteAddr,
+		ClientVersion: c.Req.UserAgent(),
+		IsRecorded:    true,
+	}
+	if len(hostname) > 0 {
+		sreq.Stage = types.SessionStageLv2
+		sreq.Hostname = hostname
+		sreq.User = user
+	}
+	var res2 *types.CreateSessionResponse
+	if res2, err = ss.CreateSession(c.Req.Context(), sreq); err != nil {
+		log.Error().Err(err).Str("account", a.User.Account).Msg("failed to create session")
+		w.Close("internal error: failed to create session")
+		err = nil
+		return
+	}
+	log.Info().Int64("sessionId", res2.Session.Id).Str("account", a.User.Account).Msg("terminal session allocated")
+	// pipe browser input
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	wch := make(chan sandbox.Window, 4)
+	cols, _ := strconv.ParseUint(c.Req.FormValue("cols"), 10, 32)
+	rows, _ := strconv.ParseUint(c.Req.FormValue("rows"), 10, 32)
+	if cols > 0 && rows > 0 {
+		wch <- sandbox.Window{Width: uint(cols), Height: uint(rows)}
+	}
+	go pipeTerminalInput(conn, pw, wch)
+	// build the exec options
+	opts := sandbox.ExecAttachOptions{
+		Command:    cmds,
+		Stdin:      pr,
+		Stdout:     w,
+		Stderr:     w,
+		IsPty:      true,
+		Term:       terminalDefaultTerm,
+		WindowChan: wch,
+	}
+	r := recorder.StartRecording(&opts, res2.Session.Id, rs)
+	// execute
+	msg := "session finished"
+	if aerr := sb.ExecAttach(opts); aerr != nil {
+		log.Error().Err(aerr).Int64("sessionId", res2.Session.Id).Msg("exec attach returns error")
+		msg = "session finished with error"
+	}
+	r.Close()
+	// finish session on behalf of the user, request context might be canceled already
+	md, _ := metadata.FromOutgoingContext(c.Req.Context())
+	ss.FinishSession(metadata.NewOutgoingContext(context.Background(), md), &types.FinishSessionRequest{Id: res2.Session.Id})
+	log.Info().Int64("sessionId", res2.Session.Id).Msg("terminal session finished")
+	w.Close(msg)
+	return
+}
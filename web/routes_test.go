@@ -0,0 +1,121 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/yankeguo/bastion/sshd/sandbox"
+	"github.com/yankeguo/bastion/types"
+	"google.golang.org/grpc"
+)
+
+// testManager sandbox manager without docker, records accounts looked up and finds no sandbox
+type testManager struct {
+	mutex    sync.Mutex
+	accounts []string
+}
+
+func (m *testManager) record(account string) (sandbox.Sandbox, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.accounts = append(m.accounts, account)
+	return nil, sandbox.ErrSandboxNotFound
+}
+
+func (m *testManager) Find(account string) (sandbox.Sandbox, error) {
+	return m.record(account)
+}
+
+func (m *testManager) FindRunning(account string) (sandbox.Sandbox, error) {
+	return m.record(account)
+}
+
+func (m *testManager) FindOrCreate(account string, mounts []sandbox.Mount) (sandbox.Sandbox, error) {
+	return m.record(account)
+}
+
+func (m *testManager) lookups() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return len(m.accounts)
+}
+
+func TestRoutes_SandboxDownloadScope(t *testing.T) {
+	withDaemon(t, func(t *testing.T, conn *grpc.ClientConn) {
+		ctx := context.Background()
+		if _, err := types.NewUserServiceClient(conn).CreateUser(ctx, &types.CreateUserRequest{Account: "test", Password: "qwerty123"}); err != nil {
+			t.Fatal(err)
+		}
+		ts := types.NewTokenServiceClient(conn)
+		ro, err := ts.CreateToken(ctx, &types.CreateTokenRequest{Account: "test", Kind: types.TokenKindPersonal, Name: "ro", Scopes: []string{types.TokenScopeReadOnly}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		admin, err := ts.CreateToken(ctx, &types.CreateTokenRequest{Account: "test", Kind: types.TokenKindPersonal, Name: "admin", Scopes: []string{types.TokenScopeAdmin}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		h := NewServer(types.WebOptions{Dev: true, DaemonEndpoint: "127.0.0.1:2995"}, types.SSHDOptions{}, &testManager{}).Handler
+		download := func(token string) string {
+			req := httptest.NewRequest(http.MethodGet, "/api/users/current/sandbox/download?file=/root/.ssh/id_rsa", nil)
+			req.Header.Set(headerKeyToken, token)
+			res := httptest.NewRecorder()
+			h.ServeHTTP(res, req)
+			return res.Body.String()
+		}
+		// read-only tokens can not download sandbox files, i.e. the sandbox private key
+		if body := download(ro.Token.Token); !strings.Contains(body, "token scope not allowed") {
+			t.Fatal("read-only token should not download sandbox files", body)
+		}
+		if body := download(admin.Token.Token); !strings.Contains(body, sandbox.ErrSandboxNotFound.Error()) {
+			t.Fatal("admin token should pass scope check", body)
+		}
+	})
+}
+
+func TestRoutes_Terminal(t *testing.T) {
+	withDaemon(t, func(t *testing.T, conn *grpc.ClientConn) {
+		ctx := context.Background()
+		if _, err := types.NewUserServiceClient(conn).CreateUser(ctx, &types.CreateUserRequest{Account: "test", Password: "qwerty123"}); err != nil {
+			t.Fatal(err)
+		}
+		res1, err := types.NewTokenServiceClient(conn).CreateToken(ctx, &types.CreateTokenRequest{Account: "test", Kind: types.TokenKindPersonal, Name: "admin", Scopes: []string{types.TokenScopeAdmin}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		token := res1.Token.Token
+		m := &testManager{}
+		h := NewServer(types.WebOptions{Dev: true, DaemonEndpoint: "127.0.0.1:2995"}, types.SSHDOptions{}, m).Handler
+		terminal := func(target string, protocols string) string {
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			req.Header.Set("Connection", "Upgrade")
+			req.Header.Set("Upgrade", "websocket")
+			if len(protocols) > 0 {
+				req.Header.Set("Sec-WebSocket-Protocol", protocols)
+			}
+			res := httptest.NewRecorder()
+			h.ServeHTTP(res, req)
+			return res.Body.String()
+		}
+		// token in query string is not accepted, it ends up in access logs
+		if body := terminal("/api/users/current/terminal?token="+token, ""); !strings.Contains(body, "not logged in") {
+			t.Fatal("should not accept token in query string", body)
+		}
+		if m.lookups() != 0 {
+			t.Fatal("should not prepare sandbox without login")
+		}
+		// token in subprotocol, every request uses the injected manager
+		for i := 1; i <= 2; i++ {
+			if body := terminal("/api/users/current/terminal", subprotocolBastion+", "+subprotocolTokenPrefix+token); !strings.Contains(body, sandbox.ErrSandboxNotFound.Error()) {
+				t.Fatal("should prepare sandbox with the injected manager", body)
+			}
+			if m.lookups() != i {
+				t.Fatal("bad sandbox lookups", m.lookups())
+			}
+		}
+	})
+}
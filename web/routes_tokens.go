@@ -8,6 +8,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"strconv"
+	"time"
 )
 
 func hideAuthenticationError(err *error) {
@@ -24,6 +25,22 @@ func hideAuthenticationError(err *error) {
 	}
 }
 
+// sessionTokenRequest request of token created by login, lifetime and idle timeout are limited by web options
+func sessionTokenRequest(c *nova.Context, account string, description string) *types.CreateTokenRequest {
+	opts := webOptions(c)
+	req := &types.CreateTokenRequest{
+		Account:     account,
+		Description: description,
+		Kind:        types.TokenKindSession,
+		Scopes:      []string{types.TokenScopeAdmin},
+		IdleTimeout: int64(opts.TokenIdleTimeout / time.Second),
+	}
+	if opts.TokenTTL > 0 {
+		req.ExpiresAt = time.Now().Add(opts.TokenTTL).Unix()
+	}
+	return req
+}
+
 func routeCreateToken(c *nova.Context) (err error) {
 	ts, us, v := tokenService(c), userService(c), view.Extract(c)
 	var res1 *types.AuthenticateUserResponse
@@ -35,10 +52,7 @@ func routeCreateToken(c *nova.Context) (err error) {
 		return
 	}
 	var res2 *types.CreateTokenResponse
-	if res2, err = ts.CreateToken(c.Req.Context(), &types.CreateTokenRequest{
-		Account:     res1.User.Account,
-		Description: c.Req.Header.Get("User-Agent"),
-	}); err != nil {
+	if res2, err = ts.CreateToken(c.Req.Context(), sessionTokenRequest(c, res1.User.Account, c.Req.Header.Get("User-Agent"))); err != nil {
 		return
 	}
 	v.Data["user"] = res1.User
@@ -47,6 +61,42 @@ func routeCreateToken(c *nova.Context) (err error) {
 	return
 }
 
+// routeGetCurrentToken returns current token and user, used by ui after oidc login to resolve the token value
+func routeGetCurrentToken(c *nova.Context) (err error) {
+	a, v := authResult(c), view.Extract(c)
+	v.Data["user"] = a.User
+	v.Data["token"] = a.Token
+	v.DataAsJSON()
+	return
+}
+
+// routeCreatePersonalToken creates a named token for automation, token value is only returned once
+func routeCreatePersonalToken(c *nova.Context) (err error) {
+	a, ts, v := authResult(c), tokenService(c), view.Extract(c)
+	req := &types.CreateTokenRequest{
+		Account: a.User.Account,
+		Kind:    types.TokenKindPersonal,
+		Name:    c.Req.FormValue("name"),
+		Scopes:  SplitFormValue(c.Req.FormValue("scopes")),
+	}
+	if ei := c.Req.FormValue("expires_in"); len(ei) > 0 {
+		var days int64
+		if days, err = strconv.ParseInt(ei, 10, 64); err != nil {
+			return
+		}
+		if days > 0 {
+			req.ExpiresAt = time.Now().Add(time.Hour * 24 * time.Duration(days)).Unix()
+		}
+	}
+	var res1 *types.CreateTokenResponse
+	if res1, err = ts.CreateToken(c.Req.Context(), req); err != nil {
+		return
+	}
+	v.Data["token"] = res1.Token
+	v.DataAsJSON()
+	return
+}
+
 func routeListTokens(c *nova.Context) (err error) {
 	a, ts, v := authResult(c), tokenService(c), view.Extract(c)
 	var res1 *types.ListTokensResponse
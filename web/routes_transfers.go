@@ -0,0 +1,122 @@
+package web
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/novakit/nova"
+	"github.com/novakit/view"
+	"github.com/pkg/errors"
+	"github.com/yankeguo/bastion/types"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const sandboxHomeDir = "/root"
+
+// sandboxPath resolve a path relative to sandbox /root, paths outside /root are rejected
+func sandboxPath(p string) (string, error) {
+	p = strings.TrimSpace(p)
+	if !path.IsAbs(p) {
+		p = path.Join(sandboxHomeDir, p)
+	}
+	p = path.Clean(p)
+	if p != sandboxHomeDir && !strings.HasPrefix(p, sandboxHomeDir+"/") {
+		return "", errors.New("path must be inside /root")
+	}
+	return p, nil
+}
+
+func routeUploadSandboxFile(c *nova.Context) (err error) {
+	a, ts, v, sb := authResult(c), transferService(c), view.Extract(c), currentSandbox(c)
+	var dir string
+	if dir, err = sandboxPath(c.Req.FormValue("dir")); err != nil {
+		return
+	}
+	var f multipart.File
+	var fh *multipart.FileHeader
+	if f, fh, err = c.Req.FormFile("file"); err != nil {
+		return
+	}
+	defer f.Close()
+	name := path.Base(fh.Filename)
+	if name == "." || name == ".." || name == "/" {
+		err = errors.New("invalid file name")
+		return
+	}
+	// calculate sha256 while streaming into the sandbox
+	h := sha256.New()
+	if err = sb.CopyTo(dir, name, 0644, fh.Size, io.TeeReader(f, h)); err != nil {
+		return
+	}
+	var res1 *types.CreateTransferResponse
+	if res1, err = ts.CreateTransfer(c.Req.Context(), &types.CreateTransferRequest{
+		Account:   a.User.Account,
+		Source:    types.TransferSourceWeb,
+		Direction: types.TransferDirectionUpload,
+		Filename:  path.Join(dir, name),
+		Mode:      0644,
+		Size:      fh.Size,
+		Sha256:    hex.EncodeToString(h.Sum(nil)),
+	}); err != nil {
+		return
+	}
+	v.Data["transfer"] = res1.Transfer
+	v.DataAsJSON()
+	return
+}
+
+func routeDownloadSandboxFile(c *nova.Context) (err error) {
+	a, ts, sb := authResult(c), transferService(c), currentSandbox(c)
+	var file string
+	if file, err = sandboxPath(c.Req.FormValue("path")); err != nil {
+		return
+	}
+	var r io.ReadCloser
+	var th *tar.Header
+	if r, th, err = sb.CopyFrom(file); err != nil {
+		return
+	}
+	defer r.Close()
+	c.Res.Header().Set("Content-Type", "application/octet-stream")
+	c.Res.Header().Set("Content-Length", strconv.FormatInt(th.Size, 10))
+	c.Res.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(file)}))
+	c.Res.WriteHeader(http.StatusOK)
+	// calculate sha256 while streaming to the client
+	h := sha256.New()
+	var n int64
+	n, err = io.Copy(io.MultiWriter(c.Res, h), r)
+	// always record the transfer, even partially downloaded
+	if _, terr := ts.CreateTransfer(c.Req.Context(), &types.CreateTransferRequest{
+		Account:   a.User.Account,
+		Source:    types.TransferSourceWeb,
+		Direction: types.TransferDirectionDownload,
+		Filename:  file,
+		Mode:      uint32(th.Mode),
+		Size:      n,
+		Sha256:    hex.EncodeToString(h.Sum(nil)),
+	}); terr != nil && err == nil {
+		err = terr
+	}
+	return
+}
+
+func routeListTransfers(c *nova.Context) (err error) {
+	ts, v := transferService(c), view.Extract(c)
+	sessionID, _ := strconv.ParseInt(c.Req.FormValue("session_id"), 10, 64)
+	var res1 *types.ListTransfersResponse
+	if res1, err = ts.ListTransfers(c.Req.Context(), &types.ListTransfersRequest{
+		Account:   c.Req.FormValue("account"),
+		SessionId: sessionID,
+	}); err != nil {
+		return
+	}
+	v.Data["transfers"] = res1.Transfers
+	v.DataAsJSON()
+	return
+}
@@ -36,6 +36,8 @@ func routeCreateUser(c *nova.Context) (err error) {
 func routeGetCurrentUser(c *nova.Context) (err error) {
 	a, v := authResult(c), view.Extract(c)
 	v.Data["user"] = a.User
+	v.Data["roles"] = a.Roles
+	v.Data["permissions"] = a.Permissions.Names()
 	v.DataAsJSON()
 	return
 }
@@ -105,6 +107,21 @@ func routeUpdateUserIsAdmin(c *nova.Context) (err error) {
 	return
 }
 
+func routeUpdateUserRoles(c *nova.Context) (err error) {
+	us, v := userService(c), view.Extract(c)
+	var res1 *types.UpdateUserResponse
+	if res1, err = us.UpdateUser(c.Req.Context(), &types.UpdateUserRequest{
+		Account:     c.Req.FormValue("account"),
+		UpdateRoles: true,
+		Roles:       SplitFormValue(c.Req.FormValue("roles")),
+	}); err != nil {
+		return
+	}
+	v.Data["user"] = res1.User
+	v.DataAsJSON()
+	return
+}
+
 func routeUpdateUserIsBlocked(c *nova.Context) (err error) {
 	us, v := userService(c), view.Extract(c)
 	var res1 *types.UpdateUserResponse
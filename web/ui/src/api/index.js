@@ -38,9 +38,22 @@ let API = {
         .then(res => {
           store.commit('setCurrentUser', res.body.user)
           store.commit('setCurrentToken', res.body.token)
-          return res
+          // permissions are resolved with roles of user and groups
+          return this.$apiGetCurrentUser().then(() => res)
         }, this.$apiErrorCallback())
     }
+    Vue.prototype.$apiGetOIDC = function () {
+      return this.$http.get('/api/oidc')
+    }
+    Vue.prototype.$apiLoginWithToken = function (token) {
+      store.commit('setCurrentToken', {token})
+      return this.$http.get('/api/tokens/current').then(res => {
+        store.commit('setCurrentUser', res.body.user)
+        // token value is hidden in response
+        store.commit('setCurrentToken', Object.assign({}, res.body.token, {token}))
+        return this.$apiGetCurrentUser().then(() => res)
+      }, this.$apiErrorCallback())
+    }
     Vue.prototype.$apiLogout = function () {
       return this.$http
         .post(
@@ -51,6 +64,7 @@ let API = {
         .then(res => {
           store.commit('setCurrentUser', null)
           store.commit('setCurrentToken', null)
+          store.commit('setPermissions', null)
           this.$router.push('/login')
           return res
         }, this.$apiErrorCallback())
@@ -58,6 +72,7 @@ let API = {
     Vue.prototype.$apiGetCurrentUser = function () {
       return this.$http.get('/api/users/current').then(res => {
         store.commit('setCurrentUser', res.body.user)
+        store.commit('setPermissions', res.body.permissions)
         return res
       }, this.$apiErrorCallback())
     }
@@ -110,6 +125,14 @@ let API = {
         return res
       }, this.$apiErrorCallback())
     }
+    Vue.prototype.$apiCreatePersonalToken = function (data) {
+      return this.$http
+        .post('/api/tokens/create_personal', data, {emulateJSON: true})
+        .then(res => {
+          this.$apiListTokens()
+          return res
+        }, this.$apiErrorCallback())
+    }
     Vue.prototype.$apiDeleteToken = function (id) {
       return this.$http
         .post('/api/tokens/destroy', {id}, {emulateJSON: true})
@@ -316,8 +339,34 @@ let API = {
           return res
         }, this.$apiErrorCallback())
     }
-    Vue.prototype.$apiListSessions = function ({skip, limit}) {
-      return this.$http.get('/api/sessions', {params: {skip, limit}}).then(null, this.$apiErrorCallback())
+    Vue.prototype.$apiListSessions = function ({skip, limit, cursor, account, hostname, command, since, until, only_recorded, only_active}) {
+      return this.$http.get('/api/sessions', {
+        params: {skip, limit, cursor, account, hostname, command, since, until, only_recorded, only_active}
+      }).then(null, this.$apiErrorCallback())
+    }
+    Vue.prototype.$apiSearchReplays = function ({keyword, match, commands, account, hostname, since, until, from, size}) {
+      return this.$http.get('/api/replays/search', {
+        params: {keyword, match, commands, account, hostname, since, until, from, size}
+      }).then(null, this.$apiErrorCallback())
+    }
+    Vue.prototype.$apiGetTranscript = function ({id}) {
+      return this.$http.get(`/api/replays/${id}/transcript`).then(null, this.$apiErrorCallback())
+    }
+    Vue.prototype.$apiUpdateSessionLegalHold = function ({id, legal_hold}) {
+      return this.$http
+        .post(
+          '/api/sessions/update_legal_hold',
+          {id, legal_hold},
+          {emulateJSON: true}
+        )
+        .then(res => {
+          this.$notify({
+            type: 'success',
+            title: '操作成功',
+            text: legal_hold ? '已设置法律保全' : '已解除法律保全'
+          })
+          return res
+        }, this.$apiErrorCallback())
     }
     Vue.prototype.$apiUpdateNodeIsKeyManaged = function ({hostname, is_key_managed}) {
       return this.$http.post(
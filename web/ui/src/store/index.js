@@ -8,6 +8,7 @@ export default new Vuex.Store({
   state: {
     currentToken: null,
     currentUser: null,
+    permissions: [],
     tokens: [],
     users: [],
     grantItems: [],
@@ -20,7 +21,9 @@ export default new Vuex.Store({
   getters: {
     isLoggedIn: state => !!state.currentToken && !!state.currentUser,
     isLoggedInAsAdmin: state =>
-      !!state.currentToken && !!state.currentUser && state.currentUser.is_admin
+      !!state.currentToken && !!state.currentUser && state.permissions.length > 0,
+    hasPermission: state => perm =>
+      !!state.currentToken && !!state.currentUser && state.permissions.indexOf(perm) >= 0
   },
   mutations: {
     setCurrentToken (state, token) {
@@ -29,6 +32,9 @@ export default new Vuex.Store({
     setCurrentUser (state, user) {
       state.currentUser = user
     },
+    setPermissions (state, permissions) {
+      state.permissions = permissions || []
+    },
     setGrantItems (state, gis) {
       state.grantItems = gis || []
     },
@@ -5,3 +5,14 @@ import "strings"
 func IsFormValueTrue(v string) bool {
 	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(v)), "t")
 }
+
+// SplitFormValue split a comma separated form value, empty items are dropped
+func SplitFormValue(v string) []string {
+	ret := make([]string, 0)
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); len(s) > 0 {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/novakit/static"
 	"github.com/novakit/view"
 	"github.com/rs/zerolog/log"
+	"github.com/yankeguo/bastion/sshd/sandbox"
 	"github.com/yankeguo/bastion/types"
 	"google.golang.org/grpc/status"
 	"net/http"
 )
 
-func NewServer(opts types.WebOptions) *http.Server {
+// NewServer create the web server, sshd options and the sandbox manager are used for sandbox access, i.e. file transfers and terminals
+func NewServer(opts types.WebOptions, sshdOpts types.SSHDOptions, m sandbox.Manager) *http.Server {
 	n := nova.New()
 	if opts.Dev {
 		n.Env = nova.Development
@@ -42,7 +44,11 @@ func NewServer(opts types.WebOptions) *http.Server {
 		BinFS:     !opts.Dev,
 	}))
 	// mount opts module
-	n.Use(optsModule(opts))
+	n.Use(optsModule(opts, sshdOpts))
+	// mount sandbox manager module
+	n.Use(sandboxManagerModule(m))
+	// mount oidc module
+	n.Use(oidcModule(opts.OIDC))
 	// mount rpc module
 	n.Use(rpcModule(opts))
 	// mount auth module